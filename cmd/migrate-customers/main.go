@@ -0,0 +1,58 @@
+// migrate-customers は旧 customers を wordpress_instagrams に移行し、
+// posts.customer_id で紐付いていた投稿を posts.wordpress_instagram_id に紐付け直す。
+//
+//	go run ./cmd/migrate-customers -dry-run  # 書き込まずに結果だけ表示する
+//	go run ./cmd/migrate-customers
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/zuxt268/homing/internal/di"
+	"github.com/zuxt268/homing/internal/infrastructure/database"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "書き込まずに移行結果だけを表示する")
+	flag.Parse()
+
+	db, err := database.NewDB()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := di.NewCustomerMigrationUsecase(db).MigrateCustomers(context.Background(), *dryRun)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if report.DryRun {
+		fmt.Println("ドライランのため、何も書き込んでいません")
+	}
+	created, moved := 0, 0
+	for _, entry := range report.Customers {
+		created += entry.Created
+		moved += entry.MovedPosts
+		fmt.Printf("customers.id=%d %s (%s) 連携: %v 作成: %d 投稿: %d件\n",
+			entry.CustomerID, entry.Name, entry.WordpressDomain, entry.WordpressInstagramIDs, entry.Created, entry.MovedPosts)
+		for _, conflict := range entry.Conflicts {
+			fmt.Printf("  要確認: %s\n", conflict)
+		}
+	}
+	if len(report.OrphanPostCustomerIDs) > 0 {
+		fmt.Printf("顧客も連携も存在しない投稿の customer_id: %v\n", report.OrphanPostCustomerIDs)
+	}
+	fmt.Printf("顧客: %d件 作成した連携: %d件 紐付け直した投稿: %d件 要確認: %d件\n",
+		len(report.Customers), created, moved, report.ConflictCount())
+	if created > 0 {
+		// 旧 customers の署名は SECRET_PHRASE + wordpress_url だったが、連携では SECRET_PHRASE + wordpress_domain になる
+		fmt.Println("作成した連携は無効の状態です。WordPressプラグインの署名を確認してから有効にしてください（POST /api/wordpress-instagram/validate）")
+	}
+	if report.ConflictCount() > 0 {
+		os.Exit(1)
+	}
+}
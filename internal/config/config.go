@@ -8,24 +8,35 @@ import (
 )
 
 type Environment struct {
-	Address                   string `envconfig:"ADDRESS"`
-	SecretPhrase              string `envconfig:"SECRET_PHRASE"`
-	AdminEmail                string `envconfig:"ADMIN_EMAIL"`
-	NoticeWebAppChannelUrl    string `envconfig:"NOTICE_WEB_APP_CHANNEL_URL"`
-	PrjARootChannelUrl        string `envconfig:"PRJ_AROOT_CHANNEL_URL"`
-	NoticeRpaChannelUrl       string `envconfig:"NOTICE_RPA_CHANNEL_URL"`
-	DBHost                    string `envconfig:"DB_HOST"`
-	DBPort                    string `envconfig:"DB_PORT" default:"3306"`
-	DBUser                    string `envconfig:"DB_USER"`
-	DBPassword                string `envconfig:"DB_PASSWORD"`
-	DBName                    string `envconfig:"DB_NAME"`
-	ClientID                  string `envconfig:"CLIENT_ID"`
-	ClientSecret              string `envconfig:"CLIENT_SECRET"`
-	GoogleCredentialPath      string `envconfig:"GOOGLE_CREDENTIAL_PATH"`
-	GoogleBusinessAccountName string `envconfig:"GOOGLE_BUSINESS_ACCOUNT_NAME"`
-	S3Bucket                  string `envconfig:"S3_BUCKET"`
-	S3Region                  string `envconfig:"S3_REGION" default:"ap-northeast-1"`
-	S3Prefix                  string `envconfig:"S3_PREFIX" default:"tmp/gbp-media/"`
+	Address                string `envconfig:"ADDRESS"`
+	SecretPhrase           string `envconfig:"SECRET_PHRASE"`
+	AdminEmail             string `envconfig:"ADMIN_EMAIL"`
+	AdminURL               string `envconfig:"ADMIN_URL"`
+	NoticeWebAppChannelUrl string `envconfig:"NOTICE_WEB_APP_CHANNEL_URL"`
+	NoticeMention          string `envconfig:"NOTICE_MENTION"`
+	NoticeSuccessDigest    bool   `envconfig:"NOTICE_SUCCESS_DIGEST"`
+	SlackSigningSecret     string `envconfig:"SLACK_SIGNING_SECRET"`
+	DigestInactiveDays     int    `envconfig:"DIGEST_INACTIVE_DAYS" default:"14"`
+	SMTPHost               string `envconfig:"SMTP_HOST"`
+	SMTPPort               string `envconfig:"SMTP_PORT" default:"587"`
+	SMTPUsername           string `envconfig:"SMTP_USERNAME"`
+	SMTPPassword           string `envconfig:"SMTP_PASSWORD"`
+	SMTPFrom               string `envconfig:"SMTP_FROM"`
+	LineChannelAccessToken string `envconfig:"LINE_CHANNEL_ACCESS_TOKEN"`
+	LineAPIURL             string `envconfig:"LINE_API_URL" default:"https://api.line.me"`
+	DBHost                 string `envconfig:"DB_HOST"`
+	DBPort                 string `envconfig:"DB_PORT" default:"3306"`
+	DBUser                 string `envconfig:"DB_USER"`
+	DBPassword             string `envconfig:"DB_PASSWORD"`
+	DBName                 string `envconfig:"DB_NAME"`
+	ClientID               string `envconfig:"CLIENT_ID"`
+	ClientSecret           string `envconfig:"CLIENT_SECRET"`
+	GoogleCredentialPath   string `envconfig:"GOOGLE_CREDENTIAL_PATH"`
+	GoogleOAuthRedirectURL string `envconfig:"GOOGLE_OAUTH_REDIRECT_URL"`
+	GoogleReviewLowRating  int    `envconfig:"GOOGLE_REVIEW_LOW_RATING" default:"3"`
+	S3Bucket               string `envconfig:"S3_BUCKET"`
+	S3Region               string `envconfig:"S3_REGION" default:"ap-northeast-1"`
+	S3Prefix               string `envconfig:"S3_PREFIX" default:"tmp/gbp-media/"`
 }
 
 var Env Environment
@@ -1,6 +1,7 @@
 package di
 
 import (
+	"github.com/zuxt268/homing/internal/config"
 	"github.com/zuxt268/homing/internal/infrastructure/driver"
 	"github.com/zuxt268/homing/internal/interface/adapter"
 	"github.com/zuxt268/homing/internal/interface/handler"
@@ -9,6 +10,10 @@ import (
 	"gorm.io/gorm"
 )
 
+func NewBaseRepository(db *gorm.DB) repository.BaseRepository {
+	return repository.NewBaseRepository(db)
+}
+
 func NewCustomerRepository(db *gorm.DB) repository.CustomerRepository {
 	return repository.NewCustomerRepository(db)
 }
@@ -21,8 +26,28 @@ func NewInstagramAdapter(httpClient driver.HttpDriver) adapter.InstagramAdapter
 	return adapter.NewInstagramAdapter(httpClient)
 }
 
-func NewSlack(httpDriver driver.HttpDriver) adapter.Slack {
-	return adapter.NewSlack(httpDriver)
+func NewSlackNotifier(httpDriver driver.HttpDriver) adapter.Notifier {
+	return adapter.NewSlackNotifier(httpDriver)
+}
+
+func NewSlackAdapter(httpDriver driver.HttpDriver) adapter.SlackAdapter {
+	return adapter.NewSlackAdapter(httpDriver)
+}
+
+func NewEmailNotifier() adapter.Notifier {
+	return adapter.NewEmailNotifier(config.Env.SMTPHost, config.Env.SMTPPort, config.Env.SMTPUsername, config.Env.SMTPPassword, config.Env.SMTPFrom)
+}
+
+func NewLineNotifier(httpDriver driver.HttpDriver) adapter.Notifier {
+	return adapter.NewLineNotifier(httpDriver, config.Env.LineAPIURL, config.Env.LineChannelAccessToken)
+}
+
+func NewWebhookNotifier() adapter.Notifier {
+	return adapter.NewWebhookNotifier(NewWebhookAdapter())
+}
+
+func NewNotificationRuleRepository(db *gorm.DB) repository.NotificationRuleRepository {
+	return repository.NewNotificationRuleRepository(db)
 }
 
 func NewWordpressAdapter(httpDriver driver.HttpDriver) adapter.WordpressAdapter {
@@ -53,8 +78,80 @@ func NewWordpressGbpRepository(db *gorm.DB) repository.WordpressGbpRepository {
 	return repository.NewWordpressGbpRepository(db)
 }
 
-func NewGbpAdapter(credentialsData []byte) (adapter.GbpAdapter, error) {
-	return adapter.NewGbpAdapter(credentialsData)
+func NewGoogleReviewRepository(db *gorm.DB) repository.GoogleReviewRepository {
+	return repository.NewGoogleReviewRepository(db)
+}
+
+func NewGoogleBusinessMetricRepository(db *gorm.DB) repository.GoogleBusinessMetricRepository {
+	return repository.NewGoogleBusinessMetricRepository(db)
+}
+
+func NewGoogleOAuthTokenRepository(db *gorm.DB) repository.GoogleOAuthTokenRepository {
+	return repository.NewGoogleOAuthTokenRepository(db)
+}
+
+func NewGoogleAccountRepository(db *gorm.DB) repository.GoogleAccountRepository {
+	return repository.NewGoogleAccountRepository(db)
+}
+
+func NewGoogleBusinessLocationChangeRepository(db *gorm.DB) repository.GoogleBusinessLocationChangeRepository {
+	return repository.NewGoogleBusinessLocationChangeRepository(db)
+}
+
+func NewGbpPostRejectionRepository(db *gorm.DB) repository.GbpPostRejectionRepository {
+	return repository.NewGbpPostRejectionRepository(db)
+}
+
+func NewFacebookInstagramRepository(db *gorm.DB) repository.FacebookInstagramRepository {
+	return repository.NewFacebookInstagramRepository(db)
+}
+
+func NewFacebookPostRepository(db *gorm.DB) repository.FacebookPostRepository {
+	return repository.NewFacebookPostRepository(db)
+}
+
+func NewFacebookAdapter(httpDriver driver.HttpDriver) adapter.FacebookAdapter {
+	return adapter.NewFacebookAdapter(httpDriver)
+}
+
+func NewFeedRepository(db *gorm.DB) repository.FeedRepository {
+	return repository.NewFeedRepository(db)
+}
+
+func NewFeedPostRepository(db *gorm.DB) repository.FeedPostRepository {
+	return repository.NewFeedPostRepository(db)
+}
+
+func NewFeedAdapter(httpDriver driver.HttpDriver) adapter.FeedAdapter {
+	return adapter.NewFeedAdapter(httpDriver)
+}
+
+func NewWebhookSubscriptionRepository(db *gorm.DB) repository.WebhookSubscriptionRepository {
+	return repository.NewWebhookSubscriptionRepository(db)
+}
+
+func NewWebhookEventRepository(db *gorm.DB) repository.WebhookEventRepository {
+	return repository.NewWebhookEventRepository(db)
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) repository.WebhookDeliveryRepository {
+	return repository.NewWebhookDeliveryRepository(db)
+}
+
+func NewSyncActivityRepository(db *gorm.DB) repository.SyncActivityRepository {
+	return repository.NewSyncActivityRepository(db)
+}
+
+func NewWebhookAdapter() adapter.WebhookAdapter {
+	return adapter.NewWebhookAdapter()
+}
+
+func NewGoogleOAuth(credentialsData []byte, redirectURL string) (adapter.GoogleOAuth, error) {
+	return adapter.NewGoogleOAuth(credentialsData, redirectURL)
+}
+
+func NewGbpAdapter(googleOAuth adapter.GoogleOAuth) adapter.GbpAdapter {
+	return adapter.NewGbpAdapter(googleOAuth)
 }
 
 func NewFileDownloader() adapter.FileDownloader {
@@ -68,7 +165,7 @@ func NewS3Adapter(bucket, region, prefix string) (adapter.S3Adapter, error) {
 func NewCustomerUsecase(httpDriver driver.HttpDriver, db *gorm.DB, gbpAdapter adapter.GbpAdapter, s3Adapter adapter.S3Adapter) usecase.CustomerUsecase {
 	return usecase.NewCustomerUsecase(
 		NewInstagramAdapter(httpDriver),
-		NewSlack(httpDriver),
+		NewNotificationUsecase(httpDriver, db),
 		NewWordpressAdapter(httpDriver),
 		gbpAdapter,
 		NewPostRepository(db),
@@ -78,14 +175,26 @@ func NewCustomerUsecase(httpDriver driver.HttpDriver, db *gorm.DB, gbpAdapter ad
 		NewGooglePostRepository(db),
 		s3Adapter,
 		NewWordpressGbpRepository(db),
+		NewGoogleBusinessRepository(db),
+		NewGoogleAccountRepository(db),
+		NewGbpPostRejectionRepository(db),
+		NewFacebookAdapter(httpDriver),
+		NewFacebookInstagramRepository(db),
+		NewFacebookPostRepository(db),
+		NewFeedAdapter(httpDriver),
+		NewFeedRepository(db),
+		NewFeedPostRepository(db),
+		NewWebhookUsecase(db),
+		NewSyncActivityRepository(db),
 	)
 }
 
 func NewTokenUsecase(httpDriver driver.HttpDriver, db *gorm.DB) usecase.TokenUsecase {
 	return usecase.NewTokenUsecase(
 		NewInstagramAdapter(httpDriver),
-		NewSlack(httpDriver),
+		NewNotificationUsecase(httpDriver, db),
 		NewTokenRepository(db),
+		NewWebhookUsecase(db),
 	)
 }
 
@@ -96,6 +205,17 @@ func NewWordpressInstagramUsecase(httpDriver driver.HttpDriver, db *gorm.DB) use
 		NewPostRepository(db),
 		NewInstagramAdapter(httpDriver),
 		NewWordpressAdapter(httpDriver),
+		NewWebhookUsecase(db),
+		NewBaseRepository(db),
+	)
+}
+
+func NewCustomerMigrationUsecase(db *gorm.DB) usecase.CustomerMigrationUsecase {
+	return usecase.NewCustomerMigrationUsecase(
+		NewCustomerRepository(db),
+		NewWordpressInstagramRepository(db),
+		NewPostRepository(db),
+		NewBaseRepository(db),
 	)
 }
 
@@ -105,6 +225,10 @@ func NewWordpressGbpUsecase(httpDriver driver.HttpDriver, db *gorm.DB, gbpAdapte
 		NewGooglePostRepository(db),
 		NewWordpressAdapter(httpDriver),
 		gbpAdapter,
+		NewGoogleBusinessRepository(db),
+		NewGoogleAccountRepository(db),
+		NewWebhookUsecase(db),
+		NewBaseRepository(db),
 	)
 }
 
@@ -116,15 +240,156 @@ func NewBusinessInstagramUsecase(httpDriver driver.HttpDriver, db *gorm.DB, gbpA
 		NewGooglePostRepository(db),
 		NewInstagramAdapter(httpDriver),
 		gbpAdapter,
+		NewGoogleAccountRepository(db),
+		NewGoogleOAuthTokenRepository(db),
+		NewWebhookUsecase(db),
+		NewBaseRepository(db),
+	)
+}
+
+func NewGoogleReviewUsecase(httpDriver driver.HttpDriver, db *gorm.DB, gbpAdapter adapter.GbpAdapter) usecase.GoogleReviewUsecase {
+	return usecase.NewGoogleReviewUsecase(
+		NewGoogleReviewRepository(db),
+		NewGoogleBusinessRepository(db),
+		NewGoogleAccountRepository(db),
+		gbpAdapter,
+		NewNotificationUsecase(httpDriver, db),
+	)
+}
+
+func NewGoogleBusinessInsightUsecase(httpDriver driver.HttpDriver, db *gorm.DB, gbpAdapter adapter.GbpAdapter) usecase.GoogleBusinessInsightUsecase {
+	return usecase.NewGoogleBusinessInsightUsecase(
+		NewGoogleBusinessRepository(db),
+		NewGoogleBusinessMetricRepository(db),
+		NewGoogleAccountRepository(db),
+		NewGooglePostRepository(db),
+		NewBusinessInstagramRepository(db),
+		NewWordpressGbpRepository(db),
+		gbpAdapter,
+		NewNotificationUsecase(httpDriver, db),
+	)
+}
+
+func NewGoogleOAuthUsecase(db *gorm.DB, googleOAuth adapter.GoogleOAuth, gbpAdapter adapter.GbpAdapter) usecase.GoogleOAuthUsecase {
+	return usecase.NewGoogleOAuthUsecase(
+		NewGoogleOAuthTokenRepository(db),
+		NewGoogleAccountRepository(db),
+		NewGoogleBusinessRepository(db),
+		googleOAuth,
+		gbpAdapter,
+	)
+}
+
+func NewGoogleBusinessLocationUsecase(db *gorm.DB, gbpAdapter adapter.GbpAdapter) usecase.GoogleBusinessLocationUsecase {
+	return usecase.NewGoogleBusinessLocationUsecase(
+		NewGoogleBusinessRepository(db),
+		NewGoogleAccountRepository(db),
+		NewGoogleBusinessLocationChangeRepository(db),
+		gbpAdapter,
+	)
+}
+
+func NewGooglePostUsecase(httpDriver driver.HttpDriver, db *gorm.DB, gbpAdapter adapter.GbpAdapter, s3Adapter adapter.S3Adapter) usecase.GooglePostUsecase {
+	return usecase.NewGooglePostUsecase(
+		NewGooglePostRepository(db),
+		NewGoogleBusinessRepository(db),
+		NewGoogleAccountRepository(db),
+		NewBusinessInstagramRepository(db),
+		NewWordpressGbpRepository(db),
+		gbpAdapter,
+		NewCustomerUsecase(httpDriver, db, gbpAdapter, s3Adapter),
+		NewNotificationUsecase(httpDriver, db),
+		NewGbpPostRejectionRepository(db),
+	)
+}
+
+func NewFacebookInstagramUsecase(httpDriver driver.HttpDriver, db *gorm.DB) usecase.FacebookInstagramUsecase {
+	return usecase.NewFacebookInstagramUsecase(
+		NewFacebookInstagramRepository(db),
+		NewFacebookPostRepository(db),
+		NewTokenRepository(db),
+		NewInstagramAdapter(httpDriver),
+		NewFacebookAdapter(httpDriver),
+		NewWebhookUsecase(db),
+	)
+}
+
+func NewFeedUsecase(httpDriver driver.HttpDriver, db *gorm.DB, gbpAdapter adapter.GbpAdapter) usecase.FeedUsecase {
+	return usecase.NewFeedUsecase(
+		NewFeedRepository(db),
+		NewFeedPostRepository(db),
+		NewGoogleBusinessRepository(db),
+		NewGoogleAccountRepository(db),
+		NewFeedAdapter(httpDriver),
+		NewWordpressAdapter(httpDriver),
+		gbpAdapter,
+		NewWebhookUsecase(db),
+	)
+}
+
+func NewNotificationUsecase(httpDriver driver.HttpDriver, db *gorm.DB) usecase.NotificationUsecase {
+	return usecase.NewNotificationUsecase(
+		NewNotificationRuleRepository(db),
+		NewSlackNotifier(httpDriver),
+		NewEmailNotifier(),
+		NewLineNotifier(httpDriver),
+		NewWebhookNotifier(),
+	)
+}
+
+func NewWebhookUsecase(db *gorm.DB) usecase.WebhookUsecase {
+	return usecase.NewWebhookUsecase(
+		NewWebhookSubscriptionRepository(db),
+		NewWebhookEventRepository(db),
+		NewWebhookDeliveryRepository(db),
+		NewWebhookAdapter(),
+	)
+}
+
+func NewDigestUsecase(httpDriver driver.HttpDriver, db *gorm.DB) usecase.DigestUsecase {
+	return usecase.NewDigestUsecase(
+		NewSyncActivityRepository(db),
+		NewWordpressInstagramRepository(db),
+		NewBusinessInstagramRepository(db),
+		NewWordpressGbpRepository(db),
+		NewFacebookInstagramRepository(db),
+		NewFeedRepository(db),
+		NewTokenRepository(db),
+		NewInstagramAdapter(httpDriver),
+		NewNotificationUsecase(httpDriver, db),
+	)
+}
+
+func NewSlackInteractionUsecase(httpDriver driver.HttpDriver, db *gorm.DB, gbpAdapter adapter.GbpAdapter, s3Adapter adapter.S3Adapter) usecase.SlackInteractionUsecase {
+	return usecase.NewSlackInteractionUsecase(
+		NewCustomerUsecase(httpDriver, db, gbpAdapter, s3Adapter),
+		NewWordpressInstagramRepository(db),
+		NewBusinessInstagramRepository(db),
+		NewWordpressGbpRepository(db),
+		NewFacebookInstagramRepository(db),
+		NewFeedRepository(db),
+		NewSlackAdapter(httpDriver),
+		NewWebhookUsecase(db),
 	)
 }
 
-func NewHandler(httpDriver driver.HttpDriver, db *gorm.DB, gbpAdapter adapter.GbpAdapter, s3Adapter adapter.S3Adapter) handler.APIHandler {
+func NewHandler(httpDriver driver.HttpDriver, db *gorm.DB, googleOAuth adapter.GoogleOAuth, gbpAdapter adapter.GbpAdapter, s3Adapter adapter.S3Adapter) handler.APIHandler {
 	return handler.NewAPIHandler(
 		NewCustomerUsecase(httpDriver, db, gbpAdapter, s3Adapter),
 		NewTokenUsecase(httpDriver, db),
 		NewWordpressInstagramUsecase(httpDriver, db),
 		NewBusinessInstagramUsecase(httpDriver, db, gbpAdapter),
 		NewWordpressGbpUsecase(httpDriver, db, gbpAdapter),
+		NewGoogleReviewUsecase(httpDriver, db, gbpAdapter),
+		NewGoogleBusinessInsightUsecase(httpDriver, db, gbpAdapter),
+		NewGoogleOAuthUsecase(db, googleOAuth, gbpAdapter),
+		NewGoogleBusinessLocationUsecase(db, gbpAdapter),
+		NewGooglePostUsecase(httpDriver, db, gbpAdapter, s3Adapter),
+		NewFacebookInstagramUsecase(httpDriver, db),
+		NewFeedUsecase(httpDriver, db, gbpAdapter),
+		NewWebhookUsecase(db),
+		NewNotificationUsecase(httpDriver, db),
+		NewDigestUsecase(httpDriver, db),
+		NewSlackInteractionUsecase(httpDriver, db, gbpAdapter, s3Adapter),
 	)
 }
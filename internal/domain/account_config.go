@@ -0,0 +1,29 @@
+package domain
+
+import "fmt"
+
+// AccountConfigFormat は連携の設定をインポート・エクスポートするファイルの形式
+type AccountConfigFormat string
+
+const (
+	AccountConfigFormatJSON AccountConfigFormat = "json"
+	AccountConfigFormatCSV  AccountConfigFormat = "csv"
+)
+
+// ParseAccountConfigFormat はクエリの format を解釈する。未指定の場合は json。
+func ParseAccountConfigFormat(format string) (AccountConfigFormat, error) {
+	switch AccountConfigFormat(format) {
+	case "", AccountConfigFormatJSON:
+		return AccountConfigFormatJSON, nil
+	case AccountConfigFormatCSV:
+		return AccountConfigFormatCSV, nil
+	}
+	return "", fmt.Errorf("%w: format は json または csv を指定してください: %s", ErrBadRequest, format)
+}
+
+func (f AccountConfigFormat) ContentType() string {
+	if f == AccountConfigFormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
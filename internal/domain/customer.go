@@ -3,6 +3,9 @@ package domain
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -27,3 +30,60 @@ func (c *Customer) GenerateAPIKey(secretPhrase string) string {
 	hash := sha256.Sum256(data)
 	return hex.EncodeToString(hash[:])
 }
+
+// LegacyInstagramAccount は旧 customers にカンマ区切りで登録されたInstagramビジネスアカウント
+type LegacyInstagramAccount struct {
+	ID   string
+	Name string
+}
+
+// InstagramAccounts は空の値を除いた、IDと名前の組のInstagramビジネスアカウント
+func (c *Customer) InstagramAccounts() []LegacyInstagramAccount {
+	var accounts []LegacyInstagramAccount
+	for i, id := range c.InstagramBusinessAccountID {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		account := LegacyInstagramAccount{ID: id}
+		if i < len(c.InstagramBusinessAccountName) {
+			account.Name = strings.TrimSpace(c.InstagramBusinessAccountName[i])
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts
+}
+
+// WordpressDomain は wordpress_url から、wordpress_instagrams の wordpress_domain の形式（ホスト名 + サブディレクトリ）を取り出す
+func (c *Customer) WordpressDomain() (string, error) {
+	raw := strings.TrimSpace(c.WordpressUrl)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("wordpress_url からドメインを取得できません: %q", c.WordpressUrl)
+	}
+	return u.Host + strings.TrimSuffix(u.Path, "/"), nil
+}
+
+// NewWordpressInstagram は旧 customers から移行する連携を作る。
+// 移行した直後に同期が過去の投稿をまとめて投稿しないよう、無効の状態で作る。
+// 連携開始日がない場合は移行した日時にする。
+func (c *Customer) NewWordpressInstagram(wordpressDomain string, account LegacyInstagramAccount, now time.Time) *WordpressInstagram {
+	wi := &WordpressInstagram{
+		Name:            c.Name,
+		WordpressDomain: wordpressDomain,
+		InstagramID:     account.ID,
+		InstagramName:   account.Name,
+		Memo:            fmt.Sprintf("customers.id=%d から移行", c.ID),
+		StartDate:       now,
+		Status:          Status(0),
+		DeleteHash:      c.DeleteHash,
+		APIMode:         WordpressAPIModeRodut,
+	}
+	if c.StartDate != nil {
+		wi.StartDate = *c.StartDate
+	}
+	return wi
+}
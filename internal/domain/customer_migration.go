@@ -0,0 +1,33 @@
+package domain
+
+// CustomerMigrationEntry は旧 customers の1件を移行した結果
+type CustomerMigrationEntry struct {
+	CustomerID      int
+	Name            string
+	WordpressDomain string
+	// WordpressInstagramIDs は移行先の連携。ドライランで新しく作る連携は 0
+	WordpressInstagramIDs []int
+	// Created は新しく作った連携の数。既に同じWordPressとInstagramの連携がある場合はそれを使う
+	Created int
+	// MovedPosts は連携に紐付け直した投稿の数
+	MovedPosts int
+	// Conflicts は自動で移行できず、確認が必要な内容
+	Conflicts []string
+}
+
+// CustomerMigrationReport は旧 customers を移行した結果
+type CustomerMigrationReport struct {
+	DryRun    bool
+	Customers []CustomerMigrationEntry
+	// OrphanPostCustomerIDs は顧客も連携も存在しない、紐付け先のない投稿の customer_id
+	OrphanPostCustomerIDs []int
+}
+
+// ConflictCount は確認が必要な内容の数
+func (r *CustomerMigrationReport) ConflictCount() int {
+	count := len(r.OrphanPostCustomerIDs)
+	for _, entry := range r.Customers {
+		count += len(entry.Conflicts)
+	}
+	return count
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomer_WordpressDomain(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://example.com/", want: "example.com"},
+		{url: "http://www.example.com", want: "www.example.com"},
+		{url: "example.com/blog/", want: "example.com/blog"},
+		{url: " https://example.com/shop ", want: "example.com/shop"},
+	}
+	for _, tt := range tests {
+		c := Customer{WordpressUrl: tt.url}
+		got, err := c.WordpressDomain()
+		require.NoError(t, err, tt.url)
+		assert.Equal(t, tt.want, got, tt.url)
+	}
+
+	_, err := (&Customer{WordpressUrl: ""}).WordpressDomain()
+	assert.Error(t, err)
+}
+
+func TestCustomer_InstagramAccounts(t *testing.T) {
+	c := Customer{
+		InstagramBusinessAccountID:   []string{"ig-1", "", " ig-3"},
+		InstagramBusinessAccountName: []string{"one", "two"},
+	}
+	assert.Equal(t, []LegacyInstagramAccount{
+		{ID: "ig-1", Name: "one"},
+		{ID: "ig-3"},
+	}, c.InstagramAccounts())
+
+	assert.Empty(t, (&Customer{InstagramBusinessAccountID: []string{""}}).InstagramAccounts())
+}
+
+func TestCustomer_NewWordpressInstagram(t *testing.T) {
+	now := time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	c := Customer{ID: 7, Name: "カフェ", DeleteHash: true, StartDate: &start}
+
+	wi := c.NewWordpressInstagram("example.com", LegacyInstagramAccount{ID: "ig-1", Name: "cafe"}, now)
+	assert.Equal(t, "カフェ", wi.Name)
+	assert.Equal(t, "example.com", wi.WordpressDomain)
+	assert.Equal(t, "ig-1", wi.InstagramID)
+	assert.Equal(t, "cafe", wi.InstagramName)
+	assert.Equal(t, "customers.id=7 から移行", wi.Memo)
+	assert.Equal(t, start, wi.StartDate)
+	assert.Equal(t, Status(0), wi.Status)
+	assert.True(t, wi.DeleteHash)
+	assert.Equal(t, WordpressAPIModeRodut, wi.APIMode)
+
+	c.StartDate = nil
+	assert.Equal(t, now, c.NewWordpressInstagram("example.com", LegacyInstagramAccount{ID: "ig-1"}, now).StartDate)
+}
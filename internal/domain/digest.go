@@ -0,0 +1,291 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DigestPeriod はダイジェストの集計期間
+type DigestPeriod string
+
+const (
+	DigestPeriodDaily  DigestPeriod = "daily"
+	DigestPeriodWeekly DigestPeriod = "weekly"
+)
+
+func (p DigestPeriod) Valid() bool {
+	return p == DigestPeriodDaily || p == DigestPeriodWeekly
+}
+
+// Duration は集計期間の長さ
+func (p DigestPeriod) Duration() time.Duration {
+	if p == DigestPeriodWeekly {
+		return 7 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+func (p DigestPeriod) Label() string {
+	if p == DigestPeriodWeekly {
+		return "週次"
+	}
+	return "日次"
+}
+
+// TokenExpiringWithin はトークンの有効期限が近いと判断する残り期間
+const TokenExpiringWithin = 10 * 24 * time.Hour
+
+// DigestAccountSummary は連携ごとの集計。Skipped は同じ投稿が何度スキップされても1件と数える。
+type DigestAccountSummary struct {
+	Account   Account
+	Published int
+	Skipped   int
+	Failed    int
+	LastError string
+}
+
+// DigestInactiveAccount は一定期間投稿が無い連携。LastPublishedAt は一度も投稿が無い場合はnil。
+type DigestInactiveAccount struct {
+	Account         Account
+	LastPublishedAt *time.Time
+}
+
+// Digest は期間内の連携結果のまとめ。投稿ごとの通知の代わりに定期的に送る。
+type Digest struct {
+	Period    DigestPeriod
+	From      time.Time
+	To        time.Time
+	Published int
+	Skipped   int
+	Failed    int
+	Accounts  []DigestAccountSummary
+
+	InactiveDays     int
+	InactiveAccounts []DigestInactiveAccount
+
+	// TokenExpiresAt はInstagramのトークンの有効期限。取得できなかった場合はnil。
+	TokenExpiresAt *time.Time
+}
+
+// NewDigest は to までの期間の連携結果を連携ごとに集計する。
+func NewDigest(period DigestPeriod, to time.Time, activities []*SyncActivity) *Digest {
+	d := &Digest{
+		Period: period,
+		From:   to.Add(-period.Duration()),
+		To:     to,
+	}
+
+	type accountKey struct {
+		Type AccountType
+		ID   int
+	}
+	summaries := map[accountKey]*DigestAccountSummary{}
+	skipped := map[accountKey]map[string]bool{}
+	// エラーは activities の並び順によらず最新のものを残す
+	lastErrorAt := map[accountKey]time.Time{}
+
+	for _, a := range activities {
+		if a.CreatedAt.Before(d.From) || !a.CreatedAt.Before(d.To) {
+			continue
+		}
+		key := accountKey{Type: a.Account.Type, ID: a.Account.ID}
+		s, ok := summaries[key]
+		if !ok {
+			s = &DigestAccountSummary{Account: a.Account}
+			summaries[key] = s
+			skipped[key] = map[string]bool{}
+		}
+		switch a.Result {
+		case SyncResultPublished:
+			s.Published++
+		case SyncResultSkipped:
+			if !skipped[key][a.PostKey] {
+				skipped[key][a.PostKey] = true
+				s.Skipped++
+			}
+		case SyncResultFailed:
+			s.Failed++
+			if a.CreatedAt.After(lastErrorAt[key]) {
+				lastErrorAt[key] = a.CreatedAt
+				s.LastError = a.Detail
+			}
+		}
+	}
+
+	for _, s := range summaries {
+		d.Published += s.Published
+		d.Skipped += s.Skipped
+		d.Failed += s.Failed
+		d.Accounts = append(d.Accounts, *s)
+	}
+	sort.Slice(d.Accounts, func(i, j int) bool {
+		return accountLess(d.Accounts[i].Account, d.Accounts[j].Account)
+	})
+	return d
+}
+
+// SetInactiveAccounts は有効な連携のうち、集計の終わりから inactiveDays 日以上投稿が無いものを記録する。
+// lastPublished は連携ごとの最後の投稿の記録。
+func (d *Digest) SetInactiveAccounts(accounts []Account, lastPublished []*SyncActivity, inactiveDays int) {
+	last := map[AccountType]map[int]time.Time{}
+	for _, a := range lastPublished {
+		if last[a.Account.Type] == nil {
+			last[a.Account.Type] = map[int]time.Time{}
+		}
+		if a.CreatedAt.After(last[a.Account.Type][a.Account.ID]) {
+			last[a.Account.Type][a.Account.ID] = a.CreatedAt
+		}
+	}
+
+	d.InactiveDays = inactiveDays
+	d.InactiveAccounts = nil
+	threshold := d.To.AddDate(0, 0, -inactiveDays)
+	for _, account := range accounts {
+		publishedAt, ok := last[account.Type][account.ID]
+		if ok && publishedAt.After(threshold) {
+			continue
+		}
+		inactive := DigestInactiveAccount{Account: account}
+		if ok {
+			inactive.LastPublishedAt = &publishedAt
+		}
+		d.InactiveAccounts = append(d.InactiveAccounts, inactive)
+	}
+	sort.Slice(d.InactiveAccounts, func(i, j int) bool {
+		return accountLess(d.InactiveAccounts[i].Account, d.InactiveAccounts[j].Account)
+	})
+}
+
+// TokenExpiring はトークンの有効期限が近い、または有効期限を過ぎているかどうか。
+func (d *Digest) TokenExpiring() bool {
+	return d.TokenExpiresAt != nil && d.TokenExpiresAt.Before(d.To.Add(TokenExpiringWithin))
+}
+
+// Subject はメールの件名
+func (d *Digest) Subject() string {
+	return fmt.Sprintf("[homing] %sダイジェスト %s", d.Period.Label(), formatDigestDate(d.To))
+}
+
+// Text はSlackなどに送るテキスト
+func (d *Digest) Text() string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("[%sダイジェスト] %s 〜 %s\n", d.Period.Label(), formatDigestTime(d.From), formatDigestTime(d.To)))
+	sb.WriteString(fmt.Sprintf("投稿: %d件 / スキップ: %d件 / 失敗: %d件\n", d.Published, d.Skipped, d.Failed))
+
+	sb.WriteString("\n■ 連携ごとの結果\n")
+	if len(d.Accounts) == 0 {
+		sb.WriteString("なし\n")
+	}
+	for _, s := range d.Accounts {
+		sb.WriteString(fmt.Sprintf("- %s: 投稿 %d件 / スキップ %d件 / 失敗 %d件\n", formatDigestAccount(s.Account), s.Published, s.Skipped, s.Failed))
+		if s.LastError != "" {
+			sb.WriteString(fmt.Sprintf("  最後のエラー: %s\n", s.LastError))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("\n■ %d日以上投稿が無い連携\n", d.InactiveDays))
+	if len(d.InactiveAccounts) == 0 {
+		sb.WriteString("なし\n")
+	}
+	for _, a := range d.InactiveAccounts {
+		sb.WriteString(fmt.Sprintf("- %s（最終投稿: %s）\n", formatDigestAccount(a.Account), formatLastPublished(a.LastPublishedAt)))
+	}
+
+	sb.WriteString("\n■ Instagramトークン\n")
+	sb.WriteString(d.TokenStatus() + "\n")
+	return sb.String()
+}
+
+// HTML はメールで送るHTML
+func (d *Digest) HTML() (string, error) {
+	buf := bytes.Buffer{}
+	if err := digestTemplate.Execute(&buf, d); err != nil {
+		return "", fmt.Errorf("ダイジェストのHTMLの作成に失敗: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// TokenStatus はトークンの有効期限の表示
+func (d *Digest) TokenStatus() string {
+	if d.TokenExpiresAt == nil {
+		return "有効期限を取得できませんでした"
+	}
+	status := fmt.Sprintf("有効期限: %s", formatDigestTime(*d.TokenExpiresAt))
+	if d.TokenExpiring() {
+		status += " ‼️有効期限が近づいています"
+	}
+	return status
+}
+
+var digestTemplate = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"account":       formatDigestAccount,
+	"time":          formatDigestTime,
+	"lastPublished": formatLastPublished,
+}).Parse(`<!DOCTYPE html>
+<html lang="ja">
+<head><meta charset="UTF-8"><title>{{.Subject}}</title></head>
+<body style="font-family: sans-serif; color: #333;">
+<h2>{{.Period.Label}}ダイジェスト</h2>
+<p>{{time .From}} 〜 {{time .To}}</p>
+<p>投稿: <b>{{.Published}}</b>件 / スキップ: <b>{{.Skipped}}</b>件 / 失敗: <b{{if .Failed}} style="color: #c00;"{{end}}>{{.Failed}}</b>件</p>
+
+<h3>連携ごとの結果</h3>
+{{- if .Accounts}}
+<table border="1" cellpadding="4" cellspacing="0" style="border-collapse: collapse;">
+<tr><th>連携</th><th>投稿</th><th>スキップ</th><th>失敗</th><th>最後のエラー</th></tr>
+{{- range .Accounts}}
+<tr><td>{{account .Account}}</td><td>{{.Published}}</td><td>{{.Skipped}}</td><td>{{.Failed}}</td><td>{{.LastError}}</td></tr>
+{{- end}}
+</table>
+{{- else}}
+<p>なし</p>
+{{- end}}
+
+<h3>{{.InactiveDays}}日以上投稿が無い連携</h3>
+{{- if .InactiveAccounts}}
+<ul>
+{{- range .InactiveAccounts}}
+<li>{{account .Account}}（最終投稿: {{lastPublished .LastPublishedAt}}）</li>
+{{- end}}
+</ul>
+{{- else}}
+<p>なし</p>
+{{- end}}
+
+<h3>Instagramトークン</h3>
+<p{{if .TokenExpiring}} style="color: #c00;"{{end}}>{{.TokenStatus}}</p>
+</body>
+</html>
+`))
+
+var digestJST = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+func formatDigestTime(t time.Time) string {
+	return t.In(digestJST).Format("2006/01/02 15:04")
+}
+
+func formatDigestDate(t time.Time) string {
+	return t.In(digestJST).Format("2006/01/02")
+}
+
+func formatLastPublished(t *time.Time) string {
+	if t == nil {
+		return "なし"
+	}
+	return formatDigestDate(*t)
+}
+
+func formatDigestAccount(a Account) string {
+	return fmt.Sprintf("%s id=%d %s", a.Type, a.ID, a.Name)
+}
+
+func accountLess(a, b Account) bool {
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	return a.ID < b.ID
+}
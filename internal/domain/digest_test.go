@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDigest(t *testing.T) {
+	to := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	wi := Account{Type: AccountTypeWordpressInstagram, ID: 3, Name: "カフェ"}
+	wg := Account{Type: AccountTypeWordpressGbp, ID: 1, Name: "パン屋"}
+	activities := []*SyncActivity{
+		{Account: wi, Result: SyncResultPublished, PostKey: "m1", CreatedAt: to.Add(-2 * time.Hour)},
+		{Account: wi, Result: SyncResultPublished, PostKey: "m2", CreatedAt: to.Add(-time.Hour)},
+		{Account: wi, Result: SyncResultFailed, Detail: "新しいエラー", CreatedAt: to.Add(-30 * time.Minute)},
+		{Account: wi, Result: SyncResultFailed, Detail: "古いエラー", CreatedAt: to.Add(-3 * time.Hour)},
+		{Account: wg, Result: SyncResultSkipped, PostKey: "10_0", CreatedAt: to.Add(-5 * time.Hour)},
+		{Account: wg, Result: SyncResultSkipped, PostKey: "10_0", CreatedAt: to.Add(-4 * time.Hour)},
+		{Account: wg, Result: SyncResultSkipped, PostKey: "10_1", CreatedAt: to.Add(-4 * time.Hour)},
+		// 期間外
+		{Account: wg, Result: SyncResultPublished, PostKey: "9_0", CreatedAt: to.Add(-25 * time.Hour)},
+		{Account: wg, Result: SyncResultPublished, PostKey: "11_0", CreatedAt: to},
+	}
+
+	d := NewDigest(DigestPeriodDaily, to, activities)
+	assert.Equal(t, to.Add(-24*time.Hour), d.From)
+	assert.Equal(t, 2, d.Published)
+	assert.Equal(t, 2, d.Skipped)
+	assert.Equal(t, 2, d.Failed)
+	assert.Equal(t, []DigestAccountSummary{
+		{Account: wg, Skipped: 2},
+		{Account: wi, Published: 2, Failed: 2, LastError: "新しいエラー"},
+	}, d.Accounts)
+
+	weekly := NewDigest(DigestPeriodWeekly, to, activities)
+	assert.Equal(t, to.Add(-7*24*time.Hour), weekly.From)
+	assert.Equal(t, 3, weekly.Published)
+}
+
+func TestDigest_SetInactiveAccounts(t *testing.T) {
+	to := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	active := Account{Type: AccountTypeFeed, ID: 1, Name: "ブログ"}
+	stale := Account{Type: AccountTypeBusinessInstagram, ID: 2, Name: "パン屋"}
+	never := Account{Type: AccountTypeBusinessInstagram, ID: 5, Name: "花屋"}
+	staleAt := to.AddDate(0, 0, -20)
+
+	d := NewDigest(DigestPeriodDaily, to, nil)
+	d.SetInactiveAccounts([]Account{active, never, stale}, []*SyncActivity{
+		{Account: active, Result: SyncResultPublished, CreatedAt: to.AddDate(0, 0, -3)},
+		{Account: stale, Result: SyncResultPublished, CreatedAt: staleAt},
+		// 停止中の連携は対象外
+		{Account: Account{Type: AccountTypeFeed, ID: 9}, Result: SyncResultPublished, CreatedAt: staleAt},
+	}, 14)
+
+	assert.Equal(t, 14, d.InactiveDays)
+	assert.Equal(t, []DigestInactiveAccount{
+		{Account: stale, LastPublishedAt: &staleAt},
+		{Account: never},
+	}, d.InactiveAccounts)
+}
+
+func TestDigest_TokenExpiring(t *testing.T) {
+	to := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	d := NewDigest(DigestPeriodDaily, to, nil)
+	assert.False(t, d.TokenExpiring())
+	assert.Equal(t, "有効期限を取得できませんでした", d.TokenStatus())
+
+	expiresAt := to.AddDate(0, 0, 30)
+	d.TokenExpiresAt = &expiresAt
+	assert.False(t, d.TokenExpiring())
+
+	expiresAt = to.AddDate(0, 0, 5)
+	assert.True(t, d.TokenExpiring())
+	assert.Equal(t, "有効期限: 2026/03/21 09:00 ‼️有効期限が近づいています", d.TokenStatus())
+}
+
+func TestDigest_Render(t *testing.T) {
+	to := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	fi := Account{Type: AccountTypeFacebookInstagram, ID: 4, Name: "<b>パン屋</b>"}
+	d := NewDigest(DigestPeriodWeekly, to, []*SyncActivity{
+		{Account: fi, Result: SyncResultPublished, PostKey: "m1", CreatedAt: to.Add(-time.Hour)},
+		{Account: fi, Result: SyncResultFailed, Detail: "token expired", CreatedAt: to.Add(-time.Hour)},
+	})
+	d.SetInactiveAccounts([]Account{{Type: AccountTypeFeed, ID: 2, Name: "ブログ"}}, nil, 14)
+
+	assert.Equal(t, "[homing] 週次ダイジェスト 2026/03/16", d.Subject())
+	assert.Equal(t, `[週次ダイジェスト] 2026/03/09 09:00 〜 2026/03/16 09:00
+投稿: 1件 / スキップ: 0件 / 失敗: 1件
+
+■ 連携ごとの結果
+- facebook_instagram id=4 <b>パン屋</b>: 投稿 1件 / スキップ 0件 / 失敗 1件
+  最後のエラー: token expired
+
+■ 14日以上投稿が無い連携
+- feed id=2 ブログ（最終投稿: なし）
+
+■ Instagramトークン
+有効期限を取得できませんでした
+`, d.Text())
+
+	html, err := d.HTML()
+	assert.NoError(t, err)
+	assert.Contains(t, html, "<h2>週次ダイジェスト</h2>")
+	assert.Contains(t, html, "<td>facebook_instagram id=4 &lt;b&gt;パン屋&lt;/b&gt;</td><td>1</td><td>0</td><td>1</td><td>token expired</td>")
+	assert.Contains(t, html, "<li>feed id=2 ブログ（最終投稿: なし）</li>")
+
+	n, err := NewDigestNotification(d)
+	assert.NoError(t, err)
+	assert.Equal(t, NotificationEventDigest, n.EventType)
+	assert.Equal(t, d.Text(), n.Text)
+	assert.Equal(t, html, n.HTML)
+	assert.Contains(t, n.HTMLMessage("店長様"), "<body style=\"font-family: sans-serif; color: #333;\">\n<p>店長様</p>\n<h2>")
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type FacebookInstagram struct {
+	ID               int
+	Name             string
+	Memo             string
+	InstagramID      string
+	InstagramName    string
+	FacebookPageID   string
+	FacebookPageName string
+	StartDate        time.Time
+	Status           Status
+	DeleteHash       bool
+	UpdatedAt        time.Time
+	CreatedAt        time.Time
+}
+
+// FacebookPage は投稿先のFacebookページ。AccessToken はページのアクセストークン。
+type FacebookPage struct {
+	ID          string
+	Name        string
+	AccessToken string
+}
+
+// FacebookPost はFacebookページに投稿したInstagramの投稿の記録
+type FacebookPost struct {
+	ID                  int
+	FacebookInstagramID int
+	MediaID             string
+	Permalink           string
+	FacebookPostID      string
+	FacebookURL         string
+	CreatedAt           time.Time
+}
+
+// FacebookPagePost はFacebookページへの投稿内容。
+// PhotoURLs が1件なら写真投稿、複数なら複数写真の投稿、VideoURL があれば動画投稿になる。
+type FacebookPagePost struct {
+	Message   string
+	PhotoURLs []string
+	VideoURL  string
+}
+
+func (p FacebookPagePost) IsVideo() bool {
+	return p.VideoURL != ""
+}
+
+// FacebookPublished は投稿結果
+type FacebookPublished struct {
+	ID  string
+	URL string
+}
+
+// facebookMaxPhotos は1投稿に添付できる写真の上限
+const facebookMaxPhotos = 10
+
+// ToFacebookPagePost はInstagramの投稿からFacebookページへの投稿内容を作る。
+// メディアのURLは mediaURL で差し替える（InstagramのCDN URLは期限切れになるため公開URLに置き換える）。
+// カルーセルは写真のみを添付し、写真が無い場合は最初の動画を動画投稿にする。
+func ToFacebookPagePost(post InstagramPost, deleteHash bool, mediaURL func(string) (string, error)) (FacebookPagePost, error) {
+	message := post.Caption
+	if deleteHash {
+		message = removeHashtags(message)
+	}
+	result := FacebookPagePost{Message: message}
+
+	switch {
+	case len(post.Children) == 0 && post.MediaType == "VIDEO":
+		u, err := mediaURL(post.MediaURL)
+		if err != nil {
+			return result, err
+		}
+		result.VideoURL = u
+	case len(post.Children) == 0:
+		u, err := mediaURL(post.MediaURL)
+		if err != nil {
+			return result, err
+		}
+		result.PhotoURLs = []string{u}
+	default:
+		var firstVideo string
+		for _, child := range post.Children {
+			if child.MediaType == "VIDEO" {
+				if firstVideo == "" {
+					firstVideo = child.MediaURL
+				}
+				continue
+			}
+			if len(result.PhotoURLs) >= facebookMaxPhotos {
+				break
+			}
+			u, err := mediaURL(child.MediaURL)
+			if err != nil {
+				return result, err
+			}
+			result.PhotoURLs = append(result.PhotoURLs, u)
+		}
+		if len(result.PhotoURLs) == 0 {
+			if firstVideo == "" {
+				return result, fmt.Errorf("%w: 投稿できるメディアがありません: %s", ErrBadRequest, post.ID)
+			}
+			u, err := mediaURL(firstVideo)
+			if err != nil {
+				return result, err
+			}
+			result.VideoURL = u
+		}
+	}
+	return result, nil
+}
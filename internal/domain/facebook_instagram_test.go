@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToFacebookPagePost(t *testing.T) {
+	public := func(u string) (string, error) {
+		return "https://s3.example.com/" + u, nil
+	}
+
+	post, err := ToFacebookPagePost(InstagramPost{MediaType: "IMAGE", MediaURL: "a.jpg", Caption: "新作です #cafe"}, true, public)
+	assert.NoError(t, err)
+	assert.Equal(t, "新作です", post.Message)
+	assert.Equal(t, []string{"https://s3.example.com/a.jpg"}, post.PhotoURLs)
+	assert.False(t, post.IsVideo())
+
+	post, err = ToFacebookPagePost(InstagramPost{MediaType: "VIDEO", MediaURL: "a.mp4", Caption: "#cafe"}, false, public)
+	assert.NoError(t, err)
+	assert.Equal(t, "#cafe", post.Message)
+	assert.Equal(t, "https://s3.example.com/a.mp4", post.VideoURL)
+	assert.True(t, post.IsVideo())
+
+	// カルーセルは写真のみ添付する
+	post, err = ToFacebookPagePost(InstagramPost{MediaType: "CAROUSEL_ALBUM", MediaURL: "a.jpg", Children: []InstagramPostChildren{
+		{MediaType: "IMAGE", MediaURL: "a.jpg"},
+		{MediaType: "VIDEO", MediaURL: "b.mp4"},
+		{MediaType: "IMAGE", MediaURL: "c.jpg"},
+	}}, false, public)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://s3.example.com/a.jpg", "https://s3.example.com/c.jpg"}, post.PhotoURLs)
+	assert.False(t, post.IsVideo())
+
+	// 写真が無いカルーセルは最初の動画を投稿する
+	post, err = ToFacebookPagePost(InstagramPost{MediaType: "CAROUSEL_ALBUM", Children: []InstagramPostChildren{
+		{MediaType: "VIDEO", MediaURL: "b.mp4"},
+		{MediaType: "VIDEO", MediaURL: "d.mp4"},
+	}}, false, public)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://s3.example.com/b.mp4", post.VideoURL)
+
+	uploadErr := errors.New("upload failed")
+	_, err = ToFacebookPagePost(InstagramPost{MediaType: "IMAGE", MediaURL: "a.jpg"}, false, func(string) (string, error) {
+		return "", uploadErr
+	})
+	assert.ErrorIs(t, err, uploadErr)
+}
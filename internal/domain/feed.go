@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Feed はRSS/Atomフィードを取得して、WordPressの記事やGBPの投稿として連携する設定。
+// WordpressDomain と BusinessName のうち設定されている方（両方可）に連携する。
+type Feed struct {
+	ID                 int
+	Name               string
+	Memo               string
+	FeedURL            string
+	FeedTitle          string
+	StartDate          time.Time
+	Status             Status
+	WordpressDomain    string
+	WordpressSiteTitle string
+	APIMode            WordpressAPIMode
+	WordpressUsername  string
+	AppPassword        string
+	Categories         []string
+	BusinessName       string
+	BusinessTitle      string
+	CallToActionType   CallToActionType
+	CallToActionURL    string
+	UpdatedAt          time.Time
+	CreatedAt          time.Time
+}
+
+func (f *Feed) HasWordpress() bool {
+	return f.WordpressDomain != ""
+}
+
+func (f *Feed) HasGbp() bool {
+	return f.BusinessName != ""
+}
+
+// WordpressInstagram はWordPressアダプターに渡すための投稿先設定を返す。
+func (f *Feed) WordpressInstagram() WordpressInstagram {
+	apiMode := f.APIMode
+	if apiMode == "" {
+		apiMode = WordpressAPIModeRodut
+	}
+	return WordpressInstagram{
+		Name:              f.Name,
+		WordpressDomain:   f.WordpressDomain,
+		Categories:        f.Categories,
+		APIMode:           apiMode,
+		WordpressUsername: f.WordpressUsername,
+		AppPassword:       f.AppPassword,
+	}
+}
+
+// Validate はフィードのURLと連携先の設定を検証する。
+func (f *Feed) Validate() error {
+	u, err := url.Parse(f.FeedURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: feed_url が不正です: %s", ErrBadRequest, f.FeedURL)
+	}
+	if !f.HasWordpress() && !f.HasGbp() {
+		return fmt.Errorf("%w: wordpress_domain か business_name のどちらかを指定してください", ErrBadRequest)
+	}
+	if f.HasWordpress() {
+		wi := f.WordpressInstagram()
+		if err := wi.ValidateAPIMode(); err != nil {
+			return err
+		}
+	}
+	if f.HasGbp() {
+		// URL未設定の場合はフィードの記事のURLを使う
+		if err := ValidateCallToAction(f.CallToActionType, f.CallToActionURL, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FeedDestination はフィードの記事の連携先
+type FeedDestination string
+
+const (
+	FeedDestinationWordpress FeedDestination = "wordpress"
+	FeedDestinationGbp       FeedDestination = "gbp"
+)
+
+// FeedPost は連携したフィードの記事の記録。GUIDと連携先の組み合わせで重複を判定する。
+type FeedPost struct {
+	ID          int
+	FeedID      int
+	Destination FeedDestination
+	GUID        string
+	Link        string
+	URL         string
+	CreatedAt   time.Time
+}
+
+// FeedChannel は取得したフィードの内容
+type FeedChannel struct {
+	Title string
+	Items []FeedItem
+}
+
+// FeedItem はフィードの記事。ImageURLs は enclosure と media:content の画像。
+type FeedItem struct {
+	GUID        string
+	Title       string
+	Link        string
+	Content     string
+	PublishedAt time.Time
+	ImageURLs   []string
+}
+
+func (i FeedItem) GUIDHash() string {
+	return FeedGUIDHash(i.GUID)
+}
+
+// FeedGUIDHash はGUIDのハッシュ。GUIDはURLのことが多く長さが決まらないため、重複判定にはハッシュを使う。
+func FeedGUIDHash(guid string) string {
+	hash := sha256.Sum256([]byte(guid))
+	return hex.EncodeToString(hash[:])
+}
+
+// GetPostDate はWordPressの投稿日時（日本時間）
+func (i FeedItem) GetPostDate() string {
+	jst := time.FixedZone("JST", 9*60*60)
+	return i.PublishedAt.In(jst).Format("2006-01-02 15:04:05")
+}
+
+// GetWordpressContent は記事の本文の末尾に元記事へのリンクを付けたもの
+func (i FeedItem) GetWordpressContent() string {
+	if i.Link == "" {
+		return i.Content
+	}
+	return fmt.Sprintf(`%s<p><a href="%s" target="_blank" rel="noopener">元の記事を見る</a></p>`, i.Content, html.EscapeString(i.Link))
+}
+
+var (
+	feedTagPattern        = regexp.MustCompile(`(?s)<[^>]*>`)
+	feedBlockTagPattern   = regexp.MustCompile(`(?i)<(br\s*/?|/p|/div|/li|/h[1-6])>`)
+	feedBlankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// GetPlainText はGBPの投稿用にタイトルと本文をテキストにしたもの
+func (i FeedItem) GetPlainText() string {
+	content := feedBlockTagPattern.ReplaceAllString(i.Content, "\n")
+	content = html.UnescapeString(feedTagPattern.ReplaceAllString(content, ""))
+	lines := strings.Split(content, "\n")
+	for idx, line := range lines {
+		lines[idx] = strings.TrimSpace(line)
+	}
+	content = feedBlankLinesPattern.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	title := strings.TrimSpace(html.UnescapeString(i.Title))
+	content = strings.TrimSpace(content)
+	if title == "" {
+		return content
+	}
+	if content == "" {
+		return title
+	}
+	return title + "\n\n" + content
+}
@@ -0,0 +1,306 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html/charset"
+)
+
+const feedNamespaceAtom = "http://www.w3.org/2005/Atom"
+
+type feedMediaContent struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Medium string `xml:"medium,attr"`
+}
+
+type feedMediaGroup struct {
+	Contents []feedMediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+}
+
+type rssEnclosure struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title          string             `xml:"title"`
+	Links          []string           `xml:"link"`
+	GUID           string             `xml:"guid"`
+	About          string             `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	PubDate        string             `xml:"pubDate"`
+	DCDate         string             `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Description    string             `xml:"description"`
+	ContentEncoded string             `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Enclosures     []rssEnclosure     `xml:"enclosure"`
+	MediaContents  []feedMediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaGroups    []feedMediaGroup   `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+// rssDocument は RSS 2.0 と RSS 1.0(RDF) の両方を読む。RSS 1.0 は item が channel の外にある。
+type rssDocument struct {
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Items []rssItem `xml:"item"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Body  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// String はテキストを返す。xhtml の場合は要素をそのまま、html/text の場合はエスケープを戻した内容を返す。
+func (t atomText) String() string {
+	if t.Type == "xhtml" {
+		return strings.TrimSpace(t.Inner)
+	}
+	return strings.TrimSpace(t.Body)
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type atomEntry struct {
+	ID            string             `xml:"http://www.w3.org/2005/Atom id"`
+	Title         atomText           `xml:"http://www.w3.org/2005/Atom title"`
+	Links         []atomLink         `xml:"http://www.w3.org/2005/Atom link"`
+	Published     string             `xml:"http://www.w3.org/2005/Atom published"`
+	Updated       string             `xml:"http://www.w3.org/2005/Atom updated"`
+	Content       atomText           `xml:"http://www.w3.org/2005/Atom content"`
+	Summary       atomText           `xml:"http://www.w3.org/2005/Atom summary"`
+	MediaContents []feedMediaContent `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaGroups   []feedMediaGroup   `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+type atomDocument struct {
+	Title   atomText    `xml:"http://www.w3.org/2005/Atom title"`
+	Entries []atomEntry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+// ParseFeed はRSS 2.0 / RSS 1.0 / Atom のフィードを解析する。
+// 投稿日時が解析できない記事は PublishedAt がゼロ値になり、連携開始日の判定で除外される。
+func ParseFeed(data []byte) (*FeedChannel, error) {
+	root, err := feedRootName(data)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case root.Space == feedNamespaceAtom && root.Local == "feed":
+		var doc atomDocument
+		if err := decodeFeed(data, &doc); err != nil {
+			return nil, err
+		}
+		channel := &FeedChannel{Title: doc.Title.String()}
+		for _, entry := range doc.Entries {
+			channel.Items = append(channel.Items, entry.toFeedItem())
+		}
+		return channel, nil
+	case root.Local == "rss" || root.Local == "RDF":
+		var doc rssDocument
+		if err := decodeFeed(data, &doc); err != nil {
+			return nil, err
+		}
+		channel := &FeedChannel{Title: strings.TrimSpace(doc.Channel.Title)}
+		for _, item := range append(doc.Channel.Items, doc.Items...) {
+			channel.Items = append(channel.Items, item.toFeedItem())
+		}
+		return channel, nil
+	}
+	return nil, fmt.Errorf("RSS/Atomのフィードではありません: <%s>", root.Local)
+}
+
+func feedRootName(data []byte) (xml.Name, error) {
+	dec := newFeedDecoder(data)
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return xml.Name{}, fmt.Errorf("フィードの解析に失敗: %w", err)
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			return start.Name, nil
+		}
+	}
+}
+
+func decodeFeed(data []byte, v any) error {
+	if err := newFeedDecoder(data).Decode(v); err != nil {
+		return fmt.Errorf("フィードの解析に失敗: %w", err)
+	}
+	return nil
+}
+
+// newFeedDecoder はShift_JIS/EUC-JPなどのフィードも読めるデコーダーを作る。
+// 実体参照(&nbsp;など)を含むフィードも多いため、Strict は無効にする。
+func newFeedDecoder(data []byte) *xml.Decoder {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.CharsetReader = charset.NewReaderLabel
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	return dec
+}
+
+func (r rssItem) toFeedItem() FeedItem {
+	var link string
+	for _, l := range r.Links {
+		if l = strings.TrimSpace(l); l != "" {
+			link = l
+			break
+		}
+	}
+	content := strings.TrimSpace(r.ContentEncoded)
+	if content == "" {
+		content = strings.TrimSpace(r.Description)
+	}
+	published := parseFeedTime(r.PubDate)
+	if published.IsZero() {
+		published = parseFeedTime(r.DCDate)
+	}
+
+	images := newFeedImages()
+	for _, e := range r.Enclosures {
+		images.add(e.URL, e.Type, "")
+	}
+	images.addMedia(r.MediaContents, r.MediaGroups)
+
+	title := strings.TrimSpace(r.Title)
+	return FeedItem{
+		GUID:        feedGUID(title, link, r.PubDate, strings.TrimSpace(r.GUID), strings.TrimSpace(r.About)),
+		Title:       title,
+		Link:        link,
+		Content:     content,
+		PublishedAt: published,
+		ImageURLs:   images.urls,
+	}
+}
+
+func (e atomEntry) toFeedItem() FeedItem {
+	var link string
+	images := newFeedImages()
+	for _, l := range e.Links {
+		switch l.Rel {
+		case "", "alternate":
+			if link == "" {
+				link = strings.TrimSpace(l.Href)
+			}
+		case "enclosure":
+			images.add(l.Href, l.Type, "")
+		}
+	}
+	images.addMedia(e.MediaContents, e.MediaGroups)
+
+	content := e.Content.String()
+	if content == "" {
+		content = e.Summary.String()
+	}
+	published := parseFeedTime(e.Published)
+	if published.IsZero() {
+		published = parseFeedTime(e.Updated)
+	}
+
+	title := e.Title.String()
+	return FeedItem{
+		GUID:        feedGUID(title, link, e.Published, strings.TrimSpace(e.ID)),
+		Title:       title,
+		Link:        link,
+		Content:     content,
+		PublishedAt: published,
+		ImageURLs:   images.urls,
+	}
+}
+
+// feedGUID は記事を一意に識別する値を返す。guid/id > rdf:about > link > タイトルと日時 の順で使う。
+func feedGUID(title, link, date string, candidates ...string) string {
+	for _, c := range candidates {
+		if c != "" {
+			return c
+		}
+	}
+	if link != "" {
+		return link
+	}
+	return title + "|" + date
+}
+
+var feedTimeLayouts = []string{
+	time.RFC1123Z,
+	time.RFC1123,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseFeedTime はフィードの日時を解析する。タイムゾーンが無い場合は日本時間とみなす。
+func parseFeedTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	jst := time.FixedZone("JST", 9*60*60)
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, jst); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+type feedImages struct {
+	urls []string
+	seen map[string]bool
+}
+
+func newFeedImages() *feedImages {
+	return &feedImages{seen: map[string]bool{}}
+}
+
+func (f *feedImages) add(rawURL, mimeType, medium string) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || f.seen[rawURL] || !isFeedImage(rawURL, mimeType, medium) {
+		return
+	}
+	f.seen[rawURL] = true
+	f.urls = append(f.urls, rawURL)
+}
+
+func (f *feedImages) addMedia(contents []feedMediaContent, groups []feedMediaGroup) {
+	for _, m := range contents {
+		f.add(m.URL, m.Type, m.Medium)
+	}
+	for _, g := range groups {
+		for _, m := range g.Contents {
+			f.add(m.URL, m.Type, m.Medium)
+		}
+	}
+}
+
+// isFeedImage は添付が画像かどうかを判定する。type/medium が無い場合は拡張子で判定する。
+func isFeedImage(rawURL, mimeType, medium string) bool {
+	if medium != "" {
+		return medium == "image"
+	}
+	if mimeType != "" {
+		return strings.HasPrefix(strings.ToLower(mimeType), "image/")
+	}
+	ext := strings.ToLower(path.Ext(strings.SplitN(rawURL, "?", 2)[0]))
+	switch ext {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp":
+		return true
+	}
+	return false
+}
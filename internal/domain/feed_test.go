@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFeed_RSS(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:media="http://search.yahoo.com/mrss/" xmlns:atom="http://www.w3.org/2005/Atom">
+<channel>
+  <title>お知らせ</title>
+  <atom:link href="https://example.com/feed" rel="self" type="application/rss+xml"/>
+  <item>
+    <title>臨時休業のお知らせ</title>
+    <link>https://example.com/news/1</link>
+    <guid isPermaLink="false">news-1</guid>
+    <pubDate>Tue, 03 Mar 2026 10:00:00 +0900</pubDate>
+    <description>概要</description>
+    <content:encoded><![CDATA[<p>本文&nbsp;です</p>]]></content:encoded>
+    <enclosure url="https://example.com/a.jpg" type="image/jpeg" length="100"/>
+    <enclosure url="https://example.com/a.mp3" type="audio/mpeg" length="100"/>
+    <media:content url="https://example.com/b.png" medium="image"/>
+    <media:group>
+      <media:content url="https://example.com/c.webp"/>
+      <media:content url="https://example.com/a.jpg" type="image/jpeg"/>
+    </media:group>
+  </item>
+  <item>
+    <title>GUIDなし</title>
+    <link>https://example.com/news/2</link>
+    <pubDate>invalid</pubDate>
+    <description>&lt;b&gt;説明&lt;/b&gt;</description>
+  </item>
+</channel>
+</rss>`)
+
+	channel, err := ParseFeed(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "お知らせ", channel.Title)
+	assert.Len(t, channel.Items, 2)
+
+	item := channel.Items[0]
+	assert.Equal(t, "news-1", item.GUID)
+	assert.Equal(t, "https://example.com/news/1", item.Link)
+	assert.Equal(t, "<p>本文&nbsp;です</p>", item.Content)
+	assert.Equal(t, time.Date(2026, 3, 3, 1, 0, 0, 0, time.UTC), item.PublishedAt.UTC())
+	assert.Equal(t, []string{"https://example.com/a.jpg", "https://example.com/b.png", "https://example.com/c.webp"}, item.ImageURLs)
+
+	item = channel.Items[1]
+	assert.Equal(t, "https://example.com/news/2", item.GUID)
+	assert.Equal(t, "<b>説明</b>", item.Content)
+	assert.True(t, item.PublishedAt.IsZero())
+	assert.Empty(t, item.ImageURLs)
+}
+
+func TestParseFeed_RDF(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel rdf:about="https://example.com/">
+    <title>ブログ</title>
+  </channel>
+  <item rdf:about="https://example.com/entry/1">
+    <title>記事</title>
+    <link>https://example.com/entry/1</link>
+    <dc:date>2026-03-03T10:00:00+09:00</dc:date>
+  </item>
+</rdf:RDF>`)
+
+	channel, err := ParseFeed(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "ブログ", channel.Title)
+	assert.Len(t, channel.Items, 1)
+	assert.Equal(t, "https://example.com/entry/1", channel.Items[0].GUID)
+	assert.Equal(t, time.Date(2026, 3, 3, 1, 0, 0, 0, time.UTC), channel.Items[0].PublishedAt.UTC())
+}
+
+func TestParseFeed_Atom(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/">
+  <title>Atom Blog</title>
+  <entry>
+    <id>tag:example.com,2026:1</id>
+    <title type="html">A &amp;amp; B</title>
+    <link rel="alternate" href="https://example.com/1"/>
+    <link rel="enclosure" type="image/png" href="https://example.com/1.png"/>
+    <updated>2026-03-04T00:00:00Z</updated>
+    <published>2026-03-03T00:00:00Z</published>
+    <summary>要約</summary>
+    <content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>本文</p></div></content>
+    <media:content url="https://example.com/2.jpg" type="image/jpeg"/>
+  </entry>
+  <entry>
+    <id>tag:example.com,2026:2</id>
+    <title>要約だけ</title>
+    <link href="https://example.com/2"/>
+    <updated>2026-03-05T00:00:00Z</updated>
+    <summary type="html">&lt;p&gt;要約&lt;/p&gt;</summary>
+  </entry>
+</feed>`)
+
+	channel, err := ParseFeed(data)
+	assert.NoError(t, err)
+	assert.Equal(t, "Atom Blog", channel.Title)
+	assert.Len(t, channel.Items, 2)
+
+	item := channel.Items[0]
+	assert.Equal(t, "tag:example.com,2026:1", item.GUID)
+	assert.Equal(t, "A &amp; B", item.Title)
+	assert.Equal(t, "https://example.com/1", item.Link)
+	assert.Contains(t, item.Content, "<p>本文</p>")
+	assert.Equal(t, time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), item.PublishedAt.UTC())
+	assert.Equal(t, []string{"https://example.com/1.png", "https://example.com/2.jpg"}, item.ImageURLs)
+
+	item = channel.Items[1]
+	assert.Equal(t, "https://example.com/2", item.Link)
+	assert.Equal(t, "<p>要約</p>", item.Content)
+	assert.Equal(t, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), item.PublishedAt.UTC())
+}
+
+func TestParseFeed_NotFeed(t *testing.T) {
+	_, err := ParseFeed([]byte(`<html><body>Not Found</body></html>`))
+	assert.Error(t, err)
+}
+
+func TestFeedItem_GetPlainText(t *testing.T) {
+	item := FeedItem{
+		Title:   "A &amp; B",
+		Content: "<p>1行目<br>2行目</p>\n\n\n<p>&lt;3行目&gt;</p>",
+	}
+	assert.Equal(t, "A & B\n\n1行目\n2行目\n\n<3行目>", item.GetPlainText())
+}
+
+func TestFeed_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		feed    Feed
+		wantErr bool
+	}{
+		{name: "WordPress", feed: Feed{FeedURL: "https://example.com/feed", WordpressDomain: "example.com"}},
+		{name: "GBP", feed: Feed{FeedURL: "https://example.com/feed", BusinessName: "locations/1", CallToActionType: CallToActionLearnMore}},
+		{name: "連携先なし", feed: Feed{FeedURL: "https://example.com/feed"}, wantErr: true},
+		{name: "URLが不正", feed: Feed{FeedURL: "example.com/feed", WordpressDomain: "example.com"}, wantErr: true},
+		{name: "wp/v2 認証情報なし", feed: Feed{FeedURL: "https://example.com/feed", WordpressDomain: "example.com", APIMode: WordpressAPIModeV2}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.feed.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrBadRequest))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
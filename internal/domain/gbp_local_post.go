@@ -0,0 +1,286 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// CallToActionType はGBPのLocal Postに付けるボタンの種類
+type CallToActionType string
+
+const (
+	// CallToActionUnset はボタン未設定。WordPress・フィード連携では「詳細」ボタンを付け、Instagram連携ではボタンを付けない。
+	CallToActionUnset CallToActionType = ""
+	// CallToActionNone はボタンを付けない
+	CallToActionNone      CallToActionType = "NONE"
+	CallToActionLearnMore CallToActionType = "LEARN_MORE"
+	CallToActionBook      CallToActionType = "BOOK"
+	CallToActionOrder     CallToActionType = "ORDER"
+	CallToActionCall      CallToActionType = "CALL"
+	CallToActionSignUp    CallToActionType = "SIGN_UP"
+)
+
+func (c CallToActionType) Valid() bool {
+	switch c {
+	case CallToActionUnset, CallToActionNone, CallToActionLearnMore, CallToActionBook, CallToActionOrder, CallToActionCall, CallToActionSignUp:
+		return true
+	}
+	return false
+}
+
+// RequiresURL はURLが必要なボタンかどうか。CALLはビジネスの電話番号が使われるためURL不要。
+func (c CallToActionType) RequiresURL() bool {
+	return c != CallToActionUnset && c != CallToActionNone && c != CallToActionCall
+}
+
+// ValidateCallToAction はアカウントに設定するボタン種別とURLを検証する。
+// allowEmptyURL が true の場合はURL未設定を許容する（投稿URLで補完されるWordPress連携用）。
+func ValidateCallToAction(actionType CallToActionType, url string, allowEmptyURL bool) error {
+	if !actionType.Valid() {
+		return fmt.Errorf("%w: call_to_action_type が不正です: %s", ErrBadRequest, actionType)
+	}
+	if actionType.RequiresURL() && url == "" && !allowEmptyURL {
+		return fmt.Errorf("%w: call_to_action_type=%s には call_to_action_url が必要です", ErrBadRequest, actionType)
+	}
+	return nil
+}
+
+// GbpTopicType はLocal Postの種類
+type GbpTopicType string
+
+const (
+	GbpTopicStandard GbpTopicType = "STANDARD"
+	GbpTopicEvent    GbpTopicType = "EVENT"
+	GbpTopicOffer    GbpTopicType = "OFFER"
+)
+
+type GbpCallToAction struct {
+	ActionType CallToActionType
+	URL        string
+}
+
+type GbpEvent struct {
+	Title string
+	Start time.Time
+	End   time.Time
+	// AllDay が true の場合は日付のみをGBPに送る
+	AllDay bool
+}
+
+type GbpOffer struct {
+	CouponCode      string
+	RedeemOnlineURL string
+	TermsConditions string
+}
+
+// GbpLocalPost はGBPに作成するLocal Postの内容
+type GbpLocalPost struct {
+	Summary      string
+	MediaURL     string
+	TopicType    GbpTopicType
+	CallToAction *GbpCallToAction
+	Event        *GbpEvent
+	Offer        *GbpOffer
+}
+
+// SetCallToAction はボタンを設定する。URLが必要な種別でURLが空の場合はボタンを付けない。
+// OFFER投稿はGBPの仕様でボタンを付けられないため無視する。
+func (p *GbpLocalPost) SetCallToAction(actionType CallToActionType, url string) {
+	if actionType == CallToActionUnset || actionType == CallToActionNone || p.TopicType == GbpTopicOffer {
+		return
+	}
+	if actionType.RequiresURL() && url == "" {
+		return
+	}
+	if actionType == CallToActionCall {
+		url = ""
+	}
+	p.CallToAction = &GbpCallToAction{
+		ActionType: actionType,
+		URL:        url,
+	}
+}
+
+// GbpTopic は投稿本文やWordPressのカスタムフィールドから読み取ったLocal Postの種類と付随情報
+type GbpTopic struct {
+	TopicType GbpTopicType
+	Event     *GbpEvent
+	Offer     *GbpOffer
+}
+
+// Apply はTopicをLocal Postに反映する。期間が読み取れなかったEVENT/OFFERはSTANDARDとして扱う。
+func (t GbpTopic) Apply(p *GbpLocalPost) {
+	p.TopicType = GbpTopicStandard
+	if t.Event == nil || t.Event.Start.IsZero() {
+		return
+	}
+	switch t.TopicType {
+	case GbpTopicEvent:
+		p.TopicType = GbpTopicEvent
+		p.Event = t.Event
+	case GbpTopicOffer:
+		p.TopicType = GbpTopicOffer
+		p.Event = t.Event
+		p.Offer = t.Offer
+		if p.Offer == nil {
+			p.Offer = &GbpOffer{}
+		}
+		p.CallToAction = nil
+	}
+}
+
+var (
+	gbpEventMarkerPattern  = regexp.MustCompile(`^【(イベント|特典)】\s*(.*)$`)
+	gbpPeriodPattern       = regexp.MustCompile(`^(?:期間|日時)\s*[:：]\s*(.+?)\s*[〜~～]\s*(.+)$`)
+	gbpCouponPattern       = regexp.MustCompile(`^クーポン(?:コード)?\s*[:：]\s*(.+)$`)
+	gbpRedeemURLPattern    = regexp.MustCompile(`^特典URL\s*[:：]\s*(\S+)$`)
+	gbpTermsPattern        = regexp.MustCompile(`^利用条件\s*[:：]\s*(.+)$`)
+	gbpScheduleTimeLayouts = []string{
+		"2006/1/2 15:04",
+		"2006-01-02 15:04",
+		"2006-01-02T15:04",
+		time.RFC3339,
+	}
+	gbpScheduleDateLayouts = []string{
+		"2006/1/2",
+		"2006-01-02",
+	}
+)
+
+// ParseGbpTopicFromCaption はInstagramのキャプション等からEVENT/OFFERの指定を読み取る。
+// 以下の形式の行をマーカーとして扱い、マーカー行は本文から取り除いて返す。
+//
+//	【イベント】春の試食会
+//	【特典】初回10%オフ
+//	期間：2026/03/01 10:00〜2026/03/05 18:00
+//	クーポン：SPRING10
+//	特典URL：https://example.com/coupon
+//	利用条件：お一人様1回限り
+func ParseGbpTopicFromCaption(caption string) (GbpTopic, string) {
+	topic := GbpTopic{TopicType: GbpTopicStandard}
+
+	lines := strings.Split(caption, "\n")
+	marker := -1
+	for i, line := range lines {
+		m := gbpEventMarkerPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		marker = i
+		topic.Event = &GbpEvent{Title: strings.TrimSpace(m[2])}
+		if m[1] == "特典" {
+			topic.TopicType = GbpTopicOffer
+			topic.Offer = &GbpOffer{}
+		} else {
+			topic.TopicType = GbpTopicEvent
+		}
+		break
+	}
+	if marker < 0 {
+		return topic, caption
+	}
+
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if i == marker {
+			continue
+		}
+		if m := gbpPeriodPattern.FindStringSubmatch(trimmed); m != nil {
+			start, startAllDay, err1 := parseGbpScheduleTime(m[1])
+			end, endAllDay, err2 := parseGbpScheduleTime(m[2])
+			if err1 == nil && err2 == nil {
+				topic.Event.Start = start
+				topic.Event.End = end
+				topic.Event.AllDay = startAllDay && endAllDay
+				continue
+			}
+		}
+		if topic.Offer != nil {
+			if m := gbpCouponPattern.FindStringSubmatch(trimmed); m != nil {
+				topic.Offer.CouponCode = strings.TrimSpace(m[1])
+				continue
+			}
+			if m := gbpRedeemURLPattern.FindStringSubmatch(trimmed); m != nil {
+				topic.Offer.RedeemOnlineURL = m[1]
+				continue
+			}
+			if m := gbpTermsPattern.FindStringSubmatch(trimmed); m != nil {
+				topic.Offer.TermsConditions = strings.TrimSpace(m[1])
+				continue
+			}
+		}
+		out = append(out, line)
+	}
+	if topic.Event.Title == "" {
+		// タイトル未指定の場合は本文の1行目を使う
+		for _, line := range out {
+			if t := strings.TrimSpace(line); t != "" {
+				topic.Event.Title = t
+				break
+			}
+		}
+	}
+	return topic, strings.Join(out, "\n")
+}
+
+// WordPressのカスタムフィールドのキー
+const (
+	GbpFieldTopicType  = "gbp_topic_type"
+	GbpFieldEventTitle = "gbp_event_title"
+	GbpFieldEventStart = "gbp_event_start"
+	GbpFieldEventEnd   = "gbp_event_end"
+	GbpFieldCouponCode = "gbp_offer_coupon_code"
+	GbpFieldRedeemURL  = "gbp_offer_redeem_url"
+	GbpFieldTerms      = "gbp_offer_terms"
+	GbpFieldCTAType    = "gbp_cta_type"
+	GbpFieldCTAURL     = "gbp_cta_url"
+)
+
+// ParseGbpTopicFromFields はWordPressのカスタムフィールドからEVENT/OFFERの指定を読み取る。
+// gbp_topic_type が無い場合は ok=false を返す。
+func ParseGbpTopicFromFields(fields map[string]string) (GbpTopic, bool) {
+	topicType := GbpTopicType(strings.ToUpper(strings.TrimSpace(fields[GbpFieldTopicType])))
+	if topicType != GbpTopicEvent && topicType != GbpTopicOffer {
+		return GbpTopic{TopicType: GbpTopicStandard}, false
+	}
+
+	topic := GbpTopic{
+		TopicType: topicType,
+		Event:     &GbpEvent{Title: strings.TrimSpace(fields[GbpFieldEventTitle])},
+	}
+	start, startAllDay, err1 := parseGbpScheduleTime(fields[GbpFieldEventStart])
+	end, endAllDay, err2 := parseGbpScheduleTime(fields[GbpFieldEventEnd])
+	if err1 == nil && err2 == nil {
+		topic.Event.Start = start
+		topic.Event.End = end
+		topic.Event.AllDay = startAllDay && endAllDay
+	}
+	if topicType == GbpTopicOffer {
+		topic.Offer = &GbpOffer{
+			CouponCode:      strings.TrimSpace(fields[GbpFieldCouponCode]),
+			RedeemOnlineURL: strings.TrimSpace(fields[GbpFieldRedeemURL]),
+			TermsConditions: strings.TrimSpace(fields[GbpFieldTerms]),
+		}
+	}
+	return topic, true
+}
+
+// parseGbpScheduleTime は期間の日時を日本時間として解釈する。日付のみの場合は allDay=true を返す。
+func parseGbpScheduleTime(s string) (time.Time, bool, error) {
+	s = strings.TrimSpace(s)
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	for _, layout := range gbpScheduleTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, jst); err == nil {
+			return t, false, nil
+		}
+	}
+	for _, layout := range gbpScheduleDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, jst); err == nil {
+			return t, true, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("日時の形式が不正です: %s", s)
+}
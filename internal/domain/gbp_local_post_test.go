@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGbpTopicFromCaption(t *testing.T) {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+
+	t.Run("マーカーなしはSTANDARD", func(t *testing.T) {
+		topic, summary := ParseGbpTopicFromCaption("本日のランチです\n#ランチ")
+		assert.Equal(t, GbpTopicStandard, topic.TopicType)
+		assert.Equal(t, "本日のランチです\n#ランチ", summary)
+	})
+
+	t.Run("イベント", func(t *testing.T) {
+		topic, summary := ParseGbpTopicFromCaption("【イベント】春の試食会\n期間：2026/03/01 10:00〜2026/03/05 18:00\n皆様のお越しをお待ちしております")
+		assert.Equal(t, GbpTopicEvent, topic.TopicType)
+		assert.Equal(t, "春の試食会", topic.Event.Title)
+		assert.Equal(t, time.Date(2026, 3, 1, 10, 0, 0, 0, jst), topic.Event.Start)
+		assert.Equal(t, time.Date(2026, 3, 5, 18, 0, 0, 0, jst), topic.Event.End)
+		assert.False(t, topic.Event.AllDay)
+		assert.Equal(t, "皆様のお越しをお待ちしております", summary)
+	})
+
+	t.Run("特典", func(t *testing.T) {
+		topic, summary := ParseGbpTopicFromCaption("【特典】\n初回10%オフ\n期間: 2026-04-01~2026-04-30\nクーポン：SPRING10\n利用条件：お一人様1回限り")
+		assert.Equal(t, GbpTopicOffer, topic.TopicType)
+		assert.Equal(t, "初回10%オフ", topic.Event.Title)
+		assert.True(t, topic.Event.AllDay)
+		assert.Equal(t, "SPRING10", topic.Offer.CouponCode)
+		assert.Equal(t, "お一人様1回限り", topic.Offer.TermsConditions)
+		assert.Equal(t, "初回10%オフ", summary)
+	})
+
+	t.Run("期間がない場合はSTANDARDとして投稿", func(t *testing.T) {
+		topic, _ := ParseGbpTopicFromCaption("【イベント】春の試食会\n詳細は後日")
+		post := &GbpLocalPost{}
+		topic.Apply(post)
+		assert.Equal(t, GbpTopicStandard, post.TopicType)
+		assert.Nil(t, post.Event)
+	})
+}
+
+func TestParseGbpTopicFromFields(t *testing.T) {
+	_, ok := ParseGbpTopicFromFields(map[string]string{})
+	assert.False(t, ok)
+
+	topic, ok := ParseGbpTopicFromFields(map[string]string{
+		GbpFieldTopicType:  "offer",
+		GbpFieldEventTitle: "夏のセール",
+		GbpFieldEventStart: "2026-07-01",
+		GbpFieldEventEnd:   "2026-07-31",
+		GbpFieldCouponCode: "SUMMER",
+	})
+	assert.True(t, ok)
+	post := &GbpLocalPost{}
+	post.SetCallToAction(CallToActionLearnMore, "https://example.com")
+	topic.Apply(post)
+	assert.Equal(t, GbpTopicOffer, post.TopicType)
+	assert.Equal(t, "SUMMER", post.Offer.CouponCode)
+	assert.Nil(t, post.CallToAction)
+}
+
+func TestGbpLocalPost_SetCallToAction(t *testing.T) {
+	post := &GbpLocalPost{TopicType: GbpTopicStandard}
+	post.SetCallToAction(CallToActionBook, "")
+	assert.Nil(t, post.CallToAction)
+
+	post.SetCallToAction(CallToActionCall, "https://example.com")
+	assert.Equal(t, &GbpCallToAction{ActionType: CallToActionCall}, post.CallToAction)
+
+	post = &GbpLocalPost{TopicType: GbpTopicStandard}
+	post.SetCallToAction(CallToActionNone, "https://example.com")
+	assert.Nil(t, post.CallToAction)
+
+	assert.ErrorIs(t, ValidateCallToAction("VISIT", "", false), ErrBadRequest)
+	assert.ErrorIs(t, ValidateCallToAction(CallToActionOrder, "", false), ErrBadRequest)
+	assert.NoError(t, ValidateCallToAction(CallToActionOrder, "", true))
+}
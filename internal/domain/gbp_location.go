@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// GBPのビジネス情報で更新できる項目（updateMask / attributeMask に使う名前）
+const (
+	GbpLocationFieldDescription  = "profile.description"
+	GbpLocationFieldPhoneNumbers = "phoneNumbers"
+	GbpLocationFieldWebsiteURI   = "websiteUri"
+	GbpLocationFieldRegularHours = "regularHours"
+	GbpLocationFieldSpecialHours = "specialHours"
+	GbpLocationFieldAttribute    = "attributes/"
+)
+
+var gbpDays = map[string]bool{
+	"MONDAY":    true,
+	"TUESDAY":   true,
+	"WEDNESDAY": true,
+	"THURSDAY":  true,
+	"FRIDAY":    true,
+	"SATURDAY":  true,
+	"SUNDAY":    true,
+}
+
+// 24:00 は終日営業の閉店時刻として許可されている
+var gbpTimeRe = regexp.MustCompile(`^(([01]\d|2[0-3]):[0-5]\d|24:00)$`)
+
+// GbpTimePeriod は通常の営業時間。時刻は "HH:MM" 形式。
+type GbpTimePeriod struct {
+	OpenDay   string
+	OpenTime  string
+	CloseDay  string
+	CloseTime string
+}
+
+// GbpSpecialHours は祝日・臨時休業などの特別営業時間。日付は "2006-01-02" 形式。
+type GbpSpecialHours struct {
+	StartDate string
+	EndDate   string
+	OpenTime  string
+	CloseTime string
+	Closed    bool
+}
+
+// GbpAttribute はビジネスの属性（"attributes/has_wheelchair_accessible_entrance" など）
+type GbpAttribute struct {
+	Name   string
+	Values []any
+	URIs   []string
+}
+
+type GbpLocationInfo struct {
+	Name             string
+	Title            string
+	Description      string
+	PrimaryPhone     string
+	AdditionalPhones []string
+	WebsiteURI       string
+	RegularHours     []GbpTimePeriod
+	SpecialHours     []GbpSpecialHours
+	Attributes       []GbpAttribute
+}
+
+// GbpLocationUpdate はビジネス情報の更新内容。nil の項目は更新しない。
+// SpecialHours は全件置き換え、AddSpecialHours は既存の特別営業時間に追加（同じ開始日は上書き）する。
+// Attributes は指定した属性のみ更新する。
+type GbpLocationUpdate struct {
+	Description     *string
+	PrimaryPhone    *string
+	WebsiteURI      *string
+	RegularHours    *[]GbpTimePeriod
+	SpecialHours    *[]GbpSpecialHours
+	AddSpecialHours []GbpSpecialHours
+	Attributes      []GbpAttribute
+}
+
+// GbpLocationChange は1項目の変更内容
+type GbpLocationChange struct {
+	Field  string
+	Before any
+	After  any
+}
+
+func (u GbpLocationUpdate) Validate() error {
+	if u.RegularHours != nil {
+		for _, p := range *u.RegularHours {
+			if !gbpDays[p.OpenDay] || !gbpDays[p.CloseDay] {
+				return fmt.Errorf("%w: 曜日が不正です: %s〜%s", ErrBadRequest, p.OpenDay, p.CloseDay)
+			}
+			if !gbpTimeRe.MatchString(p.OpenTime) || !gbpTimeRe.MatchString(p.CloseTime) {
+				return fmt.Errorf("%w: 時刻はHH:MM形式で指定してください: %s〜%s", ErrBadRequest, p.OpenTime, p.CloseTime)
+			}
+		}
+	}
+	var specialHours []GbpSpecialHours
+	if u.SpecialHours != nil {
+		specialHours = append(specialHours, *u.SpecialHours...)
+	}
+	specialHours = append(specialHours, u.AddSpecialHours...)
+	for _, p := range specialHours {
+		start, err := time.Parse("2006-01-02", p.StartDate)
+		if err != nil {
+			return fmt.Errorf("%w: 日付はYYYY-MM-DD形式で指定してください: %s", ErrBadRequest, p.StartDate)
+		}
+		if p.EndDate != "" {
+			end, err := time.Parse("2006-01-02", p.EndDate)
+			if err != nil {
+				return fmt.Errorf("%w: 日付はYYYY-MM-DD形式で指定してください: %s", ErrBadRequest, p.EndDate)
+			}
+			if end.Before(start) {
+				return fmt.Errorf("%w: 終了日は開始日以降を指定してください: %s〜%s", ErrBadRequest, p.StartDate, p.EndDate)
+			}
+		}
+		if p.Closed {
+			continue
+		}
+		if !gbpTimeRe.MatchString(p.OpenTime) || !gbpTimeRe.MatchString(p.CloseTime) {
+			return fmt.Errorf("%w: 営業する日は時刻をHH:MM形式で指定してください: %s", ErrBadRequest, p.StartDate)
+		}
+	}
+	for _, a := range u.Attributes {
+		if !strings.HasPrefix(a.Name, GbpLocationFieldAttribute) || a.Name == GbpLocationFieldAttribute {
+			return fmt.Errorf("%w: 属性名は attributes/ から始まる必要があります: %s", ErrBadRequest, a.Name)
+		}
+	}
+	return nil
+}
+
+// Diff は現在の情報に対して実際に変更される項目を返す。
+func (u GbpLocationUpdate) Diff(current GbpLocationInfo) []GbpLocationChange {
+	var changes []GbpLocationChange
+	add := func(field string, before, after any) {
+		if !reflect.DeepEqual(before, after) {
+			changes = append(changes, GbpLocationChange{Field: field, Before: before, After: after})
+		}
+	}
+
+	if u.Description != nil {
+		add(GbpLocationFieldDescription, current.Description, *u.Description)
+	}
+	if u.PrimaryPhone != nil {
+		add(GbpLocationFieldPhoneNumbers, current.PrimaryPhone, *u.PrimaryPhone)
+	}
+	if u.WebsiteURI != nil {
+		add(GbpLocationFieldWebsiteURI, current.WebsiteURI, *u.WebsiteURI)
+	}
+	if u.RegularHours != nil {
+		add(GbpLocationFieldRegularHours, normalizePeriods(current.RegularHours), normalizePeriods(*u.RegularHours))
+	}
+	if specialHours, ok := u.specialHours(current); ok {
+		add(GbpLocationFieldSpecialHours, normalizePeriods(current.SpecialHours), normalizePeriods(specialHours))
+	}
+	for _, attr := range u.Attributes {
+		before := GbpAttribute{Name: attr.Name}
+		for _, c := range current.Attributes {
+			if c.Name == attr.Name {
+				before = c
+				break
+			}
+		}
+		add(attr.Name, normalizeAttribute(before), normalizeAttribute(attr))
+	}
+	return changes
+}
+
+// Apply は更新内容を反映したビジネス情報を返す。
+func (u GbpLocationUpdate) Apply(current GbpLocationInfo) GbpLocationInfo {
+	info := current
+	if u.Description != nil {
+		info.Description = *u.Description
+	}
+	if u.PrimaryPhone != nil {
+		info.PrimaryPhone = *u.PrimaryPhone
+	}
+	if u.WebsiteURI != nil {
+		info.WebsiteURI = *u.WebsiteURI
+	}
+	if u.RegularHours != nil {
+		info.RegularHours = *u.RegularHours
+	}
+	if specialHours, ok := u.specialHours(current); ok {
+		info.SpecialHours = specialHours
+	}
+	if len(u.Attributes) > 0 {
+		info.Attributes = u.Attributes
+	}
+	return info
+}
+
+// specialHours は更新後の特別営業時間を返す。特別営業時間を更新しない場合は false。
+func (u GbpLocationUpdate) specialHours(current GbpLocationInfo) ([]GbpSpecialHours, bool) {
+	if u.SpecialHours == nil && len(u.AddSpecialHours) == 0 {
+		return nil, false
+	}
+	base := current.SpecialHours
+	if u.SpecialHours != nil {
+		base = *u.SpecialHours
+	}
+	result := make([]GbpSpecialHours, 0, len(base)+len(u.AddSpecialHours))
+	for _, p := range base {
+		replaced := false
+		for _, added := range u.AddSpecialHours {
+			if added.StartDate == p.StartDate {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			result = append(result, p)
+		}
+	}
+	result = append(result, u.AddSpecialHours...)
+	return result, true
+}
+
+// normalizePeriods は nil と空スライスを同じものとして比較するためのヘルパー
+func normalizePeriods[T any](periods []T) []T {
+	if len(periods) == 0 {
+		return []T{}
+	}
+	return periods
+}
+
+func normalizeAttribute(a GbpAttribute) GbpAttribute {
+	if len(a.Values) == 0 {
+		a.Values = nil
+	}
+	if len(a.URIs) == 0 {
+		a.URIs = nil
+	}
+	return a
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGbpLocationUpdate_Diff(t *testing.T) {
+	current := GbpLocationInfo{
+		Description:  "老舗の洋食店です",
+		PrimaryPhone: "03-1234-5678",
+		RegularHours: []GbpTimePeriod{
+			{OpenDay: "MONDAY", OpenTime: "11:00", CloseDay: "MONDAY", CloseTime: "21:00"},
+		},
+		SpecialHours: []GbpSpecialHours{
+			{StartDate: "2026-12-31", Closed: true},
+		},
+	}
+
+	t.Run("変更のない項目は差分に含めない", func(t *testing.T) {
+		description := "老舗の洋食店です"
+		phone := "03-9999-0000"
+		update := GbpLocationUpdate{Description: &description, PrimaryPhone: &phone}
+		changes := update.Diff(current)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, GbpLocationFieldPhoneNumbers, changes[0].Field)
+		assert.Equal(t, "03-1234-5678", changes[0].Before)
+		assert.Equal(t, "03-9999-0000", changes[0].After)
+	})
+
+	t.Run("臨時休業の追加は既存の特別営業時間に追加される", func(t *testing.T) {
+		update := GbpLocationUpdate{
+			AddSpecialHours: []GbpSpecialHours{{StartDate: "2027-01-01", Closed: true}},
+		}
+		changes := update.Diff(current)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, GbpLocationFieldSpecialHours, changes[0].Field)
+		assert.Equal(t, []GbpSpecialHours{
+			{StartDate: "2026-12-31", Closed: true},
+			{StartDate: "2027-01-01", Closed: true},
+		}, changes[0].After)
+	})
+
+	t.Run("同じ開始日は上書き", func(t *testing.T) {
+		update := GbpLocationUpdate{
+			AddSpecialHours: []GbpSpecialHours{{StartDate: "2026-12-31", OpenTime: "11:00", CloseTime: "15:00"}},
+		}
+		info := update.Apply(current)
+		assert.Equal(t, []GbpSpecialHours{
+			{StartDate: "2026-12-31", OpenTime: "11:00", CloseTime: "15:00"},
+		}, info.SpecialHours)
+	})
+
+	t.Run("営業時間を空にする", func(t *testing.T) {
+		update := GbpLocationUpdate{RegularHours: &[]GbpTimePeriod{}}
+		changes := update.Diff(current)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, GbpLocationFieldRegularHours, changes[0].Field)
+	})
+}
+
+func TestGbpLocationUpdate_Validate(t *testing.T) {
+	valid := GbpLocationUpdate{
+		RegularHours: &[]GbpTimePeriod{
+			{OpenDay: "FRIDAY", OpenTime: "18:00", CloseDay: "SATURDAY", CloseTime: "02:00"},
+		},
+		AddSpecialHours: []GbpSpecialHours{{StartDate: "2026-08-13", EndDate: "2026-08-16", Closed: true}},
+		Attributes:      []GbpAttribute{{Name: "attributes/has_wheelchair_accessible_entrance", Values: []any{true}}},
+	}
+	assert.NoError(t, valid.Validate())
+
+	invalid := []GbpLocationUpdate{
+		{RegularHours: &[]GbpTimePeriod{{OpenDay: "MON", OpenTime: "11:00", CloseDay: "MONDAY", CloseTime: "21:00"}}},
+		{RegularHours: &[]GbpTimePeriod{{OpenDay: "MONDAY", OpenTime: "25:00", CloseDay: "MONDAY", CloseTime: "21:00"}}},
+		{AddSpecialHours: []GbpSpecialHours{{StartDate: "2026/08/13", Closed: true}}},
+		{AddSpecialHours: []GbpSpecialHours{{StartDate: "2026-08-16", EndDate: "2026-08-13", Closed: true}}},
+		{AddSpecialHours: []GbpSpecialHours{{StartDate: "2026-08-13"}}},
+		{Attributes: []GbpAttribute{{Name: "has_wheelchair_accessible_entrance"}}},
+	}
+	for _, u := range invalid {
+		assert.True(t, errors.Is(u.Validate(), ErrBadRequest))
+	}
+}
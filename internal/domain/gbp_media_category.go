@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GbpMediaCategory はGBPの写真のカテゴリ（locationAssociation.category）。
+// プロフィール画像を差し替えてしまう COVER / PROFILE / LOGO は連携では使わない。
+type GbpMediaCategory string
+
+const (
+	GbpMediaCategoryNone         GbpMediaCategory = ""
+	GbpMediaCategoryAdditional   GbpMediaCategory = "ADDITIONAL"
+	GbpMediaCategoryExterior     GbpMediaCategory = "EXTERIOR"
+	GbpMediaCategoryInterior     GbpMediaCategory = "INTERIOR"
+	GbpMediaCategoryProduct      GbpMediaCategory = "PRODUCT"
+	GbpMediaCategoryAtWork       GbpMediaCategory = "AT_WORK"
+	GbpMediaCategoryFoodAndDrink GbpMediaCategory = "FOOD_AND_DRINK"
+	GbpMediaCategoryMenu         GbpMediaCategory = "MENU"
+	GbpMediaCategoryCommonArea   GbpMediaCategory = "COMMON_AREA"
+	GbpMediaCategoryRooms        GbpMediaCategory = "ROOMS"
+	GbpMediaCategoryTeams        GbpMediaCategory = "TEAMS"
+)
+
+// GbpFieldMediaCategory はWordPressの投稿ごとにカテゴリを指定するカスタムフィールド
+const GbpFieldMediaCategory = "gbp_media_category"
+
+func (c GbpMediaCategory) Valid() bool {
+	switch c {
+	case GbpMediaCategoryNone, GbpMediaCategoryAdditional, GbpMediaCategoryExterior, GbpMediaCategoryInterior,
+		GbpMediaCategoryProduct, GbpMediaCategoryAtWork, GbpMediaCategoryFoodAndDrink, GbpMediaCategoryMenu,
+		GbpMediaCategoryCommonArea, GbpMediaCategoryRooms, GbpMediaCategoryTeams:
+		return true
+	}
+	return false
+}
+
+// GbpMediaCategoryRule はキャプション（本文）にキーワードが含まれる場合にカテゴリを上書きするルール。
+// ハッシュタグで指定する場合はキーワードを "#メニュー" のようにする。
+type GbpMediaCategoryRule struct {
+	Keyword  string
+	Category GbpMediaCategory
+}
+
+// ValidateGbpMediaCategory はアカウントに設定するデフォルトのカテゴリとルールを検証する。
+func ValidateGbpMediaCategory(category GbpMediaCategory, rules []GbpMediaCategoryRule) error {
+	if !category.Valid() {
+		return fmt.Errorf("%w: media_category が不正です: %s", ErrBadRequest, category)
+	}
+	for _, rule := range rules {
+		if strings.TrimSpace(rule.Keyword) == "" {
+			return fmt.Errorf("%w: media_category_rules の keyword が空です", ErrBadRequest)
+		}
+		if rule.Category == GbpMediaCategoryNone || !rule.Category.Valid() {
+			return fmt.Errorf("%w: media_category_rules の category が不正です: %s", ErrBadRequest, rule.Category)
+		}
+	}
+	return nil
+}
+
+// ResolveGbpMediaCategory は投稿のキャプションから写真のカテゴリを決める。
+// ルールは先頭から順に評価し（大文字小文字は区別しない）、最初に一致したものを使う。
+// 一致しない場合はデフォルト、デフォルトも未設定の場合は ADDITIONAL。
+func ResolveGbpMediaCategory(defaultCategory GbpMediaCategory, rules []GbpMediaCategoryRule, caption string) GbpMediaCategory {
+	text := strings.ToLower(caption)
+	for _, rule := range rules {
+		if rule.Keyword != "" && strings.Contains(text, strings.ToLower(rule.Keyword)) {
+			return rule.Category
+		}
+	}
+	if defaultCategory == GbpMediaCategoryNone {
+		return GbpMediaCategoryAdditional
+	}
+	return defaultCategory
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveGbpMediaCategory(t *testing.T) {
+	rules := []GbpMediaCategoryRule{
+		{Keyword: "#メニュー", Category: GbpMediaCategoryMenu},
+		{Keyword: "外観", Category: GbpMediaCategoryExterior},
+		{Keyword: "#Staff", Category: GbpMediaCategoryTeams},
+	}
+
+	assert.Equal(t, GbpMediaCategoryMenu, ResolveGbpMediaCategory(GbpMediaCategoryFoodAndDrink, rules, "春の新作です\n#メニュー #外観"))
+	assert.Equal(t, GbpMediaCategoryExterior, ResolveGbpMediaCategory(GbpMediaCategoryFoodAndDrink, rules, "お店の外観をリニューアルしました"))
+	assert.Equal(t, GbpMediaCategoryTeams, ResolveGbpMediaCategory(GbpMediaCategoryFoodAndDrink, rules, "#staff紹介"))
+	assert.Equal(t, GbpMediaCategoryFoodAndDrink, ResolveGbpMediaCategory(GbpMediaCategoryFoodAndDrink, rules, "本日のランチ"))
+	assert.Equal(t, GbpMediaCategoryAdditional, ResolveGbpMediaCategory(GbpMediaCategoryNone, nil, "本日のランチ"))
+}
+
+func TestValidateGbpMediaCategory(t *testing.T) {
+	assert.NoError(t, ValidateGbpMediaCategory(GbpMediaCategoryNone, nil))
+	assert.NoError(t, ValidateGbpMediaCategory(GbpMediaCategoryInterior, []GbpMediaCategoryRule{{Keyword: "#メニュー", Category: GbpMediaCategoryMenu}}))
+
+	assert.True(t, errors.Is(ValidateGbpMediaCategory("COVER", nil), ErrBadRequest))
+	assert.True(t, errors.Is(ValidateGbpMediaCategory(GbpMediaCategoryNone, []GbpMediaCategoryRule{{Keyword: " ", Category: GbpMediaCategoryMenu}}), ErrBadRequest))
+	assert.True(t, errors.Is(ValidateGbpMediaCategory(GbpMediaCategoryNone, []GbpMediaCategoryRule{{Keyword: "#メニュー"}}), ErrBadRequest))
+}
@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GbpRejectionReason はGBPがLocal Postを拒否した理由の分類
+type GbpRejectionReason string
+
+const (
+	GbpRejectionPhone      GbpRejectionReason = "phone"
+	GbpRejectionURL        GbpRejectionReason = "url"
+	GbpRejectionAddress    GbpRejectionReason = "address"
+	GbpRejectionHashtag    GbpRejectionReason = "hashtag"
+	GbpRejectionTooLong    GbpRejectionReason = "too_long"
+	GbpRejectionProhibited GbpRejectionReason = "prohibited_content"
+	GbpRejectionOther      GbpRejectionReason = "other"
+)
+
+// GbpPostRejection は拒否されたLocal Postの記録。
+// 同じ投稿（CustomerID, MediaID）は同期のたびに再投稿されるため、最新の内容で上書きし Count を増やす。
+type GbpPostRejection struct {
+	ID           int
+	CustomerID   int
+	MediaID      string
+	BusinessName string
+	Reason       GbpRejectionReason
+	Field        string
+	Message      string
+	Summary      string
+	Count        int
+	UpdatedAt    time.Time
+	CreatedAt    time.Time
+}
+
+// GbpPostRejectedError はGBPがLocal Postをポリシー違反として拒否したときのエラー
+type GbpPostRejectedError struct {
+	Reason  GbpRejectionReason
+	Field   string
+	Message string
+}
+
+func (e *GbpPostRejectedError) Error() string {
+	return fmt.Sprintf("Local Postが拒否されました (%s): %s %s", e.Reason, e.Field, e.Message)
+}
+
+// Is は拒否されたLocal Postが投稿されていないため ErrSyncRejected として扱う。
+func (e *GbpPostRejectedError) Is(target error) bool {
+	return target == ErrSyncRejected
+}
+
+// ClassifyGbpRejection はGBPのエラーメッセージから拒否理由を分類する。
+func ClassifyGbpRejection(message string) GbpRejectionReason {
+	m := strings.ToLower(message)
+	contains := func(keywords ...string) bool {
+		for _, k := range keywords {
+			if strings.Contains(m, k) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case contains("phone"):
+		return GbpRejectionPhone
+	case contains("url", "link", "website"):
+		return GbpRejectionURL
+	case contains("address"):
+		return GbpRejectionAddress
+	case contains("hashtag"):
+		return GbpRejectionHashtag
+	case contains("too long", "length", "exceed"):
+		return GbpRejectionTooLong
+	case contains("policy", "prohibited", "violat", "inappropriate", "spam"):
+		return GbpRejectionProhibited
+	}
+	return GbpRejectionOther
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// GbpSanitizeRule はLocal Postの本文から除去する内容の種類。
+// GBPは本文の電話番号・住所・URL・ハッシュタグをポリシー違反として拒否することがある。
+type GbpSanitizeRule string
+
+const (
+	GbpSanitizeURL     GbpSanitizeRule = "url"
+	GbpSanitizePhone   GbpSanitizeRule = "phone"
+	GbpSanitizeAddress GbpSanitizeRule = "address"
+	GbpSanitizeHashtag GbpSanitizeRule = "hashtag"
+)
+
+var gbpSanitizePatterns = map[GbpSanitizeRule]*regexp.Regexp{
+	GbpSanitizeURL:     regexp.MustCompile(`https?://\S+`),
+	GbpSanitizePhone:   regexp.MustCompile(`☎️\s*[\d\-\s]+`),
+	GbpSanitizeAddress: regexp.MustCompile(`📍[^📍🚃⏰🗓️☎️🚗\n]+`),
+	GbpSanitizeHashtag: regexp.MustCompile(`#\S+(?:[ \t　]+#\S+)*`),
+}
+
+// 適用順（URL内の#をハッシュタグとして扱わないようURLを先に除去する）
+var gbpSanitizeOrder = []GbpSanitizeRule{GbpSanitizeURL, GbpSanitizePhone, GbpSanitizeAddress, GbpSanitizeHashtag}
+
+var gbpSpacesPattern = regexp.MustCompile(`[ \t　]{2,}`)
+
+// GbpSanitizeConfig はアカウントごとの本文の除去ルール。
+// Patterns には任意の正規表現を指定でき、一致した部分を除去する。
+type GbpSanitizeConfig struct {
+	Rules    []GbpSanitizeRule
+	Patterns []string
+}
+
+// DefaultGbpSanitizeConfig は設定がない場合に使う除去ルール（すべての組み込みルール）
+func DefaultGbpSanitizeConfig() GbpSanitizeConfig {
+	return GbpSanitizeConfig{Rules: append([]GbpSanitizeRule{}, gbpSanitizeOrder...)}
+}
+
+func (c GbpSanitizeConfig) Validate() error {
+	for _, rule := range c.Rules {
+		if _, ok := gbpSanitizePatterns[rule]; !ok {
+			return fmt.Errorf("%w: sanitize_config の rules が不正です: %s", ErrBadRequest, rule)
+		}
+	}
+	for _, pattern := range c.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%w: sanitize_config の patterns が正規表現として不正です: %s", ErrBadRequest, pattern)
+		}
+	}
+	return nil
+}
+
+// Sanitize はルールに一致した部分を除去し、連続する空白と空行を詰める。
+func (c GbpSanitizeConfig) Sanitize(content string) string {
+	enabled := map[GbpSanitizeRule]bool{}
+	for _, rule := range c.Rules {
+		enabled[rule] = true
+	}
+	for _, rule := range gbpSanitizeOrder {
+		if enabled[rule] {
+			content = gbpSanitizePatterns[rule].ReplaceAllString(content, "")
+		}
+	}
+	for _, pattern := range c.Patterns {
+		// Validate 済みの前提だが、不正なパターンは無視する
+		if re, err := regexp.Compile(pattern); err == nil {
+			content = re.ReplaceAllString(content, "")
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = gbpSpacesPattern.ReplaceAllString(line, " ")
+		line = strings.TrimSpace(line)
+		if line != "" {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// GbpSummaryMaxLength はLocal Postの本文の最大文字数
+const GbpSummaryMaxLength = 1500
+
+const gbpEllipsis = "…"
+
+// TruncateGbpSummary は本文を maxRunes 文字（バイトではなく文字数）以内に切り詰める。
+// 切り詰める場合は末尾に「…」を付け、後半に文の区切り（。！？改行）があればそこで切る。
+func TruncateGbpSummary(s string, maxRunes int) string {
+	if utf8.RuneCountInString(s) <= maxRunes {
+		return s
+	}
+	runes := []rune(s)[:maxRunes-utf8.RuneCountInString(gbpEllipsis)]
+
+	// 区切りが前半にしかない場合は文の途中で切る（本文が短くなりすぎないように）
+	for i := len(runes) - 1; i >= len(runes)/2; i-- {
+		switch runes[i] {
+		case '。', '！', '？', '!', '?', '\n':
+			return strings.TrimRight(string(runes[:i+1]), "\n") + gbpEllipsis
+		}
+	}
+	return strings.TrimSpace(string(runes)) + gbpEllipsis
+}
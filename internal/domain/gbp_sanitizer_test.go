@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGbpSanitizeConfig_Sanitize(t *testing.T) {
+	content := "春の新作です https://example.com/menu\n☎️ 03-1234-5678\n📍東京都渋谷区1-2-3\n\n#カフェ #渋谷"
+
+	assert.Equal(t, "春の新作です", DefaultGbpSanitizeConfig().Sanitize(content))
+
+	// ハッシュタグだけ残す
+	config := GbpSanitizeConfig{Rules: []GbpSanitizeRule{GbpSanitizeURL, GbpSanitizePhone, GbpSanitizeAddress}}
+	assert.Equal(t, "春の新作です\n#カフェ #渋谷", config.Sanitize(content))
+
+	// ルールが空の場合は空行と空白を詰めるだけ
+	assert.Equal(t, "a b\nc", GbpSanitizeConfig{}.Sanitize("a   b\n\n  c  "))
+
+	// 任意の正規表現
+	config = GbpSanitizeConfig{Patterns: []string{`LINE ID: \S+`}}
+	assert.Equal(t, "ご予約は", config.Sanitize("ご予約は LINE ID: @homing"))
+}
+
+func TestGbpSanitizeConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultGbpSanitizeConfig().Validate())
+	assert.NoError(t, GbpSanitizeConfig{Patterns: []string{`\d+円`}}.Validate())
+
+	assert.True(t, errors.Is(GbpSanitizeConfig{Rules: []GbpSanitizeRule{"email"}}.Validate(), ErrBadRequest))
+	assert.True(t, errors.Is(GbpSanitizeConfig{Patterns: []string{`(`}}.Validate(), ErrBadRequest))
+}
+
+func TestTruncateGbpSummary(t *testing.T) {
+	assert.Equal(t, "短い本文", TruncateGbpSummary("短い本文", 10))
+
+	// マルチバイト文字を途中で切らない
+	got := TruncateGbpSummary(strings.Repeat("あ", 20), 10)
+	assert.True(t, utf8.ValidString(got))
+	assert.Equal(t, strings.Repeat("あ", 9)+"…", got)
+
+	// 後半に文の区切りがあればそこで切る
+	assert.Equal(t, "今日は晴れ。明日も晴れ。…", TruncateGbpSummary("今日は晴れ。明日も晴れ。明後日は雨の予報です", 16))
+
+	// 区切りが前半にしかない場合は文の途中で切る
+	assert.Equal(t, "晴れ。明日も晴れのち曇…", TruncateGbpSummary("晴れ。明日も晴れのち曇りの予報です", 12))
+}
+
+func TestClassifyGbpRejection(t *testing.T) {
+	assert.Equal(t, GbpRejectionPhone, ClassifyGbpRejection("Summary must not contain a phone number."))
+	assert.Equal(t, GbpRejectionURL, ClassifyGbpRejection("Invalid URL in summary"))
+	assert.Equal(t, GbpRejectionTooLong, ClassifyGbpRejection("Summary is too long"))
+	assert.Equal(t, GbpRejectionProhibited, ClassifyGbpRejection("The post violates our content policy"))
+	assert.Equal(t, GbpRejectionOther, ClassifyGbpRejection("Unknown error"))
+}
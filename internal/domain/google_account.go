@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// GoogleAccount はGBPのアカウント（個人アカウント・ビジネスグループ）。
+// 認証に使うトークンは GoogleOAuthTokenID で紐づける。
+type GoogleAccount struct {
+	ID                 int
+	Name               string
+	AccountName        string
+	Type               string
+	GoogleOAuthTokenID int
+	UpdatedAt          time.Time
+	CreatedAt          time.Time
+}
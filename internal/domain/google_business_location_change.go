@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// GoogleBusinessLocationChange はhomingから更新したビジネス情報の変更履歴。
+// Before / After は変更前後の値をJSONで保持する。
+type GoogleBusinessLocationChange struct {
+	ID           int
+	BusinessName string
+	Field        string
+	Before       string
+	After        string
+	CreatedAt    time.Time
+}
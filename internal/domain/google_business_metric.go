@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+// GBP Performance APIの日次指標
+const (
+	MetricImpressionsDesktopMaps   = "BUSINESS_IMPRESSIONS_DESKTOP_MAPS"
+	MetricImpressionsDesktopSearch = "BUSINESS_IMPRESSIONS_DESKTOP_SEARCH"
+	MetricImpressionsMobileMaps    = "BUSINESS_IMPRESSIONS_MOBILE_MAPS"
+	MetricImpressionsMobileSearch  = "BUSINESS_IMPRESSIONS_MOBILE_SEARCH"
+	MetricConversations            = "BUSINESS_CONVERSATIONS"
+	MetricDirectionRequests        = "BUSINESS_DIRECTION_REQUESTS"
+	MetricCallClicks               = "CALL_CLICKS"
+	MetricWebsiteClicks            = "WEBSITE_CLICKS"
+	MetricBookings                 = "BUSINESS_BOOKINGS"
+)
+
+// GbpDailyMetrics は取り込み対象の日次指標
+var GbpDailyMetrics = []string{
+	MetricImpressionsDesktopMaps,
+	MetricImpressionsDesktopSearch,
+	MetricImpressionsMobileMaps,
+	MetricImpressionsMobileSearch,
+	MetricConversations,
+	MetricDirectionRequests,
+	MetricCallClicks,
+	MetricWebsiteClicks,
+	MetricBookings,
+}
+
+type GoogleBusinessMetric struct {
+	ID           int
+	BusinessName string
+	Metric       string
+	Date         time.Time
+	Value        int64
+}
+
+// GoogleBusinessMetricSummary はレポート用に指標をまとめたもの
+type GoogleBusinessMetricSummary struct {
+	// Views はGoogleマップでの表示回数
+	Views int64
+	// Searches はGoogle検索での表示回数
+	Searches          int64
+	Calls             int64
+	WebsiteClicks     int64
+	DirectionRequests int64
+	Conversations     int64
+	Bookings          int64
+}
+
+func (s *GoogleBusinessMetricSummary) Add(metric string, value int64) {
+	switch metric {
+	case MetricImpressionsDesktopMaps, MetricImpressionsMobileMaps:
+		s.Views += value
+	case MetricImpressionsDesktopSearch, MetricImpressionsMobileSearch:
+		s.Searches += value
+	case MetricCallClicks:
+		s.Calls += value
+	case MetricWebsiteClicks:
+		s.WebsiteClicks += value
+	case MetricDirectionRequests:
+		s.DirectionRequests += value
+	case MetricConversations:
+		s.Conversations += value
+	case MetricBookings:
+		s.Bookings += value
+	}
+}
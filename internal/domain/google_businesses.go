@@ -3,8 +3,20 @@ package domain
 import "time"
 
 type GoogleBusinesses struct {
-	ID        int
-	Name      string
-	Title     string
-	CreatedAt time.Time
+	ID              int
+	GoogleAccountID int
+	Name            string
+	Title           string
+	// PhotoRetentionCount はhomingが投稿した写真を最新何件まで残すか（0は無制限）
+	PhotoRetentionCount int
+	// LocalPostRetentionDays はhomingが投稿したLocal Postを何日で削除するか（0は無期限）
+	LocalPostRetentionDays int
+	// ReviewsImportedAt は口コミを初めて取り込んだ日時（未取り込みは nil）
+	ReviewsImportedAt *time.Time
+	CreatedAt         time.Time
+}
+
+// HasRetention は保持ポリシーが設定されているかどうかを返す。
+func (b *GoogleBusinesses) HasRetention() bool {
+	return b.PhotoRetentionCount > 0 || b.LocalPostRetentionDays > 0
 }
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+type GoogleOAuthToken struct {
+	ID           int
+	AccessToken  string
+	RefreshToken string
+	TokenType    string
+	Expiry       time.Time
+	UpdatedAt    time.Time
+	CreatedAt    time.Time
+}
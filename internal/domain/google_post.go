@@ -1,20 +1,46 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 type GooglePost struct {
 	ID           int
 	InstagramURL string
 	MediaID      string
 	CustomerID   int
-	Name         string
-	GoogleURL    string
-	CreateTime   string
-	PostType     string
-	CreatedAt    time.Time
+	Source       SyncSource
+	// Destination は PostType に対応する投稿先（gbp_photo・gbp_post）
+	Destination SyncDestination
+	Status      SyncRecordStatus
+	// BusinessInstagramID・WordpressGbpID は投稿元の連携。どちらか一方だけを持つ
+	BusinessInstagramID *int
+	WordpressGbpID      *int
+	Name                string
+	GoogleURL           string
+	CreateTime          string
+	// PublishedAt は CreateTime（GBPが返す投稿日時）を読んだもの
+	PublishedAt *time.Time
+	PostType    string
+	// DeletedAt はhomingからGBP上のメディア・Local Postを削除した日時。
+	// 削除後もレコードは残し、同期で再投稿されないようにする。
+	DeletedAt *time.Time
+	CreatedAt time.Time
 }
 
 const (
 	PostTypePhoto = "photo"
 	PostTypePost  = "post"
 )
+
+// BusinessName はリソース名（accounts/xxx/locations/yyy/media/zzz）からビジネス名（locations/yyy）を返す。
+func (p *GooglePost) BusinessName() string {
+	parts := strings.Split(p.Name, "/")
+	for i := 0; i+1 < len(parts); i++ {
+		if parts[i] == "locations" {
+			return "locations/" + parts[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGooglePost_BusinessName(t *testing.T) {
+	media := &GooglePost{Name: "accounts/111/locations/222/media/AF1Qip"}
+	assert.Equal(t, "locations/222", media.BusinessName())
+
+	localPost := &GooglePost{Name: "accounts/111/locations/222/localPosts/333"}
+	assert.Equal(t, "locations/222", localPost.BusinessName())
+
+	assert.Equal(t, "", (&GooglePost{}).BusinessName())
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+type GoogleReview struct {
+	ID             int
+	BusinessName   string
+	ReviewName     string
+	ReviewerName   string
+	StarRating     int
+	Comment        string
+	ReplyComment   string
+	ReplyUpdatedAt *time.Time
+	ReviewedAt     time.Time
+	UpdateTime     string
+	UpdatedAt      time.Time
+	CreatedAt      time.Time
+}
+
+// IsLowRated は評価が閾値以下かどうか。評価なし(0)は低評価として扱わない。
+func (r *GoogleReview) IsLowRated(threshold int) bool {
+	return r.StarRating > 0 && r.StarRating <= threshold
+}
+
+func (r *GoogleReview) HasReply() bool {
+	return r.ReplyComment != ""
+}
+
+// StarRatingToInt はGBP APIの評価（"ONE"〜"FIVE"）を数値に変換する。
+func StarRatingToInt(starRating string) int {
+	switch starRating {
+	case "ONE":
+		return 1
+	case "TWO":
+		return 2
+	case "THREE":
+		return 3
+	case "FOUR":
+		return 4
+	case "FIVE":
+		return 5
+	}
+	return 0
+}
@@ -6,17 +6,26 @@ import (
 )
 
 var (
-	ErrNotFound            = errors.New("not found")
-	ErrBadRequest          = errors.New("bad request")
-	ErrUnauthorized        = errors.New("unauthorized")
-	ErrForbidden           = errors.New("forbidden")
-	ErrInternalServerError = errors.New("internal server error")
-	ErrNotImplemented      = errors.New("not implemented")
-	ErrWordpressConnection = errors.New("ワードプレスとの疎通に失敗しました。ドメインを確認してください")
-	ErrInstagramConnection = errors.New("インスタグラムとの疎通に失敗しました。ID、トークンの権限を確認してください")
-	ErrTokenNotFound       = errors.New("トークンが登録されていません。")
-	ErrBusinessConnection  = errors.New("GBPとの疎通に失敗しました。")
-	ErrDuplicate           = errors.New("duplicate")
+	ErrNotFound              = errors.New("not found")
+	ErrBadRequest            = errors.New("bad request")
+	ErrUnauthorized          = errors.New("unauthorized")
+	ErrForbidden             = errors.New("forbidden")
+	ErrInternalServerError   = errors.New("internal server error")
+	ErrNotImplemented        = errors.New("not implemented")
+	ErrWordpressConnection   = errors.New("ワードプレスとの疎通に失敗しました。ドメインを確認してください")
+	ErrInstagramConnection   = errors.New("インスタグラムとの疎通に失敗しました。ID、トークンの権限を確認してください")
+	ErrTokenNotFound         = errors.New("トークンが登録されていません。")
+	ErrBusinessConnection    = errors.New("GBPとの疎通に失敗しました。")
+	ErrDuplicate             = errors.New("duplicate")
+	ErrMediaURLExpired       = errors.New("メディアURLの有効期限が切れています")
+	ErrGoogleNotAuthorized   = errors.New("Googleアカウントが認証されていません。/api/oauth/google/start から認証してください")
+	ErrGoogleAccountNotFound = errors.New("ビジネスに紐づくGoogleアカウントが見つかりません。/api/google-business/fetch を実行してください")
+	ErrFacebookConnection    = errors.New("Facebookページとの疎通に失敗しました。ページID、トークンの権限を確認してください")
+	ErrFeedConnection        = errors.New("フィードの取得に失敗しました。URLを確認してください")
+	ErrWordpressSignature    = errors.New("WordPressのプラグインで署名が一致しませんでした")
+	ErrWordpressPluginOld    = errors.New("WordPressのプラグインが署名の確認に対応していません")
+	ErrSyncUnconfirmed       = errors.New("投稿できたか確認できません。投稿先を確認し、投稿されていない場合はリトライしてください")
+	ErrSyncRejected          = errors.New("投稿先が投稿を受け付けませんでした")
 )
 
 type HomingErr struct {
@@ -39,6 +39,23 @@ func (i *InstagramPost) SetDeleteHashFlag(deleteHash bool) {
 	i.DeleteHash = deleteHash
 }
 
+// RefreshMediaURLs は再取得した投稿の media_url で自身と子要素のURLを置き換える。
+// InstagramのCDN URLは署名付きで期限切れになるため、ダウンロード失敗時に利用する。
+func (i *InstagramPost) RefreshMediaURLs(fresh *InstagramPost) {
+	if fresh.MediaURL != "" {
+		i.MediaURL = fresh.MediaURL
+	}
+	freshChildren := make(map[string]string, len(fresh.Children))
+	for _, child := range fresh.Children {
+		freshChildren[child.ID] = child.MediaURL
+	}
+	for idx, child := range i.Children {
+		if u, ok := freshChildren[child.ID]; ok && u != "" {
+			i.Children[idx].MediaURL = u
+		}
+	}
+}
+
 type InstagramPostChildren struct {
 	MediaType string
 	MediaURL  string
@@ -3,6 +3,8 @@ package domain
 import (
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInstagramPost(t *testing.T) {
@@ -11,3 +13,41 @@ func TestInstagramPost(t *testing.T) {
 	}
 	fmt.Println(post.GetPostDate())
 }
+
+func TestInstagramPost_RefreshMediaURLs(t *testing.T) {
+	post := InstagramPost{
+		ID:        "m1",
+		MediaType: "CAROUSEL_ALBUM",
+		MediaURL:  "https://cdn.example.com/old.jpg",
+		Children: []InstagramPostChildren{
+			{ID: "c1", MediaType: "IMAGE", MediaURL: "https://cdn.example.com/c1_old.jpg"},
+			{ID: "c2", MediaType: "VIDEO", MediaURL: "https://cdn.example.com/c2_old.mp4"},
+			{ID: "c3", MediaType: "IMAGE", MediaURL: "https://cdn.example.com/c3_old.jpg"},
+		},
+	}
+	fresh := &InstagramPost{
+		ID:       "m1",
+		MediaURL: "https://cdn.example.com/new.jpg",
+		Children: []InstagramPostChildren{
+			// 順番が変わっても id で対応させる
+			{ID: "c2", MediaURL: "https://cdn.example.com/c2_new.mp4"},
+			{ID: "c1", MediaURL: "https://cdn.example.com/c1_new.jpg"},
+			// 空のURLでは上書きしない
+			{ID: "c3", MediaURL: ""},
+			{ID: "c9", MediaURL: "https://cdn.example.com/c9_new.jpg"},
+		},
+	}
+
+	post.RefreshMediaURLs(fresh)
+
+	assert.Equal(t, "https://cdn.example.com/new.jpg", post.MediaURL)
+	assert.Equal(t, []InstagramPostChildren{
+		{ID: "c1", MediaType: "IMAGE", MediaURL: "https://cdn.example.com/c1_new.jpg"},
+		{ID: "c2", MediaType: "VIDEO", MediaURL: "https://cdn.example.com/c2_new.mp4"},
+		{ID: "c3", MediaType: "IMAGE", MediaURL: "https://cdn.example.com/c3_old.jpg"},
+	}, post.Children)
+
+	// 再取得した投稿に media_url が無い場合は元のURLを残す
+	post.RefreshMediaURLs(&InstagramPost{ID: "m1"})
+	assert.Equal(t, "https://cdn.example.com/new.jpg", post.MediaURL)
+}
@@ -0,0 +1,314 @@
+package domain
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// NotificationEventType は運用担当者や顧客に送る通知の種類
+type NotificationEventType string
+
+const (
+	NotificationEventSyncSucceeded  NotificationEventType = "sync.succeeded"
+	NotificationEventSyncFailed     NotificationEventType = "sync.failed"
+	NotificationEventTokenExpiring  NotificationEventType = "token.expiring"
+	NotificationEventTokenHealthy   NotificationEventType = "token.healthy"
+	NotificationEventReviewReceived NotificationEventType = "review.received"
+	NotificationEventReviewLowRated NotificationEventType = "review.low_rated"
+	NotificationEventDigest         NotificationEventType = "digest"
+	// NotificationEventTest は通知ルールの確認用。ルールの対象には指定できない。
+	NotificationEventTest NotificationEventType = "test"
+)
+
+var NotificationEventTypes = []NotificationEventType{
+	NotificationEventSyncSucceeded,
+	NotificationEventSyncFailed,
+	NotificationEventTokenExpiring,
+	NotificationEventTokenHealthy,
+	NotificationEventReviewReceived,
+	NotificationEventReviewLowRated,
+	NotificationEventDigest,
+}
+
+func (t NotificationEventType) Valid() bool {
+	for _, v := range NotificationEventTypes {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Urgent は担当者の確認が必要な通知かどうか。ルールが無い場合の既定のSlack通知でメンションする。
+func (t NotificationEventType) Urgent() bool {
+	return t == NotificationEventSyncFailed || t == NotificationEventReviewLowRated
+}
+
+// NotificationChannel は通知の送信手段
+type NotificationChannel string
+
+const (
+	NotificationChannelSlack   NotificationChannel = "slack"
+	NotificationChannelEmail   NotificationChannel = "email"
+	NotificationChannelLine    NotificationChannel = "line"
+	NotificationChannelWebhook NotificationChannel = "webhook"
+)
+
+var NotificationChannels = []NotificationChannel{
+	NotificationChannelSlack,
+	NotificationChannelEmail,
+	NotificationChannelLine,
+	NotificationChannelWebhook,
+}
+
+func (c NotificationChannel) Valid() bool {
+	for _, v := range NotificationChannels {
+		if c == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification は送信手段によらない通知の内容。Subject はメールの件名などに、Text は本文に使う。
+// HTML はメールでHTMLを送れる場合だけ、Actions はSlackでボタンを表示できる場合だけ使う。
+type Notification struct {
+	EventType NotificationEventType
+	Account   Account
+	Subject   string
+	Text      string
+	HTML      string
+	Actions   []NotificationAction
+}
+
+// Message はメンションを先頭に付けた本文を返す。
+func (n *Notification) Message(mention string) string {
+	if mention == "" {
+		return n.Text
+	}
+	return mention + "\n" + n.Text
+}
+
+// HTMLMessage はメンションをbodyの先頭に付けたHTMLを返す。
+func (n *Notification) HTMLMessage(mention string) string {
+	if mention == "" || n.HTML == "" {
+		return n.HTML
+	}
+	p := "<p>" + template.HTMLEscapeString(mention) + "</p>"
+	if i := strings.Index(n.HTML, "<body"); i >= 0 {
+		if j := strings.Index(n.HTML[i:], ">"); j >= 0 {
+			k := i + j + 1
+			return n.HTML[:k] + "\n" + p + n.HTML[k:]
+		}
+	}
+	return p + "\n" + n.HTML
+}
+
+// NotificationRule は通知の送り先。
+// EventTypes が空の場合は全ての通知を、AccountType / AccountID が指定されている場合はその連携の通知だけを送る。
+// Destination は送信手段ごとに、SlackのIncoming Webhook URL、メールアドレス（カンマ区切り）、LINEの送信先ID、webhookのURLを指定する。
+type NotificationRule struct {
+	ID          int
+	Name        string
+	EventTypes  []NotificationEventType
+	AccountType AccountType
+	AccountID   int
+	Channel     NotificationChannel
+	Destination string
+	Mention     string
+	Status      Status
+	UpdatedAt   time.Time
+	CreatedAt   time.Time
+}
+
+func (r *NotificationRule) Validate() error {
+	for _, t := range r.EventTypes {
+		if !t.Valid() {
+			return fmt.Errorf("%w: event_types が不正です: %s", ErrBadRequest, t)
+		}
+	}
+	if r.AccountType != "" && !r.AccountType.Valid() {
+		return fmt.Errorf("%w: account_type が不正です: %s", ErrBadRequest, r.AccountType)
+	}
+	if r.AccountID != 0 && r.AccountType == "" {
+		return fmt.Errorf("%w: account_id を指定する場合は account_type も指定してください", ErrBadRequest)
+	}
+	if !r.Channel.Valid() {
+		return fmt.Errorf("%w: channel が不正です: %s", ErrBadRequest, r.Channel)
+	}
+	if strings.TrimSpace(r.Destination) == "" {
+		return fmt.Errorf("%w: destination を指定してください", ErrBadRequest)
+	}
+	return nil
+}
+
+// Matches は通知を送る対象かどうかを判定する。
+func (r *NotificationRule) Matches(n *Notification) bool {
+	if r.Status != 1 {
+		return false
+	}
+	if len(r.EventTypes) > 0 {
+		matched := false
+		for _, t := range r.EventTypes {
+			if t == n.EventType {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if r.AccountType != "" && r.AccountType != n.Account.Type {
+		return false
+	}
+	if r.AccountID != 0 && r.AccountID != n.Account.ID {
+		return false
+	}
+	return true
+}
+
+// NewSyncFailedNotification は同期に失敗した時の通知を作る。
+func NewSyncFailedNotification(flow string, err error, account Account) Notification {
+	flow = strings.TrimSpace(flow)
+	return Notification{
+		EventType: NotificationEventSyncFailed,
+		Account:   account,
+		Subject:   fmt.Sprintf("[homing] 同期エラー: %s", flow),
+		Text: fmt.Sprintf(`[%s]
+error: %s
+顧客: id=%d, name=%s`, flow, err.Error(), account.ID, account.Name),
+	}
+}
+
+// NewWordpressInstagramNotification はInstagramの投稿をWordPressに投稿した時の通知を作る。
+func NewWordpressInstagramNotification(wi *WordpressInstagram, wordpressURL, instagramURL string) Notification {
+	return Notification{
+		EventType: NotificationEventSyncSucceeded,
+		Account:   wi.Account(),
+		Subject:   fmt.Sprintf("[homing] WordPressに投稿しました: %s", wi.Name),
+		Text: fmt.Sprintf(`[Instagram => WordPress]
+id: %d
+name: %s
+WordPress: %s
+Instagram: %s
+`, wi.ID, wi.Name, wordpressURL, instagramURL),
+	}
+}
+
+// NewBusinessInstagramNotification はInstagramの投稿をGBPに投稿した時の通知を作る。
+func NewBusinessInstagramNotification(bi *BusinessInstagram, instagramURL, postType string) Notification {
+	return Notification{
+		EventType: NotificationEventSyncSucceeded,
+		Account:   bi.Account(),
+		Subject:   fmt.Sprintf("[homing] Googleビジネスプロフィールに投稿しました: %s", bi.Name),
+		Text: fmt.Sprintf(`[Instagram => GBP: %s]
+id: %d
+name: %s
+Instagram: %s
+`, postType, bi.ID, bi.Name, instagramURL),
+	}
+}
+
+// NewWordpressGbpNotification はWordPressの投稿をGBPに投稿した時の通知を作る。
+func NewWordpressGbpNotification(wg *WordpressGbp, postType, mediaURL, wordpressURL string) Notification {
+	return Notification{
+		EventType: NotificationEventSyncSucceeded,
+		Account:   wg.Account(),
+		Subject:   fmt.Sprintf("[homing] Googleビジネスプロフィールに投稿しました: %s", wg.Name),
+		Text: fmt.Sprintf(`[WordPress => GBP: %s]
+id: %d
+name: %s
+wordpress: %s
+gbp: %s
+`, postType, wg.ID, wg.Name, wordpressURL, mediaURL),
+	}
+}
+
+// NewFacebookInstagramNotification はInstagramの投稿をFacebookページに投稿した時の通知を作る。
+func NewFacebookInstagramNotification(fi *FacebookInstagram, facebookURL, instagramURL string) Notification {
+	return Notification{
+		EventType: NotificationEventSyncSucceeded,
+		Account:   fi.Account(),
+		Subject:   fmt.Sprintf("[homing] Facebookに投稿しました: %s", fi.Name),
+		Text: fmt.Sprintf(`[Instagram => Facebook]
+id: %d
+name: %s
+Facebook: %s
+Instagram: %s
+`, fi.ID, fi.Name, facebookURL, instagramURL),
+	}
+}
+
+// NewFeedNotification はフィードの記事を投稿した時の通知を作る。
+func NewFeedNotification(feed *Feed, destination FeedDestination, url, link string) Notification {
+	return Notification{
+		EventType: NotificationEventSyncSucceeded,
+		Account:   feed.Account(),
+		Subject:   fmt.Sprintf("[homing] フィードの記事を投稿しました: %s", feed.Name),
+		Text: fmt.Sprintf(`[Feed => %s]
+id: %d
+name: %s
+投稿: %s
+記事: %s
+`, destination, feed.ID, feed.Name, url, link),
+	}
+}
+
+// NewTokenExpiringNotification はInstagramのトークンの有効期限が近い時の通知を作る。
+func NewTokenExpiringNotification() Notification {
+	return Notification{
+		EventType: NotificationEventTokenExpiring,
+		Subject:   "[homing] トークンの有効期限が近づいています",
+		Text:      "‼️トークンの有効期限が近づいています",
+	}
+}
+
+// NewTokenHealthyNotification はInstagramのトークンに問題が無い時の通知を作る。
+func NewTokenHealthyNotification() Notification {
+	return Notification{
+		EventType: NotificationEventTokenHealthy,
+		Subject:   "[homing] トークンは有効です",
+		Text:      "healthy",
+	}
+}
+
+// NewDigestNotification は連携結果のダイジェストの通知を作る。
+func NewDigestNotification(d *Digest) (Notification, error) {
+	html, err := d.HTML()
+	if err != nil {
+		return Notification{}, err
+	}
+	return Notification{
+		EventType: NotificationEventDigest,
+		Subject:   d.Subject(),
+		Text:      d.Text(),
+		HTML:      html,
+	}, nil
+}
+
+// NewGoogleReviewNotification はGBPに口コミが投稿された時の通知を作る。
+func NewGoogleReviewNotification(business *GoogleBusinesses, review *GoogleReview, lowRated bool) Notification {
+	stars := strings.Repeat("★", review.StarRating) + strings.Repeat("☆", 5-review.StarRating)
+	n := Notification{
+		EventType: NotificationEventReviewReceived,
+		Account:   business.Account(),
+		Subject:   fmt.Sprintf("[homing] 口コミが投稿されました: %s", business.Title),
+	}
+	sb := strings.Builder{}
+	if lowRated {
+		n.EventType = NotificationEventReviewLowRated
+		n.Subject = fmt.Sprintf("[homing] 低評価の口コミが投稿されました: %s", business.Title)
+		sb.WriteString("⚠️低評価の口コミが投稿されました\n")
+	}
+	sb.WriteString(fmt.Sprintf(`[GBP口コミ: %s]
+id: %d
+評価: %s
+投稿者: %s
+%s
+`, business.Title, review.ID, stars, review.ReviewerName, review.Comment))
+	n.Text = sb.String()
+	return n
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NotificationActionType は通知から実行できる操作の種類
+type NotificationActionType string
+
+const (
+	NotificationActionRetryPost     NotificationActionType = "retry_post"
+	NotificationActionResyncAccount NotificationActionType = "resync_account"
+	NotificationActionPauseAccount  NotificationActionType = "pause_account"
+	NotificationActionOpenAdmin     NotificationActionType = "open_admin"
+)
+
+// NotificationAction は通知に付けるボタン。URL がある場合はリンクとして開き、それ以外はhomingで操作を実行する。
+type NotificationAction struct {
+	Type    NotificationActionType
+	Label   string
+	Account Account
+	PostKey string
+	URL     string
+}
+
+// Value はボタンに持たせる操作の対象。"account_type:account_id" または "account_type:account_id:post_key" の形式。
+func (a NotificationAction) Value() string {
+	value := fmt.Sprintf("%s:%d", a.Account.Type, a.Account.ID)
+	if a.PostKey != "" {
+		value += ":" + a.PostKey
+	}
+	return value
+}
+
+// ParseNotificationAction はボタンの種類と Value から操作を復元する。
+func ParseNotificationAction(actionType, value string) (NotificationAction, error) {
+	action := NotificationAction{Type: NotificationActionType(actionType)}
+	switch action.Type {
+	case NotificationActionRetryPost, NotificationActionResyncAccount, NotificationActionPauseAccount:
+	default:
+		return action, fmt.Errorf("%w: 不明な操作です: %s", ErrBadRequest, actionType)
+	}
+
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) < 2 {
+		return action, fmt.Errorf("%w: 操作の対象が不正です: %s", ErrBadRequest, value)
+	}
+	id, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return action, fmt.Errorf("%w: 操作の対象が不正です: %s", ErrBadRequest, value)
+	}
+	action.Account = Account{Type: AccountType(parts[0]), ID: id}
+	if !action.Account.Type.Valid() {
+		return action, fmt.Errorf("%w: 操作の対象が不正です: %s", ErrBadRequest, value)
+	}
+	if len(parts) == 3 {
+		action.PostKey = parts[2]
+	}
+	if action.Type == NotificationActionRetryPost && action.PostKey == "" {
+		return action, fmt.Errorf("%w: 再投稿する投稿が指定されていません", ErrBadRequest)
+	}
+	return action, nil
+}
+
+// RetryPostSupported は投稿を1件だけ連携し直せる連携かどうか。投稿元がInstagramの連携だけ対応している。
+func RetryPostSupported(t AccountType) bool {
+	return t == AccountTypeWordpressInstagram || t == AccountTypeBusinessInstagram
+}
+
+// NewSyncFailedActions は同期に失敗した時の通知に付けるボタンを作る。
+// postKey は失敗した投稿が分かる場合だけ指定する。adminURL が空の場合は管理画面へのリンクを付けない。
+func NewSyncFailedActions(account Account, postKey, adminURL string) []NotificationAction {
+	if account.ID == 0 {
+		return nil
+	}
+	var actions []NotificationAction
+	if postKey != "" && RetryPostSupported(account.Type) {
+		actions = append(actions, NotificationAction{Type: NotificationActionRetryPost, Label: "投稿をリトライ", Account: account, PostKey: postKey})
+	}
+	actions = append(actions,
+		NotificationAction{Type: NotificationActionResyncAccount, Label: "アカウントを再同期", Account: account},
+		NotificationAction{Type: NotificationActionPauseAccount, Label: "アカウントを停止", Account: account},
+	)
+	if adminURL != "" {
+		actions = append(actions, NotificationAction{
+			Type:    NotificationActionOpenAdmin,
+			Label:   "管理画面で開く",
+			Account: account,
+			URL:     fmt.Sprintf("%s/%s/%d", strings.TrimRight(adminURL, "/"), strings.ReplaceAll(string(account.Type), "_", "-"), account.ID),
+		})
+	}
+	return actions
+}
+
+// slackSignatureTolerance はリプレイ攻撃を防ぐため、これより古いリクエストは受け付けない
+const slackSignatureTolerance = 5 * time.Minute
+
+// VerifySlackSignature はSlackからのリクエストの署名を検証する。
+// 署名は "v0=" + hex(HMAC-SHA256(signing secret, "v0:" + timestamp + ":" + body))。
+func VerifySlackSignature(secret, timestamp, signature string, body []byte, now time.Time) error {
+	if secret == "" {
+		return fmt.Errorf("%w: Slackの署名シークレットが設定されていません", ErrUnauthorized)
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: タイムスタンプが不正です", ErrUnauthorized)
+	}
+	if math.Abs(now.Sub(time.Unix(ts, 0)).Seconds()) > slackSignatureTolerance.Seconds() {
+		return fmt.Errorf("%w: リクエストが古すぎます", ErrUnauthorized)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("%w: 署名が一致しません", ErrUnauthorized)
+	}
+	return nil
+}
+
+func (a NotificationAction) doneLabel() string {
+	switch a.Type {
+	case NotificationActionRetryPost:
+		return "投稿をリトライ"
+	case NotificationActionResyncAccount:
+		return "アカウントを再同期"
+	case NotificationActionPauseAccount:
+		return "アカウントを停止"
+	}
+	return string(a.Type)
+}
+
+// InProgress は操作を受け付けた時にメッセージに付ける文言。userID はボタンを押したSlackのユーザーID。
+func (a NotificationAction) InProgress(userID string) string {
+	return fmt.Sprintf("⏳ <@%s> が%sしています", userID, a.doneLabel())
+}
+
+// Outcome は操作の結果としてメッセージに付ける文言。
+func (a NotificationAction) Outcome(userID string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("❌ <@%s> が%sしましたが失敗しました: %s", userID, a.doneLabel(), err.Error())
+	}
+	return fmt.Sprintf("✅ <@%s> が%sしました", userID, a.doneLabel())
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNotificationAction(t *testing.T) {
+	account := Account{Type: AccountTypeBusinessInstagram, ID: 12}
+	retry := NotificationAction{Type: NotificationActionRetryPost, Account: account, PostKey: "1790_1"}
+	assert.Equal(t, "business_instagram:12:1790_1", retry.Value())
+
+	parsed, err := ParseNotificationAction("retry_post", retry.Value())
+	assert.NoError(t, err)
+	assert.Equal(t, retry.Type, parsed.Type)
+	assert.Equal(t, account, parsed.Account)
+	assert.Equal(t, "1790_1", parsed.PostKey)
+
+	parsed, err = ParseNotificationAction("pause_account", "feed:3")
+	assert.NoError(t, err)
+	assert.Equal(t, Account{Type: AccountTypeFeed, ID: 3}, parsed.Account)
+
+	for _, tt := range []struct{ actionType, value string }{
+		{"delete_account", "feed:3"},
+		{"open_admin", "feed:3"},
+		{"resync_account", "feed"},
+		{"resync_account", "feed:abc"},
+		{"resync_account", "unknown:3"},
+		{"retry_post", "feed:3"},
+	} {
+		_, err := ParseNotificationAction(tt.actionType, tt.value)
+		assert.True(t, errors.Is(err, ErrBadRequest), tt)
+	}
+}
+
+func TestNewSyncFailedActions(t *testing.T) {
+	assert.Nil(t, NewSyncFailedActions(Account{}, "", ""))
+
+	wi := Account{Type: AccountTypeWordpressInstagram, ID: 1}
+	actions := NewSyncFailedActions(wi, "m1", "https://admin.example.com/")
+	assert.Len(t, actions, 4)
+	assert.Equal(t, NotificationActionRetryPost, actions[0].Type)
+	assert.Equal(t, "m1", actions[0].PostKey)
+	assert.Equal(t, "https://admin.example.com/wordpress-instagram/1", actions[3].URL)
+
+	// 投稿が分からない場合や、投稿単位で連携し直せない連携はリトライを付けない
+	actions = NewSyncFailedActions(wi, "", "")
+	assert.Equal(t, []NotificationActionType{NotificationActionResyncAccount, NotificationActionPauseAccount}, []NotificationActionType{actions[0].Type, actions[1].Type})
+	assert.Len(t, NewSyncFailedActions(Account{Type: AccountTypeFeed, ID: 2}, "https://example.com/a", ""), 2)
+}
+
+func TestVerifySlackSignature(t *testing.T) {
+	now := time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte("payload=%7B%22type%22%3A%22block_actions%22%7D")
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("v0:" + timestamp + ":" + string(body)))
+	signature := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.NoError(t, VerifySlackSignature("secret", timestamp, signature, body, now))
+	assert.NoError(t, VerifySlackSignature("secret", timestamp, signature, body, now.Add(4*time.Minute)))
+
+	for _, err := range []error{
+		VerifySlackSignature("", timestamp, signature, body, now),
+		VerifySlackSignature("other", timestamp, signature, body, now),
+		VerifySlackSignature("secret", timestamp, signature, []byte("payload=x"), now),
+		VerifySlackSignature("secret", "abc", signature, body, now),
+		VerifySlackSignature("secret", timestamp, signature, body, now.Add(10*time.Minute)),
+	} {
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+	}
+}
+
+func TestNotificationAction_Outcome(t *testing.T) {
+	a := NotificationAction{Type: NotificationActionPauseAccount}
+	assert.Equal(t, "⏳ <@U1> がアカウントを停止しています", a.InProgress("U1"))
+	assert.Equal(t, "✅ <@U1> がアカウントを停止しました", a.Outcome("U1", nil))
+	assert.Equal(t, "❌ <@U1> がアカウントを停止しましたが失敗しました: not found", a.Outcome("U1", errors.New("not found")))
+}
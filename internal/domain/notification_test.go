@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    NotificationRule
+		wantErr bool
+	}{
+		{name: "全通知", rule: NotificationRule{Channel: NotificationChannelSlack, Destination: "https://hooks.slack.com/services/xxx"}},
+		{name: "連携を指定", rule: NotificationRule{EventTypes: []NotificationEventType{NotificationEventSyncSucceeded}, AccountType: AccountTypeBusinessInstagram, AccountID: 4, Channel: NotificationChannelLine, Destination: "U1234"}},
+		{name: "不明な通知", rule: NotificationRule{EventTypes: []NotificationEventType{NotificationEventTest}, Channel: NotificationChannelSlack, Destination: "https://hooks.slack.com/services/xxx"}, wantErr: true},
+		{name: "不明な連携", rule: NotificationRule{AccountType: "twitter", Channel: NotificationChannelSlack, Destination: "https://hooks.slack.com/services/xxx"}, wantErr: true},
+		{name: "連携IDだけ指定", rule: NotificationRule{AccountID: 4, Channel: NotificationChannelSlack, Destination: "https://hooks.slack.com/services/xxx"}, wantErr: true},
+		{name: "不明な送信手段", rule: NotificationRule{Channel: "fax", Destination: "03-0000-0000"}, wantErr: true},
+		{name: "送り先なし", rule: NotificationRule{Channel: NotificationChannelEmail, Destination: " "}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrBadRequest))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNotificationRule_Matches(t *testing.T) {
+	n := &Notification{
+		EventType: NotificationEventReviewLowRated,
+		Account:   Account{Type: AccountTypeGoogleBusiness, ID: 7},
+	}
+	tests := []struct {
+		name string
+		rule NotificationRule
+		want bool
+	}{
+		{name: "全通知", rule: NotificationRule{Status: 1}, want: true},
+		{name: "通知の種類が一致", rule: NotificationRule{Status: 1, EventTypes: []NotificationEventType{NotificationEventSyncFailed, NotificationEventReviewLowRated}}, want: true},
+		{name: "連携IDが一致", rule: NotificationRule{Status: 1, AccountType: AccountTypeGoogleBusiness, AccountID: 7}, want: true},
+		{name: "停止中", rule: NotificationRule{Status: 0}},
+		{name: "通知の種類が違う", rule: NotificationRule{Status: 1, EventTypes: []NotificationEventType{NotificationEventReviewReceived}}},
+		{name: "連携の種類が違う", rule: NotificationRule{Status: 1, AccountType: AccountTypeFeed}},
+		{name: "連携IDが違う", rule: NotificationRule{Status: 1, AccountType: AccountTypeGoogleBusiness, AccountID: 8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Matches(n))
+		})
+	}
+}
+
+func TestNewGoogleReviewNotification(t *testing.T) {
+	business := &GoogleBusinesses{ID: 2, Title: "カフェ渋谷店"}
+	review := &GoogleReview{ID: 9, StarRating: 4, ReviewerName: "山田", Comment: "おいしい"}
+
+	n := NewGoogleReviewNotification(business, review, false)
+	assert.Equal(t, NotificationEventReviewReceived, n.EventType)
+	assert.Equal(t, Account{Type: AccountTypeGoogleBusiness, ID: 2, Name: "カフェ渋谷店"}, n.Account)
+	assert.Contains(t, n.Text, "評価: ★★★★☆")
+	assert.NotContains(t, n.Text, "低評価")
+	assert.False(t, n.EventType.Urgent())
+
+	n = NewGoogleReviewNotification(business, review, true)
+	assert.Equal(t, NotificationEventReviewLowRated, n.EventType)
+	assert.Contains(t, n.Text, "⚠️低評価の口コミが投稿されました")
+	assert.True(t, n.EventType.Urgent())
+}
+
+func TestNotification_Message(t *testing.T) {
+	n := NewSyncFailedNotification(" instagram => facebook ", errors.New("token expired"), Account{Type: AccountTypeFacebookInstagram, ID: 3, Name: "パン屋"})
+	assert.Equal(t, "[instagram => facebook]\nerror: token expired\n顧客: id=3, name=パン屋", n.Message(""))
+	assert.Equal(t, "<@U0123>\n[instagram => facebook]\nerror: token expired\n顧客: id=3, name=パン屋", n.Message("<@U0123>"))
+}
@@ -0,0 +1,48 @@
+package domain
+
+// OnboardingCheckName は連携を作成する前に確認する項目
+type OnboardingCheckName string
+
+const (
+	// OnboardingCheckSettings はボタン・写真のカテゴリ・テンプレートなどの設定値
+	OnboardingCheckSettings OnboardingCheckName = "settings"
+	// OnboardingCheckWordpressSite はWordPressのサイト（rodutプラグイン、またはアプリケーションパスワード）
+	OnboardingCheckWordpressSite OnboardingCheckName = "wordpress_site"
+	// OnboardingCheckWordpressSignature はrodutプラグインとのHMACの鍵
+	OnboardingCheckWordpressSignature OnboardingCheckName = "wordpress_signature"
+	// OnboardingCheckWordpressGbpPosts はrodutプラグインのGBP連携用の記事一覧
+	OnboardingCheckWordpressGbpPosts OnboardingCheckName = "wordpress_gbp_posts"
+	// OnboardingCheckInstagramAccount はトークンから見えるInstagramアカウント
+	OnboardingCheckInstagramAccount OnboardingCheckName = "instagram_account"
+	// OnboardingCheckGoogleBusiness はGoogleアカウントから見えるGBPのビジネス
+	OnboardingCheckGoogleBusiness OnboardingCheckName = "google_business"
+)
+
+// OnboardingCheck は連携の確認項目1件の結果。
+// 成功した場合は Detail に確認できた内容（サイト名、アカウント名など）を、
+// 失敗した場合は Error に原因を、Action に対処方法を入れる。
+type OnboardingCheck struct {
+	Name   OnboardingCheckName
+	OK     bool
+	Detail string
+	Error  string
+	Action string
+}
+
+func NewOnboardingPassed(name OnboardingCheckName, detail string) OnboardingCheck {
+	return OnboardingCheck{Name: name, OK: true, Detail: detail}
+}
+
+func NewOnboardingFailed(name OnboardingCheckName, err error, action string) OnboardingCheck {
+	return OnboardingCheck{Name: name, Error: err.Error(), Action: action}
+}
+
+// OnboardingPassed は全ての確認項目が成功したかどうか。
+func OnboardingPassed(checks []OnboardingCheck) bool {
+	for _, check := range checks {
+		if !check.OK {
+			return false
+		}
+	}
+	return true
+}
@@ -3,8 +3,18 @@ package domain
 import "time"
 
 type Post struct {
-	ID           int
-	WordpressURL string
-	InstagramURL string
-	CreatedAt    time.Time
+	ID      int
+	MediaID string
+	// WordpressInstagramID は連携（wordpress_instagrams.id）。旧 customers の投稿は移行するまで nil
+	WordpressInstagramID *int
+	Source               SyncSource
+	Destination          SyncDestination
+	Status               SyncRecordStatus
+	// IdempotencyKey はWordPressのプラグインに送る冪等キー
+	IdempotencyKey *string
+	WordpressURL   string
+	InstagramURL   string
+	// PostedAt は投稿元（Instagram）の投稿日時
+	PostedAt  *time.Time
+	CreatedAt time.Time
 }
@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+// SyncResult は連携1件ごとの結果
+type SyncResult string
+
+const (
+	SyncResultPublished SyncResult = "published"
+	SyncResultSkipped   SyncResult = "skipped"
+	SyncResultFailed    SyncResult = "failed"
+)
+
+// SyncActivity は連携の結果の記録。ダイジェストの集計に使う。
+// PostKey は投稿元の記事やメディアのID。投稿によらない失敗の場合は空になる。
+type SyncActivity struct {
+	ID        int
+	Account   Account
+	Result    SyncResult
+	PostKey   string
+	Detail    string
+	CreatedAt time.Time
+}
+
+// syncActivityDetailMaxRunes は detail カラムの長さ
+const syncActivityDetailMaxRunes = 1000
+
+func NewSyncActivity(account Account, result SyncResult, postKey, detail string) *SyncActivity {
+	runes := []rune(detail)
+	if len(runes) > syncActivityDetailMaxRunes {
+		runes = runes[:syncActivityDetailMaxRunes]
+	}
+	return &SyncActivity{
+		Account: account,
+		Result:  result,
+		PostKey: postKey,
+		Detail:  string(runes),
+	}
+}
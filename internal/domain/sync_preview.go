@@ -0,0 +1,49 @@
+package domain
+
+// SyncDestination は投稿先の種類。ドライランの結果と、同期の記録（posts・google_posts の destination_type）で使う
+type SyncDestination string
+
+const (
+	SyncDestinationWordpress SyncDestination = "wordpress"
+	SyncDestinationGbpPhoto  SyncDestination = "gbp_photo"
+	SyncDestinationGbpPost   SyncDestination = "gbp_post"
+	SyncDestinationFacebook  SyncDestination = "facebook"
+)
+
+// SyncPreview はドライランで、同期した場合に投稿される内容1件。
+// Error が空でない場合は、その連携（PostKey が空でない場合はその投稿）の同期が失敗することを表す。
+type SyncPreview struct {
+	Account     Account
+	Destination SyncDestination
+	// PostKey は投稿元の記事やメディアのID
+	PostKey string
+	// SourceURL は投稿元のURL（Instagramのパーマリンク、WordPressやフィードの記事のURL）
+	SourceURL string
+	// Title と Content はWordPressの記事のタイトルと本文のHTML。Facebookは Content が本文
+	Title   string
+	Content string
+	// Summary、TopicType、CallToAction はGBPのLocal Postの内容（整形・切り詰め後）
+	Summary      string
+	TopicType    GbpTopicType
+	CallToAction *GbpCallToAction
+	// MediaURLs はアップロードされるメディア。ドライランではアップロードしないため投稿元のURLのまま
+	MediaURLs []string
+	Error     string
+}
+
+// NewGbpLocalPostPreview はLocal Postの内容からドライランの結果を作る。
+func NewGbpLocalPostPreview(account Account, postKey, sourceURL string, localPost GbpLocalPost) SyncPreview {
+	preview := SyncPreview{
+		Account:      account,
+		Destination:  SyncDestinationGbpPost,
+		PostKey:      postKey,
+		SourceURL:    sourceURL,
+		Summary:      localPost.Summary,
+		TopicType:    localPost.TopicType,
+		CallToAction: localPost.CallToAction,
+	}
+	if localPost.MediaURL != "" {
+		preview.MediaURLs = []string{localPost.MediaURL}
+	}
+	return preview
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// SyncSource は同期の記録（posts・google_posts）の投稿元の種類
+type SyncSource string
+
+const (
+	SyncSourceInstagram SyncSource = "instagram"
+	SyncSourceWordpress SyncSource = "wordpress"
+)
+
+// SyncRecordStatus は同期の記録（posts・google_posts）の状態。
+// 投稿先に送る前に reserved で記録し、投稿できたら published にする。
+// 処理が途中で止まって reserved のまま残った記録は次回の同期で片付け、投稿できたか確認できない場合は unconfirmed にする。
+type SyncRecordStatus string
+
+const (
+	SyncRecordReserved    SyncRecordStatus = "reserved"
+	SyncRecordPublished   SyncRecordStatus = "published"
+	SyncRecordUnconfirmed SyncRecordStatus = "unconfirmed"
+)
+
+// SyncReservationTimeout は reserved のまま処理が止まったとみなすまでの時間。
+// 投稿中の予約を片付けないよう、1件の投稿（メディアのアップロードを含む）にかかる時間より長くする。
+const SyncReservationTimeout = 30 * time.Minute
+
+// IsSyncRejectedStatus は投稿先が返したステータスコードが、投稿が作られていないことが確かなエラー（4xx）かどうかを返す。
+// 408（タイムアウト）と 409（競合）は投稿先で処理された可能性があるため含めない。
+func IsSyncRejectedStatus(statusCode int) bool {
+	if statusCode == http.StatusRequestTimeout || statusCode == http.StatusConflict {
+		return false
+	}
+	return statusCode >= 400 && statusCode < 500
+}
+
+// NewSyncIdempotencyKey は投稿先・顧客ID・メディアIDから冪等キーを作る。
+// 同じ投稿は何度投稿し直しても同じキーになるため、投稿先はキーで重複を判定できる。
+func NewSyncIdempotencyKey(destination SyncDestination, customerID int, mediaID string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s:%d:%s", destination, customerID, mediaID)))
+	return hex.EncodeToString(sum[:])
+}
+
+// GooglePostDestination は google_posts の post_type に対応する投稿先
+func GooglePostDestination(postType string) SyncDestination {
+	if postType == PostTypePost {
+		return SyncDestinationGbpPost
+	}
+	return SyncDestinationGbpPhoto
+}
+
+// syncTimeLayouts はInstagram（2006-01-02T15:04:05+0000）とGBP（RFC3339）が返す日時の形式
+var syncTimeLayouts = []string{
+	"2006-01-02T15:04:05-0700",
+	time.RFC3339Nano,
+}
+
+// ParseSyncTime は投稿元・投稿先のAPIが返す日時の文字列を読む。空や読めない形式の場合は nil
+func ParseSyncTime(value string) *time.Time {
+	for _, layout := range syncTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
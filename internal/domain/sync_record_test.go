@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSyncTime(t *testing.T) {
+	instagram := ParseSyncTime("2025-09-29T10:19:24+0000")
+	require.NotNil(t, instagram)
+	assert.True(t, instagram.Equal(time.Date(2025, 9, 29, 10, 19, 24, 0, time.UTC)))
+
+	gbp := ParseSyncTime("2026-03-18T01:02:03.456789Z")
+	require.NotNil(t, gbp)
+	assert.True(t, gbp.Equal(time.Date(2026, 3, 18, 1, 2, 3, 456789000, time.UTC)))
+
+	assert.Nil(t, ParseSyncTime(""))
+	assert.Nil(t, ParseSyncTime("1640995200"))
+}
+
+func TestGooglePostDestination(t *testing.T) {
+	assert.Equal(t, SyncDestinationGbpPhoto, GooglePostDestination(PostTypePhoto))
+	assert.Equal(t, SyncDestinationGbpPost, GooglePostDestination(PostTypePost))
+	assert.Equal(t, SyncDestinationGbpPhoto, GooglePostDestination(""))
+}
+
+func TestNewSyncIdempotencyKey(t *testing.T) {
+	key := NewSyncIdempotencyKey(SyncDestinationWordpress, 100001, "m1")
+	assert.Len(t, key, 64)
+	// 同じ投稿は何度でも同じキーになり、投稿先・顧客・メディアのどれかが異なれば別のキーになる
+	assert.Equal(t, key, NewSyncIdempotencyKey(SyncDestinationWordpress, 100001, "m1"))
+	assert.NotEqual(t, key, NewSyncIdempotencyKey(SyncDestinationWordpress, 100002, "m1"))
+	assert.NotEqual(t, key, NewSyncIdempotencyKey(SyncDestinationWordpress, 100001, "m2"))
+	assert.NotEqual(t, key, NewSyncIdempotencyKey(SyncDestinationGbpPost, 100001, "m1"))
+}
+
+func TestIsSyncRejectedStatus(t *testing.T) {
+	assert.True(t, IsSyncRejectedStatus(400))
+	assert.True(t, IsSyncRejectedStatus(403))
+	assert.True(t, IsSyncRejectedStatus(429))
+	// 投稿先で処理された可能性がある
+	assert.False(t, IsSyncRejectedStatus(408))
+	assert.False(t, IsSyncRejectedStatus(409))
+	assert.False(t, IsSyncRejectedStatus(500))
+	assert.False(t, IsSyncRejectedStatus(503))
+	assert.False(t, IsSyncRejectedStatus(201))
+}
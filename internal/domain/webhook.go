@@ -0,0 +1,322 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WebhookEventType は外部に通知するイベントの種類
+type WebhookEventType string
+
+const (
+	WebhookEventWordpressPostPublished WebhookEventType = "wordpress.post_published"
+	WebhookEventGbpPhotoUploaded       WebhookEventType = "gbp.photo_uploaded"
+	WebhookEventGbpPostPublished       WebhookEventType = "gbp.post_published"
+	WebhookEventFacebookPostPublished  WebhookEventType = "facebook.post_published"
+	WebhookEventSyncFailed             WebhookEventType = "sync.failed"
+	WebhookEventTokenExpiring          WebhookEventType = "token.expiring"
+	WebhookEventAccountDisabled        WebhookEventType = "account.disabled"
+)
+
+var WebhookEventTypes = []WebhookEventType{
+	WebhookEventWordpressPostPublished,
+	WebhookEventGbpPhotoUploaded,
+	WebhookEventGbpPostPublished,
+	WebhookEventFacebookPostPublished,
+	WebhookEventSyncFailed,
+	WebhookEventTokenExpiring,
+	WebhookEventAccountDisabled,
+}
+
+func (t WebhookEventType) Valid() bool {
+	for _, v := range WebhookEventTypes {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// AccountType は連携設定の種類。イベントがどの連携のものかを表す。
+type AccountType string
+
+const (
+	AccountTypeWordpressInstagram AccountType = "wordpress_instagram"
+	AccountTypeBusinessInstagram  AccountType = "business_instagram"
+	AccountTypeWordpressGbp       AccountType = "wordpress_gbp"
+	AccountTypeFacebookInstagram  AccountType = "facebook_instagram"
+	AccountTypeFeed               AccountType = "feed"
+	AccountTypeGoogleBusiness     AccountType = "google_business"
+)
+
+var AccountTypes = []AccountType{
+	AccountTypeWordpressInstagram,
+	AccountTypeBusinessInstagram,
+	AccountTypeWordpressGbp,
+	AccountTypeFacebookInstagram,
+	AccountTypeFeed,
+	AccountTypeGoogleBusiness,
+}
+
+func (t AccountType) Valid() bool {
+	for _, v := range AccountTypes {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Account はイベントの対象の連携設定。トークンの期限切れなど連携によらないイベントはゼロ値。
+type Account struct {
+	Type AccountType `json:"type"`
+	ID   int         `json:"id"`
+	Name string      `json:"name"`
+}
+
+func (c *WordpressInstagram) Account() Account {
+	return Account{Type: AccountTypeWordpressInstagram, ID: c.ID, Name: c.Name}
+}
+
+func (b *BusinessInstagram) Account() Account {
+	return Account{Type: AccountTypeBusinessInstagram, ID: b.ID, Name: b.Name}
+}
+
+func (w *WordpressGbp) Account() Account {
+	return Account{Type: AccountTypeWordpressGbp, ID: w.ID, Name: w.Name}
+}
+
+func (f *FacebookInstagram) Account() Account {
+	return Account{Type: AccountTypeFacebookInstagram, ID: f.ID, Name: f.Name}
+}
+
+func (f *Feed) Account() Account {
+	return Account{Type: AccountTypeFeed, ID: f.ID, Name: f.Name}
+}
+
+func (b *GoogleBusinesses) Account() Account {
+	return Account{Type: AccountTypeGoogleBusiness, ID: b.ID, Name: b.Title}
+}
+
+// WebhookSubscription はWebhookの送信先。
+// EventTypes のイベントのうち、AccountType / AccountID が指定されている場合はその連携のイベントだけを送る。
+type WebhookSubscription struct {
+	ID          int
+	Name        string
+	URL         string
+	Secret      string
+	EventTypes  []WebhookEventType
+	AccountType AccountType
+	AccountID   int
+	Status      Status
+	UpdatedAt   time.Time
+	CreatedAt   time.Time
+}
+
+func (s *WebhookSubscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: url が不正です: %s", ErrBadRequest, s.URL)
+	}
+	if len(s.EventTypes) == 0 {
+		return fmt.Errorf("%w: event_types を指定してください", ErrBadRequest)
+	}
+	for _, t := range s.EventTypes {
+		if !t.Valid() {
+			return fmt.Errorf("%w: event_types が不正です: %s", ErrBadRequest, t)
+		}
+	}
+	if s.AccountType != "" && !s.AccountType.Valid() {
+		return fmt.Errorf("%w: account_type が不正です: %s", ErrBadRequest, s.AccountType)
+	}
+	if s.AccountID != 0 && s.AccountType == "" {
+		return fmt.Errorf("%w: account_id を指定する場合は account_type も指定してください", ErrBadRequest)
+	}
+	return nil
+}
+
+// Subscribes はイベントを送信する対象かどうかを判定する。
+func (s *WebhookSubscription) Subscribes(event *WebhookEvent) bool {
+	if s.Status != 1 {
+		return false
+	}
+	subscribed := false
+	for _, t := range s.EventTypes {
+		if t == event.EventType {
+			subscribed = true
+			break
+		}
+	}
+	if !subscribed {
+		return false
+	}
+	if s.AccountType != "" && s.AccountType != event.Account.Type {
+		return false
+	}
+	if s.AccountID != 0 && s.AccountID != event.Account.ID {
+		return false
+	}
+	return true
+}
+
+// GenerateWebhookSecret は署名用のシークレットを作る。
+func GenerateWebhookSecret() (string, error) {
+	return randomHex(32)
+}
+
+// WebhookEvent は送信したイベント。再送できるように、送信する本文(Payload)をそのまま保存する。
+type WebhookEvent struct {
+	ID        int
+	EventID   string
+	EventType WebhookEventType
+	Account   Account
+	Payload   string
+	CreatedAt time.Time
+}
+
+type webhookPayload struct {
+	ID        string           `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Account   *Account         `json:"account,omitempty"`
+	Data      any              `json:"data"`
+}
+
+// NewWebhookEvent はイベントと送信する本文を作る。
+func NewWebhookEvent(eventType WebhookEventType, account Account, data any, now time.Time) (*WebhookEvent, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, err
+	}
+	eventID := "evt_" + id
+	payload := webhookPayload{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: now,
+		Data:      data,
+	}
+	if account.Type != "" {
+		payload.Account = &account
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookEvent{
+		EventID:   eventID,
+		EventType: eventType,
+		Account:   account,
+		Payload:   string(b),
+		CreatedAt: now,
+	}, nil
+}
+
+// 送信時のヘッダー。受信側は X-Homing-Signature を検証する。
+const (
+	WebhookHeaderEvent     = "X-Homing-Event"
+	WebhookHeaderEventID   = "X-Homing-Event-Id"
+	WebhookHeaderDelivery  = "X-Homing-Delivery"
+	WebhookHeaderTimestamp = "X-Homing-Timestamp"
+	WebhookHeaderSignature = "X-Homing-Signature"
+)
+
+// SignWebhook は "timestamp.payload" のHMAC-SHA256を返す（"sha256=" + hex）。
+// タイムスタンプを含めることで、受信側で古いリクエストの再利用を検出できる。
+func SignWebhook(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookHeaders は送信時のヘッダーを作る。
+func WebhookHeaders(sub *WebhookSubscription, event *WebhookEvent, deliveryID int, now time.Time) map[string]string {
+	ts := now.Unix()
+	return map[string]string{
+		"Content-Type":         "application/json",
+		WebhookHeaderEvent:     string(event.EventType),
+		WebhookHeaderEventID:   event.EventID,
+		WebhookHeaderDelivery:  strconv.Itoa(deliveryID),
+		WebhookHeaderTimestamp: strconv.FormatInt(ts, 10),
+		WebhookHeaderSignature: SignWebhook(sub.Secret, ts, []byte(event.Payload)),
+	}
+}
+
+// WebhookDeliveryStatus は送信の状態
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryRetrying  WebhookDeliveryStatus = "retrying"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookMaxAttempts は自動で再送する回数の上限（初回を含む）
+const WebhookMaxAttempts = 5
+
+// webhookResponseMaxRunes は送信ログに残すレスポンス本文の長さ
+const webhookResponseMaxRunes = 1000
+
+// WebhookDelivery はイベントを送信先に送った記録。失敗した場合は NextRetryAt に再送する。
+type WebhookDelivery struct {
+	ID             int
+	EventID        int
+	SubscriptionID int
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	ResponseStatus int
+	ResponseBody   string
+	Error          string
+	NextRetryAt    *time.Time
+	DeliveredAt    *time.Time
+	UpdatedAt      time.Time
+	CreatedAt      time.Time
+}
+
+// RecordAttempt は送信結果を記録する。2xx以外は失敗として、上限まで間隔を空けて再送する（1分, 4分, 16分, 64分）。
+func (d *WebhookDelivery) RecordAttempt(statusCode int, body string, err error, now time.Time) {
+	d.Attempts++
+	d.ResponseStatus = statusCode
+	runes := []rune(body)
+	if len(runes) > webhookResponseMaxRunes {
+		runes = runes[:webhookResponseMaxRunes]
+	}
+	d.ResponseBody = string(runes)
+	d.Error = ""
+	d.NextRetryAt = nil
+
+	if err == nil && statusCode >= 200 && statusCode < 300 {
+		d.Status = WebhookDeliverySucceeded
+		d.DeliveredAt = &now
+		return
+	}
+	if err != nil {
+		d.Error = SyncErrorMessage(err)
+	} else {
+		d.Error = fmt.Sprintf("status code: %d", statusCode)
+	}
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = WebhookDeliveryFailed
+		return
+	}
+	d.Status = WebhookDeliveryRetrying
+	next := now.Add(time.Minute << (2 * (d.Attempts - 1)))
+	d.NextRetryAt = &next
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSubscription_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		sub     WebhookSubscription
+		wantErr bool
+	}{
+		{name: "全連携", sub: WebhookSubscription{URL: "https://example.com/hook", EventTypes: []WebhookEventType{WebhookEventSyncFailed}}},
+		{name: "連携を指定", sub: WebhookSubscription{URL: "https://example.com/hook", EventTypes: []WebhookEventType{WebhookEventGbpPostPublished}, AccountType: AccountTypeWordpressGbp, AccountID: 3}},
+		{name: "URLが不正", sub: WebhookSubscription{URL: "ftp://example.com", EventTypes: []WebhookEventType{WebhookEventSyncFailed}}, wantErr: true},
+		{name: "イベントなし", sub: WebhookSubscription{URL: "https://example.com/hook"}, wantErr: true},
+		{name: "不明なイベント", sub: WebhookSubscription{URL: "https://example.com/hook", EventTypes: []WebhookEventType{"post.deleted"}}, wantErr: true},
+		{name: "不明な連携", sub: WebhookSubscription{URL: "https://example.com/hook", EventTypes: []WebhookEventType{WebhookEventSyncFailed}, AccountType: "twitter"}, wantErr: true},
+		{name: "連携IDだけ指定", sub: WebhookSubscription{URL: "https://example.com/hook", EventTypes: []WebhookEventType{WebhookEventSyncFailed}, AccountID: 3}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sub.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrBadRequest))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWebhookSubscription_Subscribes(t *testing.T) {
+	event := &WebhookEvent{
+		EventType: WebhookEventWordpressPostPublished,
+		Account:   Account{Type: AccountTypeWordpressInstagram, ID: 5},
+	}
+	tests := []struct {
+		name string
+		sub  WebhookSubscription
+		want bool
+	}{
+		{name: "全連携", sub: WebhookSubscription{Status: 1, EventTypes: []WebhookEventType{WebhookEventSyncFailed, WebhookEventWordpressPostPublished}}, want: true},
+		{name: "連携の種類が一致", sub: WebhookSubscription{Status: 1, EventTypes: []WebhookEventType{WebhookEventWordpressPostPublished}, AccountType: AccountTypeWordpressInstagram}, want: true},
+		{name: "連携IDが一致", sub: WebhookSubscription{Status: 1, EventTypes: []WebhookEventType{WebhookEventWordpressPostPublished}, AccountType: AccountTypeWordpressInstagram, AccountID: 5}, want: true},
+		{name: "停止中", sub: WebhookSubscription{Status: 0, EventTypes: []WebhookEventType{WebhookEventWordpressPostPublished}}},
+		{name: "イベントが違う", sub: WebhookSubscription{Status: 1, EventTypes: []WebhookEventType{WebhookEventSyncFailed}}},
+		{name: "連携の種類が違う", sub: WebhookSubscription{Status: 1, EventTypes: []WebhookEventType{WebhookEventWordpressPostPublished}, AccountType: AccountTypeFeed}},
+		{name: "連携IDが違う", sub: WebhookSubscription{Status: 1, EventTypes: []WebhookEventType{WebhookEventWordpressPostPublished}, AccountType: AccountTypeWordpressInstagram, AccountID: 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.sub.Subscribes(event))
+		})
+	}
+}
+
+func TestNewWebhookEvent(t *testing.T) {
+	now := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+	account := Account{Type: AccountTypeFeed, ID: 2, Name: "お知らせ"}
+
+	event, err := NewWebhookEvent(WebhookEventSyncFailed, account, map[string]string{"error": "timeout"}, now)
+	assert.NoError(t, err)
+	assert.Regexp(t, `^evt_[0-9a-f]{32}$`, event.EventID)
+
+	var payload map[string]any
+	assert.NoError(t, json.Unmarshal([]byte(event.Payload), &payload))
+	assert.Equal(t, event.EventID, payload["id"])
+	assert.Equal(t, "sync.failed", payload["type"])
+	assert.Equal(t, "2026-03-14T09:00:00Z", payload["created_at"])
+	assert.Equal(t, map[string]any{"type": "feed", "id": float64(2), "name": "お知らせ"}, payload["account"])
+	assert.Equal(t, map[string]any{"error": "timeout"}, payload["data"])
+
+	// 連携によらないイベントは account を含めない
+	event, err = NewWebhookEvent(WebhookEventTokenExpiring, Account{}, map[string]string{}, now)
+	assert.NoError(t, err)
+	payload = map[string]any{}
+	assert.NoError(t, json.Unmarshal([]byte(event.Payload), &payload))
+	assert.NotContains(t, payload, "account")
+}
+
+func TestSignWebhook(t *testing.T) {
+	// echo -n '1700000000.{"id":"evt_1"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=af784f27423c462e20039559cd4264140f7b7ed4c9090e26fd663faa5eeb8dda",
+		SignWebhook("secret", 1700000000, []byte(`{"id":"evt_1"}`)),
+	)
+}
+
+func TestWebhookDelivery_RecordAttempt(t *testing.T) {
+	now := time.Date(2026, 3, 14, 9, 0, 0, 0, time.UTC)
+
+	d := WebhookDelivery{Status: WebhookDeliveryPending}
+	d.RecordAttempt(200, "ok", nil, now)
+	assert.Equal(t, WebhookDeliverySucceeded, d.Status)
+	assert.Equal(t, 1, d.Attempts)
+	assert.Equal(t, now, *d.DeliveredAt)
+	assert.Nil(t, d.NextRetryAt)
+
+	d = WebhookDelivery{Status: WebhookDeliveryPending}
+	wantWaits := []time.Duration{time.Minute, 4 * time.Minute, 16 * time.Minute, 64 * time.Minute}
+	for i, wait := range wantWaits {
+		d.RecordAttempt(500, "error", nil, now)
+		assert.Equal(t, WebhookDeliveryRetrying, d.Status)
+		assert.Equal(t, i+1, d.Attempts)
+		assert.Equal(t, now.Add(wait), *d.NextRetryAt)
+		assert.Equal(t, "status code: 500", d.Error)
+	}
+	d.RecordAttempt(0, "", errors.New("connection refused"), now)
+	assert.Equal(t, WebhookDeliveryFailed, d.Status)
+	assert.Equal(t, WebhookMaxAttempts, d.Attempts)
+	assert.Nil(t, d.NextRetryAt)
+	assert.Equal(t, "connection refused", d.Error)
+	assert.Nil(t, d.DeliveredAt)
+}
@@ -3,6 +3,9 @@ package domain
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
+	"html"
+	"strings"
 	"time"
 )
 
@@ -18,6 +21,12 @@ type WordpressInstagram struct {
 	Status             Status
 	DeleteHash         bool
 	Categories         []string
+	APIMode            WordpressAPIMode
+	WordpressUsername  string
+	AppPassword        string
+	Template           string
+	LastSyncedAt       *time.Time
+	LastSyncError      string
 	UpdatedAt          time.Time
 	CreatedAt          time.Time
 }
@@ -28,4 +37,82 @@ func (c *WordpressInstagram) GenerateAPIKey(secretPhrase string) string {
 	return hex.EncodeToString(hash[:])
 }
 
+// WordpressAPIMode はWordPressへの投稿方法。
+// rodut はhomingのプラグイン（rodut/v1）、wp_v2 はWordPress標準のREST API（wp/v2）とアプリケーションパスワードを使う。
+type WordpressAPIMode string
+
+const (
+	WordpressAPIModeRodut WordpressAPIMode = "rodut"
+	WordpressAPIModeV2    WordpressAPIMode = "wp_v2"
+)
+
+// IsV2 は標準のREST APIで投稿するかどうか（未設定はプラグイン）
+func (c *WordpressInstagram) IsV2() bool {
+	return c.APIMode == WordpressAPIModeV2
+}
+
+// ValidateAPIMode は投稿方法と認証情報の組み合わせを検証する。
+func (c *WordpressInstagram) ValidateAPIMode() error {
+	switch c.APIMode {
+	case WordpressAPIModeRodut:
+		return nil
+	case WordpressAPIModeV2:
+		if c.WordpressUsername == "" || c.AppPassword == "" {
+			return fmt.Errorf("%w: api_mode が wp_v2 の場合は wordpress_username と app_password が必要です", ErrBadRequest)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: api_mode が不正です: %s", ErrBadRequest, c.APIMode)
+}
+
+// テンプレートで使える置換文字列
+const (
+	WordpressTemplateContent   = "{{content}}"
+	WordpressTemplateCaption   = "{{caption}}"
+	WordpressTemplatePermalink = "{{permalink}}"
+	WordpressTemplateDate      = "{{date}}"
+)
+
+// ValidateTemplate はテンプレートに投稿の本文が含まれているかを検証する（未設定は本文のみ）。
+func (c *WordpressInstagram) ValidateTemplate() error {
+	if c.Template != "" && !strings.Contains(c.Template, WordpressTemplateContent) {
+		return fmt.Errorf("%w: template には %s を含めてください", ErrBadRequest, WordpressTemplateContent)
+	}
+	return nil
+}
+
+// RenderContent は連携先のテンプレートで記事の本文を作る。
+// 同じInstagramを複数のサイトに連携する場合に、サイトごとに前後の文言などを変えられるようにする。
+func (c *WordpressInstagram) RenderContent(post InstagramPost) string {
+	content := post.GetContent()
+	if c.Template == "" {
+		return content
+	}
+	caption := post.Caption
+	if post.DeleteHash {
+		caption = removeHashtags(caption)
+	}
+	return strings.NewReplacer(
+		WordpressTemplateContent, content,
+		WordpressTemplateCaption, html.EscapeString(caption),
+		WordpressTemplatePermalink, html.EscapeString(post.Permalink),
+		WordpressTemplateDate, post.GetPostDate(),
+	).Replace(c.Template)
+}
+
+// wordpressSyncErrorMaxRunes は last_sync_error カラムの長さ
+const wordpressSyncErrorMaxRunes = 1000
+
+// SyncErrorMessage は連携先ごとの同期結果として保存するエラー文。成功時は空文字。
+func SyncErrorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	runes := []rune(err.Error())
+	if len(runes) > wordpressSyncErrorMaxRunes {
+		runes = runes[:wordpressSyncErrorMaxRunes]
+	}
+	return string(runes)
+}
+
 type Status int
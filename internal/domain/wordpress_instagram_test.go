@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWordpressInstagram_ValidateAPIMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		wi      WordpressInstagram
+		wantErr bool
+	}{
+		{name: "プラグイン", wi: WordpressInstagram{APIMode: WordpressAPIModeRodut}},
+		{name: "wp/v2", wi: WordpressInstagram{APIMode: WordpressAPIModeV2, WordpressUsername: "editor", AppPassword: "xxxx"}},
+		{name: "wp/v2 パスワードなし", wi: WordpressInstagram{APIMode: WordpressAPIModeV2, WordpressUsername: "editor"}, wantErr: true},
+		{name: "不明なモード", wi: WordpressInstagram{APIMode: "xmlrpc"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.wi.ValidateAPIMode()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrBadRequest))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWordpressInstagram_ValidateTemplate(t *testing.T) {
+	assert.NoError(t, (&WordpressInstagram{}).ValidateTemplate())
+	assert.NoError(t, (&WordpressInstagram{Template: "<p>PR</p>{{content}}"}).ValidateTemplate())
+	assert.True(t, errors.Is((&WordpressInstagram{Template: "{{caption}}"}).ValidateTemplate(), ErrBadRequest))
+}
+
+func TestWordpressInstagram_RenderContent(t *testing.T) {
+	post := InstagramPost{
+		Caption:    "新メニュー <限定> #cafe",
+		Permalink:  "https://www.instagram.com/p/abc/",
+		Timestamp:  "2026-03-03T01:00:00+0000",
+		MediaType:  "IMAGE",
+		SourceURLs: []string{"https://example.com/a.jpg"},
+		DeleteHash: true,
+	}
+
+	wi := WordpressInstagram{}
+	assert.Equal(t, post.GetContent(), wi.RenderContent(post))
+
+	wi.Template = `{{content}}<p>{{caption}}</p><p>{{date}} <a href="{{permalink}}">Instagram</a></p>`
+	assert.Equal(t, post.GetContent()+`<p>新メニュー &lt;限定&gt;</p><p>2026-03-03 10:00:00 <a href="https://www.instagram.com/p/abc/">Instagram</a></p>`, wi.RenderContent(post))
+}
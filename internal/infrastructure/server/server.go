@@ -35,9 +35,13 @@ func Run() {
 	if err != nil {
 		log.Fatal("Failed to read credentials file:", err)
 	}
-	gbpAdapter, err := di.NewGbpAdapter(credentialsData)
+	googleOAuth, err := di.NewGoogleOAuth(credentialsData, config.Env.GoogleOAuthRedirectURL)
 	if err != nil {
-		log.Fatal("Failed to initialize GBP adapter:", err)
+		log.Fatal("Failed to initialize Google OAuth:", err)
+	}
+	gbpAdapter := di.NewGbpAdapter(googleOAuth)
+	if err := di.NewGoogleOAuthUsecase(db, googleOAuth, gbpAdapter).LoadToken(context.Background()); err != nil {
+		log.Fatal("Failed to load Google token:", err)
 	}
 
 	// S3Adapter初期化
@@ -54,7 +58,7 @@ func Run() {
 	e.Use(middleware.Recover())
 
 	// ハンドラー初期化
-	apiHandler := di.NewHandler(httpDriver, db, gbpAdapter, s3Adapter)
+	apiHandler := di.NewHandler(httpDriver, db, googleOAuth, gbpAdapter, s3Adapter)
 
 	// Swagger ルート
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
@@ -72,6 +76,21 @@ func Run() {
 	api.POST("/sync/wordpress-gbp", apiHandler.SyncAllWordpressGbp)
 	api.POST("/sync/wordpress-gbp/:id", apiHandler.SyncOneWordpressGbp)
 
+	api.POST("/sync/facebook-instagram", apiHandler.SyncAllFacebookInstagram)
+	api.POST("/sync/facebook-instagram/:id", apiHandler.SyncOneFacebookInstagram)
+
+	api.POST("/sync/feed", apiHandler.SyncAllFeed)
+	api.POST("/sync/feed/:id", apiHandler.SyncOneFeed)
+
+	api.POST("/sync/google-review", apiHandler.SyncGoogleReview)
+	api.POST("/sync/google-business-insights", apiHandler.SyncGoogleBusinessInsights)
+	api.POST("/sync/google-post-retention", apiHandler.ApplyGooglePostRetention)
+	api.POST("/sync/webhook-retry", apiHandler.RetryWebhookDeliveries)
+	api.POST("/sync/digest", apiHandler.SendDigest)
+
+	api.GET("/oauth/google/start", apiHandler.StartGoogleOAuth)
+	api.GET("/oauth/google/callback", apiHandler.GoogleOAuthCallback)
+
 	api.POST("/token", apiHandler.SaveToken)
 	api.GET("/token", apiHandler.GetToken)
 	api.POST("/token/check", apiHandler.CheckToken)
@@ -80,24 +99,86 @@ func Run() {
 	api.GET("/wordpress-instagram", apiHandler.GetWordpressInstagramList)
 	api.GET("/wordpress-instagram/:id", apiHandler.GetWordpressInstagram)
 	api.POST("/wordpress-instagram", apiHandler.CreateWordpressInstagram)
+	api.POST("/wordpress-instagram/validate", apiHandler.ValidateWordpressInstagram)
+	api.POST("/wordpress-instagram/import", apiHandler.ImportWordpressInstagram)
+	api.GET("/wordpress-instagram/export", apiHandler.ExportWordpressInstagram)
 	api.PUT("/wordpress-instagram/:id", apiHandler.UpdateWordpressInstagram)
 	api.DELETE("/wordpress-instagram/:id", apiHandler.DeleteWordpressInstagram)
 
+	api.GET("/facebook-instagram/count", apiHandler.GetFacebookInstagramCount)
+	api.GET("/facebook-instagram", apiHandler.GetFacebookInstagramList)
+	api.GET("/facebook-instagram/:id", apiHandler.GetFacebookInstagram)
+	api.POST("/facebook-instagram", apiHandler.CreateFacebookInstagram)
+	api.PUT("/facebook-instagram/:id", apiHandler.UpdateFacebookInstagram)
+	api.DELETE("/facebook-instagram/:id", apiHandler.DeleteFacebookInstagram)
+
+	api.GET("/feed", apiHandler.GetFeedList)
+	api.GET("/feed/:id", apiHandler.GetFeed)
+	api.POST("/feed", apiHandler.CreateFeed)
+	api.PUT("/feed/:id", apiHandler.UpdateFeed)
+	api.DELETE("/feed/:id", apiHandler.DeleteFeed)
+
+	api.GET("/webhook-subscription", apiHandler.GetWebhookSubscriptionList)
+	api.GET("/webhook-subscription/:id", apiHandler.GetWebhookSubscription)
+	api.POST("/webhook-subscription", apiHandler.CreateWebhookSubscription)
+	api.PUT("/webhook-subscription/:id", apiHandler.UpdateWebhookSubscription)
+	api.DELETE("/webhook-subscription/:id", apiHandler.DeleteWebhookSubscription)
+
+	api.GET("/webhook-event", apiHandler.GetWebhookEventList)
+	api.GET("/webhook-event/:id", apiHandler.GetWebhookEvent)
+	api.POST("/webhook-event/:id/replay", apiHandler.ReplayWebhookEvent)
+	api.GET("/webhook-delivery", apiHandler.GetWebhookDeliveryList)
+
+	api.GET("/notification-rule", apiHandler.GetNotificationRuleList)
+	api.GET("/notification-rule/:id", apiHandler.GetNotificationRule)
+	api.POST("/notification-rule", apiHandler.CreateNotificationRule)
+	api.PUT("/notification-rule/:id", apiHandler.UpdateNotificationRule)
+	api.DELETE("/notification-rule/:id", apiHandler.DeleteNotificationRule)
+	api.POST("/notification-rule/:id/test", apiHandler.TestNotificationRule)
+
+	api.GET("/digest", apiHandler.GetDigest)
+	api.GET("/digest/html", apiHandler.GetDigestHTML)
+
+	api.POST("/slack/interactivity", apiHandler.SlackInteraction)
+
+	api.GET("/google-account", apiHandler.GetGoogleAccountList)
+
 	api.GET("/google-business", apiHandler.GetGoogleBusinessList)
 	api.POST("/google-business/fetch", apiHandler.FetchGoogleBusinessList)
+	api.GET("/google-business/:id/insights", apiHandler.GetGoogleBusinessInsights)
+	api.GET("/google-business/:id/location", apiHandler.GetGoogleBusinessLocation)
+	api.POST("/google-business/:id/location/preview", apiHandler.PreviewGoogleBusinessLocation)
+	api.PUT("/google-business/:id/location", apiHandler.UpdateGoogleBusinessLocation)
+	api.GET("/google-business/:id/location/changes", apiHandler.GetGoogleBusinessLocationChanges)
+	api.GET("/google-business/:id/posts", apiHandler.GetGooglePostList)
+	api.PUT("/google-business/:id/retention", apiHandler.UpdateGoogleBusinessRetention)
+	api.DELETE("/google-post/:id", apiHandler.DeleteGooglePost)
+	api.POST("/google-post/:id/repost", apiHandler.RepostGooglePost)
+	api.GET("/gbp-post-rejection", apiHandler.GetGbpPostRejectionList)
 
 	api.GET("/wordpress-gbp", apiHandler.GetWordpressGbpList)
 	api.GET("/wordpress-gbp/:id", apiHandler.GetWordpressGbp)
 	api.POST("/wordpress-gbp", apiHandler.CreateWordpressGbp)
+	api.POST("/wordpress-gbp/validate", apiHandler.ValidateWordpressGbp)
+	api.POST("/wordpress-gbp/import", apiHandler.ImportWordpressGbp)
+	api.GET("/wordpress-gbp/export", apiHandler.ExportWordpressGbp)
 	api.PUT("/wordpress-gbp/:id", apiHandler.UpdateWordpressGbp)
 	api.DELETE("/wordpress-gbp/:id", apiHandler.DeleteWordpressGbp)
 
 	api.GET("/business-instagram", apiHandler.GetBusinessInstagramList)
 	api.GET("/business-instagram/:id", apiHandler.GetBusinessInstagram)
 	api.POST("/business-instagram", apiHandler.CreateBusinessInstagram)
+	api.POST("/business-instagram/validate", apiHandler.ValidateBusinessInstagram)
+	api.POST("/business-instagram/import", apiHandler.ImportBusinessInstagram)
+	api.GET("/business-instagram/export", apiHandler.ExportBusinessInstagram)
 	api.PUT("/business-instagram/:id", apiHandler.UpdateBusinessInstagram)
 	api.DELETE("/business-instagram/:id", apiHandler.DeleteBusinessInstagram)
 
+	api.GET("/google-review", apiHandler.GetGoogleReviewList)
+	api.GET("/google-review/:id", apiHandler.GetGoogleReview)
+	api.PUT("/google-review/:id/reply", apiHandler.ReplyGoogleReview)
+	api.DELETE("/google-review/:id/reply", apiHandler.DeleteGoogleReviewReply)
+
 	srv := &http.Server{
 		Addr:    config.Env.Address,
 		Handler: e,
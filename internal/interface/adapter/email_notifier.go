@@ -0,0 +1,98 @@
+package adapter
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+)
+
+type emailNotifier struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewEmailNotifier はSMTPでメールを送る。destination は宛先のメールアドレス（カンマ区切り）、mention は本文の先頭に付ける文字列。
+// username が空の場合は認証しない。
+func NewEmailNotifier(host, port, username, password, from string) Notifier {
+	return &emailNotifier{
+		host:     host,
+		port:     port,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (e *emailNotifier) Notify(ctx context.Context, destination, mention string, n domain.Notification) error {
+	if e.host == "" {
+		return fmt.Errorf("SMTPサーバーが設定されていません")
+	}
+	var to []string
+	for _, addr := range strings.Split(destination, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+	if len(to) == 0 {
+		return fmt.Errorf("メールの宛先がありません")
+	}
+
+	var auth smtp.Auth
+	if e.username != "" {
+		auth = smtp.PlainAuth("", e.username, e.password, e.host)
+	}
+	msg := buildMail(e.from, to, n.Subject, n.Message(mention), n.HTMLMessage(mention), time.Now())
+	if err := smtp.SendMail(net.JoinHostPort(e.host, e.port), auth, e.from, to, msg); err != nil {
+		return fmt.Errorf("メールの送信に失敗: %w", err)
+	}
+	return nil
+}
+
+// buildMail は日本語の件名・本文を送れるように、件名はMIMEエンコード、本文はbase64にする。
+// html が空でない場合はテキストとHTMLの multipart/alternative にする。
+func buildMail(from string, to []string, subject, body, html string, now time.Time) []byte {
+	sb := strings.Builder{}
+	sb.WriteString("From: " + from + "\r\n")
+	sb.WriteString("To: " + strings.Join(to, ", ") + "\r\n")
+	sb.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", subject) + "\r\n")
+	sb.WriteString("Date: " + now.Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	if html == "" {
+		writeMailPart(&sb, "text/plain", body)
+		return []byte(sb.String())
+	}
+
+	boundary := multipart.NewWriter(io.Discard).Boundary()
+	sb.WriteString("Content-Type: multipart/alternative; boundary=\"" + boundary + "\"\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString("--" + boundary + "\r\n")
+	writeMailPart(&sb, "text/plain", body)
+	sb.WriteString("--" + boundary + "\r\n")
+	writeMailPart(&sb, "text/html", html)
+	sb.WriteString("--" + boundary + "--\r\n")
+	return []byte(sb.String())
+}
+
+func writeMailPart(sb *strings.Builder, contentType, body string) {
+	sb.WriteString("Content-Type: " + contentType + "; charset=UTF-8\r\n")
+	sb.WriteString("Content-Transfer-Encoding: base64\r\n")
+	sb.WriteString("\r\n")
+	encoded := base64.StdEncoding.EncodeToString([]byte(body))
+	for len(encoded) > 76 {
+		sb.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	sb.WriteString(encoded + "\r\n")
+}
@@ -0,0 +1,159 @@
+package adapter
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
+)
+
+type receivedMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// startSMTPServer はテスト用に1通だけ受け取るSMTPサーバーを起動する。
+func startSMTPServer(t *testing.T) (string, string, <-chan receivedMail) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	received := make(chan receivedMail, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		tp := textproto.NewConn(conn)
+		var m receivedMail
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				_ = tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				m.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+				_ = tp.PrintfLine("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				m.to = append(m.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+				_ = tp.PrintfLine("250 OK")
+			case cmd == "DATA":
+				_ = tp.PrintfLine("354 Start mail input")
+				data, err := io.ReadAll(tp.DotReader())
+				if err != nil {
+					return
+				}
+				m.data = string(data)
+				_ = tp.PrintfLine("250 OK")
+			case cmd == "QUIT":
+				_ = tp.PrintfLine("221 Bye")
+				received <- m
+				return
+			default:
+				_ = tp.PrintfLine("502 Command not implemented")
+			}
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(ln.Addr().String())
+	assert.NoError(t, err)
+	return host, port, received
+}
+
+func TestEmailNotifier_Notify(t *testing.T) {
+	host, port, received := startSMTPServer(t)
+
+	business := &domain.GoogleBusinesses{ID: 2, Title: "カフェ渋谷店"}
+	review := &domain.GoogleReview{ID: 9, StarRating: 2, ReviewerName: "山田", Comment: "待ち時間が長い"}
+	n := domain.NewGoogleReviewNotification(business, review, true)
+
+	notifier := NewEmailNotifier(host, port, "", "", "homing@example.com")
+	err := notifier.Notify(context.Background(), "owner@example.com, staff@example.com", "店長様", n)
+	assert.NoError(t, err)
+
+	m := <-received
+	assert.Equal(t, "homing@example.com", m.from)
+	assert.Equal(t, []string{"owner@example.com", "staff@example.com"}, m.to)
+
+	msg, err := mail.ReadMessage(strings.NewReader(m.data))
+	assert.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, "[homing] 低評価の口コミが投稿されました: カフェ渋谷店", subject)
+	assert.Equal(t, "base64", msg.Header.Get("Content-Transfer-Encoding"))
+
+	encoded, err := io.ReadAll(msg.Body)
+	assert.NoError(t, err)
+	body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(body), "店長様\n⚠️低評価の口コミが投稿されました\n"))
+	assert.Contains(t, string(body), "評価: ★★☆☆☆")
+}
+
+func TestEmailNotifier_NotifyWithoutSetting(t *testing.T) {
+	n := domain.NewTokenExpiringNotification()
+
+	err := NewEmailNotifier("", "587", "", "", "homing@example.com").Notify(context.Background(), "owner@example.com", "", n)
+	assert.Error(t, err)
+
+	err = NewEmailNotifier("127.0.0.1", "25", "", "", "homing@example.com").Notify(context.Background(), " , ", "", n)
+	assert.Error(t, err)
+}
+
+func TestEmailNotifier_NotifyHTML(t *testing.T) {
+	host, port, received := startSMTPServer(t)
+
+	n := domain.Notification{
+		EventType: domain.NotificationEventDigest,
+		Subject:   "[homing] 日次ダイジェスト 2026/03/16",
+		Text:      "投稿: 1件",
+		HTML:      "<html><body><p>投稿: 1件</p></body></html>",
+	}
+	err := NewEmailNotifier(host, port, "", "", "homing@example.com").Notify(context.Background(), "owner@example.com", "", n)
+	assert.NoError(t, err)
+
+	m := <-received
+	msg, err := mail.ReadMessage(strings.NewReader(m.data))
+	assert.NoError(t, err)
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, "base64", part.Header.Get("Content-Transfer-Encoding"))
+		encoded, err := io.ReadAll(part)
+		assert.NoError(t, err)
+		body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(encoded), "\r\n", ""))
+		assert.NoError(t, err)
+		parts = append(parts, part.Header.Get("Content-Type")+": "+string(body))
+	}
+	assert.Equal(t, []string{
+		"text/plain; charset=UTF-8: 投稿: 1件",
+		"text/html; charset=UTF-8: <html><body><p>投稿: 1件</p></body></html>",
+	}, parts)
+}
@@ -0,0 +1,146 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+type FacebookAdapter interface {
+	GetPage(ctx context.Context, token, pageID string) (*domain.FacebookPage, error)
+	Publish(ctx context.Context, page *domain.FacebookPage, post domain.FacebookPagePost) (*domain.FacebookPublished, error)
+}
+
+func NewFacebookAdapter(httpDriver driver.HttpDriver) FacebookAdapter {
+	return &facebookAdapter{
+		httpDriver: httpDriver,
+		baseURL:    baseURL,
+	}
+}
+
+type facebookAdapter struct {
+	httpDriver driver.HttpDriver
+	baseURL    string
+}
+
+// GetPage はシステムユーザーのトークンでページを取得し、投稿に使うページのアクセストークンを得る。
+func (a *facebookAdapter) GetPage(ctx context.Context, token, pageID string) (*domain.FacebookPage, error) {
+	req := external.FacebookPageRequest{
+		AccessToken: token,
+		Fields:      "id,name,access_token",
+	}
+	respBody, err := a.httpDriver.Get(ctx, a.baseURL+"/"+pageID, req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get facebook page: %w", err)
+	}
+	if err := toFacebookError(respBody); err != nil {
+		return nil, fmt.Errorf("failed to get facebook page: %w", err)
+	}
+	var pageDto external.FacebookPageResponse
+	if err := json.Unmarshal(respBody, &pageDto); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal facebook page response: %w", err)
+	}
+	return &domain.FacebookPage{
+		ID:          pageDto.Id,
+		Name:        pageDto.Name,
+		AccessToken: pageDto.AccessToken,
+	}, nil
+}
+
+// Publish はページに投稿する。
+// 複数写真の場合は写真を非公開でアップロードしてから、まとめてフィードに投稿する。
+func (a *facebookAdapter) Publish(ctx context.Context, page *domain.FacebookPage, post domain.FacebookPagePost) (*domain.FacebookPublished, error) {
+	switch {
+	case post.IsVideo():
+		resp, err := a.post(ctx, page.ID+"/videos", map[string]any{
+			"file_url":     post.VideoURL,
+			"description":  post.Message,
+			"access_token": page.AccessToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish facebook video: %w", err)
+		}
+		return &domain.FacebookPublished{
+			ID:  resp.Id,
+			URL: fmt.Sprintf("https://www.facebook.com/%s/videos/%s", page.ID, resp.Id),
+		}, nil
+
+	case len(post.PhotoURLs) == 1:
+		resp, err := a.post(ctx, page.ID+"/photos", map[string]any{
+			"url":          post.PhotoURLs[0],
+			"caption":      post.Message,
+			"access_token": page.AccessToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish facebook photo: %w", err)
+		}
+		postID := resp.PostId
+		if postID == "" {
+			postID = resp.Id
+		}
+		return &domain.FacebookPublished{
+			ID:  postID,
+			URL: "https://www.facebook.com/" + postID,
+		}, nil
+
+	case len(post.PhotoURLs) > 1:
+		attachedMedia := make([]map[string]string, 0, len(post.PhotoURLs))
+		for _, photoURL := range post.PhotoURLs {
+			resp, err := a.post(ctx, page.ID+"/photos", map[string]any{
+				"url":          photoURL,
+				"published":    false,
+				"access_token": page.AccessToken,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to upload facebook photo: %w", err)
+			}
+			attachedMedia = append(attachedMedia, map[string]string{"media_fbid": resp.Id})
+		}
+		resp, err := a.post(ctx, page.ID+"/feed", map[string]any{
+			"message":        post.Message,
+			"attached_media": attachedMedia,
+			"access_token":   page.AccessToken,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to publish facebook feed: %w", err)
+		}
+		return &domain.FacebookPublished{
+			ID:  resp.Id,
+			URL: "https://www.facebook.com/" + resp.Id,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: 投稿するメディアがありません", domain.ErrBadRequest)
+}
+
+func (a *facebookAdapter) post(ctx context.Context, path string, body map[string]any) (*external.FacebookPublishResponse, error) {
+	respBody, err := a.httpDriver.Post(ctx, a.baseURL+"/"+path, body, map[string]string{
+		"Content-Type": "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := toFacebookError(respBody); err != nil {
+		return nil, err
+	}
+	var resp external.FacebookPublishResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal facebook response: %w, body: %s", err, string(respBody))
+	}
+	if resp.Id == "" {
+		return nil, fmt.Errorf("facebook response has no id, body: %s", string(respBody))
+	}
+	return &resp, nil
+}
+
+// toFacebookError はGraph APIのエラーレスポンスをエラーに変換する（エラーでなければ nil）。
+func toFacebookError(body []byte) error {
+	var errResp external.FacebookErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error == nil {
+		return nil
+	}
+	return fmt.Errorf("code=%d, type=%s, message=%s", errResp.Error.Code, errResp.Error.Type, errResp.Error.Message)
+}
@@ -0,0 +1,71 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+)
+
+func TestFacebookAdapter_Publish(t *testing.T) {
+	var requests []string
+	var feedBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/page1/photos":
+			if body["published"] == false {
+				_, _ = w.Write([]byte(`{"id":"photo` + body["url"].(string) + `"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":"photo1","post_id":"page1_post1"}`))
+		case "/page1/feed":
+			feedBody = body
+			_, _ = w.Write([]byte(`{"id":"page1_post2"}`))
+		case "/page1/videos":
+			_, _ = w.Write([]byte(`{"id":"video1"}`))
+		}
+	}))
+	defer server.Close()
+
+	a := &facebookAdapter{httpDriver: driver.NewClient(http.DefaultClient), baseURL: server.URL}
+	page := &domain.FacebookPage{ID: "page1", AccessToken: "page-token"}
+
+	published, err := a.Publish(context.Background(), page, domain.FacebookPagePost{Message: "hello", PhotoURLs: []string{"a"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "page1_post1", published.ID)
+	assert.Equal(t, "https://www.facebook.com/page1_post1", published.URL)
+
+	requests = nil
+	published, err = a.Publish(context.Background(), page, domain.FacebookPagePost{Message: "hello", PhotoURLs: []string{"a", "b"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "page1_post2", published.ID)
+	assert.Equal(t, []string{"POST /page1/photos", "POST /page1/photos", "POST /page1/feed"}, requests)
+	assert.Equal(t, []any{map[string]any{"media_fbid": "photoa"}, map[string]any{"media_fbid": "photob"}}, feedBody["attached_media"])
+
+	published, err = a.Publish(context.Background(), page, domain.FacebookPagePost{Message: "hello", VideoURL: "v"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://www.facebook.com/page1/videos/video1", published.URL)
+}
+
+func TestFacebookAdapter_PublishError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"Invalid parameter","type":"OAuthException","code":100}}`))
+	}))
+	defer server.Close()
+
+	a := &facebookAdapter{httpDriver: driver.NewClient(http.DefaultClient), baseURL: server.URL}
+	_, err := a.Publish(context.Background(), &domain.FacebookPage{ID: "page1"}, domain.FacebookPagePost{PhotoURLs: []string{"a"}})
+	assert.ErrorContains(t, err, "Invalid parameter")
+
+	_, err = a.GetPage(context.Background(), "token", "page1")
+	assert.ErrorContains(t, err, "OAuthException")
+}
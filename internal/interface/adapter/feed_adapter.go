@@ -0,0 +1,41 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+)
+
+type FeedAdapter interface {
+	Fetch(ctx context.Context, feedURL string) (*domain.FeedChannel, error)
+}
+
+func NewFeedAdapter(httpDriver driver.HttpDriver) FeedAdapter {
+	return &feedAdapter{
+		httpDriver: httpDriver,
+	}
+}
+
+type feedAdapter struct {
+	httpDriver driver.HttpDriver
+}
+
+// Fetch はRSS/Atomフィードを取得して解析する。
+func (a *feedAdapter) Fetch(ctx context.Context, feedURL string) (*domain.FeedChannel, error) {
+	// User-Agentが無いとBot対策で弾くサーバーがあるため指定する
+	header := map[string]string{
+		"User-Agent": "homing-feed-reader",
+		"Accept":     "application/rss+xml, application/atom+xml, application/xml, text/xml",
+	}
+	resp, err := a.httpDriver.Get(ctx, feedURL, nil, header)
+	if err != nil {
+		return nil, fmt.Errorf("フィードの取得に失敗: %w", err)
+	}
+	channel, err := domain.ParseFeed(resp)
+	if err != nil {
+		return nil, fmt.Errorf("%w (feed_url=%s, response=%s)", err, feedURL, truncateResponse(resp))
+	}
+	return channel, nil
+}
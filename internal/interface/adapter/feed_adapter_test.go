@@ -0,0 +1,37 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+)
+
+func TestFeedAdapter_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("User-Agent"))
+		switch r.URL.Path {
+		case "/feed":
+			w.Header().Set("Content-Type", "application/rss+xml; charset=UTF-8")
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><rss version="2.0"><channel><title>News</title><item><title>A</title><guid>1</guid></item></channel></rss>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<html><body>Not Found</body></html>`))
+		}
+	}))
+	defer server.Close()
+
+	a := NewFeedAdapter(driver.NewClient(http.DefaultClient))
+
+	channel, err := a.Fetch(context.Background(), server.URL+"/feed")
+	assert.NoError(t, err)
+	assert.Equal(t, "News", channel.Title)
+	assert.Len(t, channel.Items, 1)
+
+	_, err = a.Fetch(context.Background(), server.URL+"/missing")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Not Found")
+}
@@ -9,6 +9,8 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+
+	"github.com/zuxt268/homing/internal/domain"
 )
 
 type FileDownloader interface {
@@ -46,6 +48,10 @@ func (f *fileDownloader) Download(ctx context.Context, urlStr string) (string, e
 		_ = resp.Body.Close()
 	}()
 
+	// InstagramのCDN URLは署名付きで、期限切れになると403/410が返る
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusGone {
+		return "", fmt.Errorf("failed to download file: status code %d: %w", resp.StatusCode, domain.ErrMediaURLExpired)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("failed to download file: status code %d", resp.StatusCode)
 	}
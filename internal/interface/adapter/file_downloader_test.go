@@ -3,9 +3,12 @@ package adapter
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
 )
 
 func TestFileDownloader_Download(t *testing.T) {
@@ -20,3 +23,17 @@ func TestFileDownloader_Download(t *testing.T) {
 	//err = downloader.DeleteTempDirectory()
 	assert.NoError(t, err)
 }
+
+func TestFileDownloader_Download_Expired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	downloader := NewFileDownloader()
+	defer func() {
+		_ = downloader.DeleteTempDirectory()
+	}()
+	_, err := downloader.Download(context.Background(), server.URL+"/image.jpg")
+	assert.ErrorIs(t, err, domain.ErrMediaURLExpired)
+}
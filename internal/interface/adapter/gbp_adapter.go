@@ -4,16 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
-	"os"
+	"net/url"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/zuxt268/homing/internal/domain"
 	"github.com/zuxt268/homing/internal/interface/dto/external"
-	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
+	"google.golang.org/api/businessprofileperformance/v1"
+	"google.golang.org/api/mybusinessaccountmanagement/v1"
 	"google.golang.org/api/mybusinessbusinessinformation/v1"
 	"google.golang.org/api/option"
 )
@@ -30,36 +33,69 @@ type Business struct {
 }
 
 type GbpAdapter interface {
-	GetAllBusinesses(ctx context.Context, accountName string) ([]Business, error)
-	UploadMedia(ctx context.Context, accountName, businessName, sourceURL, mediaFormat string) (*external.GoogleBusinessMediaUploadResponse, error)
-	GetBusiness(ctx context.Context, businessName string) (Business, error)
-	CreateLocalPost(ctx context.Context, accountName, businessName, summary, sourceURL, callToActionURL string) (*external.GoogleBusinessLocalPostResponse, error)
+	ListAccounts(ctx context.Context, tokenID int) ([]*domain.GoogleAccount, error)
+
+	GetAllBusinesses(ctx context.Context, account *domain.GoogleAccount) ([]Business, error)
+	UploadMedia(ctx context.Context, account *domain.GoogleAccount, businessName, sourceURL, mediaFormat string, category domain.GbpMediaCategory) (*external.GoogleBusinessMediaUploadResponse, error)
+	GetBusiness(ctx context.Context, account *domain.GoogleAccount, businessName string) (Business, error)
+	GetLocationInfo(ctx context.Context, account *domain.GoogleAccount, businessName string) (domain.GbpLocationInfo, error)
+	UpdateLocationInfo(ctx context.Context, account *domain.GoogleAccount, businessName string, info domain.GbpLocationInfo, fields []string, validateOnly bool) error
+	CreateLocalPost(ctx context.Context, account *domain.GoogleAccount, businessName string, post domain.GbpLocalPost) (*external.GoogleBusinessLocalPostResponse, error)
+	// DeleteMedia, DeleteLocalPost はGBP上ですでに削除されている場合もエラーにしない
+	DeleteMedia(ctx context.Context, account *domain.GoogleAccount, mediaName string) error
+	DeleteLocalPost(ctx context.Context, account *domain.GoogleAccount, localPostName string) error
+
+	ListReviews(ctx context.Context, account *domain.GoogleAccount, businessName string) ([]external.GoogleBusinessReview, error)
+	UpdateReviewReply(ctx context.Context, account *domain.GoogleAccount, reviewName, comment string) (*external.GoogleBusinessReviewReply, error)
+	DeleteReviewReply(ctx context.Context, account *domain.GoogleAccount, reviewName string) error
+
+	FetchDailyMetrics(ctx context.Context, account *domain.GoogleAccount, businessName string, from, to time.Time) ([]*domain.GoogleBusinessMetric, error)
 }
 
 type gbpAdapter struct {
-	client *http.Client
+	oauth GoogleOAuth
 }
 
-func NewGbpAdapter(credentialsData []byte) (GbpAdapter, error) {
-	ctx := context.Background()
+func NewGbpAdapter(oauth GoogleOAuth) GbpAdapter {
+	return &gbpAdapter{
+		oauth: oauth,
+	}
+}
 
-	// GBP 用スコープ
-	config, err := google.ConfigFromJSON(credentialsData,
-		"https://www.googleapis.com/auth/business.manage",
-	)
+func (a *gbpAdapter) ListAccounts(ctx context.Context, tokenID int) ([]*domain.GoogleAccount, error) {
+	client, err := a.oauth.Client(ctx, tokenID)
 	if err != nil {
-		return nil, fmt.Errorf("OAuth設定エラー: %v", err)
+		return nil, err
+	}
+	accountSvc, err := mybusinessaccountmanagement.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("アカウント管理API 初期化エラー: %v", err)
 	}
 
-	client := getOAuthClient(ctx, config)
-
-	return &gbpAdapter{
-		client: client,
-	}, nil
+	var accounts []*domain.GoogleAccount
+	err = accountSvc.Accounts.List().PageSize(20).Pages(ctx, func(resp *mybusinessaccountmanagement.ListAccountsResponse) error {
+		for _, acc := range resp.Accounts {
+			accounts = append(accounts, &domain.GoogleAccount{
+				Name:               acc.Name,
+				AccountName:        acc.AccountName,
+				Type:               acc.Type,
+				GoogleOAuthTokenID: tokenID,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("アカウント取得エラー: %v", err)
+	}
+	return accounts, nil
 }
 
-func (a *gbpAdapter) GetAllBusinesses(ctx context.Context, accountName string) ([]Business, error) {
-	businessSvc, err := mybusinessbusinessinformation.NewService(ctx, option.WithHTTPClient(a.client))
+func (a *gbpAdapter) GetAllBusinesses(ctx context.Context, account *domain.GoogleAccount) ([]Business, error) {
+	client, err := a.oauth.Client(ctx, account.GoogleOAuthTokenID)
+	if err != nil {
+		return nil, err
+	}
+	businessSvc, err := mybusinessbusinessinformation.NewService(ctx, option.WithHTTPClient(client))
 	if err != nil {
 		return nil, fmt.Errorf("ビジネス情報API 初期化エラー: %v", err)
 	}
@@ -68,7 +104,7 @@ func (a *gbpAdapter) GetAllBusinesses(ctx context.Context, accountName string) (
 	var businesses []Business
 
 	for {
-		call := businessSvc.Accounts.Locations.List(accountName).
+		call := businessSvc.Accounts.Locations.List(account.Name).
 			ReadMask("name,title,storefrontAddress,profile").
 			PageSize(100)
 
@@ -110,16 +146,19 @@ func (a *gbpAdapter) GetAllBusinesses(ctx context.Context, accountName string) (
 	return businesses, nil
 }
 
-func (a *gbpAdapter) UploadMedia(ctx context.Context, accountName, businessName, sourceURL, mediaFormat string) (*external.GoogleBusinessMediaUploadResponse, error) {
+func (a *gbpAdapter) UploadMedia(ctx context.Context, account *domain.GoogleAccount, businessName, sourceURL, mediaFormat string, category domain.GbpMediaCategory) (*external.GoogleBusinessMediaUploadResponse, error) {
 	locationID := extractLocationID(businessName)
-	parent := fmt.Sprintf("%s/locations/%s", accountName, locationID)
+	parent := fmt.Sprintf("%s/locations/%s", account.Name, locationID)
 
 	// sourceUrl 方式で media.create を呼ぶ
 	createURL := fmt.Sprintf("https://mybusiness.googleapis.com/v4/%s/media", parent)
+	if category == domain.GbpMediaCategoryNone {
+		category = domain.GbpMediaCategoryAdditional
+	}
 	reqBody := map[string]any{
 		"mediaFormat": mediaFormat,
 		"locationAssociation": map[string]string{
-			"category": "ADDITIONAL",
+			"category": string(category),
 		},
 		"sourceUrl": sourceURL,
 	}
@@ -134,7 +173,11 @@ func (a *gbpAdapter) UploadMedia(ctx context.Context, accountName, businessName,
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.client.Do(req)
+	client, err := a.oauth.Client(ctx, account.GoogleOAuthTokenID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("media.createエラー: %v", err)
 	}
@@ -146,7 +189,7 @@ func (a *gbpAdapter) UploadMedia(ctx context.Context, accountName, businessName,
 	}
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
-		return nil, fmt.Errorf("アップロード失敗 (ステータス: %d): %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("アップロード失敗 (%w)", &gbpStatusError{StatusCode: resp.StatusCode, Body: string(body)})
 	}
 
 	var uploadResponse external.GoogleBusinessMediaUploadResponse
@@ -157,27 +200,50 @@ func (a *gbpAdapter) UploadMedia(ctx context.Context, accountName, businessName,
 	return &uploadResponse, nil
 }
 
-func (a *gbpAdapter) CreateLocalPost(ctx context.Context, accountName, businessName, summary, sourceURL, callToActionURL string) (*external.GoogleBusinessLocalPostResponse, error) {
+func (a *gbpAdapter) CreateLocalPost(ctx context.Context, account *domain.GoogleAccount, businessName string, post domain.GbpLocalPost) (*external.GoogleBusinessLocalPostResponse, error) {
 	locationID := extractLocationID(businessName)
-	parent := fmt.Sprintf("%s/locations/%s", accountName, locationID)
+	parent := fmt.Sprintf("%s/locations/%s", account.Name, locationID)
 
 	createURL := fmt.Sprintf("https://mybusiness.googleapis.com/v4/%s/localPosts", parent)
+	topicType := post.TopicType
+	if topicType == "" {
+		topicType = domain.GbpTopicStandard
+	}
 	reqBody := map[string]any{
-		"summary":      summary,
-		"topicType":    "STANDARD",
+		"summary":      post.Summary,
+		"topicType":    string(topicType),
 		"languageCode": "ja",
 	}
-	if sourceURL != "" {
+	if post.MediaURL != "" {
 		reqBody["media"] = map[string]string{
 			"mediaFormat": "PHOTO",
-			"sourceUrl":   sourceURL,
+			"sourceUrl":   post.MediaURL,
 		}
 	}
-	if callToActionURL != "" {
-		reqBody["callToAction"] = map[string]string{
-			"actionType": "LEARN_MORE",
-			"url":        callToActionURL,
+	if post.CallToAction != nil {
+		callToAction := map[string]string{
+			"actionType": string(post.CallToAction.ActionType),
+		}
+		if post.CallToAction.URL != "" {
+			callToAction["url"] = post.CallToAction.URL
 		}
+		reqBody["callToAction"] = callToAction
+	}
+	if post.Event != nil {
+		reqBody["event"] = toLocalPostEvent(post.Event)
+	}
+	if post.Offer != nil {
+		offer := map[string]string{}
+		if post.Offer.CouponCode != "" {
+			offer["couponCode"] = post.Offer.CouponCode
+		}
+		if post.Offer.RedeemOnlineURL != "" {
+			offer["redeemOnlineUrl"] = post.Offer.RedeemOnlineURL
+		}
+		if post.Offer.TermsConditions != "" {
+			offer["termsConditions"] = post.Offer.TermsConditions
+		}
+		reqBody["offer"] = offer
 	}
 
 	jsonData, err := json.Marshal(reqBody)
@@ -191,7 +257,11 @@ func (a *gbpAdapter) CreateLocalPost(ctx context.Context, accountName, businessN
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := a.client.Do(req)
+	client, err := a.oauth.Client(ctx, account.GoogleOAuthTokenID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("localPosts.createエラー: %v", err)
 	}
@@ -203,7 +273,10 @@ func (a *gbpAdapter) CreateLocalPost(ctx context.Context, accountName, businessN
 	}
 
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
-		return nil, fmt.Errorf("Local Post作成失敗 (ステータス: %d): %s", resp.StatusCode, string(body))
+		if rejected := toGbpPostRejectedError(resp.StatusCode, body); rejected != nil {
+			return nil, rejected
+		}
+		return nil, fmt.Errorf("Local Post作成失敗 (%w)", &gbpStatusError{StatusCode: resp.StatusCode, Body: string(body)})
 	}
 
 	var postResponse external.GoogleBusinessLocalPostResponse
@@ -214,8 +287,28 @@ func (a *gbpAdapter) CreateLocalPost(ctx context.Context, accountName, businessN
 	return &postResponse, nil
 }
 
-func (a *gbpAdapter) GetBusiness(ctx context.Context, businessName string) (Business, error) {
-	businessSvc, err := mybusinessbusinessinformation.NewService(ctx, option.WithHTTPClient(a.client))
+func (a *gbpAdapter) DeleteMedia(ctx context.Context, account *domain.GoogleAccount, mediaName string) error {
+	deleteURL := fmt.Sprintf("https://mybusiness.googleapis.com/v4/%s", mediaName)
+	if err := a.doJSON(ctx, account, http.MethodDelete, deleteURL, nil, nil); err != nil && !isGbpNotFound(err) {
+		return fmt.Errorf("media.deleteエラー: %w", err)
+	}
+	return nil
+}
+
+func (a *gbpAdapter) DeleteLocalPost(ctx context.Context, account *domain.GoogleAccount, localPostName string) error {
+	deleteURL := fmt.Sprintf("https://mybusiness.googleapis.com/v4/%s", localPostName)
+	if err := a.doJSON(ctx, account, http.MethodDelete, deleteURL, nil, nil); err != nil && !isGbpNotFound(err) {
+		return fmt.Errorf("localPosts.deleteエラー: %w", err)
+	}
+	return nil
+}
+
+func (a *gbpAdapter) GetBusiness(ctx context.Context, account *domain.GoogleAccount, businessName string) (Business, error) {
+	client, err := a.oauth.Client(ctx, account.GoogleOAuthTokenID)
+	if err != nil {
+		return Business{}, err
+	}
+	businessSvc, err := mybusinessbusinessinformation.NewService(ctx, option.WithHTTPClient(client))
 	if err != nil {
 		return Business{}, err
 	}
@@ -247,71 +340,403 @@ func (a *gbpAdapter) GetBusiness(ctx context.Context, businessName string) (Busi
 	return business, nil
 }
 
-// ヘルパー関数: locationNameからlocation IDを抽出
-func extractLocationID(locationName string) string {
-	// "accounts/xxx/locations/12345" -> "12345"
-	parts := filepath.Base(locationName)
-	return parts
-}
+func (a *gbpAdapter) GetLocationInfo(ctx context.Context, account *domain.GoogleAccount, businessName string) (domain.GbpLocationInfo, error) {
+	client, err := a.oauth.Client(ctx, account.GoogleOAuthTokenID)
+	if err != nil {
+		return domain.GbpLocationInfo{}, err
+	}
+	businessSvc, err := mybusinessbusinessinformation.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return domain.GbpLocationInfo{}, fmt.Errorf("ビジネス情報API 初期化エラー: %v", err)
+	}
 
-// ヘルパー関数: OAuth2クライアント取得
-func getOAuthClient(ctx context.Context, config *oauth2.Config) *http.Client {
-	tokenFile := "./credentials/token.json"
+	location := "locations/" + extractLocationID(businessName)
+	loc, err := businessSvc.Locations.Get(location).
+		ReadMask("name,title,phoneNumbers,websiteUri,regularHours,specialHours,profile").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return domain.GbpLocationInfo{}, fmt.Errorf("ビジネス情報取得エラー: %v", err)
+	}
+	attrs, err := businessSvc.Locations.GetAttributes(location + "/attributes").Context(ctx).Do()
+	if err != nil {
+		return domain.GbpLocationInfo{}, fmt.Errorf("属性取得エラー: %v", err)
+	}
 
-	tok, err := tokenFromFile(tokenFile)
+	info := domain.GbpLocationInfo{
+		Name:       businessName,
+		Title:      loc.Title,
+		WebsiteURI: loc.WebsiteUri,
+	}
+	if loc.Profile != nil {
+		info.Description = loc.Profile.Description
+	}
+	if loc.PhoneNumbers != nil {
+		info.PrimaryPhone = loc.PhoneNumbers.PrimaryPhone
+		info.AdditionalPhones = loc.PhoneNumbers.AdditionalPhones
+	}
+	if loc.RegularHours != nil {
+		for _, p := range loc.RegularHours.Periods {
+			info.RegularHours = append(info.RegularHours, domain.GbpTimePeriod{
+				OpenDay:   p.OpenDay,
+				OpenTime:  fromTimeOfDay(p.OpenTime),
+				CloseDay:  p.CloseDay,
+				CloseTime: fromTimeOfDay(p.CloseTime),
+			})
+		}
+	}
+	if loc.SpecialHours != nil {
+		for _, p := range loc.SpecialHours.SpecialHourPeriods {
+			sh := domain.GbpSpecialHours{
+				StartDate: fromDate(p.StartDate),
+				EndDate:   fromDate(p.EndDate),
+				Closed:    p.Closed,
+			}
+			if !p.Closed {
+				sh.OpenTime = fromTimeOfDay(p.OpenTime)
+				sh.CloseTime = fromTimeOfDay(p.CloseTime)
+			}
+			info.SpecialHours = append(info.SpecialHours, sh)
+		}
+	}
+	for _, attr := range attrs.Attributes {
+		a := domain.GbpAttribute{
+			Name:   attr.Name,
+			Values: attr.Values,
+		}
+		for _, u := range attr.UriValues {
+			a.URIs = append(a.URIs, u.Uri)
+		}
+		info.Attributes = append(info.Attributes, a)
+	}
+	return info, nil
+}
+
+// UpdateLocationInfo は fields に指定した項目のみ更新する。validateOnly の場合はGBP側で検証のみ行う（属性は検証対象外）。
+func (a *gbpAdapter) UpdateLocationInfo(ctx context.Context, account *domain.GoogleAccount, businessName string, info domain.GbpLocationInfo, fields []string, validateOnly bool) error {
+	client, err := a.oauth.Client(ctx, account.GoogleOAuthTokenID)
+	if err != nil {
+		return err
+	}
+	businessSvc, err := mybusinessbusinessinformation.NewService(ctx, option.WithHTTPClient(client))
 	if err != nil {
-		// 初回認証
-		authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
-		log.Printf("以下のURLにアクセスしてGoogleログインしてください:\n%s\n", authURL)
+		return fmt.Errorf("ビジネス情報API 初期化エラー: %v", err)
+	}
 
-		var code string
-		fmt.Print("認証コードを貼り付けてください: ")
-		fmt.Scan(&code)
+	var locationMask, attributeMask []string
+	for _, field := range fields {
+		if strings.HasPrefix(field, domain.GbpLocationFieldAttribute) {
+			attributeMask = append(attributeMask, field)
+		} else {
+			locationMask = append(locationMask, field)
+		}
+	}
+	location := "locations/" + extractLocationID(businessName)
+
+	if len(locationMask) > 0 {
+		loc := &mybusinessbusinessinformation.Location{
+			Profile: &mybusinessbusinessinformation.Profile{
+				Description: info.Description,
+			},
+			PhoneNumbers: &mybusinessbusinessinformation.PhoneNumbers{
+				PrimaryPhone:     info.PrimaryPhone,
+				AdditionalPhones: info.AdditionalPhones,
+			},
+			WebsiteUri:   info.WebsiteURI,
+			RegularHours: &mybusinessbusinessinformation.BusinessHours{},
+			SpecialHours: &mybusinessbusinessinformation.SpecialHours{},
+		}
+		for _, p := range info.RegularHours {
+			loc.RegularHours.Periods = append(loc.RegularHours.Periods, &mybusinessbusinessinformation.TimePeriod{
+				OpenDay:   p.OpenDay,
+				OpenTime:  toTimeOfDay(p.OpenTime),
+				CloseDay:  p.CloseDay,
+				CloseTime: toTimeOfDay(p.CloseTime),
+			})
+		}
+		for _, p := range info.SpecialHours {
+			period := &mybusinessbusinessinformation.SpecialHourPeriod{
+				StartDate: toDateMessage(p.StartDate),
+				EndDate:   toDateMessage(p.EndDate),
+				Closed:    p.Closed,
+			}
+			if !p.Closed {
+				period.OpenTime = toTimeOfDay(p.OpenTime)
+				period.CloseTime = toTimeOfDay(p.CloseTime)
+			}
+			loc.SpecialHours.SpecialHourPeriods = append(loc.SpecialHours.SpecialHourPeriods, period)
+		}
+		_, err := businessSvc.Locations.Patch(location, loc).
+			UpdateMask(strings.Join(locationMask, ",")).
+			ValidateOnly(validateOnly).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return fmt.Errorf("ビジネス情報更新エラー: %v", err)
+		}
+	}
 
-		tok, err = config.Exchange(ctx, code)
+	if len(attributeMask) > 0 && !validateOnly {
+		attrs := &mybusinessbusinessinformation.Attributes{
+			Name: location + "/attributes",
+		}
+		for _, attr := range info.Attributes {
+			a := &mybusinessbusinessinformation.Attribute{
+				Name:   attr.Name,
+				Values: attr.Values,
+			}
+			for _, u := range attr.URIs {
+				a.UriValues = append(a.UriValues, &mybusinessbusinessinformation.UriAttributeValue{Uri: u})
+			}
+			attrs.Attributes = append(attrs.Attributes, a)
+		}
+		_, err := businessSvc.Locations.UpdateAttributes(location+"/attributes", attrs).
+			AttributeMask(strings.Join(attributeMask, ",")).
+			Context(ctx).
+			Do()
 		if err != nil {
-			log.Fatalf("認証コード交換エラー: %v", err)
+			return fmt.Errorf("属性更新エラー: %v", err)
+		}
+	}
+	return nil
+}
+
+func (a *gbpAdapter) ListReviews(ctx context.Context, account *domain.GoogleAccount, businessName string) ([]external.GoogleBusinessReview, error) {
+	locationID := extractLocationID(businessName)
+	parent := fmt.Sprintf("%s/locations/%s", account.Name, locationID)
+
+	var reviews []external.GoogleBusinessReview
+	pageToken := ""
+	for {
+		listURL := fmt.Sprintf("https://mybusiness.googleapis.com/v4/%s/reviews?pageSize=50&orderBy=updateTime%%20desc", parent)
+		if pageToken != "" {
+			listURL += "&pageToken=" + url.QueryEscape(pageToken)
+		}
+
+		var reviewsResp external.GoogleBusinessReviewsResponse
+		if err := a.doJSON(ctx, account, http.MethodGet, listURL, nil, &reviewsResp); err != nil {
+			return nil, fmt.Errorf("reviews.listエラー: %w", err)
+		}
+		reviews = append(reviews, reviewsResp.Reviews...)
+
+		if reviewsResp.NextPageToken == "" {
+			break
 		}
-		saveToken(tokenFile, tok)
-		log.Println("token.json 保存完了")
+		pageToken = reviewsResp.NextPageToken
 	}
+	return reviews, nil
+}
 
-	// トークンソースを作成（自動更新機能付き）
-	tokenSource := config.TokenSource(ctx, tok)
+func (a *gbpAdapter) UpdateReviewReply(ctx context.Context, account *domain.GoogleAccount, reviewName, comment string) (*external.GoogleBusinessReviewReply, error) {
+	replyURL := fmt.Sprintf("https://mybusiness.googleapis.com/v4/%s/reply", reviewName)
+	var reply external.GoogleBusinessReviewReply
+	if err := a.doJSON(ctx, account, http.MethodPut, replyURL, map[string]string{"comment": comment}, &reply); err != nil {
+		return nil, fmt.Errorf("reviews.updateReplyエラー: %w", err)
+	}
+	return &reply, nil
+}
 
-	// トークンを取得（必要に応じて自動更新される）
-	newToken, err := tokenSource.Token()
+func (a *gbpAdapter) DeleteReviewReply(ctx context.Context, account *domain.GoogleAccount, reviewName string) error {
+	replyURL := fmt.Sprintf("https://mybusiness.googleapis.com/v4/%s/reply", reviewName)
+	if err := a.doJSON(ctx, account, http.MethodDelete, replyURL, nil, nil); err != nil {
+		return fmt.Errorf("reviews.deleteReplyエラー: %w", err)
+	}
+	return nil
+}
+
+func (a *gbpAdapter) FetchDailyMetrics(ctx context.Context, account *domain.GoogleAccount, businessName string, from, to time.Time) ([]*domain.GoogleBusinessMetric, error) {
+	client, err := a.oauth.Client(ctx, account.GoogleOAuthTokenID)
 	if err != nil {
-		log.Fatalf("トークン取得エラー: %v", err)
+		return nil, err
+	}
+	performanceSvc, err := businessprofileperformance.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, fmt.Errorf("パフォーマンスAPI 初期化エラー: %v", err)
 	}
 
-	// トークンが更新された場合は保存
-	if newToken.AccessToken != tok.AccessToken {
-		saveToken(tokenFile, newToken)
-		log.Println("トークン自動更新・保存完了")
+	location := "locations/" + extractLocationID(businessName)
+	resp, err := performanceSvc.Locations.FetchMultiDailyMetricsTimeSeries(location).
+		DailyMetrics(domain.GbpDailyMetrics...).
+		DailyRangeStartDateYear(int64(from.Year())).
+		DailyRangeStartDateMonth(int64(from.Month())).
+		DailyRangeStartDateDay(int64(from.Day())).
+		DailyRangeEndDateYear(int64(to.Year())).
+		DailyRangeEndDateMonth(int64(to.Month())).
+		DailyRangeEndDateDay(int64(to.Day())).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("指標取得エラー: %v", err)
 	}
 
-	return oauth2.NewClient(ctx, tokenSource)
+	var metrics []*domain.GoogleBusinessMetric
+	for _, multi := range resp.MultiDailyMetricTimeSeries {
+		for _, series := range multi.DailyMetricTimeSeries {
+			if series.TimeSeries == nil {
+				continue
+			}
+			for _, dv := range series.TimeSeries.DatedValues {
+				if dv.Date == nil {
+					continue
+				}
+				metrics = append(metrics, &domain.GoogleBusinessMetric{
+					BusinessName: businessName,
+					Metric:       series.DailyMetric,
+					Date:         time.Date(int(dv.Date.Year), time.Month(dv.Date.Month), int(dv.Date.Day), 0, 0, 0, 0, time.Local),
+					Value:        dv.Value,
+				})
+			}
+		}
+	}
+	return metrics, nil
 }
 
-// token.json を読む
-func tokenFromFile(path string) (*oauth2.Token, error) {
-	f, err := os.Open(path)
+// doJSON はGBP APIにJSONリクエストを送り、レスポンスを out にデコードする。out が nil の場合はデコードしない。
+func (a *gbpAdapter) doJSON(ctx context.Context, account *domain.GoogleAccount, method, endpoint string, reqBody any, out any) error {
+	var bodyReader io.Reader
+	if reqBody != nil {
+		jsonData, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("JSON作成エラー: %v", err)
+		}
+		bodyReader = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bodyReader)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("リクエスト作成エラー: %v", err)
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client, err := a.oauth.Client(ctx, account.GoogleOAuthTokenID)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("レスポンス読み込みエラー: %v", err)
 	}
-	defer f.Close()
-	tok := &oauth2.Token{}
-	err = json.NewDecoder(f).Decode(tok)
-	return tok, err
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &gbpStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
+
+	if out == nil || len(body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("レスポンスパースエラー: %v", err)
+	}
+	return nil
+}
+
+// gbpStatusError はGBP APIが2xx以外のステータスを返したときのエラー
+type gbpStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *gbpStatusError) Error() string {
+	return fmt.Sprintf("ステータス: %d: %s", e.StatusCode, e.Body)
+}
+
+// Is はGBPが投稿を受け付けなかった（4xx）場合に domain.ErrSyncRejected として扱う。
+func (e *gbpStatusError) Is(target error) bool {
+	return target == domain.ErrSyncRejected && domain.IsSyncRejectedStatus(e.StatusCode)
 }
 
-// トークンを保存
-func saveToken(path string, token *oauth2.Token) {
-	f, err := os.Create(path)
+// toGbpPostRejectedError は400のエラーレスポンスに項目ごとの詳細があれば拒否エラーに変換する。
+// 詳細が無い場合（認証エラーなど）は nil を返す。
+func toGbpPostRejectedError(statusCode int, body []byte) *domain.GbpPostRejectedError {
+	if statusCode != http.StatusBadRequest {
+		return nil
+	}
+	var errResp external.GoogleBusinessErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		return nil
+	}
+	for _, detail := range errResp.Error.Details {
+		for _, d := range detail.ErrorDetails {
+			return &domain.GbpPostRejectedError{
+				Reason:  domain.ClassifyGbpRejection(d.Field + " " + d.Message),
+				Field:   d.Field,
+				Message: d.Message,
+			}
+		}
+	}
+	return nil
+}
+
+func isGbpNotFound(err error) bool {
+	var statusErr *gbpStatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound
+}
+
+// ヘルパー関数: "HH:MM" とAPIの時刻の相互変換
+func toTimeOfDay(s string) *mybusinessbusinessinformation.TimeOfDay {
+	var h, m int64
+	if _, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil {
+		return nil
+	}
+	return &mybusinessbusinessinformation.TimeOfDay{Hours: h, Minutes: m}
+}
+
+func fromTimeOfDay(t *mybusinessbusinessinformation.TimeOfDay) string {
+	if t == nil {
+		return "00:00"
+	}
+	return fmt.Sprintf("%02d:%02d", t.Hours, t.Minutes)
+}
+
+// ヘルパー関数: "2006-01-02" とAPIの日付の相互変換
+func toDateMessage(s string) *mybusinessbusinessinformation.Date {
+	d, err := time.Parse("2006-01-02", s)
 	if err != nil {
-		log.Fatalf("token 保存エラー: %v", err)
+		return nil
+	}
+	return &mybusinessbusinessinformation.Date{Year: int64(d.Year()), Month: int64(d.Month()), Day: int64(d.Day())}
+}
+
+func fromDate(d *mybusinessbusinessinformation.Date) string {
+	if d == nil {
+		return ""
+	}
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// ヘルパー関数: Local PostのeventをAPIの形式に変換
+func toLocalPostEvent(event *domain.GbpEvent) map[string]any {
+	toDate := func(t time.Time) map[string]int {
+		return map[string]int{"year": t.Year(), "month": int(t.Month()), "day": t.Day()}
+	}
+	toTime := func(t time.Time) map[string]int {
+		return map[string]int{"hours": t.Hour(), "minutes": t.Minute()}
 	}
-	defer f.Close()
-	json.NewEncoder(f).Encode(token)
+	schedule := map[string]any{
+		"startDate": toDate(event.Start),
+		"endDate":   toDate(event.End),
+	}
+	if !event.AllDay {
+		schedule["startTime"] = toTime(event.Start)
+		schedule["endTime"] = toTime(event.End)
+	}
+	return map[string]any{
+		"title":    event.Title,
+		"schedule": schedule,
+	}
+}
+
+// ヘルパー関数: locationNameからlocation IDを抽出
+func extractLocationID(locationName string) string {
+	// "accounts/xxx/locations/12345" -> "12345"
+	parts := filepath.Base(locationName)
+	return parts
 }
@@ -0,0 +1,149 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// GoogleOAuth はGBP APIを呼び出すためのOAuthクライアントをトークンごとに管理する。
+// トークンは SetToken で差し替えられるため、再認証後もサーバーの再起動は不要。
+type GoogleOAuth interface {
+	AuthCodeURL(state, verifier string) string
+	Exchange(ctx context.Context, code, verifier string) (*domain.GoogleOAuthToken, error)
+	// SetToken は token.ID をキーにトークンを登録（差し替え）する
+	SetToken(token *domain.GoogleOAuthToken)
+	// OnTokenRefresh はアクセストークンが自動更新されたときに呼ばれる関数を登録する
+	OnTokenRefresh(fn func(token *domain.GoogleOAuthToken))
+	Client(ctx context.Context, tokenID int) (*http.Client, error)
+}
+
+type googleOAuth struct {
+	config *oauth2.Config
+
+	mu        sync.RWMutex
+	sources   map[int]oauth2.TokenSource
+	onRefresh func(token *domain.GoogleOAuthToken)
+}
+
+func NewGoogleOAuth(credentialsData []byte, redirectURL string) (GoogleOAuth, error) {
+	// GBP 用スコープ
+	config, err := google.ConfigFromJSON(credentialsData,
+		"https://www.googleapis.com/auth/business.manage",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("OAuth設定エラー: %v", err)
+	}
+	if redirectURL != "" {
+		config.RedirectURL = redirectURL
+	}
+	return &googleOAuth{
+		config:  config,
+		sources: map[int]oauth2.TokenSource{},
+	}, nil
+}
+
+func (a *googleOAuth) AuthCodeURL(state, verifier string) string {
+	// refresh_token を確実に受け取るため offline + 同意画面を毎回表示する
+	return a.config.AuthCodeURL(state,
+		oauth2.AccessTypeOffline,
+		oauth2.ApprovalForce,
+		oauth2.S256ChallengeOption(verifier),
+	)
+}
+
+func (a *googleOAuth) Exchange(ctx context.Context, code, verifier string) (*domain.GoogleOAuthToken, error) {
+	tok, err := a.config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("認証コード交換エラー: %w", err)
+	}
+	return toGoogleOAuthToken(tok), nil
+}
+
+func (a *googleOAuth) SetToken(token *domain.GoogleOAuthToken) {
+	tokenID := token.ID
+	src := &refreshNotifyingTokenSource{
+		base:        a.config.TokenSource(context.Background(), toOAuth2Token(token)),
+		accessToken: token.AccessToken,
+		notify: func(tok *oauth2.Token) {
+			a.notifyRefresh(tokenID, tok)
+		},
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.sources[tokenID] = oauth2.ReuseTokenSource(toOAuth2Token(token), src)
+}
+
+func (a *googleOAuth) OnTokenRefresh(fn func(token *domain.GoogleOAuthToken)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onRefresh = fn
+}
+
+func (a *googleOAuth) Client(ctx context.Context, tokenID int) (*http.Client, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	source, ok := a.sources[tokenID]
+	if !ok {
+		return nil, domain.ErrGoogleNotAuthorized
+	}
+	return oauth2.NewClient(ctx, source), nil
+}
+
+func (a *googleOAuth) notifyRefresh(tokenID int, tok *oauth2.Token) {
+	a.mu.RLock()
+	fn := a.onRefresh
+	a.mu.RUnlock()
+	if fn != nil {
+		token := toGoogleOAuthToken(tok)
+		token.ID = tokenID
+		fn(token)
+	}
+}
+
+// refreshNotifyingTokenSource はアクセストークンが更新されたことを通知する
+type refreshNotifyingTokenSource struct {
+	base oauth2.TokenSource
+
+	mu          sync.Mutex
+	accessToken string
+	notify      func(tok *oauth2.Token)
+}
+
+func (s *refreshNotifyingTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	changed := tok.AccessToken != s.accessToken
+	s.accessToken = tok.AccessToken
+	s.mu.Unlock()
+	if changed {
+		s.notify(tok)
+	}
+	return tok, nil
+}
+
+func toGoogleOAuthToken(tok *oauth2.Token) *domain.GoogleOAuthToken {
+	return &domain.GoogleOAuthToken{
+		AccessToken:  tok.AccessToken,
+		RefreshToken: tok.RefreshToken,
+		TokenType:    tok.TokenType,
+		Expiry:       tok.Expiry,
+	}
+}
+
+func toOAuth2Token(token *domain.GoogleOAuthToken) *oauth2.Token {
+	return &oauth2.Token{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+		Expiry:       token.Expiry,
+	}
+}
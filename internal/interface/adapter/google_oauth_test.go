@@ -0,0 +1,59 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
+	"golang.org/x/oauth2"
+)
+
+func TestGoogleOAuth_Client(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"new-access","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer apiServer.Close()
+
+	o := &googleOAuth{
+		config: &oauth2.Config{
+			Endpoint: oauth2.Endpoint{TokenURL: tokenServer.URL},
+		},
+		sources: map[int]oauth2.TokenSource{},
+	}
+
+	_, err := o.Client(context.Background(), 1)
+	assert.ErrorIs(t, err, domain.ErrGoogleNotAuthorized)
+
+	var refreshed *domain.GoogleOAuthToken
+	o.OnTokenRefresh(func(token *domain.GoogleOAuthToken) {
+		refreshed = token
+	})
+	o.SetToken(&domain.GoogleOAuthToken{
+		ID:           1,
+		AccessToken:  "old-access",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Hour),
+	})
+
+	client, err := o.Client(context.Background(), 1)
+	assert.NoError(t, err)
+	resp, err := client.Get(apiServer.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	// 期限切れのトークンは更新され、refresh_token を引き継いで通知される
+	if assert.NotNil(t, refreshed) {
+		assert.Equal(t, 1, refreshed.ID)
+		assert.Equal(t, "new-access", refreshed.AccessToken)
+		assert.Equal(t, "refresh", refreshed.RefreshToken)
+	}
+}
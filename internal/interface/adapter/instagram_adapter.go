@@ -14,6 +14,7 @@ import (
 type InstagramAdapter interface {
 	GetPosts25(ctx context.Context, token, instagramID string) ([]domain.InstagramPost, error)
 	GetPostsAll(ctx context.Context, token, instagramID string) ([]domain.InstagramPost, error)
+	GetMedia(ctx context.Context, token, mediaID string) (*domain.InstagramPost, error)
 	GetAccount(ctx context.Context, token, instagramID string) (*domain.InstagramAccount, error)
 	DebugToken(ctx context.Context, userToken string) (*external.DebugTokenResponse, error)
 }
@@ -39,7 +40,7 @@ type instagramAdapter struct {
 func (a *instagramAdapter) GetPosts25(ctx context.Context, token string, instagramID string) ([]domain.InstagramPost, error) {
 	req := &external.InstagramRequest{
 		AccessToken: token,
-		Fields:      "media{id,permalink,caption,timestamp,media_type,media_url,children{media_type,media_url}}",
+		Fields:      "media{id,permalink,caption,timestamp,media_type,media_url,children{id,media_type,media_url}}",
 	}
 	endpoint := baseURL + "/" + instagramID
 	resp, err := a.httpDriver.Get(ctx, endpoint, req, nil)
@@ -58,7 +59,7 @@ func (a *instagramAdapter) GetPostsAll(ctx context.Context, token, instagramID s
 
 	req := &external.InstagramRequest{
 		AccessToken: token,
-		Fields:      "media{id,permalink,caption,timestamp,media_type,media_url,children{media_type,media_url}}",
+		Fields:      "media{id,permalink,caption,timestamp,media_type,media_url,children{id,media_type,media_url}}",
 	}
 	endpoint := baseURL + "/" + instagramID
 	resp, err := a.httpDriver.Get(ctx, endpoint, req, nil)
@@ -94,6 +95,27 @@ func (a *instagramAdapter) GetPostsAll(ctx context.Context, token, instagramID s
 	return result, nil
 }
 
+// GetMedia はメディアを1件取得する。期限切れになった media_url（子要素含む）の再取得に使う。
+func (a *instagramAdapter) GetMedia(ctx context.Context, token, mediaID string) (*domain.InstagramPost, error) {
+	req := &external.InstagramRequest{
+		AccessToken: token,
+		Fields:      "id,permalink,caption,timestamp,media_type,media_url,children{id,media_type,media_url}",
+	}
+	endpoint := baseURL + "/" + mediaID
+	resp, err := a.httpDriver.Get(ctx, endpoint, req, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get media: %w", err)
+	}
+	var mediaDto external.InstagramGetMediaResponse
+	if err := json.Unmarshal(resp, &mediaDto); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal instagram media response: %w, body: %s", err, string(resp))
+	}
+	if mediaDto.Id == "" {
+		return nil, fmt.Errorf("failed to get media: id=%s, body: %s", mediaID, string(resp))
+	}
+	return external.ToInstagramPostEntity(&mediaDto), nil
+}
+
 func (a *instagramAdapter) DebugToken(ctx context.Context, token string) (*external.DebugTokenResponse, error) {
 	appToken := fmt.Sprintf("%s|%s", a.clientID, a.clientSecret)
 	endpoint := "https://graph.facebook.com/debug_token"
@@ -0,0 +1,63 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+// lineTextMaxRunes はLINEのテキストメッセージの上限
+const lineTextMaxRunes = 5000
+
+type lineNotifier struct {
+	httpDriver         driver.HttpDriver
+	baseURL            string
+	channelAccessToken string
+}
+
+// NewLineNotifier はLINE Messaging APIのプッシュメッセージで通知する。
+// destination はユーザー・グループ・トークルームのID、mention は本文の先頭に付ける文字列。
+func NewLineNotifier(httpDriver driver.HttpDriver, baseURL, channelAccessToken string) Notifier {
+	return &lineNotifier{
+		httpDriver:         httpDriver,
+		baseURL:            baseURL,
+		channelAccessToken: channelAccessToken,
+	}
+}
+
+func (l *lineNotifier) Notify(ctx context.Context, destination, mention string, n domain.Notification) error {
+	if l.channelAccessToken == "" {
+		return fmt.Errorf("LINEのチャネルアクセストークンが設定されていません")
+	}
+	text := []rune(n.Message(mention))
+	if len(text) > lineTextMaxRunes {
+		text = text[:lineTextMaxRunes]
+	}
+
+	body, err := l.httpDriver.Post(ctx, l.baseURL+"/v2/bot/message/push", external.LinePushRequest{
+		To: destination,
+		Messages: []external.LineMessage{
+			{Type: "text", Text: string(text)},
+		},
+	}, map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": "Bearer " + l.channelAccessToken,
+	})
+	if err != nil {
+		return err
+	}
+
+	// 成功した場合は {} が返る
+	var resp external.LineResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("LINEのレスポンスが不正です: %s", string(body))
+	}
+	if resp.Message != "" {
+		return fmt.Errorf("LINEへの通知に失敗: %s", resp.Message)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+func TestLineNotifier_Notify(t *testing.T) {
+	var received external.LinePushRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/bot/message/push", r.URL.Path)
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Authentication failed. Confirm that the access token in the authorization header is valid."}`))
+			return
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	n := domain.NewTokenExpiringNotification()
+
+	notifier := NewLineNotifier(driver.NewClient(http.DefaultClient), server.URL, "token")
+	assert.NoError(t, notifier.Notify(context.Background(), "U1234", "担当者さん", n))
+	assert.Equal(t, "U1234", received.To)
+	assert.Len(t, received.Messages, 1)
+	assert.Equal(t, "text", received.Messages[0].Type)
+	assert.Equal(t, "担当者さん\n‼️トークンの有効期限が近づいています", received.Messages[0].Text)
+
+	notifier = NewLineNotifier(driver.NewClient(http.DefaultClient), server.URL, "wrong")
+	err := notifier.Notify(context.Background(), "U1234", "", n)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Authentication failed")
+
+	notifier = NewLineNotifier(driver.NewClient(http.DefaultClient), server.URL, "")
+	assert.Error(t, notifier.Notify(context.Background(), "U1234", "", n))
+}
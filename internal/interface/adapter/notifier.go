@@ -0,0 +1,12 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+)
+
+// Notifier は通知を送る。destination と mention の意味は送信手段ごとに異なる（domain.NotificationRule を参照）。
+type Notifier interface {
+	Notify(ctx context.Context, destination, mention string, n domain.Notification) error
+}
@@ -14,6 +14,7 @@ import (
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/google/uuid"
+	"github.com/zuxt268/homing/internal/domain"
 )
 
 type S3Adapter interface {
@@ -58,6 +59,10 @@ func (a *s3Adapter) UploadFromURL(ctx context.Context, sourceURL string) (string
 	}
 	defer resp.Body.Close()
 
+	// InstagramのCDN URLは署名付きで、期限切れになると403/410が返る
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusGone {
+		return "", fmt.Errorf("ダウンロード失敗 (ステータス: %d): %w", resp.StatusCode, domain.ErrMediaURLExpired)
+	}
 	if resp.StatusCode != http.StatusOK {
 		return "", fmt.Errorf("ダウンロード失敗 (ステータス: %d)", resp.StatusCode)
 	}
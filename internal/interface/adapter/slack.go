@@ -1,153 +0,0 @@
-package adapter
-
-import (
-	"context"
-	"fmt"
-	"strings"
-
-	"github.com/zuxt268/homing/internal/config"
-	"github.com/zuxt268/homing/internal/domain"
-	"github.com/zuxt268/homing/internal/infrastructure/driver"
-	"github.com/zuxt268/homing/internal/interface/dto/external"
-)
-
-type Slack interface {
-	Error(ctx context.Context, msg string, err error, customerID int, customerName string) error
-	SendMessage(ctx context.Context, payload external.SlackRequest) error
-	SendTokenExpired(ctx context.Context) error
-	SendHealthy(ctx context.Context) error
-
-	SuccessWI(ctx context.Context, wi *domain.WordpressInstagram, wordpressUrl, instagramUrl string) error
-	SuccessBI(ctx context.Context, bi *domain.BusinessInstagram, instagramUrl, postType string) error
-	SuccessWG(ctx context.Context, wg *domain.WordpressGbp, postType string, mediaUrl string, wordpressUrl string) error
-}
-
-type slack struct {
-	httpDriver             driver.HttpDriver
-	noticeWebAppChannelUrl string
-	prjARootChannelUrl     string
-	noticeRpaChannelUrl    string
-}
-
-func NewSlack(httpDriver driver.HttpDriver) Slack {
-	return &slack{
-		httpDriver:             httpDriver,
-		noticeWebAppChannelUrl: config.Env.NoticeWebAppChannelUrl,
-		prjARootChannelUrl:     config.Env.PrjARootChannelUrl,
-		noticeRpaChannelUrl:    config.Env.NoticeRpaChannelUrl,
-	}
-}
-
-const template = `[%s]
-error: %s
-顧客: id=%d, name=%s`
-
-func (s *slack) Error(ctx context.Context, msg string, err error, customerID int, customerName string) error {
-	sb := strings.Builder{}
-	sb.WriteString("｀｀｀")
-	sb.WriteString("<@U04P797HYPM>\n")
-	sb.WriteString(fmt.Sprintf(template, strings.TrimSpace(msg), err.Error(), customerID, customerName))
-	sb.WriteString("｀｀｀")
-	return s.noticeWebAppChannel(ctx, external.SlackRequest{
-		Text:      sb.String(),
-		Username:  "homing",
-		IconEmoji: ":cat:",
-	})
-}
-
-func (s *slack) SendMessage(ctx context.Context, payload external.SlackRequest) error {
-	return s.noticeWebAppChannel(ctx, payload)
-}
-
-func (s *slack) SendHealthy(ctx context.Context) error {
-	return s.noticeWebAppChannel(ctx, external.SlackRequest{
-		Text:      "```healthy```",
-		Username:  "[A-Root Systemトークン]",
-		IconEmoji: ":panda_face:",
-	})
-}
-
-func (s *slack) SendTokenExpired(ctx context.Context) error {
-	return s.noticeWebAppChannel(ctx, external.SlackRequest{
-		Text:      "‼️トークンの有効期限が近づいています",
-		Username:  "[A-Root Systemトークン]",
-		IconEmoji: ":panda_face:",
-	})
-}
-
-func (s *slack) noticeWebAppChannel(ctx context.Context, payload external.SlackRequest) error {
-	_, err := s.httpDriver.Post(ctx, config.Env.NoticeWebAppChannelUrl, payload, map[string]string{
-		"Content-Type": "application/json",
-	})
-	return err
-}
-
-func (s *slack) prjARootChannel(ctx context.Context, payload external.SlackRequest) error {
-	_, err := s.httpDriver.Post(ctx, config.Env.PrjARootChannelUrl, payload, map[string]string{
-		"Content-Type": "application/json",
-	})
-	return err
-}
-
-func (s *slack) noticeRpaChannel(ctx context.Context, payload external.SlackRequest) error {
-	_, err := s.httpDriver.Post(ctx, config.Env.NoticeRpaChannelUrl, payload, map[string]string{
-		"Content-Type": "application/json",
-	})
-	return err
-}
-
-const templateWiSuccess = `[Instagram => WordPress]
-id: %d
-name: %s
-WordPress: %s
-Instagram: %s
-`
-
-func (s *slack) SuccessWI(ctx context.Context, wi *domain.WordpressInstagram, wordpressUrl, instagramUrl string) error {
-	sb := strings.Builder{}
-	sb.WriteString("｀｀｀")
-	sb.WriteString(fmt.Sprintf(templateWiSuccess, wi.ID, wi.Name, wordpressUrl, instagramUrl))
-	sb.WriteString("｀｀｀")
-	return s.noticeWebAppChannel(ctx, external.SlackRequest{
-		Text:      sb.String(),
-		Username:  "homing",
-		IconEmoji: ":cat:",
-	})
-}
-
-const templateBiSuccess = `[Instagram => GBP: %s]
-id: %d
-name: %s
-Instagram: %s
-`
-
-func (s *slack) SuccessBI(ctx context.Context, bi *domain.BusinessInstagram, instagramUrl, postType string) error {
-	sb := strings.Builder{}
-	sb.WriteString("｀｀｀")
-	sb.WriteString(fmt.Sprintf(templateBiSuccess, postType, bi.ID, bi.Name, instagramUrl))
-	sb.WriteString("｀｀｀")
-	return s.noticeWebAppChannel(ctx, external.SlackRequest{
-		Text:      sb.String(),
-		Username:  "homing",
-		IconEmoji: ":cat:",
-	})
-}
-
-const templateWgSuccess = `[WordPress => GBP: %s]
-id: %d
-name: %s
-wordpress: %s
-gbp: %s
-`
-
-func (s *slack) SuccessWG(ctx context.Context, wg *domain.WordpressGbp, postType string, mediaUrl string, wordpressUrl string) error {
-	sb := strings.Builder{}
-	sb.WriteString("｀｀｀")
-	sb.WriteString(fmt.Sprintf(templateWgSuccess, postType, wg.ID, wg.Name, wordpressUrl, mediaUrl))
-	sb.WriteString("｀｀｀")
-	return s.noticeWebAppChannel(ctx, external.SlackRequest{
-		Text:      sb.String(),
-		Username:  "homing",
-		IconEmoji: ":cat:",
-	})
-}
@@ -0,0 +1,52 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+type SlackAdapter interface {
+	// ReplaceMessage はボタンが押されたメッセージを、ボタンを外して操作の結果を付けたものに置き換える。
+	ReplaceMessage(ctx context.Context, responseURL, text, outcome string) error
+}
+
+type slackAdapter struct {
+	httpDriver driver.HttpDriver
+}
+
+func NewSlackAdapter(httpDriver driver.HttpDriver) SlackAdapter {
+	return &slackAdapter{
+		httpDriver: httpDriver,
+	}
+}
+
+func (s *slackAdapter) ReplaceMessage(ctx context.Context, responseURL, text, outcome string) error {
+	payload := external.SlackRequest{
+		Text:            text,
+		ReplaceOriginal: true,
+		Blocks: append(slackTextBlocks(text), external.SlackBlock{
+			Type: "section",
+			Text: &external.SlackText{Type: "mrkdwn", Text: outcome},
+		}),
+	}
+	body, err := s.httpDriver.Post(ctx, responseURL, payload, map[string]string{
+		"Content-Type": "application/json",
+	})
+	if err != nil {
+		return err
+	}
+	// response_url は成功すると {"ok":true} を返す（古い形式では "ok"）
+	if strings.TrimSpace(string(body)) == "ok" {
+		return nil
+	}
+	var resp external.SlackResponse
+	if err := json.Unmarshal(body, &resp); err != nil || !resp.OK {
+		return fmt.Errorf("slackのメッセージの更新に失敗: %s", string(body))
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+func TestSlackAdapter_ReplaceMessage(t *testing.T) {
+	var received external.SlackRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		default:
+			_, _ = w.Write([]byte(`{"ok":false,"error":"expired_url"}`))
+		}
+	}))
+	defer server.Close()
+
+	slack := NewSlackAdapter(driver.NewClient(http.DefaultClient))
+	assert.NoError(t, slack.ReplaceMessage(context.Background(), server.URL+"/ok", "同期に失敗", "✅ <@U1> がアカウントを再同期しました"))
+	assert.True(t, received.ReplaceOriginal)
+	assert.Equal(t, "同期に失敗", received.Text)
+	assert.Len(t, received.Blocks, 2)
+	assert.Equal(t, "✅ <@U1> がアカウントを再同期しました", received.Blocks[1].Text.Text)
+
+	err := slack.ReplaceMessage(context.Background(), server.URL+"/expired", "同期に失敗", "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "expired_url")
+}
@@ -0,0 +1,90 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+type slackNotifier struct {
+	httpDriver driver.HttpDriver
+}
+
+// NewSlackNotifier はSlackのIncoming Webhookに通知する。destination はWebhook URL、mention は "<@U...>" の形式。
+func NewSlackNotifier(httpDriver driver.HttpDriver) Notifier {
+	return &slackNotifier{
+		httpDriver: httpDriver,
+	}
+}
+
+func (s *slackNotifier) Notify(ctx context.Context, destination, mention string, n domain.Notification) error {
+	sb := strings.Builder{}
+	sb.WriteString("｀｀｀")
+	sb.WriteString(n.Message(mention))
+	sb.WriteString("｀｀｀")
+	payload := external.SlackRequest{
+		Text:      sb.String(),
+		Username:  "homing",
+		IconEmoji: ":cat:",
+	}
+	if len(n.Actions) > 0 {
+		payload.Blocks = append(slackTextBlocks(payload.Text), slackActionsBlock(n.Actions))
+	}
+	if n.EventType == domain.NotificationEventTokenExpiring || n.EventType == domain.NotificationEventTokenHealthy {
+		payload.Username = "[A-Root Systemトークン]"
+		payload.IconEmoji = ":panda_face:"
+	}
+
+	body, err := s.httpDriver.Post(ctx, destination, payload, map[string]string{
+		"Content-Type": "application/json",
+	})
+	if err != nil {
+		return err
+	}
+	// Incoming Webhookは成功すると "ok" を返し、失敗するとエラーの理由を返す
+	if strings.TrimSpace(string(body)) != "ok" {
+		return fmt.Errorf("slackへの通知に失敗: %s", string(body))
+	}
+	return nil
+}
+
+// slackSectionMaxRunes はsectionブロックのテキストの上限
+const slackSectionMaxRunes = 3000
+
+// slackTextBlocks は通知の本文をsectionブロックにする。
+func slackTextBlocks(text string) []external.SlackBlock {
+	runes := []rune(text)
+	if len(runes) > slackSectionMaxRunes {
+		runes = runes[:slackSectionMaxRunes]
+	}
+	return []external.SlackBlock{{
+		Type: "section",
+		Text: &external.SlackText{Type: "mrkdwn", Text: string(runes)},
+	}}
+}
+
+// slackActionsBlock は通知のボタンをactionsブロックにする。
+func slackActionsBlock(actions []domain.NotificationAction) external.SlackBlock {
+	block := external.SlackBlock{Type: "actions"}
+	for _, action := range actions {
+		element := external.SlackElement{
+			Type:     "button",
+			Text:     &external.SlackText{Type: "plain_text", Text: action.Label},
+			ActionID: string(action.Type),
+			Value:    action.Value(),
+			URL:      action.URL,
+		}
+		switch action.Type {
+		case domain.NotificationActionRetryPost:
+			element.Style = "primary"
+		case domain.NotificationActionPauseAccount:
+			element.Style = "danger"
+		}
+		block.Elements = append(block.Elements, element)
+	}
+	return block
+}
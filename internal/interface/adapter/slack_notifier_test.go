@@ -0,0 +1,69 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+func TestSlackNotifier_Notify(t *testing.T) {
+	var received external.SlackRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			_, _ = w.Write([]byte("ok"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte("no_service"))
+		}
+	}))
+	defer server.Close()
+
+	n := domain.NewSyncFailedNotification("instagram => wordpress", assert.AnError, domain.Account{Type: domain.AccountTypeWordpressInstagram, ID: 1, Name: "カフェ"})
+	notifier := NewSlackNotifier(driver.NewClient(http.DefaultClient))
+
+	assert.NoError(t, notifier.Notify(context.Background(), server.URL+"/ok", "<@U0123>", n))
+	assert.Equal(t, "homing", received.Username)
+	assert.Contains(t, received.Text, "<@U0123>\n[instagram => wordpress]")
+	assert.Contains(t, received.Text, "顧客: id=1, name=カフェ")
+
+	err := notifier.Notify(context.Background(), server.URL+"/missing", "", n)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no_service")
+}
+
+func TestSlackNotifier_NotifyActions(t *testing.T) {
+	var received external.SlackRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	account := domain.Account{Type: domain.AccountTypeWordpressInstagram, ID: 1, Name: "カフェ"}
+	n := domain.NewSyncFailedNotification("instagram => wordpress", assert.AnError, account)
+	n.Actions = domain.NewSyncFailedActions(account, "m1", "https://admin.example.com")
+	notifier := NewSlackNotifier(driver.NewClient(http.DefaultClient))
+
+	assert.NoError(t, notifier.Notify(context.Background(), server.URL, "", n))
+	assert.Len(t, received.Blocks, 2)
+	assert.Equal(t, "section", received.Blocks[0].Type)
+	assert.Equal(t, received.Text, received.Blocks[0].Text.Text)
+
+	actions := received.Blocks[1]
+	assert.Equal(t, "actions", actions.Type)
+	assert.Len(t, actions.Elements, 4)
+	assert.Equal(t, "retry_post", actions.Elements[0].ActionID)
+	assert.Equal(t, "wordpress_instagram:1:m1", actions.Elements[0].Value)
+	assert.Equal(t, "primary", actions.Elements[0].Style)
+	assert.Equal(t, "danger", actions.Elements[2].Style)
+	assert.Equal(t, "https://admin.example.com/wordpress-instagram/1", actions.Elements[3].URL)
+}
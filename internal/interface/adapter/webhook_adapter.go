@@ -0,0 +1,57 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+type WebhookAdapter interface {
+	Send(ctx context.Context, endpoint string, header map[string]string, payload []byte) (*external.WebhookResponse, error)
+}
+
+func NewWebhookAdapter() WebhookAdapter {
+	return &webhookAdapter{
+		// 送信先が応答しない場合に同期処理を止めないよう、タイムアウトを短めにする
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type webhookAdapter struct {
+	httpClient *http.Client
+}
+
+// Send はイベントをPOSTする。通信に失敗した場合だけエラーを返す。
+func (a *webhookAdapter) Send(ctx context.Context, endpoint string, header map[string]string, payload []byte) (*external.WebhookResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "homing-webhook")
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("webhookの送信に失敗: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	// レスポンスは送信ログに残すだけなので先頭だけ読む
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, err
+	}
+	return &external.WebhookResponse{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}, nil
+}
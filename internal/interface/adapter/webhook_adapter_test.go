@@ -0,0 +1,53 @@
+package adapter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
+)
+
+func TestWebhookAdapter_Send(t *testing.T) {
+	const secret = "test-secret"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, _ := strconv.ParseInt(r.Header.Get(domain.WebhookHeaderTimestamp), 10, 64)
+		if r.Header.Get(domain.WebhookHeaderSignature) != domain.SignWebhook(secret, ts, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte("invalid signature"))
+			return
+		}
+		assert.Equal(t, string(domain.WebhookEventSyncFailed), r.Header.Get(domain.WebhookHeaderEvent))
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	event, err := domain.NewWebhookEvent(domain.WebhookEventSyncFailed, domain.Account{Type: domain.AccountTypeFeed, ID: 1, Name: "news"}, map[string]string{"error": "timeout"}, time.Now())
+	assert.NoError(t, err)
+
+	a := NewWebhookAdapter()
+
+	header := domain.WebhookHeaders(&domain.WebhookSubscription{Secret: secret}, event, 1, time.Now())
+	resp, err := a.Send(context.Background(), server.URL, header, []byte(event.Payload))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// シークレットが違う場合は受信側で検証に失敗する
+	header = domain.WebhookHeaders(&domain.WebhookSubscription{Secret: "other"}, event, 1, time.Now())
+	resp, err = a.Send(context.Background(), server.URL, header, []byte(event.Payload))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "invalid signature", resp.Body)
+
+	// 接続できない場合はエラー
+	server.Close()
+	_, err = a.Send(context.Background(), server.URL, header, []byte(event.Payload))
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+type webhookNotifier struct {
+	webhookAdapter WebhookAdapter
+}
+
+// NewWebhookNotifier は任意のURLに通知をJSONでPOSTする。destination はURL、mention はそのまま送る。
+func NewWebhookNotifier(webhookAdapter WebhookAdapter) Notifier {
+	return &webhookNotifier{
+		webhookAdapter: webhookAdapter,
+	}
+}
+
+func (w *webhookNotifier) Notify(ctx context.Context, destination, mention string, n domain.Notification) error {
+	payload := external.NotificationWebhookRequest{
+		EventType: string(n.EventType),
+		Subject:   n.Subject,
+		Text:      n.Text,
+		Mention:   mention,
+	}
+	if n.Account.Type != "" {
+		payload.Account = &external.NotificationAccount{
+			Type: string(n.Account.Type),
+			ID:   n.Account.ID,
+			Name: n.Account.Name,
+		}
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := w.webhookAdapter.Send(ctx, destination, map[string]string{
+		"Content-Type": "application/json",
+	}, b)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhookへの通知に失敗: status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	var received external.NotificationWebhookRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hook":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	feed := &domain.Feed{ID: 3, Name: "お知らせ"}
+	n := domain.NewFeedNotification(feed, domain.FeedDestinationWordpress, "https://example.com/?p=1", "https://news.example.com/1")
+	notifier := NewWebhookNotifier(NewWebhookAdapter())
+
+	assert.NoError(t, notifier.Notify(context.Background(), server.URL+"/hook", "@ops", n))
+	assert.Equal(t, "sync.succeeded", received.EventType)
+	assert.Equal(t, &external.NotificationAccount{Type: "feed", ID: 3, Name: "お知らせ"}, received.Account)
+	assert.Equal(t, n.Subject, received.Subject)
+	assert.Equal(t, n.Text, received.Text)
+	assert.Equal(t, "@ops", received.Mention)
+
+	err := notifier.Notify(context.Background(), server.URL+"/error", "", n)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+}
@@ -28,7 +28,10 @@ import (
 
 type WordpressAdapter interface {
 	GetTitle(ctx context.Context, domain string) (string, error)
+	GetSiteTitle(ctx context.Context, wi domain.WordpressInstagram) (string, error)
+	VerifySignature(ctx context.Context, wi domain.WordpressInstagram) error
 	Post(ctx context.Context, in external.WordpressPostInput) (*domain.Post, error)
+	PostArticle(ctx context.Context, in external.WordpressArticleInput) (*domain.Post, error)
 	FileUpload(ctx context.Context, in external.WordpressFileUploadInput) (*external.WordpressFileUploadResponse, error)
 	GetGbpPosts(ctx context.Context, domain string) ([]external.WordpressGbpPost, error)
 }
@@ -40,6 +43,7 @@ func NewWordpressAdapter(
 	secretPhrase := config.Env.SecretPhrase
 	return &wordpressAdapter{
 		httpDriver:   httpDriver,
+		httpClient:   &http.Client{},
 		adminEmail:   adminEmail,
 		secretPhrase: secretPhrase,
 	}
@@ -47,6 +51,7 @@ func NewWordpressAdapter(
 
 type wordpressAdapter struct {
 	httpDriver   driver.HttpDriver
+	httpClient   *http.Client
 	adminEmail   string
 	secretPhrase string
 }
@@ -72,14 +77,89 @@ func (a *wordpressAdapter) GetTitle(ctx context.Context, domain string) (string,
 	return titleResponse.Title, nil
 }
 
+// GetSiteTitle は連携の投稿方法に合わせてサイト名を取得する（疎通確認を兼ねる）。
+func (a *wordpressAdapter) GetSiteTitle(ctx context.Context, wi domain.WordpressInstagram) (string, error) {
+	if wi.IsV2() {
+		return a.getSiteTitleV2(ctx, wi)
+	}
+	return a.GetTitle(ctx, wi.WordpressDomain)
+}
+
+// VerifySignature は投稿と同じ署名で何もしないリクエスト（rodut/v1/verify）を送り、HMACの鍵が一致するかを確認する。
+// wp_v2 の場合はアプリケーションパスワードで認証できるかを確認する。
+func (a *wordpressAdapter) VerifySignature(ctx context.Context, wi domain.WordpressInstagram) error {
+	if wi.IsV2() {
+		var user external.WordpressV2UserResponse
+		return a.doV2(ctx, wi, http.MethodGet, "/wp/v2/users/me", nil, nil, &user)
+	}
+	reqBody := external.WordpressVerifyPayload{Email: a.adminEmail}
+	header, err := external.GetWordpressHeader(reqBody, wi.GenerateAPIKey(a.secretPhrase))
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return err
+	}
+	// ポート付きのドメインでもパースできるようにスキーマを付けてから解析する
+	u, err := url.Parse("https://" + wi.WordpressDomain)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	q.Set("rest_route", "/rodut/v1/verify")
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	respBody, _ := io.ReadAll(resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w (ステータス: %d)", domain.ErrWordpressSignature, resp.StatusCode)
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w (ステータス: %d)", domain.ErrWordpressPluginOld, resp.StatusCode)
+	}
+	return fmt.Errorf("ステータス: %d: %s", resp.StatusCode, truncateResponse(respBody))
+}
+
 func (a *wordpressAdapter) Post(ctx context.Context, input external.WordpressPostInput) (*domain.Post, error) {
+	return a.PostArticle(ctx, external.WordpressArticleInput{
+		WordpressInstagram: input.WordpressInstagram,
+		Title:              input.Post.GetTitle(),
+		Content:            input.Post.GetContent(),
+		PostDate:           input.Post.GetPostDate(),
+		FeaturedMediaID:    input.Post.FeaturedMediaID,
+	})
+}
+
+// PostArticle はタイトルと本文を指定して記事を投稿する。
+func (a *wordpressAdapter) PostArticle(ctx context.Context, input external.WordpressArticleInput) (*domain.Post, error) {
+	if input.WordpressInstagram.IsV2() {
+		return a.postV2(ctx, input)
+	}
 	reqBody := external.WordpressPostPayload{
-		Email:         a.adminEmail,
-		Title:         input.Post.GetTitle(),
-		Content:       input.Post.GetContent(),
-		PostDate:      input.Post.GetPostDate(),
-		FeaturedMedia: input.Post.FeaturedMediaID,
-		PostCategory:  input.WordpressInstagram.Categories,
+		Email:          a.adminEmail,
+		Title:          input.Title,
+		Content:        input.Content,
+		PostDate:       input.PostDate,
+		FeaturedMedia:  input.FeaturedMediaID,
+		PostCategory:   input.WordpressInstagram.Categories,
+		IdempotencyKey: input.IdempotencyKey,
 	}
 	apiKey := input.WordpressInstagram.GenerateAPIKey(a.secretPhrase)
 
@@ -114,6 +194,9 @@ func (a *wordpressAdapter) Post(ctx context.Context, input external.WordpressPos
 }
 
 func (a *wordpressAdapter) FileUpload(ctx context.Context, in external.WordpressFileUploadInput) (*external.WordpressFileUploadResponse, error) {
+	if in.WordpressInstagram.IsV2() {
+		return a.fileUploadV2(ctx, in)
+	}
 	file, err := os.Open(in.Path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file: %w", err)
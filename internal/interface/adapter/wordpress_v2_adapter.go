@@ -0,0 +1,200 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+// WordPress標準のREST API（wp/v2）とアプリケーションパスワードで投稿する。
+// rodut/v1 プラグインを導入できないサイト向けで、投稿結果（アイキャッチ・カテゴリ・投稿日時）はプラグインと同じになるようにする。
+
+// getSiteTitleV2 はサイト名を取得し、アプリケーションパスワードで認証できることを確認する。
+func (a *wordpressAdapter) getSiteTitleV2(ctx context.Context, wi domain.WordpressInstagram) (string, error) {
+	var site external.WordpressV2SiteResponse
+	if err := a.doV2(ctx, wi, http.MethodGet, "/", nil, nil, &site); err != nil {
+		return "", err
+	}
+	var user external.WordpressV2UserResponse
+	if err := a.doV2(ctx, wi, http.MethodGet, "/wp/v2/users/me", nil, nil, &user); err != nil {
+		return "", err
+	}
+	return site.Name, nil
+}
+
+func (a *wordpressAdapter) postV2(ctx context.Context, input external.WordpressArticleInput) (*domain.Post, error) {
+	categoryIDs, err := a.resolveCategoriesV2(ctx, input.WordpressInstagram, input.WordpressInstagram.Categories)
+	if err != nil {
+		return nil, fmt.Errorf("カテゴリの取得に失敗: %w", err)
+	}
+
+	reqBody := external.WordpressV2PostPayload{
+		Title:   input.Title,
+		Content: input.Content,
+		Status:  "publish",
+		// wp/v2 の date はサイトのタイムゾーンの ISO8601
+		Date:          strings.Replace(input.PostDate, " ", "T", 1),
+		FeaturedMedia: input.FeaturedMediaID,
+		Categories:    categoryIDs,
+	}
+	var postDto external.WordpressV2PostResponse
+	if err := a.doV2(ctx, input.WordpressInstagram, http.MethodPost, "/wp/v2/posts", nil, &reqBody, &postDto); err != nil {
+		return nil, fmt.Errorf("記事の投稿に失敗: %w", err)
+	}
+	return &domain.Post{
+		ID:           postDto.Id,
+		WordpressURL: postDto.Link,
+	}, nil
+}
+
+// resolveCategoriesV2 はカテゴリ名をIDに変換する。存在しないカテゴリは作成する。
+func (a *wordpressAdapter) resolveCategoriesV2(ctx context.Context, wi domain.WordpressInstagram, names []string) ([]int, error) {
+	ids := make([]int, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		var categories []external.WordpressV2Category
+		query := url.Values{"search": {name}, "per_page": {"100"}}
+		if err := a.doV2(ctx, wi, http.MethodGet, "/wp/v2/categories", query, nil, &categories); err != nil {
+			return nil, err
+		}
+		id := 0
+		for _, c := range categories {
+			// REST APIのカテゴリ名はHTMLエスケープされている（& → &amp;）
+			if strings.EqualFold(html.UnescapeString(c.Name), name) {
+				id = c.Id
+				break
+			}
+		}
+		if id == 0 {
+			var created external.WordpressV2Category
+			if err := a.doV2(ctx, wi, http.MethodPost, "/wp/v2/categories", nil, map[string]string{"name": name}, &created); err != nil {
+				return nil, err
+			}
+			id = created.Id
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (a *wordpressAdapter) fileUploadV2(ctx context.Context, in external.WordpressFileUploadInput) (*external.WordpressFileUploadResponse, error) {
+	data, err := os.ReadFile(in.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	fileName := filepath.Base(in.Path)
+	mimeType := mime.TypeByExtension(filepath.Ext(fileName))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+
+	req, err := a.newV2Request(ctx, in.WordpressInstagram, http.MethodPost, "/wp/v2/media", nil, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mimeType)
+	req.Header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, fileName))
+
+	var uploadResponse external.WordpressFileUploadResponse
+	if err := a.sendV2(req, &uploadResponse); err != nil {
+		return nil, fmt.Errorf("failed to upload file: %w", err)
+	}
+	return &uploadResponse, nil
+}
+
+func (a *wordpressAdapter) doV2(ctx context.Context, wi domain.WordpressInstagram, method, route string, query url.Values, reqBody any, out any) error {
+	var body io.Reader
+	if reqBody != nil {
+		jsonData, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("JSON作成エラー: %w", err)
+		}
+		body = bytes.NewReader(jsonData)
+	}
+	req, err := a.newV2Request(ctx, wi, method, route, query, body)
+	if err != nil {
+		return err
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	return a.sendV2(req, out)
+}
+
+// newV2Request は rest_route でルートを指定したリクエストを作る（パーマリンク設定に依存しないようにするため）。
+func (a *wordpressAdapter) newV2Request(ctx context.Context, wi domain.WordpressInstagram, method, route string, query url.Values, body io.Reader) (*http.Request, error) {
+	// ポート付きのドメインでもパースできるようにスキーマを付けてから解析する
+	u, err := url.Parse("https://" + wi.WordpressDomain)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("rest_route", route)
+	for k, v := range query {
+		q[k] = v
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(wi.WordpressUsername, wi.AppPassword)
+	return req, nil
+}
+
+func (a *wordpressAdapter) sendV2(req *http.Request, out any) error {
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("レスポンス読み込みエラー: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var errResp external.WordpressV2ErrorResponse
+		if err := unmarshalWordpressResponse(respBody, &errResp); err == nil && errResp.Code != "" {
+			return &wordpressV2StatusError{StatusCode: resp.StatusCode, Message: errResp.Code + ": " + errResp.Message}
+		}
+		return &wordpressV2StatusError{StatusCode: resp.StatusCode, Message: truncateResponse(respBody)}
+	}
+	if err := unmarshalWordpressResponse(respBody, out); err != nil {
+		return fmt.Errorf("JSONの変換に失敗: %w (endpoint=%s)", err, req.URL.String())
+	}
+	return nil
+}
+
+// wordpressV2StatusError はREST APIが2xx以外のステータスを返したときのエラー
+type wordpressV2StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *wordpressV2StatusError) Error() string {
+	return fmt.Sprintf("ステータス: %d: %s", e.StatusCode, e.Message)
+}
+
+// Is はWordPressが投稿を受け付けなかった（4xx）場合に domain.ErrSyncRejected として扱う。
+func (e *wordpressV2StatusError) Is(target error) bool {
+	return target == domain.ErrSyncRejected && domain.IsSyncRejectedStatus(e.StatusCode)
+}
@@ -0,0 +1,211 @@
+package adapter
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/infrastructure/driver"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+)
+
+func newWordpressV2TestServer(t *testing.T, handler http.HandlerFunc) (*wordpressAdapter, domain.WordpressInstagram) {
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	a := &wordpressAdapter{
+		httpDriver: driver.NewClient(server.Client()),
+		httpClient: server.Client(),
+	}
+	wi := domain.WordpressInstagram{
+		WordpressDomain:   strings.TrimPrefix(server.URL, "https://"),
+		APIMode:           domain.WordpressAPIModeV2,
+		WordpressUsername: "editor",
+		AppPassword:       "abcd efgh",
+		Categories:        []string{"News & Topics", "新着"},
+	}
+	return a, wi
+}
+
+func TestWordpressAdapter_GetSiteTitleV2(t *testing.T) {
+	var routes []string
+	a, wi := newWordpressV2TestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "editor", user)
+		assert.Equal(t, "abcd efgh", pass)
+		routes = append(routes, r.URL.Query().Get("rest_route"))
+		switch r.URL.Query().Get("rest_route") {
+		case "/":
+			_, _ = w.Write([]byte(`{"name":"Example Site"}`))
+		case "/wp/v2/users/me":
+			_, _ = w.Write([]byte(`{"id":1,"name":"editor"}`))
+		}
+	})
+
+	title, err := a.GetSiteTitle(context.Background(), wi)
+	assert.NoError(t, err)
+	assert.Equal(t, "Example Site", title)
+	assert.Equal(t, []string{"/", "/wp/v2/users/me"}, routes)
+}
+
+func TestWordpressAdapter_GetSiteTitleV2Unauthorized(t *testing.T) {
+	a, wi := newWordpressV2TestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("rest_route") == "/" {
+			_, _ = w.Write([]byte(`{"name":"Example Site"}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":"rest_not_logged_in","message":"You are not currently logged in."}`))
+	})
+
+	_, err := a.GetSiteTitle(context.Background(), wi)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "rest_not_logged_in")
+}
+
+func TestWordpressAdapter_PostV2(t *testing.T) {
+	var postBody external.WordpressV2PostPayload
+	var createdCategory map[string]string
+	a, wi := newWordpressV2TestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Query().Get("rest_route") {
+		case "GET /wp/v2/categories":
+			if r.URL.Query().Get("search") == "News & Topics" {
+				_, _ = w.Write([]byte(`[{"id":3,"name":"News &amp; Topics Archive"},{"id":5,"name":"news &amp; topics"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		case "POST /wp/v2/categories":
+			_ = json.NewDecoder(r.Body).Decode(&createdCategory)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":9,"name":"新着"}`))
+		case "POST /wp/v2/posts":
+			_ = json.NewDecoder(r.Body).Decode(&postBody)
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":42,"link":"https://example.com/?p=42"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	post := domain.InstagramPost{
+		Caption:   "タイトル\n本文",
+		Timestamp: "2025-09-29T10:19:24+0000",
+		MediaType: "IMAGE",
+	}
+	post.SetFeaturedMediaID(7)
+	post.AppendSourceURL("https://example.com/photo.jpg")
+
+	posted, err := a.Post(context.Background(), external.WordpressPostInput{
+		Post:               post,
+		WordpressInstagram: wi,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, posted.ID)
+	assert.Equal(t, "https://example.com/?p=42", posted.WordpressURL)
+	assert.Equal(t, map[string]string{"name": "新着"}, createdCategory)
+	assert.Equal(t, []int{5, 9}, postBody.Categories)
+	assert.Equal(t, 7, postBody.FeaturedMedia)
+	assert.Equal(t, "publish", postBody.Status)
+	assert.Equal(t, "2025-09-29T19:19:24", postBody.Date)
+}
+
+func TestWordpressAdapter_PostV2Error(t *testing.T) {
+	status := http.StatusBadRequest
+	a, wi := newWordpressV2TestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[{"id":5,"name":"新着"}]`))
+			return
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"code":"rest_invalid_param","message":"invalid"}`))
+	})
+	wi.Categories = []string{"新着"}
+	input := external.WordpressPostInput{Post: domain.InstagramPost{Caption: "タイトル"}, WordpressInstagram: wi}
+
+	// 4xx は投稿されていないことが確か
+	_, err := a.Post(context.Background(), input)
+	assert.ErrorIs(t, err, domain.ErrSyncRejected)
+	assert.Contains(t, err.Error(), "ステータス: 400: rest_invalid_param: invalid")
+
+	// 5xx は投稿されたか分からない
+	status = http.StatusBadGateway
+	_, err = a.Post(context.Background(), input)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrSyncRejected)
+}
+
+func TestWordpressAdapter_FileUploadV2(t *testing.T) {
+	var disposition, contentType string
+	var uploaded []byte
+	a, wi := newWordpressV2TestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wp/v2/media", r.URL.Query().Get("rest_route"))
+		disposition = r.Header.Get("Content-Disposition")
+		contentType = r.Header.Get("Content-Type")
+		uploaded, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":11,"source_url":"https://example.com/photo.jpg","mime_type":"image/jpeg"}`))
+	})
+
+	path := filepath.Join(t.TempDir(), "photo.jpg")
+	assert.NoError(t, os.WriteFile(path, []byte("jpeg-bytes"), 0o600))
+
+	resp, err := a.FileUpload(context.Background(), external.WordpressFileUploadInput{
+		Path:               path,
+		WordpressInstagram: wi,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 11, resp.Id)
+	assert.Equal(t, `attachment; filename="photo.jpg"`, disposition)
+	assert.Equal(t, "image/jpeg", contentType)
+	assert.Equal(t, []byte("jpeg-bytes"), uploaded)
+}
+
+func TestWordpressAdapter_VerifySignature(t *testing.T) {
+	status := http.StatusOK
+	a, wi := newWordpressV2TestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/rodut/v1/verify", r.URL.Query().Get("rest_route"))
+
+		// 投稿と同じく「タイムスタンプ.本文」をドメインごとの鍵で署名している
+		body, _ := io.ReadAll(r.Body)
+		apiKey := (&domain.WordpressInstagram{WordpressDomain: r.Host}).GenerateAPIKey("secret")
+		mac := hmac.New(sha256.New, []byte(apiKey))
+		mac.Write([]byte(r.Header.Get("X-Timestamp") + "." + string(body)))
+		assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), r.Header.Get("X-Signature"))
+		assert.JSONEq(t, `{"email":"admin@example.com"}`, string(body))
+		w.WriteHeader(status)
+	})
+	wi.APIMode = domain.WordpressAPIModeRodut
+	a.adminEmail = "admin@example.com"
+	a.secretPhrase = "secret"
+
+	assert.NoError(t, a.VerifySignature(context.Background(), wi))
+
+	status = http.StatusForbidden
+	assert.ErrorIs(t, a.VerifySignature(context.Background(), wi), domain.ErrWordpressSignature)
+
+	status = http.StatusNotFound
+	assert.ErrorIs(t, a.VerifySignature(context.Background(), wi), domain.ErrWordpressPluginOld)
+}
+
+func TestWordpressAdapter_VerifySignatureV2(t *testing.T) {
+	a, wi := newWordpressV2TestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wp/v2/users/me", r.URL.Query().Get("rest_route"))
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":"incorrect_password","message":"invalid"}`))
+	})
+
+	assert.EqualError(t, a.VerifySignature(context.Background(), wi), "ステータス: 401: incorrect_password: invalid")
+}
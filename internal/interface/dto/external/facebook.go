@@ -0,0 +1,27 @@
+package external
+
+type FacebookPageRequest struct {
+	AccessToken string `param:"access_token"`
+	Fields      string `param:"fields"`
+}
+
+type FacebookPageResponse struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	AccessToken string `json:"access_token"`
+}
+
+// FacebookPublishResponse は /photos・/feed・/videos のレスポンス。
+// 写真を公開で投稿した場合は post_id に投稿のIDが入る。
+type FacebookPublishResponse struct {
+	Id     string `json:"id"`
+	PostId string `json:"post_id"`
+}
+
+type FacebookErrorResponse struct {
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+}
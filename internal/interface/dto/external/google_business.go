@@ -14,4 +14,45 @@ type GoogleBusinessLocalPostResponse struct {
 	Summary    string `json:"summary"`
 	CreateTime string `json:"createTime"`
 	SearchURL  string `json:"searchUrl"`
-}
\ No newline at end of file
+}
+type GoogleBusinessReviewsResponse struct {
+	Reviews          []GoogleBusinessReview `json:"reviews"`
+	AverageRating    float64                `json:"averageRating"`
+	TotalReviewCount int                    `json:"totalReviewCount"`
+	NextPageToken    string                 `json:"nextPageToken"`
+}
+
+type GoogleBusinessReview struct {
+	Name     string `json:"name"`
+	ReviewID string `json:"reviewId"`
+	Reviewer struct {
+		DisplayName string `json:"displayName"`
+		IsAnonymous bool   `json:"isAnonymous"`
+	} `json:"reviewer"`
+	StarRating  string                     `json:"starRating"`
+	Comment     string                     `json:"comment"`
+	CreateTime  string                     `json:"createTime"`
+	UpdateTime  string                     `json:"updateTime"`
+	ReviewReply *GoogleBusinessReviewReply `json:"reviewReply,omitempty"`
+}
+
+type GoogleBusinessReviewReply struct {
+	Comment    string `json:"comment"`
+	UpdateTime string `json:"updateTime"`
+}
+
+// GoogleBusinessErrorResponse はGBP APIのエラーレスポンス
+type GoogleBusinessErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			ErrorDetails []struct {
+				Code    int    `json:"code"`
+				Field   string `json:"field"`
+				Message string `json:"message"`
+			} `json:"errorDetails"`
+		} `json:"details"`
+	} `json:"error"`
+}
@@ -80,6 +80,42 @@ type InstagramGetPostsNextResponse struct {
 	} `json:"paging"`
 }
 
+type InstagramGetMediaResponse struct {
+	Id        string `json:"id"`
+	Permalink string `json:"permalink"`
+	Timestamp string `json:"timestamp"`
+	MediaType string `json:"media_type"`
+	MediaUrl  string `json:"media_url"`
+	Children  struct {
+		Data []struct {
+			MediaType string `json:"media_type"`
+			MediaUrl  string `json:"media_url"`
+			Id        string `json:"id"`
+		} `json:"data"`
+	} `json:"children,omitempty"`
+	Caption string `json:"caption,omitempty"`
+}
+
+func ToInstagramPostEntity(dto *InstagramGetMediaResponse) *domain.InstagramPost {
+	children := make([]domain.InstagramPostChildren, 0, len(dto.Children.Data))
+	for _, child := range dto.Children.Data {
+		children = append(children, domain.InstagramPostChildren{
+			MediaType: child.MediaType,
+			MediaURL:  child.MediaUrl,
+			ID:        child.Id,
+		})
+	}
+	return &domain.InstagramPost{
+		ID:        dto.Id,
+		Permalink: dto.Permalink,
+		Caption:   dto.Caption,
+		Timestamp: dto.Timestamp,
+		MediaType: dto.MediaType,
+		MediaURL:  dto.MediaUrl,
+		Children:  children,
+	}
+}
+
 func ToInstagramPostsEntity(dto *InstagramGetPostsResponse) []domain.InstagramPost {
 	var posts []domain.InstagramPost
 	for _, post := range dto.Media.Data {
@@ -0,0 +1,20 @@
+package external
+
+type LinePushRequest struct {
+	To       string        `json:"to"`
+	Messages []LineMessage `json:"messages"`
+}
+
+type LineMessage struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// LineResponse は失敗した場合に Message にエラーの内容が入る。
+type LineResponse struct {
+	Message string `json:"message"`
+	Details []struct {
+		Message  string `json:"message"`
+		Property string `json:"property"`
+	} `json:"details"`
+}
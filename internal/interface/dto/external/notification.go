@@ -0,0 +1,15 @@
+package external
+
+type NotificationWebhookRequest struct {
+	EventType string               `json:"event_type"`
+	Account   *NotificationAccount `json:"account,omitempty"`
+	Subject   string               `json:"subject"`
+	Text      string               `json:"text"`
+	Mention   string               `json:"mention,omitempty"`
+}
+
+type NotificationAccount struct {
+	Type string `json:"type"`
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,7 @@
+package external
+
+// WebhookResponse は送信先からのレスポンス。2xx以外も送信ログに残すためエラーにはしない。
+type WebhookResponse struct {
+	StatusCode int
+	Body       string
+}
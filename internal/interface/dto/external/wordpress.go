@@ -19,6 +19,8 @@ type WordpressPostPayload struct {
 	PostDate      string   `json:"post_date"`
 	FeaturedMedia int      `json:"featured_media"`
 	PostCategory  []string `json:"post_category"`
+	// IdempotencyKey が同じ記事は、プラグインが新しく作らずに投稿済みの記事を返す
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
 }
 
 func GetWordpressHeader(payload any, apiKeyHex string) (map[string]string, error) {
@@ -59,6 +61,17 @@ type WordpressPostInput struct {
 	Post               domain.InstagramPost
 }
 
+// WordpressArticleInput はInstagram以外の記事を投稿するときの入力。PostDate は日本時間の "2006-01-02 15:04:05"。
+type WordpressArticleInput struct {
+	WordpressInstagram domain.WordpressInstagram
+	Title              string
+	Content            string
+	PostDate           string
+	FeaturedMediaID    int
+	// IdempotencyKey は rodut プラグインに送る冪等キー。wp/v2 では使わない
+	IdempotencyKey string
+}
+
 type WordpressFileUploadInput struct {
 	Path               string
 	WordpressInstagram domain.WordpressInstagram
@@ -68,6 +81,11 @@ type WordpressFileUploadPayload struct {
 	Email string `json:"email"`
 }
 
+// WordpressVerifyPayload は署名の確認用に送る、何もしないリクエスト
+type WordpressVerifyPayload struct {
+	Email string `json:"email"`
+}
+
 type WordpressFileUploadResponse struct {
 	Id        int    `json:"id"`
 	SourceUrl string `json:"source_url"`
@@ -84,4 +102,37 @@ type WordpressGbpPost struct {
 	PublishedAt string   `json:"published_at"`
 	Content     string   `json:"content"`
 	MediaURLs   []string `json:"media_urls"`
+	// CustomFields は gbp_topic_type, gbp_event_start 等のGBP連携用カスタムフィールド
+	CustomFields WordpressCustomFields `json:"custom_fields"`
+}
+
+// WordpressCustomFields はWordPressのカスタムフィールド。
+// PHPの空配列は [] でエンコードされるため、オブジェクト以外は空として扱う。
+// 値が配列の場合（同じキーのメタが複数ある場合）は先頭の値を使う。
+type WordpressCustomFields map[string]string
+
+func (f *WordpressCustomFields) UnmarshalJSON(data []byte) error {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		*f = WordpressCustomFields{}
+		return nil
+	}
+	fields := make(WordpressCustomFields, len(raw))
+	for k, v := range raw {
+		if list, ok := v.([]any); ok {
+			if len(list) == 0 {
+				continue
+			}
+			v = list[0]
+		}
+		switch val := v.(type) {
+		case string:
+			fields[k] = val
+		case nil:
+		default:
+			fields[k] = fmt.Sprint(val)
+		}
+	}
+	*f = fields
+	return nil
 }
@@ -0,0 +1,36 @@
+package external
+
+// WordpressV2PostPayload は wp/v2/posts に送る内容
+type WordpressV2PostPayload struct {
+	Title         string `json:"title"`
+	Content       string `json:"content"`
+	Status        string `json:"status"`
+	Date          string `json:"date"`
+	FeaturedMedia int    `json:"featured_media,omitempty"`
+	Categories    []int  `json:"categories,omitempty"`
+}
+
+type WordpressV2PostResponse struct {
+	Id   int    `json:"id"`
+	Link string `json:"link"`
+}
+
+type WordpressV2Category struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type WordpressV2SiteResponse struct {
+	Name string `json:"name"`
+}
+
+type WordpressV2UserResponse struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// WordpressV2ErrorResponse はREST APIのエラーレスポンス（WP_Error）
+type WordpressV2ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
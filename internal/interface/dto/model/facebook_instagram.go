@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type FacebookInstagram struct {
+	ID               int       `gorm:"column:id;primaryKey;autoIncrement"`
+	Name             string    `gorm:"column:name"`
+	Memo             string    `gorm:"column:memo"`
+	InstagramID      string    `gorm:"column:instagram_id"`
+	InstagramName    string    `gorm:"column:instagram_name"`
+	FacebookPageID   string    `gorm:"column:facebook_page_id"`
+	FacebookPageName string    `gorm:"column:facebook_page_name"`
+	StartDate        time.Time `gorm:"column:start_date"`
+	Status           int       `gorm:"column:status"`
+	DeleteHash       bool      `gorm:"column:delete_hash"`
+	UpdatedAt        time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	CreatedAt        time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*FacebookInstagram) TableName() string {
+	return "facebook_instagrams"
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type FacebookPost struct {
+	ID                  int       `gorm:"column:id;primaryKey;autoIncrement"`
+	FacebookInstagramID int       `gorm:"column:facebook_instagram_id"`
+	MediaID             string    `gorm:"column:media_id"`
+	Permalink           string    `gorm:"column:permalink"`
+	FacebookPostID      string    `gorm:"column:facebook_post_id"`
+	FacebookURL         string    `gorm:"column:facebook_url"`
+	CreatedAt           time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*FacebookPost) TableName() string {
+	return "facebook_posts"
+}
@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+type Feed struct {
+	ID                 int       `gorm:"column:id;primaryKey;autoIncrement"`
+	Name               string    `gorm:"column:name"`
+	Memo               string    `gorm:"column:memo"`
+	FeedURL            string    `gorm:"column:feed_url"`
+	FeedTitle          string    `gorm:"column:feed_title"`
+	StartDate          time.Time `gorm:"column:start_date"`
+	Status             int       `gorm:"column:status"`
+	WordpressDomain    string    `gorm:"column:wordpress_domain"`
+	WordpressSiteTitle string    `gorm:"column:wordpress_site_title"`
+	APIMode            string    `gorm:"column:api_mode"`
+	WordpressUsername  string    `gorm:"column:wordpress_username"`
+	AppPassword        string    `gorm:"column:app_password"`
+	Categories         string    `gorm:"column:categories"`
+	BusinessName       string    `gorm:"column:business_name"`
+	BusinessTitle      string    `gorm:"column:business_title"`
+	CallToActionType   string    `gorm:"column:call_to_action_type"`
+	CallToActionURL    string    `gorm:"column:call_to_action_url"`
+	UpdatedAt          time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	CreatedAt          time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*Feed) TableName() string {
+	return "feeds"
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type FeedPost struct {
+	ID          int       `gorm:"column:id;primaryKey;autoIncrement"`
+	FeedID      int       `gorm:"column:feed_id"`
+	Destination string    `gorm:"column:destination"`
+	GUIDHash    string    `gorm:"column:guid_hash"`
+	GUID        string    `gorm:"column:guid"`
+	Link        string    `gorm:"column:link"`
+	URL         string    `gorm:"column:url"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*FeedPost) TableName() string {
+	return "feed_posts"
+}
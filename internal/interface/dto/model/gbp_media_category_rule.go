@@ -0,0 +1,7 @@
+package model
+
+// GbpMediaCategoryRule は business_instagrams / wordpress_gbps の media_category_rules に保存するJSONの要素
+type GbpMediaCategoryRule struct {
+	Keyword  string `json:"keyword"`
+	Category string `json:"category"`
+}
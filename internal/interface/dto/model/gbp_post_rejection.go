@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type GbpPostRejection struct {
+	ID           int       `gorm:"column:id;primaryKey;autoIncrement"`
+	CustomerID   int       `gorm:"column:customer_id"`
+	MediaID      string    `gorm:"column:media_id"`
+	BusinessName string    `gorm:"column:business_name"`
+	Reason       string    `gorm:"column:reason"`
+	Field        string    `gorm:"column:field"`
+	Message      string    `gorm:"column:message"`
+	Summary      string    `gorm:"column:summary"`
+	Count        int       `gorm:"column:count"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*GbpPostRejection) TableName() string {
+	return "gbp_post_rejections"
+}
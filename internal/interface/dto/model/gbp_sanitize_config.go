@@ -0,0 +1,7 @@
+package model
+
+// GbpSanitizeConfig は business_instagrams / wordpress_gbps の sanitize_config に保存するJSON
+type GbpSanitizeConfig struct {
+	Rules    []string `json:"rules"`
+	Patterns []string `json:"patterns"`
+}
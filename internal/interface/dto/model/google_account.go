@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type GoogleAccount struct {
+	ID                 int       `gorm:"column:id;primaryKey;autoIncrement"`
+	Name               string    `gorm:"column:name"`
+	AccountName        string    `gorm:"column:account_name"`
+	Type               string    `gorm:"column:type"`
+	GoogleOAuthTokenID int       `gorm:"column:google_oauth_token_id"`
+	UpdatedAt          time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	CreatedAt          time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*GoogleAccount) TableName() string {
+	return "google_accounts"
+}
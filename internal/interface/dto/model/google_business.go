@@ -5,12 +5,16 @@ import (
 )
 
 type GoogleBusiness struct {
-	ID        int       `gorm:"column:id;primaryKey;autoIncrement"`
-	Name      string    `gorm:"column:name"`
-	Title     string    `gorm:"column:title"`
-	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime"`
+	ID                     int        `gorm:"column:id;primaryKey;autoIncrement"`
+	GoogleAccountID        int        `gorm:"column:google_account_id"`
+	Name                   string     `gorm:"column:name"`
+	Title                  string     `gorm:"column:title"`
+	PhotoRetentionCount    int        `gorm:"column:photo_retention_count"`
+	LocalPostRetentionDays int        `gorm:"column:local_post_retention_days"`
+	ReviewsImportedAt      *time.Time `gorm:"column:reviews_imported_at"`
+	CreatedAt              time.Time  `gorm:"column:created_at;autoCreateTime"`
 }
 
 func (*GoogleBusiness) TableName() string {
 	return "google_businesses"
-}
\ No newline at end of file
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+type GoogleBusinessLocationChange struct {
+	ID           int       `gorm:"column:id;primaryKey;autoIncrement"`
+	BusinessName string    `gorm:"column:business_name"`
+	Field        string    `gorm:"column:field"`
+	Before       string    `gorm:"column:before_value"`
+	After        string    `gorm:"column:after_value"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*GoogleBusinessLocationChange) TableName() string {
+	return "google_business_location_changes"
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type GoogleBusinessMetric struct {
+	ID           int       `gorm:"column:id;primaryKey;autoIncrement"`
+	BusinessName string    `gorm:"column:business_name"`
+	Metric       string    `gorm:"column:metric"`
+	Date         time.Time `gorm:"column:date;type:date"`
+	Value        int64     `gorm:"column:value"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*GoogleBusinessMetric) TableName() string {
+	return "google_business_metrics"
+}
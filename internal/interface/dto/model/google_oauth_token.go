@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type GoogleOAuthToken struct {
+	ID           int       `gorm:"column:id;primaryKey;autoIncrement"`
+	AccessToken  string    `gorm:"column:access_token"`
+	RefreshToken string    `gorm:"column:refresh_token"`
+	TokenType    string    `gorm:"column:token_type"`
+	Expiry       time.Time `gorm:"column:expiry"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*GoogleOAuthToken) TableName() string {
+	return "google_oauth_tokens"
+}
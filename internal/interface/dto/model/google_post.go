@@ -5,17 +5,24 @@ import (
 )
 
 type GooglePost struct {
-	ID           int       `gorm:"column:id;primaryKey;autoIncrement"`
-	InstagramURL string    `gorm:"column:instagram_url"`
-	MediaID      string    `gorm:"column:media_id"`
-	CustomerID   int       `gorm:"column:customer_id"`
-	Name         string    `gorm:"column:name"`
-	GoogleURL    string    `gorm:"column:google_url"`
-	CreateTime   string    `gorm:"column:create_time"`
-	PostType     string    `gorm:"column:post_type"`
-	CreatedAt    time.Time `gorm:"column:created_at;autoCreateTime"`
+	ID                  int        `gorm:"column:id;primaryKey;autoIncrement"`
+	InstagramURL        string     `gorm:"column:instagram_url"`
+	MediaID             string     `gorm:"column:media_id"`
+	CustomerID          int        `gorm:"column:customer_id"`
+	Source              string     `gorm:"column:source"`
+	Destination         string     `gorm:"column:destination"`
+	Status              string     `gorm:"column:status"`
+	BusinessInstagramID *int       `gorm:"column:business_instagram_id"`
+	WordpressGbpID      *int       `gorm:"column:wordpress_gbp_id"`
+	Name                string     `gorm:"column:name"`
+	GoogleURL           string     `gorm:"column:google_url"`
+	CreateTime          string     `gorm:"column:create_time"`
+	PublishedAt         *time.Time `gorm:"column:published_at"`
+	PostType            string     `gorm:"column:post_type"`
+	DeletedAt           *time.Time `gorm:"column:deleted_at"`
+	CreatedAt           time.Time  `gorm:"column:created_at;autoCreateTime"`
 }
 
 func (*GooglePost) TableName() string {
 	return "google_posts"
-}
\ No newline at end of file
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type GoogleReview struct {
+	ID             int        `gorm:"column:id;primaryKey;autoIncrement"`
+	BusinessName   string     `gorm:"column:business_name"`
+	ReviewName     string     `gorm:"column:review_name"`
+	ReviewerName   string     `gorm:"column:reviewer_name"`
+	StarRating     int        `gorm:"column:star_rating"`
+	Comment        string     `gorm:"column:comment"`
+	ReplyComment   string     `gorm:"column:reply_comment"`
+	ReplyUpdatedAt *time.Time `gorm:"column:reply_updated_at"`
+	ReviewedAt     time.Time  `gorm:"column:reviewed_at"`
+	UpdateTime     string     `gorm:"column:update_time"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*GoogleReview) TableName() string {
+	return "google_reviews"
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+type NotificationRule struct {
+	ID          int       `gorm:"column:id;primaryKey;autoIncrement"`
+	Name        string    `gorm:"column:name"`
+	EventTypes  string    `gorm:"column:event_types"`
+	AccountType string    `gorm:"column:account_type"`
+	AccountID   int       `gorm:"column:account_id"`
+	Channel     string    `gorm:"column:channel"`
+	Destination string    `gorm:"column:destination"`
+	Mention     string    `gorm:"column:mention"`
+	Status      int       `gorm:"column:status"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*NotificationRule) TableName() string {
+	return "notification_rules"
+}
@@ -3,12 +3,19 @@ package model
 import "time"
 
 type Post struct {
-	ID            int       `gorm:"column:id;primaryKey"`
-	MediaID       string    `gorm:"column:media_id"`
-	CustomerID    int       `gorm:"column:customer_id"`
-	Timestamp     string    `gorm:"column:timestamp"`
-	MediaURL      string    `gorm:"column:media_url"`
-	CreatedAt     time.Time `gorm:"column:created_at"`
-	Permalink     string    `gorm:"column:permalink"`
-	WordpressLink string    `gorm:"column:wordpress_link"`
+	ID         int    `gorm:"column:id;primaryKey"`
+	MediaID    string `gorm:"column:media_id"`
+	CustomerID int    `gorm:"column:customer_id"`
+	// WordpressInstagramID は連携（wordpress_instagrams.id）。旧 customers の投稿は移行するまで NULL
+	WordpressInstagramID *int       `gorm:"column:wordpress_instagram_id"`
+	Source               string     `gorm:"column:source"`
+	Destination          string     `gorm:"column:destination"`
+	Status               string     `gorm:"column:status"`
+	IdempotencyKey       *string    `gorm:"column:idempotency_key"`
+	Timestamp            string     `gorm:"column:timestamp"`
+	PostedAt             *time.Time `gorm:"column:posted_at"`
+	MediaURL             string     `gorm:"column:media_url"`
+	CreatedAt            time.Time  `gorm:"column:created_at"`
+	Permalink            string     `gorm:"column:permalink"`
+	WordpressLink        string     `gorm:"column:wordpress_link"`
 }
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type SyncActivity struct {
+	ID          int       `gorm:"column:id;primaryKey;autoIncrement"`
+	AccountType string    `gorm:"column:account_type"`
+	AccountID   int       `gorm:"column:account_id"`
+	AccountName string    `gorm:"column:account_name"`
+	Result      string    `gorm:"column:result"`
+	PostKey     string    `gorm:"column:post_key"`
+	Detail      string    `gorm:"column:detail"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*SyncActivity) TableName() string {
+	return "sync_activities"
+}
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+type WebhookSubscription struct {
+	ID          int       `gorm:"column:id;primaryKey;autoIncrement"`
+	Name        string    `gorm:"column:name"`
+	URL         string    `gorm:"column:url"`
+	Secret      string    `gorm:"column:secret"`
+	EventTypes  string    `gorm:"column:event_types"`
+	AccountType string    `gorm:"column:account_type"`
+	AccountID   int       `gorm:"column:account_id"`
+	Status      int       `gorm:"column:status"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;autoUpdateTime"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*WebhookSubscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+type WebhookEvent struct {
+	ID          int       `gorm:"column:id;primaryKey;autoIncrement"`
+	EventID     string    `gorm:"column:event_id"`
+	EventType   string    `gorm:"column:event_type"`
+	AccountType string    `gorm:"column:account_type"`
+	AccountID   int       `gorm:"column:account_id"`
+	AccountName string    `gorm:"column:account_name"`
+	Payload     string    `gorm:"column:payload"`
+	CreatedAt   time.Time `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*WebhookEvent) TableName() string {
+	return "webhook_events"
+}
+
+type WebhookDelivery struct {
+	ID             int        `gorm:"column:id;primaryKey;autoIncrement"`
+	EventID        int        `gorm:"column:event_id"`
+	SubscriptionID int        `gorm:"column:subscription_id"`
+	Status         string     `gorm:"column:status"`
+	Attempts       int        `gorm:"column:attempts"`
+	ResponseStatus int        `gorm:"column:response_status"`
+	ResponseBody   string     `gorm:"column:response_body"`
+	Error          string     `gorm:"column:error"`
+	NextRetryAt    *time.Time `gorm:"column:next_retry_at"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;autoUpdateTime"`
+	CreatedAt      time.Time  `gorm:"column:created_at;autoCreateTime"`
+}
+
+func (*WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
@@ -0,0 +1,13 @@
+package req
+
+type ImportAccountConfig struct {
+	// Format は json（デフォルト）または csv
+	Format string `query:"format"`
+	// DryRun が true の場合は各行を検証した結果だけを返し、何も作成しない
+	DryRun bool `query:"dry_run"`
+}
+
+type ExportAccountConfig struct {
+	// Format は json（デフォルト）または csv
+	Format string `query:"format"`
+}
@@ -18,10 +18,16 @@ type GetBusinessInstagramDetail struct {
 }
 
 type BusinessInstagram struct {
-	Name         string    `json:"name"`
-	BusinessName string    `json:"business_name"`
-	InstagramID  string    `json:"instagram_id"`
-	Memo         string    `json:"memo"`
-	StartDate    time.Time `json:"start_date"`
-	Status       int       `json:"status"`
+	Name               string                 `json:"name"`
+	BusinessName       string                 `json:"business_name"`
+	InstagramID        string                 `json:"instagram_id"`
+	Memo               string                 `json:"memo"`
+	CallToActionType   string                 `json:"call_to_action_type"`
+	CallToActionURL    string                 `json:"call_to_action_url"`
+	MediaCategory      string                 `json:"media_category"`
+	MediaCategoryRules []GbpMediaCategoryRule `json:"media_category_rules"`
+	// SanitizeConfig が null の場合はデフォルトのルールを使う
+	SanitizeConfig *GbpSanitizeConfig `json:"sanitize_config"`
+	StartDate      time.Time          `json:"start_date"`
+	Status         int                `json:"status"`
 }
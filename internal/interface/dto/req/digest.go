@@ -0,0 +1,6 @@
+package req
+
+// GetDigest は period を省略すると日次のダイジェストを作る。
+type GetDigest struct {
+	Period string `query:"period"`
+}
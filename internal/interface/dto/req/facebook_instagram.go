@@ -0,0 +1,37 @@
+package req
+
+import "time"
+
+type GetFacebookInstagram struct {
+	Limit            *int    `query:"limit"`
+	Offset           *int    `query:"offset"`
+	Name             *string `query:"name"`
+	InstagramName    *string `query:"instagram_name"`
+	FacebookPageName *string `query:"facebook_page_name"`
+	Status           *int    `query:"status"`
+}
+
+type GetFacebookInstagramDetail struct {
+	Limit  *int `query:"limit"`
+	Offset *int `query:"offset"`
+}
+
+type CreateFacebookInstagram struct {
+	Name           string    `json:"name"`
+	Memo           string    `json:"memo"`
+	InstagramID    string    `json:"instagram_id"`
+	FacebookPageID string    `json:"facebook_page_id"`
+	StartDate      time.Time `json:"start_date"`
+	Status         int       `json:"status"`
+	DeleteHash     bool      `json:"delete_hash"`
+}
+
+type UpdateFacebookInstagram struct {
+	Name           *string    `json:"name"`
+	Memo           *string    `json:"memo"`
+	InstagramID    *string    `json:"instagram_id"`
+	FacebookPageID *string    `json:"facebook_page_id"`
+	StartDate      *time.Time `json:"start_date"`
+	Status         *int       `json:"status"`
+	DeleteHash     *bool      `json:"delete_hash"`
+}
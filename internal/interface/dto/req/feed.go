@@ -0,0 +1,50 @@
+package req
+
+import "time"
+
+type GetFeed struct {
+	Limit   *int    `query:"limit"`
+	Offset  *int    `query:"offset"`
+	Name    *string `query:"name"`
+	FeedURL *string `query:"feed_url"`
+	Status  *int    `query:"status"`
+}
+
+type GetFeedDetail struct {
+	Limit  *int `query:"limit"`
+	Offset *int `query:"offset"`
+}
+
+// CreateFeed は wordpress_domain と business_name のうち指定した方（両方可）に連携する。
+// WordPressの api_mode などは WordpressInstagram と同じ。
+type CreateFeed struct {
+	Name              string    `json:"name"`
+	Memo              string    `json:"memo"`
+	FeedURL           string    `json:"feed_url"`
+	StartDate         time.Time `json:"start_date"`
+	Status            int       `json:"status"`
+	WordpressDomain   string    `json:"wordpress_domain"`
+	APIMode           string    `json:"api_mode"`
+	WordpressUsername string    `json:"wordpress_username"`
+	AppPassword       string    `json:"app_password"`
+	Categories        []string  `json:"categories"`
+	BusinessName      string    `json:"business_name"`
+	CallToActionType  string    `json:"call_to_action_type"`
+	CallToActionURL   string    `json:"call_to_action_url"`
+}
+
+type UpdateFeed struct {
+	Name              *string    `json:"name"`
+	Memo              *string    `json:"memo"`
+	FeedURL           *string    `json:"feed_url"`
+	StartDate         *time.Time `json:"start_date"`
+	Status            *int       `json:"status"`
+	WordpressDomain   *string    `json:"wordpress_domain"`
+	APIMode           *string    `json:"api_mode"`
+	WordpressUsername *string    `json:"wordpress_username"`
+	AppPassword       *string    `json:"app_password"`
+	Categories        []string   `json:"categories"`
+	BusinessName      *string    `json:"business_name"`
+	CallToActionType  *string    `json:"call_to_action_type"`
+	CallToActionURL   *string    `json:"call_to_action_url"`
+}
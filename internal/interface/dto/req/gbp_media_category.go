@@ -0,0 +1,6 @@
+package req
+
+type GbpMediaCategoryRule struct {
+	Keyword  string `json:"keyword"`
+	Category string `json:"category"`
+}
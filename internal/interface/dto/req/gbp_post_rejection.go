@@ -0,0 +1,8 @@
+package req
+
+type GetGbpPostRejections struct {
+	Limit      *int    `query:"limit"`
+	Offset     *int    `query:"offset"`
+	CustomerID *int    `query:"customer_id"`
+	Reason     *string `query:"reason"`
+}
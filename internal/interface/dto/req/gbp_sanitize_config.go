@@ -0,0 +1,8 @@
+package req
+
+// GbpSanitizeConfig はLocal Postの本文から除去するルール。
+// Rules は url / phone / address / hashtag、Patterns は任意の正規表現。
+type GbpSanitizeConfig struct {
+	Rules    []string `json:"rules"`
+	Patterns []string `json:"patterns"`
+}
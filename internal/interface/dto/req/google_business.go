@@ -0,0 +1,14 @@
+package req
+
+type GetGoogleBusinessInsights struct {
+	// From, To は "2006-01-02" 形式。未指定の場合は当月
+	From *string `query:"from"`
+	To   *string `query:"to"`
+}
+
+type UpdateGoogleBusinessRetention struct {
+	// PhotoRetentionCount は最新何件の写真を残すか（0は無制限）
+	PhotoRetentionCount *int `json:"photo_retention_count"`
+	// LocalPostRetentionDays は何日経過したLocal Postを削除するか（0は無期限）
+	LocalPostRetentionDays *int `json:"local_post_retention_days"`
+}
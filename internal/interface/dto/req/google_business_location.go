@@ -0,0 +1,38 @@
+package req
+
+type GoogleBusinessTimePeriod struct {
+	OpenDay   string `json:"open_day"`
+	OpenTime  string `json:"open_time"`
+	CloseDay  string `json:"close_day"`
+	CloseTime string `json:"close_time"`
+}
+
+type GoogleBusinessSpecialHours struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+	Closed    bool   `json:"closed"`
+}
+
+type GoogleBusinessAttribute struct {
+	Name   string   `json:"name"`
+	Values []any    `json:"values"`
+	URIs   []string `json:"uris"`
+}
+
+// UpdateGoogleBusinessLocation は未指定（null）の項目は更新しない
+type UpdateGoogleBusinessLocation struct {
+	Description     *string                       `json:"description"`
+	PrimaryPhone    *string                       `json:"primary_phone"`
+	WebsiteURI      *string                       `json:"website_uri"`
+	RegularHours    *[]GoogleBusinessTimePeriod   `json:"regular_hours"`
+	SpecialHours    *[]GoogleBusinessSpecialHours `json:"special_hours"`
+	AddSpecialHours []GoogleBusinessSpecialHours  `json:"add_special_hours"`
+	Attributes      []GoogleBusinessAttribute     `json:"attributes"`
+}
+
+type GetGoogleBusinessLocationChanges struct {
+	Limit  *int `query:"limit"`
+	Offset *int `query:"offset"`
+}
@@ -0,0 +1,7 @@
+package req
+
+type GoogleOAuthCallback struct {
+	State string `query:"state"`
+	Code  string `query:"code"`
+	Error string `query:"error"`
+}
@@ -0,0 +1,8 @@
+package req
+
+type GetGooglePosts struct {
+	Limit    *int    `query:"limit"`
+	Offset   *int    `query:"offset"`
+	PostType *string `query:"post_type"`
+	Deleted  *bool   `query:"deleted"`
+}
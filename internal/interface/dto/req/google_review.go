@@ -0,0 +1,13 @@
+package req
+
+type GetGoogleReview struct {
+	Limit         *int    `query:"limit"`
+	Offset        *int    `query:"offset"`
+	BusinessName  *string `query:"business_name"`
+	MaxStarRating *int    `query:"max_star_rating"`
+	Unreplied     *bool   `query:"unreplied"`
+}
+
+type GoogleReviewReply struct {
+	Comment string `json:"comment"`
+}
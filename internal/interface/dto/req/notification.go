@@ -0,0 +1,32 @@
+package req
+
+type GetNotificationRule struct {
+	Limit   *int    `query:"limit"`
+	Offset  *int    `query:"offset"`
+	Name    *string `query:"name"`
+	Channel *string `query:"channel"`
+	Status  *int    `query:"status"`
+}
+
+// CreateNotificationRule は event_types を省略すると全ての通知を、account_type / account_id を指定するとその連携の通知だけを送る。
+type CreateNotificationRule struct {
+	Name        string   `json:"name"`
+	EventTypes  []string `json:"event_types"`
+	AccountType string   `json:"account_type"`
+	AccountID   int      `json:"account_id"`
+	Channel     string   `json:"channel"`
+	Destination string   `json:"destination"`
+	Mention     string   `json:"mention"`
+	Status      int      `json:"status"`
+}
+
+type UpdateNotificationRule struct {
+	Name        *string  `json:"name"`
+	EventTypes  []string `json:"event_types"`
+	AccountType *string  `json:"account_type"`
+	AccountID   *int     `json:"account_id"`
+	Channel     *string  `json:"channel"`
+	Destination *string  `json:"destination"`
+	Mention     *string  `json:"mention"`
+	Status      *int     `json:"status"`
+}
@@ -0,0 +1,7 @@
+package req
+
+// SyncOptions は同期のエンドポイントに共通のクエリパラメータ。
+// DryRun が true の場合は何も投稿・保存せずに、投稿される内容を返す。
+type SyncOptions struct {
+	DryRun bool `query:"dry_run"`
+}
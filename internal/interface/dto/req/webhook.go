@@ -0,0 +1,58 @@
+package req
+
+type GetWebhookSubscription struct {
+	Limit  *int    `query:"limit"`
+	Offset *int    `query:"offset"`
+	Name   *string `query:"name"`
+	Status *int    `query:"status"`
+}
+
+// CreateWebhookSubscription は account_type / account_id を指定すると、その連携のイベントだけを送る。
+// secret を省略した場合は自動で作成する。
+type CreateWebhookSubscription struct {
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret"`
+	EventTypes  []string `json:"event_types"`
+	AccountType string   `json:"account_type"`
+	AccountID   int      `json:"account_id"`
+	Status      int      `json:"status"`
+}
+
+type UpdateWebhookSubscription struct {
+	Name        *string  `json:"name"`
+	URL         *string  `json:"url"`
+	Secret      *string  `json:"secret"`
+	EventTypes  []string `json:"event_types"`
+	AccountType *string  `json:"account_type"`
+	AccountID   *int     `json:"account_id"`
+	Status      *int     `json:"status"`
+	// RotateSecret が true の場合はシークレットを作り直す
+	RotateSecret bool `json:"rotate_secret"`
+}
+
+type GetWebhookEvent struct {
+	Limit       *int    `query:"limit"`
+	Offset      *int    `query:"offset"`
+	EventType   *string `query:"event_type"`
+	AccountType *string `query:"account_type"`
+	AccountID   *int    `query:"account_id"`
+}
+
+type GetWebhookEventDetail struct {
+	Limit  *int `query:"limit"`
+	Offset *int `query:"offset"`
+}
+
+type GetWebhookDelivery struct {
+	Limit          *int    `query:"limit"`
+	Offset         *int    `query:"offset"`
+	EventID        *int    `query:"event_id"`
+	SubscriptionID *int    `query:"subscription_id"`
+	Status         *string `query:"status"`
+}
+
+// ReplayWebhookEvent は subscription_id を省略した場合、現在そのイベントを購読している送信先すべてに再送する。
+type ReplayWebhookEvent struct {
+	SubscriptionID *int `json:"subscription_id"`
+}
@@ -15,10 +15,16 @@ type GetWordpressGbpDetail struct {
 }
 
 type WordpressGbp struct {
-	Name            string    `json:"name"`
-	WordpressDomain string    `json:"wordpress_domain"`
-	BusinessName    string    `json:"business_name"`
-	Memo            string    `json:"memo"`
-	StartDate       time.Time `json:"start_date"`
-	Status          int       `json:"status"`
+	Name               string                 `json:"name"`
+	WordpressDomain    string                 `json:"wordpress_domain"`
+	BusinessName       string                 `json:"business_name"`
+	Memo               string                 `json:"memo"`
+	CallToActionType   string                 `json:"call_to_action_type"`
+	CallToActionURL    string                 `json:"call_to_action_url"`
+	MediaCategory      string                 `json:"media_category"`
+	MediaCategoryRules []GbpMediaCategoryRule `json:"media_category_rules"`
+	// SanitizeConfig が null の場合はデフォルトのルールを使う
+	SanitizeConfig *GbpSanitizeConfig `json:"sanitize_config"`
+	StartDate      time.Time          `json:"start_date"`
+	Status         int                `json:"status"`
 }
@@ -28,6 +28,12 @@ type CreateWordpressInstagram struct {
 	Status          int       `json:"status"`
 	DeleteHash      bool      `json:"delete_hash"`
 	Categories      []string  `json:"categories"`
+	// APIMode は rodut（プラグイン、デフォルト）または wp_v2（標準REST API + アプリケーションパスワード）
+	APIMode           string `json:"api_mode"`
+	WordpressUsername string `json:"wordpress_username"`
+	AppPassword       string `json:"app_password"`
+	// Template は記事本文のテンプレート。{{content}} は必須で、{{caption}} {{permalink}} {{date}} も使える
+	Template string `json:"template"`
 }
 
 type UpdateWordpressInstagram struct {
@@ -39,4 +45,10 @@ type UpdateWordpressInstagram struct {
 	Status      *int       `json:"status"`
 	DeleteHash  *bool      `json:"delete_hash"`
 	Categories  []string   `json:"categories"`
+	// APIMode は rodut（プラグイン）または wp_v2（標準REST API + アプリケーションパスワード）
+	APIMode           *string `json:"api_mode"`
+	WordpressUsername *string `json:"wordpress_username"`
+	AppPassword       *string `json:"app_password"`
+	// Template は記事本文のテンプレート。{{content}} は必須で、{{caption}} {{permalink}} {{date}} も使える
+	Template *string `json:"template"`
 }
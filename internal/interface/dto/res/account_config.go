@@ -0,0 +1,17 @@
+package res
+
+type AccountConfigImportRow struct {
+	// Row はヘッダーを除いた1始まりの行番号（JSONの場合は配列の1始まりの番号）
+	Row   int    `json:"row"`
+	OK    bool   `json:"ok"`
+	ID    int    `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type AccountConfigImport struct {
+	DryRun  bool                     `json:"dry_run"`
+	OK      bool                     `json:"ok"`
+	Total   int                      `json:"total"`
+	Created int                      `json:"created"`
+	Rows    []AccountConfigImportRow `json:"rows"`
+}
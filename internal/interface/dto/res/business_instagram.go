@@ -3,18 +3,23 @@ package res
 import "time"
 
 type BusinessInstagram struct {
-	ID            int       `json:"id"`
-	Name          string    `json:"name"`
-	BusinessName  string    `json:"business_name"`
-	BusinessTitle string    `json:"business_title"`
-	InstagramID   string    `json:"instagram_id"`
-	InstagramName string    `json:"instagram_name"`
-	Memo          string    `json:"memo"`
-	MapsURL       string    `json:"maps_url"`
-	StartDate     time.Time `json:"start_date"`
-	Status        int       `json:"status"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID                 int                    `json:"id"`
+	Name               string                 `json:"name"`
+	BusinessName       string                 `json:"business_name"`
+	BusinessTitle      string                 `json:"business_title"`
+	InstagramID        string                 `json:"instagram_id"`
+	InstagramName      string                 `json:"instagram_name"`
+	Memo               string                 `json:"memo"`
+	MapsURL            string                 `json:"maps_url"`
+	CallToActionType   string                 `json:"call_to_action_type"`
+	CallToActionURL    string                 `json:"call_to_action_url"`
+	MediaCategory      string                 `json:"media_category"`
+	MediaCategoryRules []GbpMediaCategoryRule `json:"media_category_rules"`
+	SanitizeConfig     *GbpSanitizeConfig     `json:"sanitize_config"`
+	StartDate          time.Time              `json:"start_date"`
+	Status             int                    `json:"status"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
 }
 
 type BusinessInstagramList struct {
@@ -23,25 +28,23 @@ type BusinessInstagramList struct {
 }
 
 type BusinessInstagramDetail struct {
-	ID                int       `json:"id"`
-	Name              string    `json:"name"`
-	BusinessName      string    `json:"business_name"`
-	BusinessTitle     string    `json:"business_title"`
-	InstagramID       string    `json:"instagram_id"`
-	InstagramName     string    `json:"instagram_name"`
-	Memo              string    `json:"memo"`
-	MapsURL           string    `json:"maps_url"`
-	StartDate         time.Time `json:"start_date"`
-	Status            int       `json:"status"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
-	GooglePhotosCount int64     `json:"google_photos_count"`
-	GooglePostsCount  int64     `json:"google_posts"`
-}
-
-type GooglePost struct {
-	GoogleURL    string    `json:"google_url"`
-	InstagramURL string    `json:"instagram_url"`
-	CreatedAt    time.Time `json:"created_at"`
-	PostType     string    `json:"post_type"`
+	ID                 int                    `json:"id"`
+	Name               string                 `json:"name"`
+	BusinessName       string                 `json:"business_name"`
+	BusinessTitle      string                 `json:"business_title"`
+	InstagramID        string                 `json:"instagram_id"`
+	InstagramName      string                 `json:"instagram_name"`
+	Memo               string                 `json:"memo"`
+	MapsURL            string                 `json:"maps_url"`
+	CallToActionType   string                 `json:"call_to_action_type"`
+	CallToActionURL    string                 `json:"call_to_action_url"`
+	MediaCategory      string                 `json:"media_category"`
+	MediaCategoryRules []GbpMediaCategoryRule `json:"media_category_rules"`
+	SanitizeConfig     *GbpSanitizeConfig     `json:"sanitize_config"`
+	StartDate          time.Time              `json:"start_date"`
+	Status             int                    `json:"status"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	GooglePhotosCount  int64                  `json:"google_photos_count"`
+	GooglePostsCount   int64                  `json:"google_posts"`
 }
@@ -0,0 +1,34 @@
+package res
+
+import "time"
+
+type Digest struct {
+	Period           string                  `json:"period"`
+	From             time.Time               `json:"from"`
+	To               time.Time               `json:"to"`
+	Published        int                     `json:"published"`
+	Skipped          int                     `json:"skipped"`
+	Failed           int                     `json:"failed"`
+	Accounts         []DigestAccountSummary  `json:"accounts"`
+	InactiveDays     int                     `json:"inactive_days"`
+	InactiveAccounts []DigestInactiveAccount `json:"inactive_accounts"`
+	TokenExpiresAt   *time.Time              `json:"token_expires_at"`
+	TokenExpiring    bool                    `json:"token_expiring"`
+}
+
+type DigestAccountSummary struct {
+	AccountType string `json:"account_type"`
+	AccountID   int    `json:"account_id"`
+	AccountName string `json:"account_name"`
+	Published   int    `json:"published"`
+	Skipped     int    `json:"skipped"`
+	Failed      int    `json:"failed"`
+	LastError   string `json:"last_error"`
+}
+
+type DigestInactiveAccount struct {
+	AccountType     string     `json:"account_type"`
+	AccountID       int        `json:"account_id"`
+	AccountName     string     `json:"account_name"`
+	LastPublishedAt *time.Time `json:"last_published_at"`
+}
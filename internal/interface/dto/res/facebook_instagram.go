@@ -0,0 +1,52 @@
+package res
+
+import "time"
+
+type FacebookInstagramList struct {
+	FacebookInstagramList []FacebookInstagram `json:"facebook_instagram_list"`
+	Paginate
+}
+
+type FacebookInstagramCount struct {
+	Count int64 `json:"count"`
+}
+
+type FacebookInstagram struct {
+	ID               int       `json:"id"`
+	Name             string    `json:"name"`
+	Memo             string    `json:"memo"`
+	InstagramID      string    `json:"instagram_id"`
+	InstagramName    string    `json:"instagram_name"`
+	FacebookPageID   string    `json:"facebook_page_id"`
+	FacebookPageName string    `json:"facebook_page_name"`
+	StartDate        time.Time `json:"start_date"`
+	Status           int       `json:"status"`
+	DeleteHash       bool      `json:"delete_hash"`
+}
+
+type FacebookInstagramDetail struct {
+	ID               int           `json:"id"`
+	Name             string        `json:"name"`
+	Memo             string        `json:"memo"`
+	InstagramID      string        `json:"instagram_id"`
+	InstagramName    string        `json:"instagram_name"`
+	FacebookPageID   string        `json:"facebook_page_id"`
+	FacebookPageName string        `json:"facebook_page_name"`
+	StartDate        time.Time     `json:"start_date"`
+	Status           int           `json:"status"`
+	DeleteHash       bool          `json:"delete_hash"`
+	Posts            FacebookPosts `json:"posts"`
+}
+
+type FacebookPosts struct {
+	Posts []FacebookPost `json:"posts"`
+	Paginate
+}
+
+type FacebookPost struct {
+	ID           int       `json:"id"`
+	MediaID      string    `json:"media_id"`
+	InstagramURL string    `json:"instagram_url"`
+	FacebookURL  string    `json:"facebook_url"`
+	CreatedAt    time.Time `json:"created_at"`
+}
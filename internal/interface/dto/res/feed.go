@@ -0,0 +1,61 @@
+package res
+
+import "time"
+
+type FeedList struct {
+	FeedList []Feed `json:"feed_list"`
+	Paginate
+}
+
+type Feed struct {
+	ID                 int       `json:"id"`
+	Name               string    `json:"name"`
+	Memo               string    `json:"memo"`
+	FeedURL            string    `json:"feed_url"`
+	FeedTitle          string    `json:"feed_title"`
+	StartDate          time.Time `json:"start_date"`
+	Status             int       `json:"status"`
+	WordpressDomain    string    `json:"wordpress_domain"`
+	WordpressSiteTitle string    `json:"wordpress_site_title"`
+	APIMode            string    `json:"api_mode"`
+	WordpressUsername  string    `json:"wordpress_username"`
+	Categories         []string  `json:"categories"`
+	BusinessName       string    `json:"business_name"`
+	BusinessTitle      string    `json:"business_title"`
+	CallToActionType   string    `json:"call_to_action_type"`
+	CallToActionURL    string    `json:"call_to_action_url"`
+}
+
+type FeedDetail struct {
+	ID                 int       `json:"id"`
+	Name               string    `json:"name"`
+	Memo               string    `json:"memo"`
+	FeedURL            string    `json:"feed_url"`
+	FeedTitle          string    `json:"feed_title"`
+	StartDate          time.Time `json:"start_date"`
+	Status             int       `json:"status"`
+	WordpressDomain    string    `json:"wordpress_domain"`
+	WordpressSiteTitle string    `json:"wordpress_site_title"`
+	APIMode            string    `json:"api_mode"`
+	WordpressUsername  string    `json:"wordpress_username"`
+	Categories         []string  `json:"categories"`
+	BusinessName       string    `json:"business_name"`
+	BusinessTitle      string    `json:"business_title"`
+	CallToActionType   string    `json:"call_to_action_type"`
+	CallToActionURL    string    `json:"call_to_action_url"`
+	Posts              FeedPosts `json:"posts"`
+}
+
+type FeedPosts struct {
+	Posts []FeedPost `json:"posts"`
+	Paginate
+}
+
+type FeedPost struct {
+	ID          int       `json:"id"`
+	Destination string    `json:"destination"`
+	GUID        string    `json:"guid"`
+	Link        string    `json:"link"`
+	URL         string    `json:"url"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,6 @@
+package res
+
+type GbpMediaCategoryRule struct {
+	Keyword  string `json:"keyword"`
+	Category string `json:"category"`
+}
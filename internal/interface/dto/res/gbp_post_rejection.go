@@ -0,0 +1,22 @@
+package res
+
+import "time"
+
+type GbpPostRejection struct {
+	ID           int       `json:"id"`
+	CustomerID   int       `json:"customer_id"`
+	MediaID      string    `json:"media_id"`
+	BusinessName string    `json:"business_name"`
+	Reason       string    `json:"reason"`
+	Field        string    `json:"field"`
+	Message      string    `json:"message"`
+	Summary      string    `json:"summary"`
+	Count        int       `json:"count"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type GbpPostRejectionList struct {
+	GbpPostRejectionList []GbpPostRejection `json:"gbp_post_rejection_list"`
+	Paginate
+}
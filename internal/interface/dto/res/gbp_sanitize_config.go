@@ -0,0 +1,6 @@
+package res
+
+type GbpSanitizeConfig struct {
+	Rules    []string `json:"rules"`
+	Patterns []string `json:"patterns"`
+}
@@ -0,0 +1,16 @@
+package res
+
+import "time"
+
+type GoogleAccountList struct {
+	GoogleAccountList []GoogleAccount `json:"google_account_list"`
+}
+
+type GoogleAccount struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	AccountName string    `json:"account_name"`
+	Type        string    `json:"type"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -8,8 +8,45 @@ type GoogleBusinessList struct {
 }
 
 type GoogleBusiness struct {
-	ID        int       `json:"id"`
-	Name      string    `json:"name"`
-	Title     string    `json:"title"`
-	CreatedAt time.Time `json:"created_at"`
-}
\ No newline at end of file
+	ID                     int       `json:"id"`
+	GoogleAccountID        int       `json:"google_account_id"`
+	Name                   string    `json:"name"`
+	Title                  string    `json:"title"`
+	PhotoRetentionCount    int       `json:"photo_retention_count"`
+	LocalPostRetentionDays int       `json:"local_post_retention_days"`
+	CreatedAt              time.Time `json:"created_at"`
+}
+type GoogleBusinessMetricSummary struct {
+	Views             int64 `json:"views"`
+	Searches          int64 `json:"searches"`
+	Calls             int64 `json:"calls"`
+	WebsiteClicks     int64 `json:"website_clicks"`
+	DirectionRequests int64 `json:"direction_requests"`
+	Conversations     int64 `json:"conversations"`
+	Bookings          int64 `json:"bookings"`
+}
+
+type GoogleBusinessInsightsDaily struct {
+	Date    string           `json:"date"`
+	Metrics map[string]int64 `json:"metrics"`
+}
+
+type GoogleBusinessInsightsMonthly struct {
+	Month             string                      `json:"month"`
+	Summary           GoogleBusinessMetricSummary `json:"summary"`
+	GooglePhotosCount int64                       `json:"google_photos_count"`
+	GooglePostsCount  int64                       `json:"google_posts_count"`
+}
+
+type GoogleBusinessInsights struct {
+	ID                int                             `json:"id"`
+	Name              string                          `json:"name"`
+	Title             string                          `json:"title"`
+	From              string                          `json:"from"`
+	To                string                          `json:"to"`
+	Summary           GoogleBusinessMetricSummary     `json:"summary"`
+	GooglePhotosCount int64                           `json:"google_photos_count"`
+	GooglePostsCount  int64                           `json:"google_posts_count"`
+	Monthly           []GoogleBusinessInsightsMonthly `json:"monthly"`
+	Daily             []GoogleBusinessInsightsDaily   `json:"daily"`
+}
@@ -0,0 +1,65 @@
+package res
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type GoogleBusinessTimePeriod struct {
+	OpenDay   string `json:"open_day"`
+	OpenTime  string `json:"open_time"`
+	CloseDay  string `json:"close_day"`
+	CloseTime string `json:"close_time"`
+}
+
+type GoogleBusinessSpecialHours struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+	Closed    bool   `json:"closed"`
+}
+
+type GoogleBusinessAttribute struct {
+	Name   string   `json:"name"`
+	Values []any    `json:"values"`
+	URIs   []string `json:"uris"`
+}
+
+type GoogleBusinessLocation struct {
+	ID               int                          `json:"id"`
+	Name             string                       `json:"name"`
+	Title            string                       `json:"title"`
+	Description      string                       `json:"description"`
+	PrimaryPhone     string                       `json:"primary_phone"`
+	AdditionalPhones []string                     `json:"additional_phones"`
+	WebsiteURI       string                       `json:"website_uri"`
+	RegularHours     []GoogleBusinessTimePeriod   `json:"regular_hours"`
+	SpecialHours     []GoogleBusinessSpecialHours `json:"special_hours"`
+	Attributes       []GoogleBusinessAttribute    `json:"attributes"`
+}
+
+type GoogleBusinessLocationDiff struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before" swaggertype:"object"`
+	After  json.RawMessage `json:"after" swaggertype:"object"`
+}
+
+type GoogleBusinessLocationPreview struct {
+	ID      int                          `json:"id"`
+	Name    string                       `json:"name"`
+	Changes []GoogleBusinessLocationDiff `json:"changes"`
+}
+
+type GoogleBusinessLocationChange struct {
+	ID        int             `json:"id"`
+	Field     string          `json:"field"`
+	Before    json.RawMessage `json:"before" swaggertype:"object"`
+	After     json.RawMessage `json:"after" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type GoogleBusinessLocationChangeList struct {
+	GoogleBusinessLocationChangeList []GoogleBusinessLocationChange `json:"google_business_location_change_list"`
+	Paginate
+}
@@ -0,0 +1,25 @@
+package res
+
+import "time"
+
+type GooglePost struct {
+	ID           int        `json:"id"`
+	CustomerID   int        `json:"customer_id"`
+	Source       string     `json:"source"`
+	Destination  string     `json:"destination"`
+	Status       string     `json:"status"`
+	PostType     string     `json:"post_type"`
+	InstagramURL string     `json:"instagram_url"`
+	MediaID      string     `json:"media_id"`
+	Name         string     `json:"name"`
+	GoogleURL    string     `json:"google_url"`
+	CreateTime   string     `json:"create_time"`
+	PublishedAt  *time.Time `json:"published_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type GooglePostList struct {
+	GooglePostList []GooglePost `json:"google_post_list"`
+	Paginate
+}
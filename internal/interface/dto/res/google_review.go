@@ -0,0 +1,22 @@
+package res
+
+import "time"
+
+type GoogleReview struct {
+	ID             int        `json:"id"`
+	BusinessName   string     `json:"business_name"`
+	ReviewName     string     `json:"review_name"`
+	ReviewerName   string     `json:"reviewer_name"`
+	StarRating     int        `json:"star_rating"`
+	Comment        string     `json:"comment"`
+	ReplyComment   string     `json:"reply_comment"`
+	ReplyUpdatedAt *time.Time `json:"reply_updated_at"`
+	ReviewedAt     time.Time  `json:"reviewed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+type GoogleReviewList struct {
+	GoogleReviewList []GoogleReview `json:"google_review_list"`
+	Paginate
+}
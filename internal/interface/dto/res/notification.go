@@ -0,0 +1,22 @@
+package res
+
+import "time"
+
+type NotificationRuleList struct {
+	NotificationRuleList []NotificationRule `json:"notification_rule_list"`
+	Paginate
+}
+
+type NotificationRule struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	EventTypes  []string  `json:"event_types"`
+	AccountType string    `json:"account_type"`
+	AccountID   int       `json:"account_id"`
+	Channel     string    `json:"channel"`
+	Destination string    `json:"destination"`
+	Mention     string    `json:"mention"`
+	Status      int       `json:"status"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
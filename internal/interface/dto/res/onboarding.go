@@ -0,0 +1,14 @@
+package res
+
+type OnboardingCheck struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Action string `json:"action,omitempty"`
+}
+
+type OnboardingCheckList struct {
+	OK     bool              `json:"ok"`
+	Checks []OnboardingCheck `json:"checks"`
+}
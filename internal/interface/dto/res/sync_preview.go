@@ -0,0 +1,22 @@
+package res
+
+type SyncPreview struct {
+	AccountType      string   `json:"account_type"`
+	AccountID        int      `json:"account_id"`
+	AccountName      string   `json:"account_name"`
+	Destination      string   `json:"destination"`
+	PostKey          string   `json:"post_key"`
+	SourceURL        string   `json:"source_url"`
+	Title            string   `json:"title,omitempty"`
+	Content          string   `json:"content,omitempty"`
+	Summary          string   `json:"summary,omitempty"`
+	TopicType        string   `json:"topic_type,omitempty"`
+	CallToActionType string   `json:"call_to_action_type,omitempty"`
+	CallToActionURL  string   `json:"call_to_action_url,omitempty"`
+	MediaURLs        []string `json:"media_urls"`
+	Error            string   `json:"error,omitempty"`
+}
+
+type SyncPreviewList struct {
+	SyncPreviewList []SyncPreview `json:"sync_preview_list"`
+}
@@ -0,0 +1,73 @@
+package res
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookSubscriptionList struct {
+	WebhookSubscriptionList []WebhookSubscription `json:"webhook_subscription_list"`
+	Paginate
+}
+
+// WebhookSubscription の secret は作成時とシークレットを変更した時だけ返す。
+type WebhookSubscription struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"`
+	EventTypes  []string  `json:"event_types"`
+	AccountType string    `json:"account_type"`
+	AccountID   int       `json:"account_id"`
+	Status      int       `json:"status"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type WebhookEventList struct {
+	WebhookEventList []WebhookEvent `json:"webhook_event_list"`
+	Paginate
+}
+
+type WebhookEvent struct {
+	ID          int             `json:"id"`
+	EventID     string          `json:"event_id"`
+	EventType   string          `json:"event_type"`
+	AccountType string          `json:"account_type"`
+	AccountID   int             `json:"account_id"`
+	AccountName string          `json:"account_name"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+type WebhookEventDetail struct {
+	ID          int                 `json:"id"`
+	EventID     string              `json:"event_id"`
+	EventType   string              `json:"event_type"`
+	AccountType string              `json:"account_type"`
+	AccountID   int                 `json:"account_id"`
+	AccountName string              `json:"account_name"`
+	Payload     json.RawMessage     `json:"payload"`
+	CreatedAt   time.Time           `json:"created_at"`
+	Deliveries  WebhookDeliveryList `json:"deliveries"`
+}
+
+type WebhookDeliveryList struct {
+	WebhookDeliveryList []WebhookDelivery `json:"webhook_delivery_list"`
+	Paginate
+}
+
+type WebhookDelivery struct {
+	ID             int        `json:"id"`
+	EventID        int        `json:"event_id"`
+	SubscriptionID int        `json:"subscription_id"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status"`
+	ResponseBody   string     `json:"response_body"`
+	Error          string     `json:"error"`
+	NextRetryAt    *time.Time `json:"next_retry_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -3,17 +3,22 @@ package res
 import "time"
 
 type WordpressGbp struct {
-	ID              int       `json:"id"`
-	Name            string    `json:"name"`
-	WordpressDomain string    `json:"wordpress_domain"`
-	BusinessName    string    `json:"business_name"`
-	BusinessTitle   string    `json:"business_title"`
-	Memo            string    `json:"memo"`
-	MapsURL         string    `json:"maps_url"`
-	StartDate       time.Time `json:"start_date"`
-	Status          int       `json:"status"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID                 int                    `json:"id"`
+	Name               string                 `json:"name"`
+	WordpressDomain    string                 `json:"wordpress_domain"`
+	BusinessName       string                 `json:"business_name"`
+	BusinessTitle      string                 `json:"business_title"`
+	Memo               string                 `json:"memo"`
+	MapsURL            string                 `json:"maps_url"`
+	CallToActionType   string                 `json:"call_to_action_type"`
+	CallToActionURL    string                 `json:"call_to_action_url"`
+	MediaCategory      string                 `json:"media_category"`
+	MediaCategoryRules []GbpMediaCategoryRule `json:"media_category_rules"`
+	SanitizeConfig     *GbpSanitizeConfig     `json:"sanitize_config"`
+	StartDate          time.Time              `json:"start_date"`
+	Status             int                    `json:"status"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
 }
 
 type WordpressGbpList struct {
@@ -22,17 +27,22 @@ type WordpressGbpList struct {
 }
 
 type WordpressGbpDetail struct {
-	ID                int       `json:"id"`
-	Name              string    `json:"name"`
-	WordpressDomain   string    `json:"wordpress_domain"`
-	BusinessName      string    `json:"business_name"`
-	BusinessTitle     string    `json:"business_title"`
-	Memo              string    `json:"memo"`
-	MapsURL           string    `json:"maps_url"`
-	StartDate         time.Time `json:"start_date"`
-	Status            int       `json:"status"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
-	GooglePhotosCount int64     `json:"google_photos_count"`
-	GooglePostsCount  int64     `json:"google_posts_count"`
+	ID                 int                    `json:"id"`
+	Name               string                 `json:"name"`
+	WordpressDomain    string                 `json:"wordpress_domain"`
+	BusinessName       string                 `json:"business_name"`
+	BusinessTitle      string                 `json:"business_title"`
+	Memo               string                 `json:"memo"`
+	MapsURL            string                 `json:"maps_url"`
+	CallToActionType   string                 `json:"call_to_action_type"`
+	CallToActionURL    string                 `json:"call_to_action_url"`
+	MediaCategory      string                 `json:"media_category"`
+	MediaCategoryRules []GbpMediaCategoryRule `json:"media_category_rules"`
+	SanitizeConfig     *GbpSanitizeConfig     `json:"sanitize_config"`
+	StartDate          time.Time              `json:"start_date"`
+	Status             int                    `json:"status"`
+	CreatedAt          time.Time              `json:"created_at"`
+	UpdatedAt          time.Time              `json:"updated_at"`
+	GooglePhotosCount  int64                  `json:"google_photos_count"`
+	GooglePostsCount   int64                  `json:"google_posts_count"`
 }
@@ -12,32 +12,42 @@ type WordpressInstagramCount struct {
 }
 
 type WordpressInstagram struct {
-	ID                 int       `json:"id"`
-	Name               string    `json:"name"`
-	WordpressDomain    string    `json:"wordpress_domain"`
-	WordpressSiteTitle string    `json:"wordpress_site_title"`
-	InstagramID        string    `json:"instagram_id"`
-	InstagramName      string    `json:"instagram_name"`
-	Memo               string    `json:"memo"`
-	StartDate          time.Time `json:"start_date"`
-	Status             int       `json:"status"`
-	DeleteHash         bool      `json:"delete_hash"`
-	Categories         []string  `json:"categories"`
+	ID                 int        `json:"id"`
+	Name               string     `json:"name"`
+	WordpressDomain    string     `json:"wordpress_domain"`
+	WordpressSiteTitle string     `json:"wordpress_site_title"`
+	InstagramID        string     `json:"instagram_id"`
+	InstagramName      string     `json:"instagram_name"`
+	Memo               string     `json:"memo"`
+	StartDate          time.Time  `json:"start_date"`
+	Status             int        `json:"status"`
+	DeleteHash         bool       `json:"delete_hash"`
+	Categories         []string   `json:"categories"`
+	APIMode            string     `json:"api_mode"`
+	WordpressUsername  string     `json:"wordpress_username"`
+	Template           string     `json:"template"`
+	LastSyncedAt       *time.Time `json:"last_synced_at"`
+	LastSyncError      string     `json:"last_sync_error"`
 }
 
 type WordpressInstagramDetail struct {
-	ID                 int       `json:"id"`
-	Name               string    `json:"name"`
-	WordpressDomain    string    `json:"wordpress_domain"`
-	WordpressSiteTitle string    `json:"wordpress_site_title"`
-	InstagramID        string    `json:"instagram_id"`
-	InstagramName      string    `json:"instagram_name"`
-	Memo               string    `json:"memo"`
-	StartDate          time.Time `json:"start_date"`
-	Status             int       `json:"status"`
-	DeleteHash         bool      `json:"delete_hash"`
-	Posts              Posts     `json:"posts"`
-	Categories         []string  `json:"categories"`
+	ID                 int        `json:"id"`
+	Name               string     `json:"name"`
+	WordpressDomain    string     `json:"wordpress_domain"`
+	WordpressSiteTitle string     `json:"wordpress_site_title"`
+	InstagramID        string     `json:"instagram_id"`
+	InstagramName      string     `json:"instagram_name"`
+	Memo               string     `json:"memo"`
+	StartDate          time.Time  `json:"start_date"`
+	Status             int        `json:"status"`
+	DeleteHash         bool       `json:"delete_hash"`
+	Posts              Posts      `json:"posts"`
+	Categories         []string   `json:"categories"`
+	APIMode            string     `json:"api_mode"`
+	WordpressUsername  string     `json:"wordpress_username"`
+	Template           string     `json:"template"`
+	LastSyncedAt       *time.Time `json:"last_synced_at"`
+	LastSyncError      string     `json:"last_sync_error"`
 }
 
 type Posts struct {
@@ -46,7 +56,10 @@ type Posts struct {
 }
 
 type Post struct {
-	WordpressUrl string    `json:"wordpress_url"`
-	InstagramUrl string    `json:"instagram_url"`
-	CreatedAt    time.Time `json:"created_at"`
+	MediaID      string     `json:"media_id"`
+	Status       string     `json:"status"`
+	WordpressUrl string     `json:"wordpress_url"`
+	InstagramUrl string     `json:"instagram_url"`
+	PostedAt     *time.Time `json:"posted_at"`
+	CreatedAt    time.Time  `json:"created_at"`
 }
@@ -1,13 +1,19 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/zuxt268/homing/internal/config"
 	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
 	"github.com/zuxt268/homing/internal/interface/dto/req"
 	"github.com/zuxt268/homing/internal/interface/dto/res"
 	_ "github.com/zuxt268/homing/internal/interface/dto/res"
@@ -15,11 +21,22 @@ import (
 )
 
 type APIHandler struct {
-	customerUsecase           usecase.CustomerUsecase
-	tokenUsecase              usecase.TokenUsecase
-	wordpressInstagramUsecase usecase.WordpressInstagramUsecase
-	businessInstagramUsecase  usecase.BusinessInstagramUsecase
-	wordpressGbpUsecase       usecase.WordpressGbpUsecase
+	customerUsecase               usecase.CustomerUsecase
+	tokenUsecase                  usecase.TokenUsecase
+	wordpressInstagramUsecase     usecase.WordpressInstagramUsecase
+	businessInstagramUsecase      usecase.BusinessInstagramUsecase
+	wordpressGbpUsecase           usecase.WordpressGbpUsecase
+	googleReviewUsecase           usecase.GoogleReviewUsecase
+	googleBusinessInsightUsecase  usecase.GoogleBusinessInsightUsecase
+	googleOAuthUsecase            usecase.GoogleOAuthUsecase
+	googleBusinessLocationUsecase usecase.GoogleBusinessLocationUsecase
+	googlePostUsecase             usecase.GooglePostUsecase
+	facebookInstagramUsecase      usecase.FacebookInstagramUsecase
+	feedUsecase                   usecase.FeedUsecase
+	webhookUsecase                usecase.WebhookUsecase
+	notificationUsecase           usecase.NotificationUsecase
+	digestUsecase                 usecase.DigestUsecase
+	slackInteractionUsecase       usecase.SlackInteractionUsecase
 }
 
 func NewAPIHandler(
@@ -28,13 +45,35 @@ func NewAPIHandler(
 	wordpressInstagramUsecase usecase.WordpressInstagramUsecase,
 	businessInstagramUsecase usecase.BusinessInstagramUsecase,
 	wordpressGbpUsecase usecase.WordpressGbpUsecase,
+	googleReviewUsecase usecase.GoogleReviewUsecase,
+	googleBusinessInsightUsecase usecase.GoogleBusinessInsightUsecase,
+	googleOAuthUsecase usecase.GoogleOAuthUsecase,
+	googleBusinessLocationUsecase usecase.GoogleBusinessLocationUsecase,
+	googlePostUsecase usecase.GooglePostUsecase,
+	facebookInstagramUsecase usecase.FacebookInstagramUsecase,
+	feedUsecase usecase.FeedUsecase,
+	webhookUsecase usecase.WebhookUsecase,
+	notificationUsecase usecase.NotificationUsecase,
+	digestUsecase usecase.DigestUsecase,
+	slackInteractionUsecase usecase.SlackInteractionUsecase,
 ) APIHandler {
 	return APIHandler{
-		customerUsecase:           customerUsecase,
-		tokenUsecase:              tokenUsecase,
-		wordpressInstagramUsecase: wordpressInstagramUsecase,
-		businessInstagramUsecase:  businessInstagramUsecase,
-		wordpressGbpUsecase:       wordpressGbpUsecase,
+		customerUsecase:               customerUsecase,
+		tokenUsecase:                  tokenUsecase,
+		wordpressInstagramUsecase:     wordpressInstagramUsecase,
+		businessInstagramUsecase:      businessInstagramUsecase,
+		wordpressGbpUsecase:           wordpressGbpUsecase,
+		googleReviewUsecase:           googleReviewUsecase,
+		googleBusinessInsightUsecase:  googleBusinessInsightUsecase,
+		googleOAuthUsecase:            googleOAuthUsecase,
+		googleBusinessLocationUsecase: googleBusinessLocationUsecase,
+		googlePostUsecase:             googlePostUsecase,
+		facebookInstagramUsecase:      facebookInstagramUsecase,
+		feedUsecase:                   feedUsecase,
+		webhookUsecase:                webhookUsecase,
+		notificationUsecase:           notificationUsecase,
+		digestUsecase:                 digestUsecase,
+		slackInteractionUsecase:       slackInteractionUsecase,
 	}
 }
 
@@ -44,10 +83,22 @@ func NewAPIHandler(
 // @Tags         sync
 // @Accept       json
 // @Produce      json
+// @Param        dry_run  query  bool  false  "trueの場合は何も投稿せず、投稿される内容（res.SyncPreviewList）を返す"
 // @Success      200  {string}  string  "全顧客同期完了"
 // @Failure      500  {string}  string  "内部サーバーエラー"
 // @Router       /api/sync/business-instagram [post]
 func (h *APIHandler) SyncAllGoogleBusinessInstagram(c echo.Context) error {
+	var params req.SyncOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if params.DryRun {
+		preview, err := h.customerUsecase.PreviewAllGoogleBusinessInstagram(c.Request().Context())
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
 	err := h.customerUsecase.SyncAllGoogleBusinessInstagram(c.Request().Context())
 	if err != nil {
 		return handleError(c, err)
@@ -61,6 +112,7 @@ func (h *APIHandler) SyncAllGoogleBusinessInstagram(c echo.Context) error {
 // @Tags         sync
 // @Accept       json
 // @Produce      json
+// @Param        dry_run  query  bool  false  "trueの場合は何も投稿せず、投稿される内容（res.SyncPreviewList）を返す"
 // @Success      200  {string}  string  "全顧客同期完了"
 // @Failure      500  {string}  string  "内部サーバーエラー"
 // @Router       /api/sync/business-instagram/{id} [post]
@@ -69,6 +121,17 @@ func (h *APIHandler) SyncOneGoogleBusinessInstagram(c echo.Context) error {
 	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
+	var params req.SyncOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if params.DryRun {
+		preview, err := h.customerUsecase.PreviewOneGoogleBusinessInstagram(c.Request().Context(), id)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
 	err := h.customerUsecase.SyncOneGoogleBusinessInstagram(c.Request().Context(), id)
 	if err != nil {
 		return handleError(c, err)
@@ -82,10 +145,22 @@ func (h *APIHandler) SyncOneGoogleBusinessInstagram(c echo.Context) error {
 // @Tags         sync
 // @Accept       json
 // @Produce      json
+// @Param        dry_run  query  bool  false  "trueの場合は何も投稿せず、投稿される内容（res.SyncPreviewList）を返す"
 // @Success      200  {string}  string  "全顧客同期完了"
 // @Failure      500  {string}  string  "内部サーバーエラー"
 // @Router       /api/sync/wordpress-instagram [post]
 func (h *APIHandler) SyncAllWordpressInstagram(c echo.Context) error {
+	var params req.SyncOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if params.DryRun {
+		preview, err := h.customerUsecase.PreviewAllWordpressInstagram(c.Request().Context())
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
 	err := h.customerUsecase.SyncAllWordpressInstagram(c.Request().Context())
 	if err != nil {
 		return handleError(c, err)
@@ -99,6 +174,7 @@ func (h *APIHandler) SyncAllWordpressInstagram(c echo.Context) error {
 // @Tags         sync
 // @Accept       json
 // @Produce      json
+// @Param        dry_run  query  bool  false  "trueの場合は何も投稿せず、投稿される内容（res.SyncPreviewList）を返す"
 // @Success      200  {string}  string  "全顧客同期完了"
 // @Failure      500  {string}  string  "内部サーバーエラー"
 // @Router       /api/sync/wordpress-instagram/{id} [post]
@@ -107,6 +183,17 @@ func (h *APIHandler) SyncOneWordpressInstagram(c echo.Context) error {
 	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
+	var params req.SyncOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if params.DryRun {
+		preview, err := h.customerUsecase.PreviewOneWordpressInstagram(c.Request().Context(), id)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
 	err := h.customerUsecase.SyncOneWordpressInstagram(c.Request().Context(), id)
 	if err != nil {
 		return handleError(c, err)
@@ -114,6 +201,134 @@ func (h *APIHandler) SyncOneWordpressInstagram(c echo.Context) error {
 	return c.JSON(http.StatusOK, "sync one")
 }
 
+// SyncAllFacebookInstagram godoc
+// @Summary      instagram => facebookにおける全顧客データ同期
+// @Description  全ての顧客のInstagramの投稿をFacebookページに投稿します
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        dry_run  query  bool  false  "trueの場合は何も投稿せず、投稿される内容（res.SyncPreviewList）を返す"
+// @Success      200  {string}  string  "全顧客同期完了"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/sync/facebook-instagram [post]
+func (h *APIHandler) SyncAllFacebookInstagram(c echo.Context) error {
+	var params req.SyncOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if params.DryRun {
+		preview, err := h.customerUsecase.PreviewAllFacebookInstagram(c.Request().Context())
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
+	err := h.customerUsecase.SyncAllFacebookInstagram(c.Request().Context())
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "sync all")
+}
+
+// SyncOneFacebookInstagram godoc
+// @Summary      instagram => facebookにおける顧客データ同期
+// @Description  指定した顧客のInstagramの投稿をFacebookページに投稿します
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Facebook Instagram ID"
+// @Param        dry_run  query  bool  false  "trueの場合は何も投稿せず、投稿される内容（res.SyncPreviewList）を返す"
+// @Success      200  {string}  string  "顧客同期完了"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/sync/facebook-instagram/{id} [post]
+func (h *APIHandler) SyncOneFacebookInstagram(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var params req.SyncOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if params.DryRun {
+		preview, err := h.customerUsecase.PreviewOneFacebookInstagram(c.Request().Context(), id)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
+	err := h.customerUsecase.SyncOneFacebookInstagram(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "sync one")
+}
+
+// SyncAllFeed godoc
+// @Summary      feed => wordpress/gbpにおける全フィード同期
+// @Description  全てのRSS/Atomフィードの新しい記事をWordPressとGBPに投稿します
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        dry_run  query  bool  false  "trueの場合は何も投稿せず、投稿される内容（res.SyncPreviewList）を返す"
+// @Success      200  {string}  string  "全フィード同期完了"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/sync/feed [post]
+func (h *APIHandler) SyncAllFeed(c echo.Context) error {
+	var params req.SyncOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if params.DryRun {
+		preview, err := h.customerUsecase.PreviewAllFeed(c.Request().Context())
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
+	err := h.customerUsecase.SyncAllFeed(c.Request().Context())
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "sync all")
+}
+
+// SyncOneFeed godoc
+// @Summary      feed => wordpress/gbpにおけるフィード同期
+// @Description  指定したRSS/Atomフィードの新しい記事をWordPressとGBPに投稿します
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Feed ID"
+// @Param        dry_run  query  bool  false  "trueの場合は何も投稿せず、投稿される内容（res.SyncPreviewList）を返す"
+// @Success      200  {string}  string  "フィード同期完了"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/sync/feed/{id} [post]
+func (h *APIHandler) SyncOneFeed(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var params req.SyncOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if params.DryRun {
+		preview, err := h.customerUsecase.PreviewOneFeed(c.Request().Context(), id)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
+	err := h.customerUsecase.SyncOneFeed(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "sync one")
+}
+
 // SaveToken godoc
 // @Summary      トークンを保存します。
 // @Description
@@ -271,6 +486,94 @@ func (h *APIHandler) CreateWordpressInstagram(c echo.Context) error {
 	return c.JSON(http.StatusCreated, item)
 }
 
+// ValidateWordpressInstagram godoc
+// @Summary      Wordpress Instagramの事前確認
+// @Description  作成前にWordPressのプラグイン・署名の鍵・Instagramアカウントを確認します。何も作成・投稿しません
+// @Tags         wordpress-instagram
+// @Accept       json
+// @Produce      json
+// @Param        body  body      req.CreateWordpressInstagram  true  "作成データ"
+// @Success      200   {object}  res.OnboardingCheckList  "確認結果"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/wordpress-instagram/validate [post]
+func (h *APIHandler) ValidateWordpressInstagram(c echo.Context) error {
+	var body req.CreateWordpressInstagram
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.wordpressInstagramUsecase.ValidateWordpressInstagram(c.Request().Context(), body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ImportWordpressInstagram godoc
+// @Summary      Wordpress Instagramの一括インポート
+// @Description  CSVまたはJSONの全ての行を作成APIと同じように検証し、全て成功した場合だけ1つのトランザクションで作成します。1行でも失敗した場合は何も作成しません
+// @Tags         wordpress-instagram
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Param        format   query     string  false  "json（デフォルト）または csv"
+// @Param        dry_run  query     bool    false  "trueの場合は各行を検証した結果だけを返し、何も作成しない"
+// @Param        body     body      []req.CreateWordpressInstagram  true  "インポートする行。CSVの場合は列名をJSONのキーと同じにする"
+// @Success      200      {object}  res.AccountConfigImport  "各行の結果"
+// @Failure      400      {string}  string  "不正なリクエスト"
+// @Failure      500      {string}  string  "内部サーバーエラー"
+// @Router       /api/wordpress-instagram/import [post]
+func (h *APIHandler) ImportWordpressInstagram(c echo.Context) error {
+	var params req.ImportAccountConfig
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	format, err := domain.ParseAccountConfigFormat(params.Format)
+	if err != nil {
+		return handleError(c, err)
+	}
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.wordpressInstagramUsecase.ImportWordpressInstagram(c.Request().Context(), format, body, params.DryRun)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ExportWordpressInstagram godoc
+// @Summary      Wordpress Instagramの一括エクスポート
+// @Description  全ての連携を、インポートできるCSVまたはJSONで出力します
+// @Tags         wordpress-instagram
+// @Produce      json
+// @Produce      text/csv
+// @Param        format  query     string  false  "json（デフォルト）または csv"
+// @Success      200     {array}   req.CreateWordpressInstagram  "全ての連携"
+// @Failure      400     {string}  string  "不正なリクエスト"
+// @Failure      500     {string}  string  "内部サーバーエラー"
+// @Router       /api/wordpress-instagram/export [get]
+func (h *APIHandler) ExportWordpressInstagram(c echo.Context) error {
+	var params req.ExportAccountConfig
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	format, err := domain.ParseAccountConfigFormat(params.Format)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	data, err := h.wordpressInstagramUsecase.ExportWordpressInstagram(c.Request().Context(), format)
+	if err != nil {
+		return handleError(c, err)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="wordpress-instagram.%s"`, format))
+	return c.Blob(http.StatusOK, format.ContentType(), data)
+}
+
 // UpdateWordpressInstagram godoc
 // @Summary      Wordpress Instagram更新
 // @Description  Wordpress Instagramを更新します
@@ -326,178 +629,1430 @@ func (h *APIHandler) DeleteWordpressInstagram(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
-// FetchGoogleBusinessList godoc
-// @Summary      Google Businessの同期
-// @Description  Google Businessを同期します
-// @Tags         google-business
+// GetFacebookInstagramList godoc
+// @Summary      Facebook Instagram一覧取得
+// @Description  Instagram => Facebookページ連携の一覧を取得します
+// @Tags         facebook-instagram
+// @Accept       json
+// @Produce      json
+// @Param        limit               query     int     false  "取得件数"
+// @Param        offset              query     int     false  "オフセット"
+// @Param        name                query     string  false  "名前（部分一致）"
+// @Param        instagram_name      query     string  false  "Instagram名（部分一致）"
+// @Param        facebook_page_name  query     string  false  "Facebookページ名（部分一致）"
+// @Param        status              query     int     false  "ステータス"
+// @Success      200  {object}  res.FacebookInstagramList  "Facebook Instagram一覧"
 // @Failure      400  {string}  string  "不正なリクエスト"
 // @Failure      500  {string}  string  "内部サーバーエラー"
-// @Router       /api/google-business/fetch [post]
-func (h *APIHandler) FetchGoogleBusinessList(c echo.Context) error {
-	err := h.businessInstagramUsecase.FetchGoogleBusinesses(c.Request().Context())
+// @Router       /api/facebook-instagram [get]
+func (h *APIHandler) GetFacebookInstagramList(c echo.Context) error {
+	var params req.GetFacebookInstagram
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	list, err := h.facebookInstagramUsecase.GetFacebookInstagramList(c.Request().Context(), params)
 	if err != nil {
 		return handleError(c, err)
 	}
-	return c.JSON(http.StatusOK, "ok")
+	return c.JSON(http.StatusOK, list)
 }
 
-// GetGoogleBusinessList godoc
-// @Summary      Google Business一覧取得
-// @Description  Google Businessの一覧を取得します（ページング対応）
-// @Tags         google-business
+// GetFacebookInstagramCount godoc
+// @Summary      Facebook Instagramの件数を取得
+// @Description  Instagram => Facebookページ連携の件数を取得します
+// @Tags         facebook-instagram
 // @Accept       json
 // @Produce      json
-// @Param        limit   query     int  false  "取得件数（デフォルト: 20）"
-// @Param        offset  query     int  false  "オフセット（デフォルト: 0）"
-// @Success      200  {object}  res.GoogleBusinessList  "Google Business一覧"
-// @Failure      400  {string}  string  "不正なリクエスト"
+// @Success      200  {object}  res.FacebookInstagramCount  "Facebook Instagramの件数"
 // @Failure      500  {string}  string  "内部サーバーエラー"
-// @Router       /api/google-business [get]
-func (h *APIHandler) GetGoogleBusinessList(c echo.Context) error {
-	limit := 20
-	offset := 0
-
-	if err := echo.QueryParamsBinder(c).
-		Int("limit", &limit).
-		Int("offset", &offset).
-		BindError(); err != nil {
-		return c.JSON(http.StatusBadRequest, err.Error())
-	}
-
-	businesses, total, err := h.businessInstagramUsecase.GetGoogleBusinesses(c.Request().Context(), limit, offset)
+// @Router       /api/facebook-instagram/count [get]
+func (h *APIHandler) GetFacebookInstagramCount(c echo.Context) error {
+	count, err := h.facebookInstagramUsecase.GetFacebookInstagramCount(c.Request().Context())
 	if err != nil {
 		return handleError(c, err)
 	}
-
-	businessList := make([]res.GoogleBusiness, 0, len(businesses))
-	for _, b := range businesses {
-		businessList = append(businessList, res.GoogleBusiness{
-			ID:        b.ID,
-			Name:      b.Name,
-			Title:     b.Title,
-			CreatedAt: b.CreatedAt,
-		})
-	}
-
-	return c.JSON(http.StatusOK, res.GoogleBusinessList{
-		GoogleBusinessList: businessList,
-		Paginate: res.Paginate{
-			Total: total,
-			Count: len(businesses),
-		},
-	})
+	return c.JSON(http.StatusOK, count)
 }
 
-// GetBusinessInstagramList godoc
-// @Summary      Business Instagram一覧取得
-// @Description  Business Instagram一覧を取得します
-// @Tags         business-instagram
+// GetFacebookInstagram godoc
+// @Summary      Facebook Instagram詳細取得
+// @Description  Instagram => Facebookページ連携の詳細と投稿履歴を取得します
+// @Tags         facebook-instagram
 // @Accept       json
 // @Produce      json
-// @Success      201   {object}  res.BusinessInstagramList  "Business Instagram"
-// @Failure      400   {string}  string  "不正なリクエスト"
-// @Failure      500   {string}  string  "内部サーバーエラー"
-// @Router       /api/business-instagram [get]
-func (h *APIHandler) GetBusinessInstagramList(c echo.Context) error {
-	var params req.GetBusinessInstagram
+// @Param        id      path      int  true   "Facebook Instagram ID"
+// @Param        limit   query     int  false  "投稿取得件数"
+// @Param        offset  query     int  false  "投稿オフセット"
+// @Success      200  {object}  res.FacebookInstagramDetail  "Facebook Instagram詳細"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/facebook-instagram/{id} [get]
+func (h *APIHandler) GetFacebookInstagram(c echo.Context) error {
+	var params req.GetFacebookInstagramDetail
 	if err := c.Bind(&params); err != nil {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
-	resp, err := h.businessInstagramUsecase.GetBusinessInstagramList(c.Request().Context(), params)
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	item, err := h.facebookInstagramUsecase.GetFacebookInstagram(c.Request().Context(), id, params)
 	if err != nil {
 		return handleError(c, err)
 	}
-	return c.JSON(http.StatusCreated, resp)
+	return c.JSON(http.StatusOK, item)
 }
 
-// GetBusinessInstagram godoc
-// @Summary      Business Instagram取得
-// @Description  Business Instagramを取得します
-// @Tags         business-instagram
+// CreateFacebookInstagram godoc
+// @Summary      Facebook Instagram作成
+// @Description  Instagram => Facebookページ連携を作成します
+// @Tags         facebook-instagram
 // @Accept       json
 // @Produce      json
-// @Param        limit   query     int  false  "取得件数"
-// @Param        offset  query     int  false  "オフセット"
-// @Success      200   {object}  res.BusinessInstagramDetail  "Business Instagram Detail"
+// @Param        body  body      req.CreateFacebookInstagram  true  "作成データ"
+// @Success      201   {object}  res.FacebookInstagram  "作成されたFacebook Instagram"
 // @Failure      400   {string}  string  "不正なリクエスト"
 // @Failure      500   {string}  string  "内部サーバーエラー"
-// @Router       /api/business-instagram/{id} [get]
-func (h *APIHandler) GetBusinessInstagram(c echo.Context) error {
-	var id int
-	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
-		return c.JSON(http.StatusBadRequest, err.Error())
-	}
-	var params req.GetBusinessInstagramDetail
-	if err := c.Bind(&params); err != nil {
+// @Router       /api/facebook-instagram [post]
+func (h *APIHandler) CreateFacebookInstagram(c echo.Context) error {
+	var body req.CreateFacebookInstagram
+	if err := c.Bind(&body); err != nil {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
-	resp, err := h.businessInstagramUsecase.GetBusinessInstagram(c.Request().Context(), id, params)
+
+	item, err := h.facebookInstagramUsecase.CreateFacebookInstagram(c.Request().Context(), body)
 	if err != nil {
 		return handleError(c, err)
 	}
-	return c.JSON(http.StatusOK, resp)
+	return c.JSON(http.StatusCreated, item)
 }
 
-// CreateBusinessInstagram godoc
-// @Summary      Business Instagram作成
-// @Description  Business Instagramを作成します
-// @Tags         business-instagram
+// UpdateFacebookInstagram godoc
+// @Summary      Facebook Instagram更新
+// @Description  Instagram => Facebookページ連携を更新します
+// @Tags         facebook-instagram
 // @Accept       json
 // @Produce      json
-// @Param        body  body      req.BusinessInstagram  true  "作成データ"
-// @Success      201   {object}  res.BusinessInstagram  "作成されたBusiness Instagram"
+// @Param        id    path      int                          true  "Facebook Instagram ID"
+// @Param        body  body      req.UpdateFacebookInstagram  true  "更新データ"
+// @Success      200   {object}  res.FacebookInstagram  "更新されたFacebook Instagram"
 // @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      404   {string}  string  "見つかりません"
 // @Failure      500   {string}  string  "内部サーバーエラー"
-// @Router       /api/business-instagram [post]
-func (h *APIHandler) CreateBusinessInstagram(c echo.Context) error {
-	var body req.BusinessInstagram
+// @Router       /api/facebook-instagram/{id} [put]
+func (h *APIHandler) UpdateFacebookInstagram(c echo.Context) error {
+	var body req.UpdateFacebookInstagram
 	if err := c.Bind(&body); err != nil {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 
-	fmt.Println(body)
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
 
-	resp, err := h.businessInstagramUsecase.CreateBusinessInstagram(c.Request().Context(), body)
+	item, err := h.facebookInstagramUsecase.UpdateFacebookInstagram(c.Request().Context(), id, body)
 	if err != nil {
 		return handleError(c, err)
 	}
-	return c.JSON(http.StatusCreated, resp)
+	return c.JSON(http.StatusOK, item)
 }
 
-// UpdateBusinessInstagram godoc
-// @Summary      Business Instagram更新
-// @Description  Business Instagramを更新します
-// @Tags         business-instagram
+// DeleteFacebookInstagram godoc
+// @Summary      Facebook Instagram削除
+// @Description  Instagram => Facebookページ連携を削除します
+// @Tags         facebook-instagram
 // @Accept       json
 // @Produce      json
-// @Param        id    path      int                    true  "Business Instagram ID"
-// @Param        body  body      req.BusinessInstagram  true  "更新データ"
-// @Success      200   {object}  res.BusinessInstagram  "更新されたBusiness Instagram"
-// @Failure      400   {string}  string  "不正なリクエスト"
-// @Failure      404   {string}  string  "見つかりません"
-// @Failure      500   {string}  string  "内部サーバーエラー"
-// @Router       /api/business-instagram/{id} [put]
-func (h *APIHandler) UpdateBusinessInstagram(c echo.Context) error {
+// @Param        id   path      int  true  "Facebook Instagram ID"
+// @Success      204  {string}  string  "削除成功"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/facebook-instagram/{id} [delete]
+func (h *APIHandler) DeleteFacebookInstagram(c echo.Context) error {
 	var id int
 	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
-	var body req.BusinessInstagram
-	if err := c.Bind(&body); err != nil {
-		return c.JSON(http.StatusBadRequest, err.Error())
-	}
 
-	resp, err := h.businessInstagramUsecase.UpdateBusinessInstagram(c.Request().Context(), id, body)
+	err := h.facebookInstagramUsecase.DeleteFacebookInstagram(c.Request().Context(), id)
 	if err != nil {
 		return handleError(c, err)
 	}
-	return c.JSON(http.StatusOK, resp)
+	return c.NoContent(http.StatusNoContent)
 }
 
-// DeleteBusinessInstagram godoc
-// @Summary      Business Instagram削除
-// @Description  Business Instagramを削除します
-// @Tags         business-instagram
+// GetFeedList godoc
+// @Summary      Feed一覧取得
+// @Description  RSS/Atomフィード連携の一覧を取得します
+// @Tags         feed
+// @Accept       json
+// @Produce      json
+// @Param        limit     query     int     false  "取得件数"
+// @Param        offset    query     int     false  "オフセット"
+// @Param        name      query     string  false  "名前（部分一致）"
+// @Param        feed_url  query     string  false  "フィードURL（部分一致）"
+// @Param        status    query     int     false  "ステータス"
+// @Success      200  {object}  res.FeedList  "Feed一覧"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/feed [get]
+func (h *APIHandler) GetFeedList(c echo.Context) error {
+	var params req.GetFeed
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	list, err := h.feedUsecase.GetFeedList(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetFeed godoc
+// @Summary      Feed詳細取得
+// @Description  RSS/Atomフィード連携の詳細と投稿履歴を取得します
+// @Tags         feed
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int  true   "Feed ID"
+// @Param        limit   query     int  false  "投稿取得件数"
+// @Param        offset  query     int  false  "投稿オフセット"
+// @Success      200  {object}  res.FeedDetail  "Feed詳細"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/feed/{id} [get]
+func (h *APIHandler) GetFeed(c echo.Context) error {
+	var params req.GetFeedDetail
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	item, err := h.feedUsecase.GetFeed(c.Request().Context(), id, params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// CreateFeed godoc
+// @Summary      Feed作成
+// @Description  RSS/Atomフィード連携を作成します。wordpress_domain と business_name のうち指定した方に連携します
+// @Tags         feed
+// @Accept       json
+// @Produce      json
+// @Param        body  body      req.CreateFeed  true  "作成データ"
+// @Success      201   {object}  res.Feed  "作成されたFeed"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/feed [post]
+func (h *APIHandler) CreateFeed(c echo.Context) error {
+	var body req.CreateFeed
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	item, err := h.feedUsecase.CreateFeed(c.Request().Context(), body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusCreated, item)
+}
+
+// UpdateFeed godoc
+// @Summary      Feed更新
+// @Description  RSS/Atomフィード連携を更新します
+// @Tags         feed
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int             true  "Feed ID"
+// @Param        body  body      req.UpdateFeed  true  "更新データ"
+// @Success      200   {object}  res.Feed  "更新されたFeed"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      404   {string}  string  "見つかりません"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/feed/{id} [put]
+func (h *APIHandler) UpdateFeed(c echo.Context) error {
+	var body req.UpdateFeed
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	item, err := h.feedUsecase.UpdateFeed(c.Request().Context(), id, body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// DeleteFeed godoc
+// @Summary      Feed削除
+// @Description  RSS/Atomフィード連携を削除します
+// @Tags         feed
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Feed ID"
+// @Success      204  {string}  string  "削除成功"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/feed/{id} [delete]
+func (h *APIHandler) DeleteFeed(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	err := h.feedUsecase.DeleteFeed(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RetryWebhookDeliveries godoc
+// @Summary      Webhookの再送
+// @Description  送信に失敗し、再送時刻を過ぎたWebhookを再送します（定期実行用）
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Success      200  {string}  string  "再送完了"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/sync/webhook-retry [post]
+func (h *APIHandler) RetryWebhookDeliveries(c echo.Context) error {
+	err := h.webhookUsecase.RetryWebhookDeliveries(c.Request().Context())
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "retry webhook deliveries")
+}
+
+// GetWebhookSubscriptionList godoc
+// @Summary      Webhook送信先一覧取得
+// @Description  Webhookの送信先の一覧を取得します。シークレットは含みません
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        limit   query     int     false  "取得件数"
+// @Param        offset  query     int     false  "オフセット"
+// @Param        name    query     string  false  "名前（部分一致）"
+// @Param        status  query     int     false  "ステータス"
+// @Success      200  {object}  res.WebhookSubscriptionList  "Webhook送信先一覧"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/webhook-subscription [get]
+func (h *APIHandler) GetWebhookSubscriptionList(c echo.Context) error {
+	var params req.GetWebhookSubscription
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	list, err := h.webhookUsecase.GetWebhookSubscriptionList(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetWebhookSubscription godoc
+// @Summary      Webhook送信先詳細取得
+// @Description  Webhookの送信先を取得します。シークレットは含みません
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Webhook送信先ID"
+// @Success      200  {object}  res.WebhookSubscription  "Webhook送信先"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/webhook-subscription/{id} [get]
+func (h *APIHandler) GetWebhookSubscription(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	item, err := h.webhookUsecase.GetWebhookSubscription(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// CreateWebhookSubscription godoc
+// @Summary      Webhook送信先作成
+// @Description  Webhookの送信先を作成します。event_types のイベントを送り、account_type / account_id を指定した場合はその連携のイベントだけを送ります。署名用のシークレットはこのレスポンスでのみ返します
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        body  body      req.CreateWebhookSubscription  true  "作成データ"
+// @Success      201   {object}  res.WebhookSubscription  "作成されたWebhook送信先"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/webhook-subscription [post]
+func (h *APIHandler) CreateWebhookSubscription(c echo.Context) error {
+	var body req.CreateWebhookSubscription
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	item, err := h.webhookUsecase.CreateWebhookSubscription(c.Request().Context(), body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusCreated, item)
+}
+
+// UpdateWebhookSubscription godoc
+// @Summary      Webhook送信先更新
+// @Description  Webhookの送信先を更新します。シークレットを変更した場合のみレスポンスにシークレットを含みます
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                            true  "Webhook送信先ID"
+// @Param        body  body      req.UpdateWebhookSubscription  true  "更新データ"
+// @Success      200   {object}  res.WebhookSubscription  "更新されたWebhook送信先"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      404   {string}  string  "見つかりません"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/webhook-subscription/{id} [put]
+func (h *APIHandler) UpdateWebhookSubscription(c echo.Context) error {
+	var body req.UpdateWebhookSubscription
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	item, err := h.webhookUsecase.UpdateWebhookSubscription(c.Request().Context(), id, body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// DeleteWebhookSubscription godoc
+// @Summary      Webhook送信先削除
+// @Description  Webhookの送信先を削除します
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Webhook送信先ID"
+// @Success      204  {string}  string  "削除成功"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/webhook-subscription/{id} [delete]
+func (h *APIHandler) DeleteWebhookSubscription(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	err := h.webhookUsecase.DeleteWebhookSubscription(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetWebhookEventList godoc
+// @Summary      Webhookイベント一覧取得
+// @Description  発生したWebhookイベントの一覧を新しい順に取得します
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        limit         query     int     false  "取得件数"
+// @Param        offset        query     int     false  "オフセット"
+// @Param        event_type    query     string  false  "イベントの種類"
+// @Param        account_type  query     string  false  "連携の種類"
+// @Param        account_id    query     int     false  "連携ID"
+// @Success      200  {object}  res.WebhookEventList  "Webhookイベント一覧"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/webhook-event [get]
+func (h *APIHandler) GetWebhookEventList(c echo.Context) error {
+	var params req.GetWebhookEvent
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	list, err := h.webhookUsecase.GetWebhookEventList(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetWebhookEvent godoc
+// @Summary      Webhookイベント詳細取得
+// @Description  Webhookイベントと送信履歴を取得します
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int  true   "WebhookイベントID"
+// @Param        limit   query     int  false  "送信履歴取得件数"
+// @Param        offset  query     int  false  "送信履歴オフセット"
+// @Success      200  {object}  res.WebhookEventDetail  "Webhookイベント詳細"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/webhook-event/{id} [get]
+func (h *APIHandler) GetWebhookEvent(c echo.Context) error {
+	var params req.GetWebhookEventDetail
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	item, err := h.webhookUsecase.GetWebhookEvent(c.Request().Context(), id, params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// ReplayWebhookEvent godoc
+// @Summary      Webhookイベント再送
+// @Description  Webhookイベントを再送します。subscription_id を省略した場合は、現在そのイベントを購読している送信先すべてに送ります
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                     true  "WebhookイベントID"
+// @Param        body  body      req.ReplayWebhookEvent  true  "再送先"
+// @Success      200   {object}  res.WebhookDeliveryList  "送信結果"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      404   {string}  string  "見つかりません"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/webhook-event/{id}/replay [post]
+func (h *APIHandler) ReplayWebhookEvent(c echo.Context) error {
+	var body req.ReplayWebhookEvent
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	list, err := h.webhookUsecase.ReplayWebhookEvent(c.Request().Context(), id, body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetWebhookDeliveryList godoc
+// @Summary      Webhook送信履歴一覧取得
+// @Description  Webhookの送信履歴を新しい順に取得します
+// @Tags         webhook
+// @Accept       json
+// @Produce      json
+// @Param        limit            query     int     false  "取得件数"
+// @Param        offset           query     int     false  "オフセット"
+// @Param        event_id         query     int     false  "WebhookイベントID"
+// @Param        subscription_id  query     int     false  "Webhook送信先ID"
+// @Param        status           query     string  false  "送信状態（pending, succeeded, retrying, failed）"
+// @Success      200  {object}  res.WebhookDeliveryList  "Webhook送信履歴一覧"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/webhook-delivery [get]
+func (h *APIHandler) GetWebhookDeliveryList(c echo.Context) error {
+	var params req.GetWebhookDelivery
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	list, err := h.webhookUsecase.GetWebhookDeliveryList(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetNotificationRuleList godoc
+// @Summary      通知ルール一覧取得
+// @Description  通知の送り先（Slack、メール、LINE、webhook）のルール一覧を取得します
+// @Tags         notification
+// @Accept       json
+// @Produce      json
+// @Param        limit    query     int     false  "取得件数"
+// @Param        offset   query     int     false  "オフセット"
+// @Param        name     query     string  false  "名前（部分一致）"
+// @Param        channel  query     string  false  "送信手段（slack, email, line, webhook）"
+// @Param        status   query     int     false  "ステータス"
+// @Success      200  {object}  res.NotificationRuleList  "通知ルール一覧"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/notification-rule [get]
+func (h *APIHandler) GetNotificationRuleList(c echo.Context) error {
+	var params req.GetNotificationRule
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	list, err := h.notificationUsecase.GetNotificationRuleList(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, list)
+}
+
+// GetNotificationRule godoc
+// @Summary      通知ルール詳細取得
+// @Description  通知ルールを取得します
+// @Tags         notification
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "通知ルールID"
+// @Success      200  {object}  res.NotificationRule  "通知ルール"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/notification-rule/{id} [get]
+func (h *APIHandler) GetNotificationRule(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	item, err := h.notificationUsecase.GetNotificationRule(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// CreateNotificationRule godoc
+// @Summary      通知ルール作成
+// @Description  通知ルールを作成します。event_types を省略すると全ての通知を、account_type / account_id を指定するとその連携の通知だけを送ります。どのルールにも当てはまらない通知は既定のSlackチャンネルに送ります
+// @Tags         notification
+// @Accept       json
+// @Produce      json
+// @Param        body  body      req.CreateNotificationRule  true  "作成データ"
+// @Success      201   {object}  res.NotificationRule  "作成された通知ルール"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/notification-rule [post]
+func (h *APIHandler) CreateNotificationRule(c echo.Context) error {
+	var body req.CreateNotificationRule
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	item, err := h.notificationUsecase.CreateNotificationRule(c.Request().Context(), body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusCreated, item)
+}
+
+// UpdateNotificationRule godoc
+// @Summary      通知ルール更新
+// @Description  通知ルールを更新します
+// @Tags         notification
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                         true  "通知ルールID"
+// @Param        body  body      req.UpdateNotificationRule  true  "更新データ"
+// @Success      200   {object}  res.NotificationRule  "更新された通知ルール"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      404   {string}  string  "見つかりません"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/notification-rule/{id} [put]
+func (h *APIHandler) UpdateNotificationRule(c echo.Context) error {
+	var body req.UpdateNotificationRule
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	item, err := h.notificationUsecase.UpdateNotificationRule(c.Request().Context(), id, body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, item)
+}
+
+// DeleteNotificationRule godoc
+// @Summary      通知ルール削除
+// @Description  通知ルールを削除します
+// @Tags         notification
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "通知ルールID"
+// @Success      204  {string}  string  "削除成功"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/notification-rule/{id} [delete]
+func (h *APIHandler) DeleteNotificationRule(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	err := h.notificationUsecase.DeleteNotificationRule(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// TestNotificationRule godoc
+// @Summary      通知ルールのテスト送信
+// @Description  通知ルールの送り先にテスト通知を送ります
+// @Tags         notification
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "通知ルールID"
+// @Success      200  {string}  string  "送信完了"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/notification-rule/{id}/test [post]
+func (h *APIHandler) TestNotificationRule(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	err := h.notificationUsecase.TestNotificationRule(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "test notification sent")
+}
+
+// SendDigest godoc
+// @Summary      ダイジェストの送信
+// @Description  期間内の連携結果のダイジェストを通知ルールに従って送ります（定期実行用）
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Param        period  query     string  false  "集計期間（daily / weekly）。省略時は daily"
+// @Success      200  {string}  string  "送信完了"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/sync/digest [post]
+func (h *APIHandler) SendDigest(c echo.Context) error {
+	var params req.GetDigest
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	err := h.digestUsecase.SendDigest(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "digest sent")
+}
+
+// GetDigest godoc
+// @Summary      ダイジェスト取得
+// @Description  期間内の連携結果のダイジェストを取得します。通知は送りません
+// @Tags         notification
+// @Accept       json
+// @Produce      json
+// @Param        period  query     string  false  "集計期間（daily / weekly）。省略時は daily"
+// @Success      200  {object}  res.Digest  "ダイジェスト"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/digest [get]
+func (h *APIHandler) GetDigest(c echo.Context) error {
+	var params req.GetDigest
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	digest, err := h.digestUsecase.GetDigest(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, digest)
+}
+
+// GetDigestHTML godoc
+// @Summary      ダイジェストのHTML取得
+// @Description  メールで送るダイジェストのHTMLを取得します。通知は送りません
+// @Tags         notification
+// @Produce      html
+// @Param        period  query     string  false  "集計期間（daily / weekly）。省略時は daily"
+// @Success      200  {string}  string  "ダイジェストのHTML"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/digest/html [get]
+func (h *APIHandler) GetDigestHTML(c echo.Context) error {
+	var params req.GetDigest
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	html, err := h.digestUsecase.GetDigestHTML(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.HTML(http.StatusOK, html)
+}
+
+// SlackInteraction godoc
+// @Summary      Slackのボタン操作の受付
+// @Description  Slackの通知のボタンが押された時にSlackから呼ばれます。署名を検証し、操作の結果で通知のメッセージを更新します
+// @Tags         notification
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        X-Slack-Request-Timestamp  header    string  true  "リクエストの時刻"
+// @Param        X-Slack-Signature          header    string  true  "リクエストの署名"
+// @Success      200  {string}  string  "受付完了"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      401  {string}  string  "署名が不正"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/slack/interactivity [post]
+func (h *APIHandler) SlackInteraction(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	err = domain.VerifySlackSignature(
+		config.Env.SlackSigningSecret,
+		c.Request().Header.Get("X-Slack-Request-Timestamp"),
+		c.Request().Header.Get("X-Slack-Signature"),
+		body,
+		time.Now(),
+	)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var payload external.SlackInteractionPayload
+	if err := json.Unmarshal([]byte(form.Get("payload")), &payload); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	err = h.slackInteractionUsecase.HandleSlackInteraction(c.Request().Context(), payload)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// FetchGoogleBusinessList godoc
+// @Summary      Google Businessの同期
+// @Description  Google Businessを同期します
+// @Tags         google-business
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-business/fetch [post]
+func (h *APIHandler) FetchGoogleBusinessList(c echo.Context) error {
+	err := h.businessInstagramUsecase.FetchGoogleBusinesses(c.Request().Context())
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "ok")
+}
+
+// GetGoogleAccountList godoc
+// @Summary      Googleアカウント一覧取得
+// @Description  認証済みのGoogleアカウント（ビジネスグループを含む）の一覧を取得します
+// @Tags         google-business
+// @Produce      json
+// @Success      200  {object}  res.GoogleAccountList  "Googleアカウント一覧"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-account [get]
+func (h *APIHandler) GetGoogleAccountList(c echo.Context) error {
+	accounts, err := h.businessInstagramUsecase.GetGoogleAccounts(c.Request().Context())
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	accountList := make([]res.GoogleAccount, 0, len(accounts))
+	for _, a := range accounts {
+		accountList = append(accountList, res.GoogleAccount{
+			ID:          a.ID,
+			Name:        a.Name,
+			AccountName: a.AccountName,
+			Type:        a.Type,
+			UpdatedAt:   a.UpdatedAt,
+			CreatedAt:   a.CreatedAt,
+		})
+	}
+	return c.JSON(http.StatusOK, res.GoogleAccountList{
+		GoogleAccountList: accountList,
+	})
+}
+
+// GetGoogleBusinessList godoc
+// @Summary      Google Business一覧取得
+// @Description  Google Businessの一覧を取得します（ページング対応）
+// @Tags         google-business
+// @Accept       json
+// @Produce      json
+// @Param        limit   query     int  false  "取得件数（デフォルト: 20）"
+// @Param        offset  query     int  false  "オフセット（デフォルト: 0）"
+// @Success      200  {object}  res.GoogleBusinessList  "Google Business一覧"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-business [get]
+func (h *APIHandler) GetGoogleBusinessList(c echo.Context) error {
+	limit := 20
+	offset := 0
+
+	if err := echo.QueryParamsBinder(c).
+		Int("limit", &limit).
+		Int("offset", &offset).
+		BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	businesses, total, err := h.businessInstagramUsecase.GetGoogleBusinesses(c.Request().Context(), limit, offset)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	businessList := make([]res.GoogleBusiness, 0, len(businesses))
+	for _, b := range businesses {
+		businessList = append(businessList, res.GoogleBusiness{
+			ID:                     b.ID,
+			GoogleAccountID:        b.GoogleAccountID,
+			Name:                   b.Name,
+			Title:                  b.Title,
+			PhotoRetentionCount:    b.PhotoRetentionCount,
+			LocalPostRetentionDays: b.LocalPostRetentionDays,
+			CreatedAt:              b.CreatedAt,
+		})
+	}
+
+	return c.JSON(http.StatusOK, res.GoogleBusinessList{
+		GoogleBusinessList: businessList,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(businesses),
+		},
+	})
+}
+
+// SyncGoogleBusinessInsights godoc
+// @Summary      GBPパフォーマンス指標の取り込み
+// @Description  全てのGoogle Businessの日次パフォーマンス指標を取得して保存します
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Success      200  {string}  string  "取り込み完了"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/sync/google-business-insights [post]
+func (h *APIHandler) SyncGoogleBusinessInsights(c echo.Context) error {
+	err := h.googleBusinessInsightUsecase.SyncInsights(c.Request().Context())
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "sync google business insights")
+}
+
+// GetGoogleBusinessInsights godoc
+// @Summary      GBPパフォーマンス指標取得
+// @Description  Google Businessの日次・月次の指標とhomingの投稿数を取得します（月次レポート用）
+// @Tags         google-business
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int     true   "Google Business ID"
+// @Param        from  query     string  false  "開始日（YYYY-MM-DD、デフォルト: 当月1日）"
+// @Param        to    query     string  false  "終了日（YYYY-MM-DD、デフォルト: 今日）"
+// @Success      200  {object}  res.GoogleBusinessInsights  "指標"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-business/{id}/insights [get]
+func (h *APIHandler) GetGoogleBusinessInsights(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var params req.GetGoogleBusinessInsights
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googleBusinessInsightUsecase.GetInsights(c.Request().Context(), id, params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// UpdateGoogleBusinessRetention godoc
+// @Summary      GBP投稿の保持ポリシー更新
+// @Description  homingが投稿した写真を最新何件まで残すか、Local Postを何日で削除するかを設定します（0は無制限）
+// @Tags         google-business
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                                true  "Google Business ID"
+// @Param        body  body      req.UpdateGoogleBusinessRetention  true  "保持ポリシー"
+// @Success      200   {object}  res.GoogleBusiness  "Google Business"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      404   {string}  string  "見つかりません"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/google-business/{id}/retention [put]
+func (h *APIHandler) UpdateGoogleBusinessRetention(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var body req.UpdateGoogleBusinessRetention
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googlePostUsecase.UpdateRetention(c.Request().Context(), id, body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetGooglePostList godoc
+// @Summary      GBP投稿一覧取得
+// @Description  homingがビジネスに投稿した写真・Local Postの一覧を新しい順に取得します
+// @Tags         google-post
+// @Accept       json
+// @Produce      json
+// @Param        id         path      int     true   "Google Business ID"
+// @Param        post_type  query     string  false  "photo / post"
+// @Param        deleted    query     bool    false  "削除済みかどうか"
+// @Param        limit      query     int     false  "取得件数"
+// @Param        offset     query     int     false  "オフセット"
+// @Success      200  {object}  res.GooglePostList  "投稿一覧"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-business/{id}/posts [get]
+func (h *APIHandler) GetGooglePostList(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var params req.GetGooglePosts
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googlePostUsecase.GetGooglePosts(c.Request().Context(), id, params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeleteGooglePost godoc
+// @Summary      GBP投稿削除
+// @Description  GBP上の写真・Local Postを削除します。homingのレコードは削除済みとして残り、同期で再投稿されません
+// @Tags         google-post
+// @Produce      json
+// @Param        id   path      int  true  "投稿ID"
+// @Success      200  {object}  res.GooglePost  "削除した投稿"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-post/{id} [delete]
+func (h *APIHandler) DeleteGooglePost(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googlePostUsecase.DeleteGooglePost(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// RepostGooglePost godoc
+// @Summary      GBP再投稿
+// @Description  投稿元（Instagram / WordPress）からこの投稿だけを改めて投稿し、投稿できたらGBP上の元の写真・Local Postを削除します
+// @Tags         google-post
+// @Produce      json
+// @Param        id   path      int  true  "投稿ID"
+// @Success      200  {object}  res.GooglePost  "再投稿した投稿"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-post/{id}/repost [post]
+func (h *APIHandler) RepostGooglePost(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googlePostUsecase.RepostGooglePost(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetGbpPostRejectionList godoc
+// @Summary      GBP投稿拒否一覧取得
+// @Description  GBPがポリシー違反として拒否したLocal Postを理由とともに新しい順に取得します
+// @Tags         google-post
+// @Accept       json
+// @Produce      json
+// @Param        customer_id  query     int     false  "顧客ID（WordPress連携は300000+ID）"
+// @Param        reason       query     string  false  "phone / url / address / hashtag / too_long / prohibited_content / other"
+// @Param        limit        query     int     false  "取得件数"
+// @Param        offset       query     int     false  "オフセット"
+// @Success      200  {object}  res.GbpPostRejectionList  "拒否一覧"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/gbp-post-rejection [get]
+func (h *APIHandler) GetGbpPostRejectionList(c echo.Context) error {
+	var params req.GetGbpPostRejections
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googlePostUsecase.GetGbpPostRejections(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ApplyGooglePostRetention godoc
+// @Summary      GBP投稿の保持ポリシー適用
+// @Description  保持ポリシーを超えた写真・Local PostをGBPから削除します（定期実行用）
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Success      200  {string}  string  "保持ポリシー適用完了"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/sync/google-post-retention [post]
+func (h *APIHandler) ApplyGooglePostRetention(c echo.Context) error {
+	err := h.googlePostUsecase.ApplyRetention(c.Request().Context())
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "apply google post retention")
+}
+
+// GetGoogleBusinessLocation godoc
+// @Summary      GBPビジネス情報取得
+// @Description  営業時間・特別営業時間・説明・電話番号・ウェブサイト・属性をGBPから取得します
+// @Tags         google-business
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "Google Business ID"
+// @Success      200  {object}  res.GoogleBusinessLocation  "ビジネス情報"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-business/{id}/location [get]
+func (h *APIHandler) GetGoogleBusinessLocation(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googleBusinessLocationUsecase.GetLocation(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// PreviewGoogleBusinessLocation godoc
+// @Summary      GBPビジネス情報の変更プレビュー
+// @Description  更新内容と現在の情報の差分を返します。GBPへの反映は行いません
+// @Tags         google-business
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                               true  "Google Business ID"
+// @Param        body  body      req.UpdateGoogleBusinessLocation  true  "更新内容"
+// @Success      200   {object}  res.GoogleBusinessLocationPreview  "差分"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      404   {string}  string  "見つかりません"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/google-business/{id}/location/preview [post]
+func (h *APIHandler) PreviewGoogleBusinessLocation(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var body req.UpdateGoogleBusinessLocation
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googleBusinessLocationUsecase.PreviewLocation(c.Request().Context(), id, body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// UpdateGoogleBusinessLocation godoc
+// @Summary      GBPビジネス情報更新
+// @Description  変更のある項目のみGBPに反映し、変更履歴を保存します
+// @Tags         google-business
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                               true  "Google Business ID"
+// @Param        body  body      req.UpdateGoogleBusinessLocation  true  "更新内容"
+// @Success      200   {object}  res.GoogleBusinessLocationPreview  "反映した差分"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      404   {string}  string  "見つかりません"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/google-business/{id}/location [put]
+func (h *APIHandler) UpdateGoogleBusinessLocation(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var body req.UpdateGoogleBusinessLocation
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googleBusinessLocationUsecase.UpdateLocation(c.Request().Context(), id, body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetGoogleBusinessLocationChanges godoc
+// @Summary      GBPビジネス情報の変更履歴取得
+// @Description  homingから行ったビジネス情報の変更履歴を新しい順に取得します
+// @Tags         google-business
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int  true   "Google Business ID"
+// @Param        limit   query     int  false  "取得件数"
+// @Param        offset  query     int  false  "オフセット"
+// @Success      200  {object}  res.GoogleBusinessLocationChangeList  "変更履歴"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-business/{id}/location/changes [get]
+func (h *APIHandler) GetGoogleBusinessLocationChanges(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var params req.GetGoogleBusinessLocationChanges
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googleBusinessLocationUsecase.GetLocationChanges(c.Request().Context(), id, params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetBusinessInstagramList godoc
+// @Summary      Business Instagram一覧取得
+// @Description  Business Instagram一覧を取得します
+// @Tags         business-instagram
+// @Accept       json
+// @Produce      json
+// @Success      201   {object}  res.BusinessInstagramList  "Business Instagram"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/business-instagram [get]
+func (h *APIHandler) GetBusinessInstagramList(c echo.Context) error {
+	var params req.GetBusinessInstagram
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.businessInstagramUsecase.GetBusinessInstagramList(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// GetBusinessInstagram godoc
+// @Summary      Business Instagram取得
+// @Description  Business Instagramを取得します
+// @Tags         business-instagram
+// @Accept       json
+// @Produce      json
+// @Param        limit   query     int  false  "取得件数"
+// @Param        offset  query     int  false  "オフセット"
+// @Success      200   {object}  res.BusinessInstagramDetail  "Business Instagram Detail"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/business-instagram/{id} [get]
+func (h *APIHandler) GetBusinessInstagram(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var params req.GetBusinessInstagramDetail
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.businessInstagramUsecase.GetBusinessInstagram(c.Request().Context(), id, params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// CreateBusinessInstagram godoc
+// @Summary      Business Instagram作成
+// @Description  Business Instagramを作成します
+// @Tags         business-instagram
+// @Accept       json
+// @Produce      json
+// @Param        body  body      req.BusinessInstagram  true  "作成データ"
+// @Success      201   {object}  res.BusinessInstagram  "作成されたBusiness Instagram"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/business-instagram [post]
+func (h *APIHandler) CreateBusinessInstagram(c echo.Context) error {
+	var body req.BusinessInstagram
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	fmt.Println(body)
+
+	resp, err := h.businessInstagramUsecase.CreateBusinessInstagram(c.Request().Context(), body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+// ValidateBusinessInstagram godoc
+// @Summary      Business Instagramの事前確認
+// @Description  作成前に設定値・Instagramアカウント・GBPのビジネスを確認します。何も作成・投稿しません
+// @Tags         business-instagram
+// @Accept       json
+// @Produce      json
+// @Param        body  body      req.BusinessInstagram  true  "作成データ"
+// @Success      200   {object}  res.OnboardingCheckList  "確認結果"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/business-instagram/validate [post]
+func (h *APIHandler) ValidateBusinessInstagram(c echo.Context) error {
+	var body req.BusinessInstagram
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.businessInstagramUsecase.ValidateBusinessInstagram(c.Request().Context(), body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ImportBusinessInstagram godoc
+// @Summary      Business Instagramの一括インポート
+// @Description  CSVまたはJSONの全ての行を作成APIと同じように検証し、全て成功した場合だけ1つのトランザクションで作成します。1行でも失敗した場合は何も作成しません
+// @Tags         business-instagram
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Param        format   query     string  false  "json（デフォルト）または csv"
+// @Param        dry_run  query     bool    false  "trueの場合は各行を検証した結果だけを返し、何も作成しない"
+// @Param        body     body      []req.BusinessInstagram  true  "インポートする行。CSVの場合は列名をJSONのキーと同じにする"
+// @Success      200      {object}  res.AccountConfigImport  "各行の結果"
+// @Failure      400      {string}  string  "不正なリクエスト"
+// @Failure      500      {string}  string  "内部サーバーエラー"
+// @Router       /api/business-instagram/import [post]
+func (h *APIHandler) ImportBusinessInstagram(c echo.Context) error {
+	var params req.ImportAccountConfig
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	format, err := domain.ParseAccountConfigFormat(params.Format)
+	if err != nil {
+		return handleError(c, err)
+	}
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.businessInstagramUsecase.ImportBusinessInstagram(c.Request().Context(), format, body, params.DryRun)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ExportBusinessInstagram godoc
+// @Summary      Business Instagramの一括エクスポート
+// @Description  全ての連携を、インポートできるCSVまたはJSONで出力します
+// @Tags         business-instagram
+// @Produce      json
+// @Produce      text/csv
+// @Param        format  query     string  false  "json（デフォルト）または csv"
+// @Success      200     {array}   req.BusinessInstagram  "全ての連携"
+// @Failure      400     {string}  string  "不正なリクエスト"
+// @Failure      500     {string}  string  "内部サーバーエラー"
+// @Router       /api/business-instagram/export [get]
+func (h *APIHandler) ExportBusinessInstagram(c echo.Context) error {
+	var params req.ExportAccountConfig
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	format, err := domain.ParseAccountConfigFormat(params.Format)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	data, err := h.businessInstagramUsecase.ExportBusinessInstagram(c.Request().Context(), format)
+	if err != nil {
+		return handleError(c, err)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="business-instagram.%s"`, format))
+	return c.Blob(http.StatusOK, format.ContentType(), data)
+}
+
+// UpdateBusinessInstagram godoc
+// @Summary      Business Instagram更新
+// @Description  Business Instagramを更新します
+// @Tags         business-instagram
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                    true  "Business Instagram ID"
+// @Param        body  body      req.BusinessInstagram  true  "更新データ"
+// @Success      200   {object}  res.BusinessInstagram  "更新されたBusiness Instagram"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      404   {string}  string  "見つかりません"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/business-instagram/{id} [put]
+func (h *APIHandler) UpdateBusinessInstagram(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var body req.BusinessInstagram
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.businessInstagramUsecase.UpdateBusinessInstagram(c.Request().Context(), id, body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeleteBusinessInstagram godoc
+// @Summary      Business Instagram削除
+// @Description  Business Instagramを削除します
+// @Tags         business-instagram
 // @Param        id    path      int     true  "Business Instagram ID"
 // @SuccessWI      204
 // @Failure      400   {string}  string  "不正なリクエスト"
@@ -523,10 +2078,22 @@ func (h *APIHandler) DeleteBusinessInstagram(c echo.Context) error {
 // @Tags         sync
 // @Accept       json
 // @Produce      json
+// @Param        dry_run  query  bool  false  "trueの場合は何も投稿せず、投稿される内容（res.SyncPreviewList）を返す"
 // @Success      200  {string}  string  "全設定同期完了"
 // @Failure      500  {string}  string  "内部サーバーエラー"
 // @Router       /api/sync/wordpress-gbp [post]
 func (h *APIHandler) SyncAllWordpressGbp(c echo.Context) error {
+	var params req.SyncOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if params.DryRun {
+		preview, err := h.customerUsecase.PreviewAllWordpressGbp(c.Request().Context())
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
 	err := h.customerUsecase.SyncAllWordpressGbp(c.Request().Context())
 	if err != nil {
 		return handleError(c, err)
@@ -541,6 +2108,7 @@ func (h *APIHandler) SyncAllWordpressGbp(c echo.Context) error {
 // @Accept       json
 // @Produce      json
 // @Param        id   path      int  true  "WordPress GBP ID"
+// @Param        dry_run  query  bool  false  "trueの場合は何も投稿せず、投稿される内容（res.SyncPreviewList）を返す"
 // @Success      200  {string}  string  "同期完了"
 // @Failure      500  {string}  string  "内部サーバーエラー"
 // @Router       /api/sync/wordpress-gbp/{id} [post]
@@ -549,6 +2117,17 @@ func (h *APIHandler) SyncOneWordpressGbp(c echo.Context) error {
 	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
+	var params req.SyncOptions
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	if params.DryRun {
+		preview, err := h.customerUsecase.PreviewOneWordpressGbp(c.Request().Context(), id)
+		if err != nil {
+			return handleError(c, err)
+		}
+		return c.JSON(http.StatusOK, preview)
+	}
 	err := h.customerUsecase.SyncOneWordpressGbp(c.Request().Context(), id)
 	if err != nil {
 		return handleError(c, err)
@@ -634,6 +2213,94 @@ func (h *APIHandler) CreateWordpressGbp(c echo.Context) error {
 	return c.JSON(http.StatusCreated, item)
 }
 
+// ValidateWordpressGbp godoc
+// @Summary      WordPress GBPの事前確認
+// @Description  作成前に設定値・WordPressのプラグイン・GBPのビジネスを確認します。何も作成・投稿しません
+// @Tags         wordpress-gbp
+// @Accept       json
+// @Produce      json
+// @Param        body  body      req.WordpressGbp  true  "作成データ"
+// @Success      200   {object}  res.OnboardingCheckList  "確認結果"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/wordpress-gbp/validate [post]
+func (h *APIHandler) ValidateWordpressGbp(c echo.Context) error {
+	var body req.WordpressGbp
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.wordpressGbpUsecase.ValidateWordpressGbp(c.Request().Context(), body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ImportWordpressGbp godoc
+// @Summary      WordPress GBPの一括インポート
+// @Description  CSVまたはJSONの全ての行を作成APIと同じように検証し、全て成功した場合だけ1つのトランザクションで作成します。1行でも失敗した場合は何も作成しません
+// @Tags         wordpress-gbp
+// @Accept       json
+// @Accept       text/csv
+// @Produce      json
+// @Param        format   query     string  false  "json（デフォルト）または csv"
+// @Param        dry_run  query     bool    false  "trueの場合は各行を検証した結果だけを返し、何も作成しない"
+// @Param        body     body      []req.WordpressGbp  true  "インポートする行。CSVの場合は列名をJSONのキーと同じにする"
+// @Success      200      {object}  res.AccountConfigImport  "各行の結果"
+// @Failure      400      {string}  string  "不正なリクエスト"
+// @Failure      500      {string}  string  "内部サーバーエラー"
+// @Router       /api/wordpress-gbp/import [post]
+func (h *APIHandler) ImportWordpressGbp(c echo.Context) error {
+	var params req.ImportAccountConfig
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	format, err := domain.ParseAccountConfigFormat(params.Format)
+	if err != nil {
+		return handleError(c, err)
+	}
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+
+	resp, err := h.wordpressGbpUsecase.ImportWordpressGbp(c.Request().Context(), format, body, params.DryRun)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ExportWordpressGbp godoc
+// @Summary      WordPress GBPの一括エクスポート
+// @Description  全ての連携を、インポートできるCSVまたはJSONで出力します
+// @Tags         wordpress-gbp
+// @Produce      json
+// @Produce      text/csv
+// @Param        format  query     string  false  "json（デフォルト）または csv"
+// @Success      200     {array}   req.WordpressGbp  "全ての連携"
+// @Failure      400     {string}  string  "不正なリクエスト"
+// @Failure      500     {string}  string  "内部サーバーエラー"
+// @Router       /api/wordpress-gbp/export [get]
+func (h *APIHandler) ExportWordpressGbp(c echo.Context) error {
+	var params req.ExportAccountConfig
+	if err := (&echo.DefaultBinder{}).BindQueryParams(c, &params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	format, err := domain.ParseAccountConfigFormat(params.Format)
+	if err != nil {
+		return handleError(c, err)
+	}
+
+	data, err := h.wordpressGbpUsecase.ExportWordpressGbp(c.Request().Context(), format)
+	if err != nil {
+		return handleError(c, err)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="wordpress-gbp.%s"`, format))
+	return c.Blob(http.StatusOK, format.ContentType(), data)
+}
+
 // UpdateWordpressGbp godoc
 // @Summary      WordPress GBP更新
 // @Description  WordPress GBPを更新します
@@ -688,6 +2355,164 @@ func (h *APIHandler) DeleteWordpressGbp(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// SyncGoogleReview godoc
+// @Summary      GBP口コミの同期
+// @Description  全てのGoogle Businessの口コミを取得して保存し、新着・低評価の口コミを通知します
+// @Tags         sync
+// @Accept       json
+// @Produce      json
+// @Success      200  {string}  string  "同期完了"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/sync/google-review [post]
+func (h *APIHandler) SyncGoogleReview(c echo.Context) error {
+	err := h.googleReviewUsecase.SyncReviews(c.Request().Context())
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "sync google review")
+}
+
+// GetGoogleReviewList godoc
+// @Summary      GBP口コミ一覧取得
+// @Description  保存済みのGBP口コミ一覧を取得します
+// @Tags         google-review
+// @Accept       json
+// @Produce      json
+// @Param        limit            query     int     false  "取得件数"
+// @Param        offset           query     int     false  "オフセット"
+// @Param        business_name    query     string  false  "ビジネス名（locations/xxx）"
+// @Param        max_star_rating  query     int     false  "指定した評価以下の口コミのみ"
+// @Param        unreplied        query     bool    false  "未返信の口コミのみ"
+// @Success      200  {object}  res.GoogleReviewList  "口コミ一覧"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-review [get]
+func (h *APIHandler) GetGoogleReviewList(c echo.Context) error {
+	var params req.GetGoogleReview
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googleReviewUsecase.GetReviewList(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// GetGoogleReview godoc
+// @Summary      GBP口コミ取得
+// @Description  GBP口コミを取得します
+// @Tags         google-review
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "口コミID"
+// @Success      200  {object}  res.GoogleReview  "口コミ"
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-review/{id} [get]
+func (h *APIHandler) GetGoogleReview(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googleReviewUsecase.GetReview(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ReplyGoogleReview godoc
+// @Summary      GBP口コミへの返信
+// @Description  GBP口コミに返信します。既に返信がある場合は更新します
+// @Tags         google-review
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                    true  "口コミID"
+// @Param        body  body      req.GoogleReviewReply  true  "返信内容"
+// @Success      200   {object}  res.GoogleReview  "返信後の口コミ"
+// @Failure      400   {string}  string  "不正なリクエスト"
+// @Failure      404   {string}  string  "見つかりません"
+// @Failure      500   {string}  string  "内部サーバーエラー"
+// @Router       /api/google-review/{id}/reply [put]
+func (h *APIHandler) ReplyGoogleReview(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	var body req.GoogleReviewReply
+	if err := c.Bind(&body); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	resp, err := h.googleReviewUsecase.ReplyReview(c.Request().Context(), id, body)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// DeleteGoogleReviewReply godoc
+// @Summary      GBP口コミの返信削除
+// @Description  GBP口コミへの返信を削除します
+// @Tags         google-review
+// @Param        id   path      int  true  "口コミID"
+// @Success      204
+// @Failure      400  {string}  string  "不正なリクエスト"
+// @Failure      404  {string}  string  "見つかりません"
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/google-review/{id}/reply [delete]
+func (h *APIHandler) DeleteGoogleReviewReply(c echo.Context) error {
+	var id int
+	if err := echo.PathParamsBinder(c).Int("id", &id).BindError(); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	err := h.googleReviewUsecase.DeleteReviewReply(c.Request().Context(), id)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// StartGoogleOAuth godoc
+// @Summary      Googleアカウント認証開始
+// @Description  Googleの認証画面にリダイレクトします。認証後は /api/oauth/google/callback に戻ります
+// @Tags         oauth
+// @Success      302
+// @Failure      500  {string}  string  "内部サーバーエラー"
+// @Router       /api/oauth/google/start [get]
+func (h *APIHandler) StartGoogleOAuth(c echo.Context) error {
+	authURL, err := h.googleOAuthUsecase.StartAuthorization(c.Request().Context())
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.Redirect(http.StatusFound, authURL)
+}
+
+// GoogleOAuthCallback godoc
+// @Summary      Googleアカウント認証コールバック
+// @Description  認証コードをトークンに交換して保存します。保存したトークンは再起動なしで反映されます
+// @Tags         oauth
+// @Produce      json
+// @Param        state  query     string  true   "state"
+// @Param        code   query     string  false  "認証コード"
+// @Param        error  query     string  false  "エラー"
+// @Success      200    {string}  string  "認証完了"
+// @Failure      400    {string}  string  "不正なリクエスト"
+// @Failure      500    {string}  string  "内部サーバーエラー"
+// @Router       /api/oauth/google/callback [get]
+func (h *APIHandler) GoogleOAuthCallback(c echo.Context) error {
+	var params req.GoogleOAuthCallback
+	if err := c.Bind(&params); err != nil {
+		return c.JSON(http.StatusBadRequest, err.Error())
+	}
+	err := h.googleOAuthUsecase.Callback(c.Request().Context(), params)
+	if err != nil {
+		return handleError(c, err)
+	}
+	return c.JSON(http.StatusOK, "Googleアカウントの認証が完了しました")
+}
+
 func handleError(c echo.Context, err error) error {
 	slog.Error("handleError", "error", err.Error())
 	switch {
@@ -695,10 +2520,18 @@ func handleError(c echo.Context, err error) error {
 		return c.JSON(http.StatusNotFound, res.ErrorResponse{Message: err.Error()})
 	case errors.Is(err, domain.ErrBadRequest):
 		return c.JSON(http.StatusBadRequest, res.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrUnauthorized):
+		return c.JSON(http.StatusUnauthorized, res.ErrorResponse{Message: err.Error()})
 	case errors.Is(err, domain.ErrWordpressConnection):
 		return c.JSON(http.StatusBadRequest, res.ErrorResponse{Message: err.Error()})
 	case errors.Is(err, domain.ErrInstagramConnection):
 		return c.JSON(http.StatusBadRequest, res.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrGoogleAccountNotFound):
+		return c.JSON(http.StatusBadRequest, res.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrFacebookConnection):
+		return c.JSON(http.StatusBadRequest, res.ErrorResponse{Message: err.Error()})
+	case errors.Is(err, domain.ErrFeedConnection):
+		return c.JSON(http.StatusBadRequest, res.ErrorResponse{Message: err.Error()})
 	default:
 		return c.JSON(http.StatusInternalServerError, res.ErrorResponse{Message: err.Error()})
 	}
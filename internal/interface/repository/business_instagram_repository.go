@@ -36,17 +36,23 @@ func (r *businessInstagramRepository) Get(ctx context.Context, f BusinessInstagr
 		return nil, err
 	}
 	return &domain.BusinessInstagram{
-		ID:            bi.ID,
-		Name:          bi.Name,
-		Memo:          bi.Memo,
-		InstagramID:   bi.InstagramID,
-		InstagramName: bi.InstagramName,
-		BusinessName:  bi.BusinessName,
-		BusinessTitle: bi.BusinessTitle,
-		StartDate:     bi.StartDate,
-		Status:        domain.Status(bi.Status),
-		UpdatedAt:     bi.UpdatedAt,
-		CreatedAt:     bi.CreatedAt,
+		ID:                 bi.ID,
+		Name:               bi.Name,
+		Memo:               bi.Memo,
+		InstagramID:        bi.InstagramID,
+		InstagramName:      bi.InstagramName,
+		BusinessName:       bi.BusinessName,
+		BusinessTitle:      bi.BusinessTitle,
+		MapsURL:            bi.MapsURL,
+		CallToActionType:   domain.CallToActionType(bi.CallToActionType),
+		CallToActionURL:    bi.CallToActionURL,
+		MediaCategory:      domain.GbpMediaCategory(bi.MediaCategory),
+		MediaCategoryRules: decodeMediaCategoryRules(bi.MediaCategoryRules),
+		SanitizeConfig:     decodeSanitizeConfig(bi.SanitizeConfig),
+		StartDate:          bi.StartDate,
+		Status:             domain.Status(bi.Status),
+		UpdatedAt:          bi.UpdatedAt,
+		CreatedAt:          bi.CreatedAt,
 	}, nil
 }
 
@@ -59,17 +65,23 @@ func (r *businessInstagramRepository) FindAll(ctx context.Context, f BusinessIns
 	businessInstagramList := make([]*domain.BusinessInstagram, 0, len(biList))
 	for _, bi := range biList {
 		businessInstagramList = append(businessInstagramList, &domain.BusinessInstagram{
-			ID:            bi.ID,
-			Name:          bi.Name,
-			Memo:          bi.Memo,
-			InstagramID:   bi.InstagramID,
-			InstagramName: bi.InstagramName,
-			BusinessName:  bi.BusinessName,
-			BusinessTitle: bi.BusinessTitle,
-			StartDate:     bi.StartDate,
-			Status:        domain.Status(bi.Status),
-			UpdatedAt:     bi.UpdatedAt,
-			CreatedAt:     bi.CreatedAt,
+			ID:                 bi.ID,
+			Name:               bi.Name,
+			Memo:               bi.Memo,
+			InstagramID:        bi.InstagramID,
+			InstagramName:      bi.InstagramName,
+			BusinessName:       bi.BusinessName,
+			BusinessTitle:      bi.BusinessTitle,
+			MapsURL:            bi.MapsURL,
+			CallToActionType:   domain.CallToActionType(bi.CallToActionType),
+			CallToActionURL:    bi.CallToActionURL,
+			MediaCategory:      domain.GbpMediaCategory(bi.MediaCategory),
+			MediaCategoryRules: decodeMediaCategoryRules(bi.MediaCategoryRules),
+			SanitizeConfig:     decodeSanitizeConfig(bi.SanitizeConfig),
+			StartDate:          bi.StartDate,
+			Status:             domain.Status(bi.Status),
+			UpdatedAt:          bi.UpdatedAt,
+			CreatedAt:          bi.CreatedAt,
 		})
 	}
 	return businessInstagramList, nil
@@ -98,29 +110,41 @@ func (r *businessInstagramRepository) Exists(ctx context.Context, f BusinessInst
 
 func (r *businessInstagramRepository) Update(ctx context.Context, businessInstagram *domain.BusinessInstagram, f BusinessInstagramFilter) error {
 	m := &model.BusinessInstagram{
-		ID:            businessInstagram.ID,
-		Name:          businessInstagram.Name,
-		Memo:          businessInstagram.Memo,
-		InstagramID:   businessInstagram.InstagramID,
-		InstagramName: businessInstagram.InstagramName,
-		BusinessName:  businessInstagram.BusinessName,
-		BusinessTitle: businessInstagram.BusinessTitle,
-		StartDate:     businessInstagram.StartDate,
-		Status:        int(businessInstagram.Status),
+		ID:                 businessInstagram.ID,
+		Name:               businessInstagram.Name,
+		Memo:               businessInstagram.Memo,
+		InstagramID:        businessInstagram.InstagramID,
+		InstagramName:      businessInstagram.InstagramName,
+		BusinessName:       businessInstagram.BusinessName,
+		BusinessTitle:      businessInstagram.BusinessTitle,
+		MapsURL:            businessInstagram.MapsURL,
+		CallToActionType:   string(businessInstagram.CallToActionType),
+		CallToActionURL:    businessInstagram.CallToActionURL,
+		MediaCategory:      string(businessInstagram.MediaCategory),
+		MediaCategoryRules: encodeMediaCategoryRules(businessInstagram.MediaCategoryRules),
+		SanitizeConfig:     encodeSanitizeConfig(businessInstagram.SanitizeConfig),
+		StartDate:          businessInstagram.StartDate,
+		Status:             int(businessInstagram.Status),
 	}
 	return r.getDB(ctx).Omit("created_at").Save(m).Error
 }
 
 func (r *businessInstagramRepository) Create(ctx context.Context, businessInstagram *domain.BusinessInstagram) error {
 	m := model.BusinessInstagram{
-		Name:          businessInstagram.Name,
-		Memo:          businessInstagram.Memo,
-		InstagramID:   businessInstagram.InstagramID,
-		InstagramName: businessInstagram.InstagramName,
-		BusinessName:  businessInstagram.BusinessName,
-		BusinessTitle: businessInstagram.BusinessTitle,
-		StartDate:     businessInstagram.StartDate,
-		Status:        int(businessInstagram.Status),
+		Name:               businessInstagram.Name,
+		Memo:               businessInstagram.Memo,
+		InstagramID:        businessInstagram.InstagramID,
+		InstagramName:      businessInstagram.InstagramName,
+		BusinessName:       businessInstagram.BusinessName,
+		BusinessTitle:      businessInstagram.BusinessTitle,
+		MapsURL:            businessInstagram.MapsURL,
+		CallToActionType:   string(businessInstagram.CallToActionType),
+		CallToActionURL:    businessInstagram.CallToActionURL,
+		MediaCategory:      string(businessInstagram.MediaCategory),
+		MediaCategoryRules: encodeMediaCategoryRules(businessInstagram.MediaCategoryRules),
+		SanitizeConfig:     encodeSanitizeConfig(businessInstagram.SanitizeConfig),
+		StartDate:          businessInstagram.StartDate,
+		Status:             int(businessInstagram.Status),
 	}
 	if err := r.getDB(ctx).Create(&m).Error; err != nil {
 		return err
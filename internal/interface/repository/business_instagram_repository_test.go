@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+func TestBusinessInstagramRepository_UpdateKeepsMapsURL(t *testing.T) {
+	repo := NewBusinessInstagramRepository(db)
+	ctx := context.Background()
+
+	bi := &domain.BusinessInstagram{
+		Name:             "Test Store",
+		InstagramID:      "123456789",
+		InstagramName:    "testuser",
+		BusinessName:     "locations/1",
+		BusinessTitle:    "Test Business",
+		MapsURL:          "https://maps.google.com/?cid=1",
+		CallToActionType: domain.CallToActionBook,
+		CallToActionURL:  "https://example.com/book",
+		StartDate:        time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Status:           1,
+	}
+	require.NoError(t, repo.Create(ctx, bi))
+	t.Cleanup(func() {
+		_ = repo.Delete(ctx, BusinessInstagramFilter{ID: util.Pointer(bi.ID)})
+	})
+
+	// 停止のように取得した連携の一部だけ変えて保存しても、他の項目は消えない
+	got, err := repo.Get(ctx, BusinessInstagramFilter{ID: util.Pointer(bi.ID)})
+	require.NoError(t, err)
+	assert.Equal(t, "https://maps.google.com/?cid=1", got.MapsURL)
+	got.Status = 0
+	require.NoError(t, repo.Update(ctx, got, BusinessInstagramFilter{ID: util.Pointer(bi.ID)}))
+
+	list, err := repo.FindAll(ctx, BusinessInstagramFilter{ID: util.Pointer(bi.ID)})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, domain.Status(0), list[0].Status)
+	assert.Equal(t, "https://maps.google.com/?cid=1", list[0].MapsURL)
+	assert.Equal(t, domain.CallToActionBook, list[0].CallToActionType)
+}
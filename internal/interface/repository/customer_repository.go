@@ -135,11 +135,15 @@ func (a *customerRepository) FindAllCustomers(ctx context.Context, filter Custom
 	result := make([]*domain.Customer, 0, len(customers))
 	for _, customer := range customers {
 		result = append(result, &domain.Customer{
-			ID:                         customer.ID,
-			Name:                       customer.Name,
-			WordpressUrl:               customer.WordpressURL,
-			FacebookToken:              customer.FacebookToken,
-			InstagramBusinessAccountID: strings.Split(customer.InstagramBusinessAccountID, ","),
+			ID:                           customer.ID,
+			Name:                         customer.Name,
+			WordpressUrl:                 customer.WordpressURL,
+			FacebookToken:                customer.FacebookToken,
+			StartDate:                    customer.StartDate,
+			InstagramBusinessAccountID:   strings.Split(customer.InstagramBusinessAccountID, ","),
+			InstagramBusinessAccountName: strings.Split(customer.InstagramBusinessAccountName, ","),
+			InstagramTokenStatus:         customer.InstagramTokenStatus,
+			DeleteHash:                   customer.DeleteHash,
 		})
 	}
 	return result, nil
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type FacebookInstagramRepository interface {
+	Get(ctx context.Context, f FacebookInstagramFilter) (*domain.FacebookInstagram, error)
+	FindAll(ctx context.Context, f FacebookInstagramFilter) ([]*domain.FacebookInstagram, error)
+	Count(ctx context.Context, f FacebookInstagramFilter) (int64, error)
+	Update(ctx context.Context, item *domain.FacebookInstagram, f FacebookInstagramFilter) error
+	Create(ctx context.Context, facebookInstagram *domain.FacebookInstagram) error
+	Delete(ctx context.Context, f FacebookInstagramFilter) error
+}
+
+type facebookInstagramRepository struct {
+	db *gorm.DB
+}
+
+func NewFacebookInstagramRepository(db *gorm.DB) FacebookInstagramRepository {
+	return &facebookInstagramRepository{
+		db: db,
+	}
+}
+
+func (r *facebookInstagramRepository) Get(ctx context.Context, f FacebookInstagramFilter) (*domain.FacebookInstagram, error) {
+	var fi model.FacebookInstagram
+	err := f.Mod(r.getDB(ctx)).Find(&fi).Error
+	if err != nil {
+		return nil, err
+	}
+	return toFacebookInstagramDomain(&fi), nil
+}
+
+func (r *facebookInstagramRepository) FindAll(ctx context.Context, f FacebookInstagramFilter) ([]*domain.FacebookInstagram, error) {
+	var fiList []*model.FacebookInstagram
+	err := f.Mod(r.getDB(ctx)).Find(&fiList).Error
+	if err != nil {
+		return nil, err
+	}
+	facebookInstagramList := make([]*domain.FacebookInstagram, 0, len(fiList))
+	for _, fi := range fiList {
+		facebookInstagramList = append(facebookInstagramList, toFacebookInstagramDomain(fi))
+	}
+	return facebookInstagramList, nil
+}
+
+func (r *facebookInstagramRepository) Count(ctx context.Context, f FacebookInstagramFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.FacebookInstagram{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *facebookInstagramRepository) Update(ctx context.Context, facebookInstagram *domain.FacebookInstagram, f FacebookInstagramFilter) error {
+	m := toFacebookInstagramModel(facebookInstagram)
+	m.ID = facebookInstagram.ID
+	return r.getDB(ctx).Omit("created_at").Save(m).Error
+}
+
+func (r *facebookInstagramRepository) Create(ctx context.Context, facebookInstagram *domain.FacebookInstagram) error {
+	m := toFacebookInstagramModel(facebookInstagram)
+	if err := r.getDB(ctx).Create(m).Error; err != nil {
+		return err
+	}
+	facebookInstagram.ID = m.ID
+	return nil
+}
+
+func (r *facebookInstagramRepository) Delete(ctx context.Context, f FacebookInstagramFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.FacebookInstagram{}).Error
+}
+
+func (r *facebookInstagramRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func toFacebookInstagramDomain(fi *model.FacebookInstagram) *domain.FacebookInstagram {
+	return &domain.FacebookInstagram{
+		ID:               fi.ID,
+		Name:             fi.Name,
+		Memo:             fi.Memo,
+		InstagramID:      fi.InstagramID,
+		InstagramName:    fi.InstagramName,
+		FacebookPageID:   fi.FacebookPageID,
+		FacebookPageName: fi.FacebookPageName,
+		StartDate:        fi.StartDate,
+		Status:           domain.Status(fi.Status),
+		DeleteHash:       fi.DeleteHash,
+		UpdatedAt:        fi.UpdatedAt,
+		CreatedAt:        fi.CreatedAt,
+	}
+}
+
+func toFacebookInstagramModel(fi *domain.FacebookInstagram) *model.FacebookInstagram {
+	return &model.FacebookInstagram{
+		Name:             fi.Name,
+		Memo:             fi.Memo,
+		InstagramID:      fi.InstagramID,
+		InstagramName:    fi.InstagramName,
+		FacebookPageID:   fi.FacebookPageID,
+		FacebookPageName: fi.FacebookPageName,
+		StartDate:        fi.StartDate,
+		Status:           int(fi.Status),
+		DeleteHash:       fi.DeleteHash,
+	}
+}
+
+type FacebookInstagramFilter struct {
+	ID             *int
+	InstagramID    *string
+	FacebookPageID *string
+	Status         *int
+	Limit          *int
+	Offset         *int
+
+	PartialName             *string
+	PartialInstagramName    *string
+	PartialFacebookPageName *string
+	OrderByIDDesc           *bool
+}
+
+func (p *FacebookInstagramFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.ID != nil {
+		db = db.Where("id = ?", *p.ID)
+	}
+	if p.InstagramID != nil {
+		db = db.Where("instagram_id = ?", *p.InstagramID)
+	}
+	if p.FacebookPageID != nil {
+		db = db.Where("facebook_page_id = ?", *p.FacebookPageID)
+	}
+	if p.Status != nil {
+		db = db.Where("status = ?", *p.Status)
+	}
+	if p.PartialName != nil || p.PartialInstagramName != nil || p.PartialFacebookPageName != nil {
+		var orConditions []string
+		var orValues []interface{}
+		if p.PartialName != nil {
+			orConditions = append(orConditions, "name like ?")
+			orValues = append(orValues, "%"+*p.PartialName+"%")
+		}
+		if p.PartialInstagramName != nil {
+			orConditions = append(orConditions, "instagram_name like ?")
+			orValues = append(orValues, "%"+*p.PartialInstagramName+"%")
+		}
+		if p.PartialFacebookPageName != nil {
+			orConditions = append(orConditions, "facebook_page_name like ?")
+			orValues = append(orValues, "%"+*p.PartialFacebookPageName+"%")
+		}
+		query := orConditions[0]
+		for i := 1; i < len(orConditions); i++ {
+			query += " OR " + orConditions[i]
+		}
+		db = db.Where(query, orValues...)
+	}
+	if p.OrderByIDDesc != nil {
+		db = db.Order("id desc")
+	}
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type FacebookPostRepository interface {
+	FindAll(ctx context.Context, f FacebookPostFilter) ([]*domain.FacebookPost, error)
+	Count(ctx context.Context, f FacebookPostFilter) (int64, error)
+	Exists(ctx context.Context, f FacebookPostFilter) (bool, error)
+	Create(ctx context.Context, post *domain.FacebookPost) error
+	Delete(ctx context.Context, f FacebookPostFilter) error
+}
+
+type facebookPostRepository struct {
+	db *gorm.DB
+}
+
+func NewFacebookPostRepository(db *gorm.DB) FacebookPostRepository {
+	return &facebookPostRepository{
+		db: db,
+	}
+}
+
+func (r *facebookPostRepository) FindAll(ctx context.Context, f FacebookPostFilter) ([]*domain.FacebookPost, error) {
+	var posts []*model.FacebookPost
+	err := f.Mod(r.getDB(ctx)).Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	postList := make([]*domain.FacebookPost, 0, len(posts))
+	for _, p := range posts {
+		postList = append(postList, &domain.FacebookPost{
+			ID:                  p.ID,
+			FacebookInstagramID: p.FacebookInstagramID,
+			MediaID:             p.MediaID,
+			Permalink:           p.Permalink,
+			FacebookPostID:      p.FacebookPostID,
+			FacebookURL:         p.FacebookURL,
+			CreatedAt:           p.CreatedAt,
+		})
+	}
+	return postList, nil
+}
+
+func (r *facebookPostRepository) Count(ctx context.Context, f FacebookPostFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.FacebookPost{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *facebookPostRepository) Exists(ctx context.Context, f FacebookPostFilter) (bool, error) {
+	var posts []*model.FacebookPost
+	err := f.Mod(r.getDB(ctx)).Find(&posts).Error
+	if err != nil {
+		return false, err
+	}
+	return len(posts) > 0, nil
+}
+
+func (r *facebookPostRepository) Create(ctx context.Context, post *domain.FacebookPost) error {
+	m := model.FacebookPost{
+		FacebookInstagramID: post.FacebookInstagramID,
+		MediaID:             post.MediaID,
+		Permalink:           post.Permalink,
+		FacebookPostID:      post.FacebookPostID,
+		FacebookURL:         post.FacebookURL,
+	}
+	if err := r.getDB(ctx).Create(&m).Error; err != nil {
+		return err
+	}
+	post.ID = m.ID
+	post.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *facebookPostRepository) Delete(ctx context.Context, f FacebookPostFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.FacebookPost{}).Error
+}
+
+func (r *facebookPostRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+type FacebookPostFilter struct {
+	FacebookInstagramID *int
+	MediaID             *string
+	Limit               *int
+	Offset              *int
+}
+
+func (p *FacebookPostFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.FacebookInstagramID != nil {
+		db = db.Where("facebook_instagram_id = ?", *p.FacebookInstagramID)
+	}
+	if p.MediaID != nil {
+		db = db.Where("media_id = ?", *p.MediaID)
+	}
+	db = db.Order("id desc")
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type FeedPostRepository interface {
+	FindAll(ctx context.Context, f FeedPostFilter) ([]*domain.FeedPost, error)
+	Count(ctx context.Context, f FeedPostFilter) (int64, error)
+	Exists(ctx context.Context, f FeedPostFilter) (bool, error)
+	Create(ctx context.Context, post *domain.FeedPost) error
+	Delete(ctx context.Context, f FeedPostFilter) error
+}
+
+type feedPostRepository struct {
+	db *gorm.DB
+}
+
+func NewFeedPostRepository(db *gorm.DB) FeedPostRepository {
+	return &feedPostRepository{
+		db: db,
+	}
+}
+
+func (r *feedPostRepository) FindAll(ctx context.Context, f FeedPostFilter) ([]*domain.FeedPost, error) {
+	var posts []*model.FeedPost
+	err := f.Mod(r.getDB(ctx)).Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+	postList := make([]*domain.FeedPost, 0, len(posts))
+	for _, p := range posts {
+		postList = append(postList, &domain.FeedPost{
+			ID:          p.ID,
+			FeedID:      p.FeedID,
+			Destination: domain.FeedDestination(p.Destination),
+			GUID:        p.GUID,
+			Link:        p.Link,
+			URL:         p.URL,
+			CreatedAt:   p.CreatedAt,
+		})
+	}
+	return postList, nil
+}
+
+func (r *feedPostRepository) Count(ctx context.Context, f FeedPostFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.FeedPost{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *feedPostRepository) Exists(ctx context.Context, f FeedPostFilter) (bool, error) {
+	var posts []*model.FeedPost
+	err := f.Mod(r.getDB(ctx)).Find(&posts).Error
+	if err != nil {
+		return false, err
+	}
+	return len(posts) > 0, nil
+}
+
+func (r *feedPostRepository) Create(ctx context.Context, post *domain.FeedPost) error {
+	m := model.FeedPost{
+		FeedID:      post.FeedID,
+		Destination: string(post.Destination),
+		GUIDHash:    domain.FeedGUIDHash(post.GUID),
+		GUID:        post.GUID,
+		Link:        post.Link,
+		URL:         post.URL,
+	}
+	if err := r.getDB(ctx).Create(&m).Error; err != nil {
+		return err
+	}
+	post.ID = m.ID
+	post.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *feedPostRepository) Delete(ctx context.Context, f FeedPostFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.FeedPost{}).Error
+}
+
+func (r *feedPostRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+type FeedPostFilter struct {
+	FeedID      *int
+	Destination *domain.FeedDestination
+	GUIDHash    *string
+	Limit       *int
+	Offset      *int
+}
+
+func (p *FeedPostFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.FeedID != nil {
+		db = db.Where("feed_id = ?", *p.FeedID)
+	}
+	if p.Destination != nil {
+		db = db.Where("destination = ?", string(*p.Destination))
+	}
+	if p.GUIDHash != nil {
+		db = db.Where("guid_hash = ?", *p.GUIDHash)
+	}
+	db = db.Order("id desc")
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type FeedRepository interface {
+	Get(ctx context.Context, f FeedFilter) (*domain.Feed, error)
+	FindAll(ctx context.Context, f FeedFilter) ([]*domain.Feed, error)
+	Count(ctx context.Context, f FeedFilter) (int64, error)
+	Update(ctx context.Context, item *domain.Feed, f FeedFilter) error
+	Create(ctx context.Context, feed *domain.Feed) error
+	Delete(ctx context.Context, f FeedFilter) error
+}
+
+type feedRepository struct {
+	db *gorm.DB
+}
+
+func NewFeedRepository(db *gorm.DB) FeedRepository {
+	return &feedRepository{
+		db: db,
+	}
+}
+
+func (r *feedRepository) Get(ctx context.Context, f FeedFilter) (*domain.Feed, error) {
+	var feed model.Feed
+	err := f.Mod(r.getDB(ctx)).Find(&feed).Error
+	if err != nil {
+		return nil, err
+	}
+	return toFeedDomain(&feed), nil
+}
+
+func (r *feedRepository) FindAll(ctx context.Context, f FeedFilter) ([]*domain.Feed, error) {
+	var feeds []*model.Feed
+	err := f.Mod(r.getDB(ctx)).Find(&feeds).Error
+	if err != nil {
+		return nil, err
+	}
+	feedList := make([]*domain.Feed, 0, len(feeds))
+	for _, feed := range feeds {
+		feedList = append(feedList, toFeedDomain(feed))
+	}
+	return feedList, nil
+}
+
+func (r *feedRepository) Count(ctx context.Context, f FeedFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.Feed{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *feedRepository) Update(ctx context.Context, feed *domain.Feed, f FeedFilter) error {
+	m := toFeedModel(feed)
+	m.ID = feed.ID
+	return r.getDB(ctx).Omit("created_at").Save(m).Error
+}
+
+func (r *feedRepository) Create(ctx context.Context, feed *domain.Feed) error {
+	m := toFeedModel(feed)
+	if err := r.getDB(ctx).Create(m).Error; err != nil {
+		return err
+	}
+	feed.ID = m.ID
+	return nil
+}
+
+func (r *feedRepository) Delete(ctx context.Context, f FeedFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.Feed{}).Error
+}
+
+func (r *feedRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func toFeedDomain(feed *model.Feed) *domain.Feed {
+	var categories []string
+	if feed.Categories != "" {
+		categories = strings.Split(feed.Categories, ",")
+	}
+	return &domain.Feed{
+		ID:                 feed.ID,
+		Name:               feed.Name,
+		Memo:               feed.Memo,
+		FeedURL:            feed.FeedURL,
+		FeedTitle:          feed.FeedTitle,
+		StartDate:          feed.StartDate,
+		Status:             domain.Status(feed.Status),
+		WordpressDomain:    feed.WordpressDomain,
+		WordpressSiteTitle: feed.WordpressSiteTitle,
+		APIMode:            domain.WordpressAPIMode(feed.APIMode),
+		WordpressUsername:  feed.WordpressUsername,
+		AppPassword:        feed.AppPassword,
+		Categories:         categories,
+		BusinessName:       feed.BusinessName,
+		BusinessTitle:      feed.BusinessTitle,
+		CallToActionType:   domain.CallToActionType(feed.CallToActionType),
+		CallToActionURL:    feed.CallToActionURL,
+		UpdatedAt:          feed.UpdatedAt,
+		CreatedAt:          feed.CreatedAt,
+	}
+}
+
+func toFeedModel(feed *domain.Feed) *model.Feed {
+	return &model.Feed{
+		Name:               feed.Name,
+		Memo:               feed.Memo,
+		FeedURL:            feed.FeedURL,
+		FeedTitle:          feed.FeedTitle,
+		StartDate:          feed.StartDate,
+		Status:             int(feed.Status),
+		WordpressDomain:    feed.WordpressDomain,
+		WordpressSiteTitle: feed.WordpressSiteTitle,
+		APIMode:            string(feed.APIMode),
+		WordpressUsername:  feed.WordpressUsername,
+		AppPassword:        feed.AppPassword,
+		Categories:         strings.Join(feed.Categories, ","),
+		BusinessName:       feed.BusinessName,
+		BusinessTitle:      feed.BusinessTitle,
+		CallToActionType:   string(feed.CallToActionType),
+		CallToActionURL:    feed.CallToActionURL,
+	}
+}
+
+type FeedFilter struct {
+	ID     *int
+	Status *int
+	Limit  *int
+	Offset *int
+
+	PartialName    *string
+	PartialFeedURL *string
+	OrderByIDDesc  *bool
+}
+
+func (p *FeedFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.ID != nil {
+		db = db.Where("id = ?", *p.ID)
+	}
+	if p.Status != nil {
+		db = db.Where("status = ?", *p.Status)
+	}
+	if p.PartialName != nil || p.PartialFeedURL != nil {
+		var orConditions []string
+		var orValues []interface{}
+		if p.PartialName != nil {
+			orConditions = append(orConditions, "name like ?")
+			orValues = append(orValues, "%"+*p.PartialName+"%")
+		}
+		if p.PartialFeedURL != nil {
+			orConditions = append(orConditions, "feed_url like ?")
+			orValues = append(orValues, "%"+*p.PartialFeedURL+"%")
+		}
+		db = db.Where(strings.Join(orConditions, " OR "), orValues...)
+	}
+	if p.OrderByIDDesc != nil {
+		db = db.Order("id desc")
+	}
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+)
+
+func encodeMediaCategoryRules(rules []domain.GbpMediaCategoryRule) string {
+	if len(rules) == 0 {
+		return ""
+	}
+	list := make([]model.GbpMediaCategoryRule, 0, len(rules))
+	for _, rule := range rules {
+		list = append(list, model.GbpMediaCategoryRule{
+			Keyword:  rule.Keyword,
+			Category: string(rule.Category),
+		})
+	}
+	data, _ := json.Marshal(list)
+	return string(data)
+}
+
+func decodeMediaCategoryRules(data string) []domain.GbpMediaCategoryRule {
+	if data == "" {
+		return nil
+	}
+	var list []model.GbpMediaCategoryRule
+	if err := json.Unmarshal([]byte(data), &list); err != nil {
+		slog.Warn("media_category_rules のパースに失敗", "error", err.Error())
+		return nil
+	}
+	rules := make([]domain.GbpMediaCategoryRule, 0, len(list))
+	for _, rule := range list {
+		rules = append(rules, domain.GbpMediaCategoryRule{
+			Keyword:  rule.Keyword,
+			Category: domain.GbpMediaCategory(rule.Category),
+		})
+	}
+	return rules
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GbpPostRejectionRepository interface {
+	FindAll(ctx context.Context, f GbpPostRejectionFilter) ([]*domain.GbpPostRejection, error)
+	Count(ctx context.Context, f GbpPostRejectionFilter) (int64, error)
+	Upsert(ctx context.Context, rejection *domain.GbpPostRejection) error
+	Delete(ctx context.Context, f GbpPostRejectionFilter) error
+}
+
+type gbpPostRejectionRepository struct {
+	db *gorm.DB
+}
+
+func NewGbpPostRejectionRepository(db *gorm.DB) GbpPostRejectionRepository {
+	return &gbpPostRejectionRepository{
+		db: db,
+	}
+}
+
+func (r *gbpPostRejectionRepository) FindAll(ctx context.Context, f GbpPostRejectionFilter) ([]*domain.GbpPostRejection, error) {
+	var rejections []*model.GbpPostRejection
+	err := f.Mod(r.getDB(ctx)).Find(&rejections).Error
+	if err != nil {
+		return nil, err
+	}
+	rejectionList := make([]*domain.GbpPostRejection, 0, len(rejections))
+	for _, m := range rejections {
+		rejectionList = append(rejectionList, &domain.GbpPostRejection{
+			ID:           m.ID,
+			CustomerID:   m.CustomerID,
+			MediaID:      m.MediaID,
+			BusinessName: m.BusinessName,
+			Reason:       domain.GbpRejectionReason(m.Reason),
+			Field:        m.Field,
+			Message:      m.Message,
+			Summary:      m.Summary,
+			Count:        m.Count,
+			UpdatedAt:    m.UpdatedAt,
+			CreatedAt:    m.CreatedAt,
+		})
+	}
+	return rejectionList, nil
+}
+
+func (r *gbpPostRejectionRepository) Count(ctx context.Context, f GbpPostRejectionFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.GbpPostRejection{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// Upsert は (customer_id, media_id) が同じ行があれば最新の内容で上書きし、回数を増やす。
+func (r *gbpPostRejectionRepository) Upsert(ctx context.Context, rejection *domain.GbpPostRejection) error {
+	m := model.GbpPostRejection{
+		CustomerID:   rejection.CustomerID,
+		MediaID:      rejection.MediaID,
+		BusinessName: rejection.BusinessName,
+		Reason:       string(rejection.Reason),
+		Field:        rejection.Field,
+		Message:      rejection.Message,
+		Summary:      rejection.Summary,
+		Count:        1,
+	}
+	return r.getDB(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"business_name": m.BusinessName,
+			"reason":        m.Reason,
+			"field":         m.Field,
+			"message":       m.Message,
+			"summary":       m.Summary,
+			"count":         gorm.Expr("count + 1"),
+		}),
+	}).Create(&m).Error
+}
+
+func (r *gbpPostRejectionRepository) Delete(ctx context.Context, f GbpPostRejectionFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.GbpPostRejection{}).Error
+}
+
+func (r *gbpPostRejectionRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+type GbpPostRejectionFilter struct {
+	CustomerID *int
+	MediaID    *string
+	Reason     *string
+	Limit      *int
+	Offset     *int
+}
+
+func (p *GbpPostRejectionFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.CustomerID != nil {
+		db = db.Where("customer_id = ?", *p.CustomerID)
+	}
+	if p.MediaID != nil {
+		db = db.Where("media_id = ?", *p.MediaID)
+	}
+	if p.Reason != nil {
+		db = db.Where("reason = ?", *p.Reason)
+	}
+	db = db.Order("updated_at desc")
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
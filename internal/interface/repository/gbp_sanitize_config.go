@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+)
+
+// encodeSanitizeConfig は未設定（nil）の場合は空文字を返す。
+func encodeSanitizeConfig(config *domain.GbpSanitizeConfig) string {
+	if config == nil {
+		return ""
+	}
+	m := model.GbpSanitizeConfig{
+		Rules:    make([]string, 0, len(config.Rules)),
+		Patterns: config.Patterns,
+	}
+	for _, rule := range config.Rules {
+		m.Rules = append(m.Rules, string(rule))
+	}
+	data, _ := json.Marshal(m)
+	return string(data)
+}
+
+func decodeSanitizeConfig(data string) *domain.GbpSanitizeConfig {
+	if data == "" {
+		return nil
+	}
+	var m model.GbpSanitizeConfig
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		slog.Warn("sanitize_config のパースに失敗", "error", err.Error())
+		return nil
+	}
+	config := &domain.GbpSanitizeConfig{Patterns: m.Patterns}
+	for _, rule := range m.Rules {
+		config.Rules = append(config.Rules, domain.GbpSanitizeRule(rule))
+	}
+	return config
+}
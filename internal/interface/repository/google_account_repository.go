@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type GoogleAccountRepository interface {
+	Get(ctx context.Context, f GoogleAccountFilter) (*domain.GoogleAccount, error)
+	FindAll(ctx context.Context, f GoogleAccountFilter) ([]*domain.GoogleAccount, error)
+	Update(ctx context.Context, item *domain.GoogleAccount, f GoogleAccountFilter) error
+	Create(ctx context.Context, googleAccount *domain.GoogleAccount) error
+	Delete(ctx context.Context, f GoogleAccountFilter) error
+}
+
+type googleAccountRepository struct {
+	db *gorm.DB
+}
+
+func NewGoogleAccountRepository(db *gorm.DB) GoogleAccountRepository {
+	return &googleAccountRepository{
+		db: db,
+	}
+}
+
+func (r *googleAccountRepository) Get(ctx context.Context, f GoogleAccountFilter) (*domain.GoogleAccount, error) {
+	var ga model.GoogleAccount
+	err := f.Mod(r.getDB(ctx)).Find(&ga).Error
+	if err != nil {
+		return nil, err
+	}
+	return toGoogleAccountDomain(&ga), nil
+}
+
+func (r *googleAccountRepository) FindAll(ctx context.Context, f GoogleAccountFilter) ([]*domain.GoogleAccount, error) {
+	var gaList []*model.GoogleAccount
+	err := f.Mod(r.getDB(ctx)).Find(&gaList).Error
+	if err != nil {
+		return nil, err
+	}
+	googleAccountList := make([]*domain.GoogleAccount, 0, len(gaList))
+	for _, ga := range gaList {
+		googleAccountList = append(googleAccountList, toGoogleAccountDomain(ga))
+	}
+	return googleAccountList, nil
+}
+
+func (r *googleAccountRepository) Update(ctx context.Context, googleAccount *domain.GoogleAccount, f GoogleAccountFilter) error {
+	m := toGoogleAccountModel(googleAccount)
+	m.ID = googleAccount.ID
+	return r.getDB(ctx).Omit("created_at").Save(m).Error
+}
+
+func (r *googleAccountRepository) Create(ctx context.Context, googleAccount *domain.GoogleAccount) error {
+	m := toGoogleAccountModel(googleAccount)
+	if err := r.getDB(ctx).Create(m).Error; err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return domain.ErrDuplicate
+		}
+		return err
+	}
+	googleAccount.ID = m.ID
+	googleAccount.CreatedAt = m.CreatedAt
+	googleAccount.UpdatedAt = m.UpdatedAt
+	return nil
+}
+
+func (r *googleAccountRepository) Delete(ctx context.Context, f GoogleAccountFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.GoogleAccount{}).Error
+}
+
+func (r *googleAccountRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func toGoogleAccountDomain(ga *model.GoogleAccount) *domain.GoogleAccount {
+	return &domain.GoogleAccount{
+		ID:                 ga.ID,
+		Name:               ga.Name,
+		AccountName:        ga.AccountName,
+		Type:               ga.Type,
+		GoogleOAuthTokenID: ga.GoogleOAuthTokenID,
+		UpdatedAt:          ga.UpdatedAt,
+		CreatedAt:          ga.CreatedAt,
+	}
+}
+
+func toGoogleAccountModel(googleAccount *domain.GoogleAccount) *model.GoogleAccount {
+	return &model.GoogleAccount{
+		Name:               googleAccount.Name,
+		AccountName:        googleAccount.AccountName,
+		Type:               googleAccount.Type,
+		GoogleOAuthTokenID: googleAccount.GoogleOAuthTokenID,
+	}
+}
+
+type GoogleAccountFilter struct {
+	ID                 *int
+	Name               *string
+	GoogleOAuthTokenID *int
+	All                *bool
+}
+
+func (p *GoogleAccountFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.All != nil && *p.All {
+		return db.Where("1 = 1")
+	}
+	if p.ID != nil {
+		db = db.Where("id = ?", *p.ID)
+	}
+	if p.Name != nil {
+		db = db.Where("name = ?", *p.Name)
+	}
+	if p.GoogleOAuthTokenID != nil {
+		db = db.Where("google_oauth_token_id = ?", *p.GoogleOAuthTokenID)
+	}
+	return db
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type GoogleBusinessLocationChangeRepository interface {
+	FindAll(ctx context.Context, f GoogleBusinessLocationChangeFilter) ([]*domain.GoogleBusinessLocationChange, error)
+	Count(ctx context.Context, f GoogleBusinessLocationChangeFilter) (int64, error)
+	BulkCreate(ctx context.Context, changes []*domain.GoogleBusinessLocationChange) error
+}
+
+type googleBusinessLocationChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewGoogleBusinessLocationChangeRepository(db *gorm.DB) GoogleBusinessLocationChangeRepository {
+	return &googleBusinessLocationChangeRepository{
+		db: db,
+	}
+}
+
+func (r *googleBusinessLocationChangeRepository) FindAll(ctx context.Context, f GoogleBusinessLocationChangeFilter) ([]*domain.GoogleBusinessLocationChange, error) {
+	var changes []*model.GoogleBusinessLocationChange
+	err := f.Mod(r.getDB(ctx)).Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	changeList := make([]*domain.GoogleBusinessLocationChange, 0, len(changes))
+	for _, c := range changes {
+		changeList = append(changeList, &domain.GoogleBusinessLocationChange{
+			ID:           c.ID,
+			BusinessName: c.BusinessName,
+			Field:        c.Field,
+			Before:       c.Before,
+			After:        c.After,
+			CreatedAt:    c.CreatedAt,
+		})
+	}
+	return changeList, nil
+}
+
+func (r *googleBusinessLocationChangeRepository) Count(ctx context.Context, f GoogleBusinessLocationChangeFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.GoogleBusinessLocationChange{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *googleBusinessLocationChangeRepository) BulkCreate(ctx context.Context, changes []*domain.GoogleBusinessLocationChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	models := make([]*model.GoogleBusinessLocationChange, 0, len(changes))
+	for _, c := range changes {
+		models = append(models, &model.GoogleBusinessLocationChange{
+			BusinessName: c.BusinessName,
+			Field:        c.Field,
+			Before:       c.Before,
+			After:        c.After,
+		})
+	}
+	if err := r.getDB(ctx).Create(&models).Error; err != nil {
+		return err
+	}
+	for i, m := range models {
+		changes[i].ID = m.ID
+		changes[i].CreatedAt = m.CreatedAt
+	}
+	return nil
+}
+
+func (r *googleBusinessLocationChangeRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+type GoogleBusinessLocationChangeFilter struct {
+	BusinessName *string
+	Limit        *int
+	Offset       *int
+}
+
+func (p *GoogleBusinessLocationChangeFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.BusinessName != nil {
+		db = db.Where("business_name = ?", *p.BusinessName)
+	}
+	db = db.Order("id desc")
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GoogleBusinessMetricRepository interface {
+	FindAll(ctx context.Context, f GoogleBusinessMetricFilter) ([]*domain.GoogleBusinessMetric, error)
+	Count(ctx context.Context, f GoogleBusinessMetricFilter) (int64, error)
+	LatestDate(ctx context.Context, businessName string) (*time.Time, error)
+	Upsert(ctx context.Context, metrics []*domain.GoogleBusinessMetric) error
+	Delete(ctx context.Context, f GoogleBusinessMetricFilter) error
+}
+
+type googleBusinessMetricRepository struct {
+	db *gorm.DB
+}
+
+func NewGoogleBusinessMetricRepository(db *gorm.DB) GoogleBusinessMetricRepository {
+	return &googleBusinessMetricRepository{
+		db: db,
+	}
+}
+
+func (r *googleBusinessMetricRepository) FindAll(ctx context.Context, f GoogleBusinessMetricFilter) ([]*domain.GoogleBusinessMetric, error) {
+	var mList []*model.GoogleBusinessMetric
+	err := f.Mod(r.getDB(ctx)).Find(&mList).Error
+	if err != nil {
+		return nil, err
+	}
+	metricList := make([]*domain.GoogleBusinessMetric, 0, len(mList))
+	for _, m := range mList {
+		metricList = append(metricList, &domain.GoogleBusinessMetric{
+			ID:           m.ID,
+			BusinessName: m.BusinessName,
+			Metric:       m.Metric,
+			Date:         m.Date,
+			Value:        m.Value,
+		})
+	}
+	return metricList, nil
+}
+
+func (r *googleBusinessMetricRepository) Count(ctx context.Context, f GoogleBusinessMetricFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.GoogleBusinessMetric{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// LatestDate は保存済みの最新日付を返す。未保存の場合は nil を返す。
+func (r *googleBusinessMetricRepository) LatestDate(ctx context.Context, businessName string) (*time.Time, error) {
+	var m model.GoogleBusinessMetric
+	err := r.getDB(ctx).
+		Where("business_name = ?", businessName).
+		Order("date desc").
+		Limit(1).
+		Find(&m).Error
+	if err != nil {
+		return nil, err
+	}
+	if m.ID == 0 {
+		return nil, nil
+	}
+	return &m.Date, nil
+}
+
+// Upsert は (business_name, metric, date) が同じ行があれば値を更新する。
+// GBPの指標は数日遅れで確定するため、直近の日付は再取得して上書きする。
+func (r *googleBusinessMetricRepository) Upsert(ctx context.Context, metrics []*domain.GoogleBusinessMetric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+	mList := make([]model.GoogleBusinessMetric, 0, len(metrics))
+	for _, metric := range metrics {
+		mList = append(mList, model.GoogleBusinessMetric{
+			BusinessName: metric.BusinessName,
+			Metric:       metric.Metric,
+			Date:         metric.Date,
+			Value:        metric.Value,
+		})
+	}
+	return r.getDB(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+	}).CreateInBatches(&mList, 500).Error
+}
+
+func (r *googleBusinessMetricRepository) Delete(ctx context.Context, f GoogleBusinessMetricFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.GoogleBusinessMetric{}).Error
+}
+
+func (r *googleBusinessMetricRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+type GoogleBusinessMetricFilter struct {
+	BusinessName *string
+	Metric       *string
+	DateFrom     *time.Time
+	DateTo       *time.Time
+	Limit        *int
+	Offset       *int
+
+	OrderByDateAsc *bool
+}
+
+func (p *GoogleBusinessMetricFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.BusinessName != nil {
+		db = db.Where("business_name = ?", *p.BusinessName)
+	}
+	if p.Metric != nil {
+		db = db.Where("metric = ?", *p.Metric)
+	}
+	if p.DateFrom != nil {
+		db = db.Where("date >= ?", p.DateFrom.Format("2006-01-02"))
+	}
+	if p.DateTo != nil {
+		db = db.Where("date <= ?", p.DateTo.Format("2006-01-02"))
+	}
+	if p.OrderByDateAsc != nil {
+		db = db.Order("date asc")
+	}
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
@@ -36,10 +36,14 @@ func (r *googleBusinessRepository) Get(ctx context.Context, f GoogleBusinessFilt
 		return nil, err
 	}
 	return &domain.GoogleBusinesses{
-		ID:        gb.ID,
-		Name:      gb.Name,
-		Title:     gb.Title,
-		CreatedAt: gb.CreatedAt,
+		ID:                     gb.ID,
+		GoogleAccountID:        gb.GoogleAccountID,
+		Name:                   gb.Name,
+		Title:                  gb.Title,
+		PhotoRetentionCount:    gb.PhotoRetentionCount,
+		LocalPostRetentionDays: gb.LocalPostRetentionDays,
+		ReviewsImportedAt:      gb.ReviewsImportedAt,
+		CreatedAt:              gb.CreatedAt,
 	}, nil
 }
 
@@ -52,10 +56,14 @@ func (r *googleBusinessRepository) FindAll(ctx context.Context, f GoogleBusiness
 	googleBusinessList := make([]*domain.GoogleBusinesses, 0, len(gbList))
 	for _, gb := range gbList {
 		googleBusinessList = append(googleBusinessList, &domain.GoogleBusinesses{
-			ID:        gb.ID,
-			Name:      gb.Name,
-			Title:     gb.Title,
-			CreatedAt: gb.CreatedAt,
+			ID:                     gb.ID,
+			GoogleAccountID:        gb.GoogleAccountID,
+			Name:                   gb.Name,
+			Title:                  gb.Title,
+			PhotoRetentionCount:    gb.PhotoRetentionCount,
+			LocalPostRetentionDays: gb.LocalPostRetentionDays,
+			ReviewsImportedAt:      gb.ReviewsImportedAt,
+			CreatedAt:              gb.CreatedAt,
 		})
 	}
 	return googleBusinessList, nil
@@ -84,17 +92,25 @@ func (r *googleBusinessRepository) Exists(ctx context.Context, f GoogleBusinessF
 
 func (r *googleBusinessRepository) Update(ctx context.Context, googleBusiness *domain.GoogleBusinesses, f GoogleBusinessFilter) error {
 	m := &model.GoogleBusiness{
-		ID:    googleBusiness.ID,
-		Name:  googleBusiness.Name,
-		Title: googleBusiness.Title,
+		ID:                     googleBusiness.ID,
+		GoogleAccountID:        googleBusiness.GoogleAccountID,
+		Name:                   googleBusiness.Name,
+		Title:                  googleBusiness.Title,
+		PhotoRetentionCount:    googleBusiness.PhotoRetentionCount,
+		LocalPostRetentionDays: googleBusiness.LocalPostRetentionDays,
+		ReviewsImportedAt:      googleBusiness.ReviewsImportedAt,
 	}
 	return r.getDB(ctx).Omit("created_at").Save(m).Error
 }
 
 func (r *googleBusinessRepository) Create(ctx context.Context, googleBusiness *domain.GoogleBusinesses) error {
 	m := model.GoogleBusiness{
-		Name:  googleBusiness.Name,
-		Title: googleBusiness.Title,
+		GoogleAccountID:        googleBusiness.GoogleAccountID,
+		Name:                   googleBusiness.Name,
+		Title:                  googleBusiness.Title,
+		PhotoRetentionCount:    googleBusiness.PhotoRetentionCount,
+		LocalPostRetentionDays: googleBusiness.LocalPostRetentionDays,
+		ReviewsImportedAt:      googleBusiness.ReviewsImportedAt,
 	}
 	if err := r.getDB(ctx).Create(&m).Error; err != nil {
 		return err
@@ -115,15 +131,17 @@ func (r *googleBusinessRepository) getDB(ctx context.Context) *gorm.DB {
 }
 
 type GoogleBusinessFilter struct {
-	ID            *int
-	Name          *string
-	Title         *string
-	Limit         *int
-	Offset        *int
-	All           *bool
-	PartialName   *string
-	PartialTitle  *string
-	OrderByIDDesc *bool
+	ID              *int
+	GoogleAccountID *int
+	Name            *string
+	Title           *string
+	Limit           *int
+	Offset          *int
+	All             *bool
+	PartialName     *string
+	PartialTitle    *string
+	OrderByIDDesc   *bool
+	HasRetention    *bool
 }
 
 func (p *GoogleBusinessFilter) Mod(db *gorm.DB) *gorm.DB {
@@ -133,6 +151,9 @@ func (p *GoogleBusinessFilter) Mod(db *gorm.DB) *gorm.DB {
 	if p.ID != nil {
 		db = db.Where("id = ?", *p.ID)
 	}
+	if p.GoogleAccountID != nil {
+		db = db.Where("google_account_id = ?", *p.GoogleAccountID)
+	}
 	if p.Name != nil {
 		db = db.Where("name = ?", *p.Name)
 	}
@@ -140,6 +161,10 @@ func (p *GoogleBusinessFilter) Mod(db *gorm.DB) *gorm.DB {
 		db = db.Where("title = ?", *p.Title)
 	}
 
+	if p.HasRetention != nil && *p.HasRetention {
+		db = db.Where("photo_retention_count > 0 OR local_post_retention_days > 0")
+	}
+
 	if p.PartialName != nil || p.PartialTitle != nil {
 		var orConditions []string
 		var orValues []interface{}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type GoogleOAuthTokenRepository interface {
+	Get(ctx context.Context, f GoogleOAuthTokenFilter) (*domain.GoogleOAuthToken, error)
+	FindAll(ctx context.Context, f GoogleOAuthTokenFilter) ([]*domain.GoogleOAuthToken, error)
+	Update(ctx context.Context, token *domain.GoogleOAuthToken, f GoogleOAuthTokenFilter) error
+	Create(ctx context.Context, token *domain.GoogleOAuthToken) error
+	Delete(ctx context.Context, f GoogleOAuthTokenFilter) error
+}
+
+type googleOAuthTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewGoogleOAuthTokenRepository(db *gorm.DB) GoogleOAuthTokenRepository {
+	return &googleOAuthTokenRepository{
+		db: db,
+	}
+}
+
+func (r *googleOAuthTokenRepository) Get(ctx context.Context, f GoogleOAuthTokenFilter) (*domain.GoogleOAuthToken, error) {
+	var token model.GoogleOAuthToken
+	err := f.Mod(r.getDB(ctx)).Find(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return toGoogleOAuthTokenDomain(&token), nil
+}
+
+func (r *googleOAuthTokenRepository) FindAll(ctx context.Context, f GoogleOAuthTokenFilter) ([]*domain.GoogleOAuthToken, error) {
+	var tokens []*model.GoogleOAuthToken
+	err := f.Mod(r.getDB(ctx)).Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	tokenList := make([]*domain.GoogleOAuthToken, 0, len(tokens))
+	for _, token := range tokens {
+		tokenList = append(tokenList, toGoogleOAuthTokenDomain(token))
+	}
+	return tokenList, nil
+}
+
+func (r *googleOAuthTokenRepository) Update(ctx context.Context, token *domain.GoogleOAuthToken, f GoogleOAuthTokenFilter) error {
+	m := toGoogleOAuthTokenModel(token)
+	m.ID = token.ID
+	return r.getDB(ctx).Omit("created_at").Save(m).Error
+}
+
+func (r *googleOAuthTokenRepository) Create(ctx context.Context, token *domain.GoogleOAuthToken) error {
+	m := toGoogleOAuthTokenModel(token)
+	if err := r.getDB(ctx).Create(m).Error; err != nil {
+		return err
+	}
+	token.ID = m.ID
+	token.CreatedAt = m.CreatedAt
+	token.UpdatedAt = m.UpdatedAt
+	return nil
+}
+
+func (r *googleOAuthTokenRepository) Delete(ctx context.Context, f GoogleOAuthTokenFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.GoogleOAuthToken{}).Error
+}
+
+func (r *googleOAuthTokenRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func toGoogleOAuthTokenDomain(token *model.GoogleOAuthToken) *domain.GoogleOAuthToken {
+	return &domain.GoogleOAuthToken{
+		ID:           token.ID,
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+		Expiry:       token.Expiry,
+		UpdatedAt:    token.UpdatedAt,
+		CreatedAt:    token.CreatedAt,
+	}
+}
+
+func toGoogleOAuthTokenModel(token *domain.GoogleOAuthToken) *model.GoogleOAuthToken {
+	return &model.GoogleOAuthToken{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+		Expiry:       token.Expiry,
+	}
+}
+
+type GoogleOAuthTokenFilter struct {
+	ID *int
+}
+
+func (p *GoogleOAuthTokenFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.ID != nil {
+		db = db.Where("id = ?", *p.ID)
+	}
+	return db
+}
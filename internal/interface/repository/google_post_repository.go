@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/zuxt268/homing/internal/domain"
@@ -38,15 +39,22 @@ func (r *googlePostRepository) Get(ctx context.Context, f GooglePostFilter) (*do
 		return nil, err
 	}
 	return &domain.GooglePost{
-		ID:           gp.ID,
-		InstagramURL: gp.InstagramURL,
-		MediaID:      gp.MediaID,
-		CustomerID:   gp.CustomerID,
-		Name:         gp.Name,
-		GoogleURL:    gp.GoogleURL,
-		CreateTime:   gp.CreateTime,
-		PostType:     gp.PostType,
-		CreatedAt:    gp.CreatedAt,
+		ID:                  gp.ID,
+		InstagramURL:        gp.InstagramURL,
+		MediaID:             gp.MediaID,
+		CustomerID:          gp.CustomerID,
+		Source:              domain.SyncSource(gp.Source),
+		Destination:         domain.SyncDestination(gp.Destination),
+		Status:              domain.SyncRecordStatus(gp.Status),
+		BusinessInstagramID: gp.BusinessInstagramID,
+		WordpressGbpID:      gp.WordpressGbpID,
+		Name:                gp.Name,
+		GoogleURL:           gp.GoogleURL,
+		CreateTime:          gp.CreateTime,
+		PublishedAt:         gp.PublishedAt,
+		PostType:            gp.PostType,
+		DeletedAt:           gp.DeletedAt,
+		CreatedAt:           gp.CreatedAt,
 	}, nil
 }
 
@@ -59,15 +67,22 @@ func (r *googlePostRepository) FindAll(ctx context.Context, f GooglePostFilter)
 	googlePostList := make([]*domain.GooglePost, 0, len(gpList))
 	for _, gp := range gpList {
 		googlePostList = append(googlePostList, &domain.GooglePost{
-			ID:           gp.ID,
-			InstagramURL: gp.InstagramURL,
-			MediaID:      gp.MediaID,
-			CustomerID:   gp.CustomerID,
-			Name:         gp.Name,
-			GoogleURL:    gp.GoogleURL,
-			CreateTime:   gp.CreateTime,
-			PostType:     gp.PostType,
-			CreatedAt:    gp.CreatedAt,
+			ID:                  gp.ID,
+			InstagramURL:        gp.InstagramURL,
+			MediaID:             gp.MediaID,
+			CustomerID:          gp.CustomerID,
+			Source:              domain.SyncSource(gp.Source),
+			Destination:         domain.SyncDestination(gp.Destination),
+			Status:              domain.SyncRecordStatus(gp.Status),
+			BusinessInstagramID: gp.BusinessInstagramID,
+			WordpressGbpID:      gp.WordpressGbpID,
+			Name:                gp.Name,
+			GoogleURL:           gp.GoogleURL,
+			CreateTime:          gp.CreateTime,
+			PublishedAt:         gp.PublishedAt,
+			PostType:            gp.PostType,
+			DeletedAt:           gp.DeletedAt,
+			CreatedAt:           gp.CreatedAt,
 		})
 	}
 	return googlePostList, nil
@@ -95,30 +110,14 @@ func (r *googlePostRepository) Exists(ctx context.Context, f GooglePostFilter) (
 }
 
 func (r *googlePostRepository) Update(ctx context.Context, googlePost *domain.GooglePost, f GooglePostFilter) error {
-	m := &model.GooglePost{
-		ID:           googlePost.ID,
-		InstagramURL: googlePost.InstagramURL,
-		MediaID:      googlePost.MediaID,
-		CustomerID:   googlePost.CustomerID,
-		Name:         googlePost.Name,
-		GoogleURL:    googlePost.GoogleURL,
-		CreateTime:   googlePost.CreateTime,
-		PostType:     googlePost.PostType,
-	}
+	m := toGooglePostModel(googlePost)
+	m.ID = googlePost.ID
 	return r.getDB(ctx).Omit("created_at").Save(m).Error
 }
 
 func (r *googlePostRepository) Create(ctx context.Context, googlePost *domain.GooglePost) error {
-	m := model.GooglePost{
-		InstagramURL: googlePost.InstagramURL,
-		MediaID:      googlePost.MediaID,
-		CustomerID:   googlePost.CustomerID,
-		Name:         googlePost.Name,
-		GoogleURL:    googlePost.GoogleURL,
-		CreateTime:   googlePost.CreateTime,
-		PostType:     googlePost.PostType,
-	}
-	if err := r.getDB(ctx).Create(&m).Error; err != nil {
+	m := toGooglePostModel(googlePost)
+	if err := r.getDB(ctx).Create(m).Error; err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
 			return domain.ErrDuplicate
@@ -129,6 +128,39 @@ func (r *googlePostRepository) Create(ctx context.Context, googlePost *domain.Go
 	return nil
 }
 
+// toGooglePostModel は保存するレコードを作る。投稿先は PostType から、投稿日時は CreateTime から決める。
+// 状態が空の場合は投稿済み（published）として保存する
+func toGooglePostModel(googlePost *domain.GooglePost) *model.GooglePost {
+	source := googlePost.Source
+	if source == "" {
+		source = domain.SyncSourceInstagram
+	}
+	status := googlePost.Status
+	if status == "" {
+		status = domain.SyncRecordPublished
+	}
+	publishedAt := googlePost.PublishedAt
+	if publishedAt == nil {
+		publishedAt = domain.ParseSyncTime(googlePost.CreateTime)
+	}
+	return &model.GooglePost{
+		InstagramURL:        googlePost.InstagramURL,
+		MediaID:             googlePost.MediaID,
+		CustomerID:          googlePost.CustomerID,
+		Source:              string(source),
+		Destination:         string(domain.GooglePostDestination(googlePost.PostType)),
+		Status:              string(status),
+		BusinessInstagramID: googlePost.BusinessInstagramID,
+		WordpressGbpID:      googlePost.WordpressGbpID,
+		Name:                googlePost.Name,
+		GoogleURL:           googlePost.GoogleURL,
+		CreateTime:          googlePost.CreateTime,
+		PublishedAt:         publishedAt,
+		PostType:            googlePost.PostType,
+		DeletedAt:           googlePost.DeletedAt,
+	}
+}
+
 func (r *googlePostRepository) Delete(ctx context.Context, f GooglePostFilter) error {
 	return f.Mod(r.getDB(ctx)).Delete(model.GooglePost{}).Error
 }
@@ -149,12 +181,18 @@ type GooglePostFilter struct {
 	GoogleURL    *string
 	CreateTime   *string
 	PostType     *string
+	Status       *string
+	CustomerIDs  []int
+	MediaIDs     []string
 	Limit        *int
 	Offset       *int
 	All          *bool
 
 	PartialInstagramURL *string
+	CreatedAtFrom       *time.Time
+	CreatedAtTo         *time.Time
 	OrderByIDDesc       *bool
+	Deleted             *bool
 }
 
 func (p *GooglePostFilter) Mod(db *gorm.DB) *gorm.DB {
@@ -185,6 +223,28 @@ func (p *GooglePostFilter) Mod(db *gorm.DB) *gorm.DB {
 	if p.PostType != nil {
 		db = db.Where("post_type = ?", *p.PostType)
 	}
+	if p.Status != nil {
+		db = db.Where("status = ?", *p.Status)
+	}
+	if p.CustomerIDs != nil {
+		db = db.Where("customer_id IN ?", p.CustomerIDs)
+	}
+	if p.MediaIDs != nil {
+		db = db.Where("media_id IN ?", p.MediaIDs)
+	}
+	if p.CreatedAtFrom != nil {
+		db = db.Where("created_at >= ?", *p.CreatedAtFrom)
+	}
+	if p.CreatedAtTo != nil {
+		db = db.Where("created_at < ?", *p.CreatedAtTo)
+	}
+	if p.Deleted != nil {
+		if *p.Deleted {
+			db = db.Where("deleted_at IS NOT NULL")
+		} else {
+			db = db.Where("deleted_at IS NULL")
+		}
+	}
 	if p.PartialInstagramURL != nil {
 		db = db.Where("instagram_url like ?", "%"+*p.PartialInstagramURL+"%")
 	}
@@ -198,4 +258,4 @@ func (p *GooglePostFilter) Mod(db *gorm.DB) *gorm.DB {
 		}
 	}
 	return db
-}
\ No newline at end of file
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type GoogleReviewRepository interface {
+	Get(ctx context.Context, f GoogleReviewFilter) (*domain.GoogleReview, error)
+	FindAll(ctx context.Context, f GoogleReviewFilter) ([]*domain.GoogleReview, error)
+	Count(ctx context.Context, f GoogleReviewFilter) (int64, error)
+	Exists(ctx context.Context, f GoogleReviewFilter) (bool, error)
+	Update(ctx context.Context, item *domain.GoogleReview, f GoogleReviewFilter) error
+	Create(ctx context.Context, googleReview *domain.GoogleReview) error
+	Delete(ctx context.Context, f GoogleReviewFilter) error
+}
+
+type googleReviewRepository struct {
+	db *gorm.DB
+}
+
+func NewGoogleReviewRepository(db *gorm.DB) GoogleReviewRepository {
+	return &googleReviewRepository{
+		db: db,
+	}
+}
+
+func (r *googleReviewRepository) Get(ctx context.Context, f GoogleReviewFilter) (*domain.GoogleReview, error) {
+	var gr model.GoogleReview
+	err := f.Mod(r.getDB(ctx)).Find(&gr).Error
+	if err != nil {
+		return nil, err
+	}
+	return toGoogleReviewDomain(&gr), nil
+}
+
+func (r *googleReviewRepository) FindAll(ctx context.Context, f GoogleReviewFilter) ([]*domain.GoogleReview, error) {
+	var grList []*model.GoogleReview
+	err := f.Mod(r.getDB(ctx)).Find(&grList).Error
+	if err != nil {
+		return nil, err
+	}
+	googleReviewList := make([]*domain.GoogleReview, 0, len(grList))
+	for _, gr := range grList {
+		googleReviewList = append(googleReviewList, toGoogleReviewDomain(gr))
+	}
+	return googleReviewList, nil
+}
+
+func (r *googleReviewRepository) Count(ctx context.Context, f GoogleReviewFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.GoogleReview{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *googleReviewRepository) Exists(ctx context.Context, f GoogleReviewFilter) (bool, error) {
+	var grList []*model.GoogleReview
+	err := f.Mod(r.getDB(ctx)).Find(&grList).Error
+	if err != nil {
+		return false, err
+	}
+	return len(grList) > 0, nil
+}
+
+func (r *googleReviewRepository) Update(ctx context.Context, googleReview *domain.GoogleReview, f GoogleReviewFilter) error {
+	m := toGoogleReviewModel(googleReview)
+	m.ID = googleReview.ID
+	return r.getDB(ctx).Omit("created_at").Save(m).Error
+}
+
+func (r *googleReviewRepository) Create(ctx context.Context, googleReview *domain.GoogleReview) error {
+	m := toGoogleReviewModel(googleReview)
+	if err := r.getDB(ctx).Create(m).Error; err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return domain.ErrDuplicate
+		}
+		return err
+	}
+	googleReview.ID = m.ID
+	googleReview.CreatedAt = m.CreatedAt
+	googleReview.UpdatedAt = m.UpdatedAt
+	return nil
+}
+
+func (r *googleReviewRepository) Delete(ctx context.Context, f GoogleReviewFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.GoogleReview{}).Error
+}
+
+func (r *googleReviewRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func toGoogleReviewDomain(gr *model.GoogleReview) *domain.GoogleReview {
+	return &domain.GoogleReview{
+		ID:             gr.ID,
+		BusinessName:   gr.BusinessName,
+		ReviewName:     gr.ReviewName,
+		ReviewerName:   gr.ReviewerName,
+		StarRating:     gr.StarRating,
+		Comment:        gr.Comment,
+		ReplyComment:   gr.ReplyComment,
+		ReplyUpdatedAt: gr.ReplyUpdatedAt,
+		ReviewedAt:     gr.ReviewedAt,
+		UpdateTime:     gr.UpdateTime,
+		UpdatedAt:      gr.UpdatedAt,
+		CreatedAt:      gr.CreatedAt,
+	}
+}
+
+func toGoogleReviewModel(googleReview *domain.GoogleReview) *model.GoogleReview {
+	return &model.GoogleReview{
+		BusinessName:   googleReview.BusinessName,
+		ReviewName:     googleReview.ReviewName,
+		ReviewerName:   googleReview.ReviewerName,
+		StarRating:     googleReview.StarRating,
+		Comment:        googleReview.Comment,
+		ReplyComment:   googleReview.ReplyComment,
+		ReplyUpdatedAt: googleReview.ReplyUpdatedAt,
+		ReviewedAt:     googleReview.ReviewedAt,
+		UpdateTime:     googleReview.UpdateTime,
+	}
+}
+
+type GoogleReviewFilter struct {
+	ID            *int
+	BusinessName  *string
+	ReviewName    *string
+	MaxStarRating *int
+	Unreplied     *bool
+	Limit         *int
+	Offset        *int
+	All           *bool
+
+	OrderByReviewedAtDesc *bool
+}
+
+func (p *GoogleReviewFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.All != nil && *p.All {
+		return db.Where("1")
+	}
+	if p.ID != nil {
+		db = db.Where("id = ?", *p.ID)
+	}
+	if p.BusinessName != nil {
+		db = db.Where("business_name = ?", *p.BusinessName)
+	}
+	if p.ReviewName != nil {
+		db = db.Where("review_name = ?", *p.ReviewName)
+	}
+	if p.MaxStarRating != nil {
+		db = db.Where("star_rating <= ?", *p.MaxStarRating)
+	}
+	if p.Unreplied != nil && *p.Unreplied {
+		db = db.Where("reply_comment = ''")
+	}
+	if p.OrderByReviewedAtDesc != nil {
+		db = db.Order("reviewed_at desc")
+	}
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
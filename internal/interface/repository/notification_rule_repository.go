@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type NotificationRuleRepository interface {
+	Get(ctx context.Context, f NotificationRuleFilter) (*domain.NotificationRule, error)
+	FindAll(ctx context.Context, f NotificationRuleFilter) ([]*domain.NotificationRule, error)
+	Count(ctx context.Context, f NotificationRuleFilter) (int64, error)
+	Update(ctx context.Context, rule *domain.NotificationRule, f NotificationRuleFilter) error
+	Create(ctx context.Context, rule *domain.NotificationRule) error
+	Delete(ctx context.Context, f NotificationRuleFilter) error
+}
+
+type notificationRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRuleRepository(db *gorm.DB) NotificationRuleRepository {
+	return &notificationRuleRepository{
+		db: db,
+	}
+}
+
+func (r *notificationRuleRepository) Get(ctx context.Context, f NotificationRuleFilter) (*domain.NotificationRule, error) {
+	var rule model.NotificationRule
+	err := f.Mod(r.getDB(ctx)).Find(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationRuleDomain(&rule), nil
+}
+
+func (r *notificationRuleRepository) FindAll(ctx context.Context, f NotificationRuleFilter) ([]*domain.NotificationRule, error) {
+	var rules []*model.NotificationRule
+	err := f.Mod(r.getDB(ctx)).Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	ruleList := make([]*domain.NotificationRule, 0, len(rules))
+	for _, rule := range rules {
+		ruleList = append(ruleList, toNotificationRuleDomain(rule))
+	}
+	return ruleList, nil
+}
+
+func (r *notificationRuleRepository) Count(ctx context.Context, f NotificationRuleFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.NotificationRule{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *notificationRuleRepository) Update(ctx context.Context, rule *domain.NotificationRule, f NotificationRuleFilter) error {
+	m := toNotificationRuleModel(rule)
+	m.ID = rule.ID
+	return r.getDB(ctx).Omit("created_at").Save(m).Error
+}
+
+func (r *notificationRuleRepository) Create(ctx context.Context, rule *domain.NotificationRule) error {
+	m := toNotificationRuleModel(rule)
+	if err := r.getDB(ctx).Create(m).Error; err != nil {
+		return err
+	}
+	rule.ID = m.ID
+	return nil
+}
+
+func (r *notificationRuleRepository) Delete(ctx context.Context, f NotificationRuleFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.NotificationRule{}).Error
+}
+
+func (r *notificationRuleRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func toNotificationRuleDomain(rule *model.NotificationRule) *domain.NotificationRule {
+	var eventTypes []domain.NotificationEventType
+	if rule.EventTypes != "" {
+		for _, t := range strings.Split(rule.EventTypes, ",") {
+			eventTypes = append(eventTypes, domain.NotificationEventType(t))
+		}
+	}
+	return &domain.NotificationRule{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		EventTypes:  eventTypes,
+		AccountType: domain.AccountType(rule.AccountType),
+		AccountID:   rule.AccountID,
+		Channel:     domain.NotificationChannel(rule.Channel),
+		Destination: rule.Destination,
+		Mention:     rule.Mention,
+		Status:      domain.Status(rule.Status),
+		UpdatedAt:   rule.UpdatedAt,
+		CreatedAt:   rule.CreatedAt,
+	}
+}
+
+func toNotificationRuleModel(rule *domain.NotificationRule) *model.NotificationRule {
+	eventTypes := make([]string, 0, len(rule.EventTypes))
+	for _, t := range rule.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+	return &model.NotificationRule{
+		Name:        rule.Name,
+		EventTypes:  strings.Join(eventTypes, ","),
+		AccountType: string(rule.AccountType),
+		AccountID:   rule.AccountID,
+		Channel:     string(rule.Channel),
+		Destination: rule.Destination,
+		Mention:     rule.Mention,
+		Status:      int(rule.Status),
+	}
+}
+
+type NotificationRuleFilter struct {
+	ID      *int
+	Status  *int
+	Channel *string
+	Limit   *int
+	Offset  *int
+
+	PartialName   *string
+	OrderByIDDesc *bool
+}
+
+func (p *NotificationRuleFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.ID != nil {
+		db = db.Where("id = ?", *p.ID)
+	}
+	if p.Status != nil {
+		db = db.Where("status = ?", *p.Status)
+	}
+	if p.Channel != nil {
+		db = db.Where("channel = ?", *p.Channel)
+	}
+	if p.PartialName != nil {
+		db = db.Where("name like ?", "%"+*p.PartialName+"%")
+	}
+	if p.OrderByIDDesc != nil {
+		db = db.Order("id desc")
+	}
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
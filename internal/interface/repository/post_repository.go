@@ -2,7 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/zuxt268/homing/internal/domain"
 	"github.com/zuxt268/homing/internal/interface/dto/model"
 	"gorm.io/gorm"
@@ -13,6 +16,12 @@ type PostRepository interface {
 	CreatePost(ctx context.Context, post *model.Post) error
 	GetPosts(ctx context.Context, filter PostFilter) ([]domain.Post, error)
 	CountPosts(ctx context.Context, filter PostFilter) (int64, error)
+	FindMediaIDs(ctx context.Context, filter PostFilter) ([]string, error)
+	FindCustomerIDs(ctx context.Context, filter PostFilter) ([]int, error)
+	ReassignPosts(ctx context.Context, filter PostFilter, customerID, wordpressInstagramID int) (int64, error)
+	ConfirmPost(ctx context.Context, id int, wordpressLink string) error
+	UpdatePostStatus(ctx context.Context, filter PostFilter, status domain.SyncRecordStatus) (int64, error)
+	DeletePosts(ctx context.Context, filter PostFilter) (int64, error)
 }
 
 type postRepository struct {
@@ -23,13 +32,19 @@ type PostFilter struct {
 	ID                   *int
 	MediaID              *string
 	CustomerID           *int
-	Timestamp            *string
-	MediaURL             *string
-	Permalink            *string
-	WordpressLink        *string
-	OrderByCreatedAtDesc *bool
-	Limit                *int
-	Offset               *int
+	WordpressInstagramID *int
+	// WithoutWordpressInstagram は連携に紐付いていない（wordpress_instagram_id が NULL の）投稿
+	WithoutWordpressInstagram *bool
+	ExcludeMediaIDs           []string
+	Status                    *string
+	CreatedAtTo               *time.Time
+	Timestamp                 *string
+	MediaURL                  *string
+	Permalink                 *string
+	WordpressLink             *string
+	OrderByCreatedAtDesc      *bool
+	Limit                     *int
+	Offset                    *int
 }
 
 func (p *PostFilter) Mod(db *gorm.DB) *gorm.DB {
@@ -42,6 +57,21 @@ func (p *PostFilter) Mod(db *gorm.DB) *gorm.DB {
 	if p.CustomerID != nil {
 		db = db.Where("customer_id = ?", *p.CustomerID)
 	}
+	if p.WordpressInstagramID != nil {
+		db = db.Where("wordpress_instagram_id = ?", *p.WordpressInstagramID)
+	}
+	if p.WithoutWordpressInstagram != nil && *p.WithoutWordpressInstagram {
+		db = db.Where("wordpress_instagram_id IS NULL")
+	}
+	if len(p.ExcludeMediaIDs) > 0 {
+		db = db.Where("media_id NOT IN ?", p.ExcludeMediaIDs)
+	}
+	if p.Status != nil {
+		db = db.Where("status = ?", *p.Status)
+	}
+	if p.CreatedAtTo != nil {
+		db = db.Where("created_at < ?", *p.CreatedAtTo)
+	}
 	if p.Timestamp != nil {
 		db = db.Where("timestamp = ?", *p.Timestamp)
 	}
@@ -75,30 +105,59 @@ func NewPostRepository(db *gorm.DB) PostRepository {
 func (r *postRepository) ExistPost(ctx context.Context, filter PostFilter) (bool, error) {
 	var posts []*model.Post
 
-	err := filter.Mod(r.db).WithContext(ctx).Find(&posts).Error
+	err := filter.Mod(r.getDB(ctx)).Find(&posts).Error
 	if err != nil {
 		return false, err
 	}
 	return len(posts) > 0, nil
 }
 
+// CreatePost は投稿を記録する。posts はInstagramからWordPressへの投稿の記録のため、投稿元・投稿先が空の場合はそれを入れる。
+// 状態が空の場合は投稿済み（published）として記録する。
+// 同じ投稿先・顧客・メディアの記録が既にある場合は domain.ErrDuplicate を返す。
 func (r *postRepository) CreatePost(ctx context.Context, post *model.Post) error {
-	return r.db.WithContext(ctx).Create(post).Error
+	if post.Source == "" {
+		post.Source = string(domain.SyncSourceInstagram)
+	}
+	if post.Destination == "" {
+		post.Destination = string(domain.SyncDestinationWordpress)
+	}
+	if post.Status == "" {
+		post.Status = string(domain.SyncRecordPublished)
+	}
+	if post.PostedAt == nil {
+		post.PostedAt = domain.ParseSyncTime(post.Timestamp)
+	}
+	if err := r.getDB(ctx).Create(post).Error; err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return domain.ErrDuplicate
+		}
+		return err
+	}
+	return nil
 }
 
 func (r *postRepository) GetPosts(ctx context.Context, filter PostFilter) ([]domain.Post, error) {
 	var posts []*model.Post
-	err := filter.Mod(r.db).WithContext(ctx).Find(&posts).Error
+	err := filter.Mod(r.getDB(ctx)).Find(&posts).Error
 	if err != nil {
 		return nil, err
 	}
 	result := make([]domain.Post, len(posts))
 	for i, post := range posts {
 		result[i] = domain.Post{
-			ID:           post.ID,
-			WordpressURL: post.WordpressLink,
-			InstagramURL: post.Permalink,
-			CreatedAt:    post.CreatedAt,
+			ID:                   post.ID,
+			MediaID:              post.MediaID,
+			WordpressInstagramID: post.WordpressInstagramID,
+			Source:               domain.SyncSource(post.Source),
+			Destination:          domain.SyncDestination(post.Destination),
+			Status:               domain.SyncRecordStatus(post.Status),
+			IdempotencyKey:       post.IdempotencyKey,
+			WordpressURL:         post.WordpressLink,
+			InstagramURL:         post.Permalink,
+			PostedAt:             post.PostedAt,
+			CreatedAt:            post.CreatedAt,
 		}
 	}
 	return result, nil
@@ -108,9 +167,72 @@ func (r *postRepository) CountPosts(ctx context.Context, filter PostFilter) (int
 	var total int64
 	filter.Offset = nil
 	filter.Limit = nil
-	err := filter.Mod(r.db).WithContext(ctx).Model(model.Post{}).Count(&total).Error
+	err := filter.Mod(r.getDB(ctx)).Model(model.Post{}).Count(&total).Error
 	if err != nil {
 		return 0, err
 	}
 	return total, nil
 }
+
+func (r *postRepository) FindMediaIDs(ctx context.Context, filter PostFilter) ([]string, error) {
+	var mediaIDs []string
+	err := filter.Mod(r.getDB(ctx)).Model(model.Post{}).Distinct().Pluck("media_id", &mediaIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return mediaIDs, nil
+}
+
+func (r *postRepository) FindCustomerIDs(ctx context.Context, filter PostFilter) ([]int, error) {
+	var customerIDs []int
+	err := filter.Mod(r.getDB(ctx)).Model(model.Post{}).Distinct().Order("customer_id").Pluck("customer_id", &customerIDs).Error
+	if err != nil {
+		return nil, err
+	}
+	return customerIDs, nil
+}
+
+// ReassignPosts は条件に一致する投稿を連携に紐付け直し、更新した件数を返す。
+func (r *postRepository) ReassignPosts(ctx context.Context, filter PostFilter, customerID, wordpressInstagramID int) (int64, error) {
+	result := filter.Mod(r.getDB(ctx)).Model(model.Post{}).Updates(map[string]interface{}{
+		"customer_id":            customerID,
+		"wordpress_instagram_id": wordpressInstagramID,
+	})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// ConfirmPost は予約した投稿を投稿済みにし、投稿先の記事URLを記録する。
+func (r *postRepository) ConfirmPost(ctx context.Context, id int, wordpressLink string) error {
+	return r.getDB(ctx).Model(model.Post{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":         string(domain.SyncRecordPublished),
+		"wordpress_link": wordpressLink,
+	}).Error
+}
+
+// UpdatePostStatus は条件に一致する投稿の状態を変え、更新した件数を返す。
+func (r *postRepository) UpdatePostStatus(ctx context.Context, filter PostFilter, status domain.SyncRecordStatus) (int64, error) {
+	result := filter.Mod(r.getDB(ctx)).Model(model.Post{}).Update("status", string(status))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// DeletePosts は条件に一致する投稿の記録を削除し、削除した件数を返す。
+func (r *postRepository) DeletePosts(ctx context.Context, filter PostFilter) (int64, error) {
+	result := filter.Mod(r.getDB(ctx)).Delete(model.Post{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *postRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/zuxt268/homing/internal/domain"
 	"github.com/zuxt268/homing/internal/interface/dto/model"
 	"github.com/zuxt268/homing/internal/interface/util"
 )
@@ -202,6 +203,79 @@ func TestPostRepository_SavePost(t *testing.T) {
 	})
 }
 
+func TestPostRepository_CreatePostDuplicate(t *testing.T) {
+	repo := NewPostRepository(db)
+	ctx := context.Background()
+
+	post := &model.Post{
+		MediaID:    "duplicate_media_001",
+		CustomerID: 100001,
+		Timestamp:  "2025-09-29T10:19:24+0000",
+		CreatedAt:  time.Now(),
+	}
+	require.NoError(t, repo.CreatePost(ctx, post))
+	assert.Equal(t, string(domain.SyncSourceInstagram), post.Source)
+	assert.Equal(t, string(domain.SyncDestinationWordpress), post.Destination)
+	require.NotNil(t, post.PostedAt)
+	assert.True(t, post.PostedAt.Equal(time.Date(2025, 9, 29, 10, 19, 24, 0, time.UTC)))
+
+	// 同じ投稿先・顧客・メディアは保存できない
+	err := repo.CreatePost(ctx, &model.Post{
+		MediaID:    "duplicate_media_001",
+		CustomerID: 100001,
+		CreatedAt:  time.Now(),
+	})
+	assert.ErrorIs(t, err, domain.ErrDuplicate)
+
+	// 顧客が異なれば保存できる
+	assert.NoError(t, repo.CreatePost(ctx, &model.Post{
+		MediaID:    "duplicate_media_001",
+		CustomerID: 100002,
+		CreatedAt:  time.Now(),
+	}))
+}
+
+func TestPostRepository_Reservation(t *testing.T) {
+	repo := NewPostRepository(db)
+	ctx := context.Background()
+
+	// 予約した投稿は投稿済みと同じく存在する
+	post := &model.Post{
+		MediaID:        "reserved_media_001",
+		CustomerID:     100003,
+		Status:         string(domain.SyncRecordReserved),
+		IdempotencyKey: util.Pointer(domain.NewSyncIdempotencyKey(domain.SyncDestinationWordpress, 100003, "reserved_media_001")),
+		CreatedAt:      time.Now().Add(-time.Hour),
+	}
+	require.NoError(t, repo.CreatePost(ctx, post))
+	exist, err := repo.ExistPost(ctx, PostFilter{MediaID: &post.MediaID, CustomerID: &post.CustomerID})
+	require.NoError(t, err)
+	assert.True(t, exist)
+
+	stale, err := repo.GetPosts(ctx, PostFilter{
+		Status:      util.Pointer(string(domain.SyncRecordReserved)),
+		CreatedAtTo: util.Pointer(time.Now().Add(-domain.SyncReservationTimeout)),
+		MediaID:     &post.MediaID,
+	})
+	require.NoError(t, err)
+	require.Len(t, stale, 1)
+	assert.Equal(t, post.IdempotencyKey, stale[0].IdempotencyKey)
+
+	require.NoError(t, repo.ConfirmPost(ctx, post.ID, "https://example.com/?p=1"))
+	posts, err := repo.GetPosts(ctx, PostFilter{ID: &post.ID})
+	require.NoError(t, err)
+	assert.Equal(t, domain.SyncRecordPublished, posts[0].Status)
+	assert.Equal(t, "https://example.com/?p=1", posts[0].WordpressURL)
+
+	// 状態を指定して更新・削除する
+	updated, err := repo.UpdatePostStatus(ctx, PostFilter{ID: &post.ID, Status: util.Pointer(string(domain.SyncRecordReserved))}, domain.SyncRecordUnconfirmed)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), updated)
+	deleted, err := repo.DeletePosts(ctx, PostFilter{ID: &post.ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+}
+
 func TestPostRepository_Integration(t *testing.T) {
 	repo := NewPostRepository(db)
 	ctx := context.Background()
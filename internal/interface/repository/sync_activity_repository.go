@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type SyncActivityRepository interface {
+	FindAll(ctx context.Context, f SyncActivityFilter) ([]*domain.SyncActivity, error)
+	// FindLatest は条件に当てはまる記録のうち、連携ごとに最新の1件を返す
+	FindLatest(ctx context.Context, f SyncActivityFilter) ([]*domain.SyncActivity, error)
+	Create(ctx context.Context, activity *domain.SyncActivity) error
+}
+
+type syncActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewSyncActivityRepository(db *gorm.DB) SyncActivityRepository {
+	return &syncActivityRepository{
+		db: db,
+	}
+}
+
+func (r *syncActivityRepository) FindAll(ctx context.Context, f SyncActivityFilter) ([]*domain.SyncActivity, error) {
+	var activities []*model.SyncActivity
+	err := f.Mod(r.getDB(ctx)).Order("id").Find(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+	return toSyncActivityDomains(activities), nil
+}
+
+func (r *syncActivityRepository) FindLatest(ctx context.Context, f SyncActivityFilter) ([]*domain.SyncActivity, error) {
+	latestIDs := f.Mod(r.getDB(ctx)).Model(&model.SyncActivity{}).
+		Select("MAX(id)").
+		Group("account_type, account_id")
+
+	var activities []*model.SyncActivity
+	err := r.getDB(ctx).Where("id IN (?)", latestIDs).Order("id").Find(&activities).Error
+	if err != nil {
+		return nil, err
+	}
+	return toSyncActivityDomains(activities), nil
+}
+
+func (r *syncActivityRepository) Create(ctx context.Context, activity *domain.SyncActivity) error {
+	m := &model.SyncActivity{
+		AccountType: string(activity.Account.Type),
+		AccountID:   activity.Account.ID,
+		AccountName: activity.Account.Name,
+		Result:      string(activity.Result),
+		PostKey:     activity.PostKey,
+		Detail:      activity.Detail,
+	}
+	if err := r.getDB(ctx).Create(m).Error; err != nil {
+		return err
+	}
+	activity.ID = m.ID
+	activity.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *syncActivityRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func toSyncActivityDomains(activities []*model.SyncActivity) []*domain.SyncActivity {
+	result := make([]*domain.SyncActivity, 0, len(activities))
+	for _, a := range activities {
+		result = append(result, &domain.SyncActivity{
+			ID: a.ID,
+			Account: domain.Account{
+				Type: domain.AccountType(a.AccountType),
+				ID:   a.AccountID,
+				Name: a.AccountName,
+			},
+			Result:    domain.SyncResult(a.Result),
+			PostKey:   a.PostKey,
+			Detail:    a.Detail,
+			CreatedAt: a.CreatedAt,
+		})
+	}
+	return result
+}
+
+type SyncActivityFilter struct {
+	Result        *domain.SyncResult
+	CreatedAtFrom *time.Time
+	CreatedAtTo   *time.Time
+}
+
+func (p *SyncActivityFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.Result != nil {
+		db = db.Where("result = ?", string(*p.Result))
+	}
+	if p.CreatedAtFrom != nil {
+		db = db.Where("created_at >= ?", *p.CreatedAtFrom)
+	}
+	if p.CreatedAtTo != nil {
+		db = db.Where("created_at < ?", *p.CreatedAtTo)
+	}
+	return db
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type WebhookDeliveryRepository interface {
+	FindAll(ctx context.Context, f WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error)
+	Count(ctx context.Context, f WebhookDeliveryFilter) (int64, error)
+	Create(ctx context.Context, delivery *domain.WebhookDelivery) error
+	Update(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
+
+type webhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *webhookDeliveryRepository) FindAll(ctx context.Context, f WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	var deliveries []*model.WebhookDelivery
+	err := f.Mod(r.getDB(ctx)).Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	deliveryList := make([]*domain.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		deliveryList = append(deliveryList, &domain.WebhookDelivery{
+			ID:             d.ID,
+			EventID:        d.EventID,
+			SubscriptionID: d.SubscriptionID,
+			Status:         domain.WebhookDeliveryStatus(d.Status),
+			Attempts:       d.Attempts,
+			ResponseStatus: d.ResponseStatus,
+			ResponseBody:   d.ResponseBody,
+			Error:          d.Error,
+			NextRetryAt:    d.NextRetryAt,
+			DeliveredAt:    d.DeliveredAt,
+			UpdatedAt:      d.UpdatedAt,
+			CreatedAt:      d.CreatedAt,
+		})
+	}
+	return deliveryList, nil
+}
+
+func (r *webhookDeliveryRepository) Count(ctx context.Context, f WebhookDeliveryFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.WebhookDelivery{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *webhookDeliveryRepository) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m := toWebhookDeliveryModel(delivery)
+	if err := r.getDB(ctx).Create(m).Error; err != nil {
+		return err
+	}
+	delivery.ID = m.ID
+	delivery.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *webhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	m := toWebhookDeliveryModel(delivery)
+	m.ID = delivery.ID
+	return r.getDB(ctx).Omit("created_at").Save(m).Error
+}
+
+func (r *webhookDeliveryRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func toWebhookDeliveryModel(delivery *domain.WebhookDelivery) *model.WebhookDelivery {
+	return &model.WebhookDelivery{
+		EventID:        delivery.EventID,
+		SubscriptionID: delivery.SubscriptionID,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		NextRetryAt:    delivery.NextRetryAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+type WebhookDeliveryFilter struct {
+	EventID        *int
+	SubscriptionID *int
+	Status         *domain.WebhookDeliveryStatus
+	Limit          *int
+	Offset         *int
+
+	// NextRetryAtBefore は再送の時刻を過ぎたものに絞り込む
+	NextRetryAtBefore *time.Time
+}
+
+func (p *WebhookDeliveryFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.EventID != nil {
+		db = db.Where("event_id = ?", *p.EventID)
+	}
+	if p.SubscriptionID != nil {
+		db = db.Where("subscription_id = ?", *p.SubscriptionID)
+	}
+	if p.Status != nil {
+		db = db.Where("status = ?", string(*p.Status))
+	}
+	if p.NextRetryAtBefore != nil {
+		db = db.Where("next_retry_at <= ?", *p.NextRetryAtBefore)
+	}
+	db = db.Order("id desc")
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
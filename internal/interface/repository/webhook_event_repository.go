@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type WebhookEventRepository interface {
+	Get(ctx context.Context, f WebhookEventFilter) (*domain.WebhookEvent, error)
+	FindAll(ctx context.Context, f WebhookEventFilter) ([]*domain.WebhookEvent, error)
+	Count(ctx context.Context, f WebhookEventFilter) (int64, error)
+	Create(ctx context.Context, event *domain.WebhookEvent) error
+}
+
+type webhookEventRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookEventRepository(db *gorm.DB) WebhookEventRepository {
+	return &webhookEventRepository{
+		db: db,
+	}
+}
+
+func (r *webhookEventRepository) Get(ctx context.Context, f WebhookEventFilter) (*domain.WebhookEvent, error) {
+	var event model.WebhookEvent
+	err := f.Mod(r.getDB(ctx)).Find(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return toWebhookEventDomain(&event), nil
+}
+
+func (r *webhookEventRepository) FindAll(ctx context.Context, f WebhookEventFilter) ([]*domain.WebhookEvent, error) {
+	var events []*model.WebhookEvent
+	err := f.Mod(r.getDB(ctx)).Find(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	eventList := make([]*domain.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		eventList = append(eventList, toWebhookEventDomain(event))
+	}
+	return eventList, nil
+}
+
+func (r *webhookEventRepository) Count(ctx context.Context, f WebhookEventFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.WebhookEvent{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *webhookEventRepository) Create(ctx context.Context, event *domain.WebhookEvent) error {
+	m := model.WebhookEvent{
+		EventID:     event.EventID,
+		EventType:   string(event.EventType),
+		AccountType: string(event.Account.Type),
+		AccountID:   event.Account.ID,
+		AccountName: event.Account.Name,
+		Payload:     event.Payload,
+		CreatedAt:   event.CreatedAt,
+	}
+	if err := r.getDB(ctx).Create(&m).Error; err != nil {
+		return err
+	}
+	event.ID = m.ID
+	return nil
+}
+
+func (r *webhookEventRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func toWebhookEventDomain(event *model.WebhookEvent) *domain.WebhookEvent {
+	return &domain.WebhookEvent{
+		ID:        event.ID,
+		EventID:   event.EventID,
+		EventType: domain.WebhookEventType(event.EventType),
+		Account: domain.Account{
+			Type: domain.AccountType(event.AccountType),
+			ID:   event.AccountID,
+			Name: event.AccountName,
+		},
+		Payload:   event.Payload,
+		CreatedAt: event.CreatedAt,
+	}
+}
+
+type WebhookEventFilter struct {
+	ID          *int
+	EventType   *string
+	AccountType *string
+	AccountID   *int
+	Limit       *int
+	Offset      *int
+}
+
+func (p *WebhookEventFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.ID != nil {
+		db = db.Where("id = ?", *p.ID)
+	}
+	if p.EventType != nil {
+		db = db.Where("event_type = ?", *p.EventType)
+	}
+	if p.AccountType != nil {
+		db = db.Where("account_type = ?", *p.AccountType)
+	}
+	if p.AccountID != nil {
+		db = db.Where("account_id = ?", *p.AccountID)
+	}
+	db = db.Order("id desc")
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
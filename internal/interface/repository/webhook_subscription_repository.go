@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscriptionRepository interface {
+	Get(ctx context.Context, f WebhookSubscriptionFilter) (*domain.WebhookSubscription, error)
+	FindAll(ctx context.Context, f WebhookSubscriptionFilter) ([]*domain.WebhookSubscription, error)
+	Count(ctx context.Context, f WebhookSubscriptionFilter) (int64, error)
+	Update(ctx context.Context, item *domain.WebhookSubscription, f WebhookSubscriptionFilter) error
+	Create(ctx context.Context, subscription *domain.WebhookSubscription) error
+	Delete(ctx context.Context, f WebhookSubscriptionFilter) error
+}
+
+type webhookSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookSubscriptionRepository(db *gorm.DB) WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{
+		db: db,
+	}
+}
+
+func (r *webhookSubscriptionRepository) Get(ctx context.Context, f WebhookSubscriptionFilter) (*domain.WebhookSubscription, error) {
+	var sub model.WebhookSubscription
+	err := f.Mod(r.getDB(ctx)).Find(&sub).Error
+	if err != nil {
+		return nil, err
+	}
+	return toWebhookSubscriptionDomain(&sub), nil
+}
+
+func (r *webhookSubscriptionRepository) FindAll(ctx context.Context, f WebhookSubscriptionFilter) ([]*domain.WebhookSubscription, error) {
+	var subs []*model.WebhookSubscription
+	err := f.Mod(r.getDB(ctx)).Find(&subs).Error
+	if err != nil {
+		return nil, err
+	}
+	subList := make([]*domain.WebhookSubscription, 0, len(subs))
+	for _, sub := range subs {
+		subList = append(subList, toWebhookSubscriptionDomain(sub))
+	}
+	return subList, nil
+}
+
+func (r *webhookSubscriptionRepository) Count(ctx context.Context, f WebhookSubscriptionFilter) (int64, error) {
+	var total int64
+	f.Offset = nil
+	f.Limit = nil
+	err := f.Mod(r.getDB(ctx)).Model(model.WebhookSubscription{}).Count(&total).Error
+	if err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (r *webhookSubscriptionRepository) Update(ctx context.Context, sub *domain.WebhookSubscription, f WebhookSubscriptionFilter) error {
+	m := toWebhookSubscriptionModel(sub)
+	m.ID = sub.ID
+	return r.getDB(ctx).Omit("created_at").Save(m).Error
+}
+
+func (r *webhookSubscriptionRepository) Create(ctx context.Context, sub *domain.WebhookSubscription) error {
+	m := toWebhookSubscriptionModel(sub)
+	if err := r.getDB(ctx).Create(m).Error; err != nil {
+		return err
+	}
+	sub.ID = m.ID
+	return nil
+}
+
+func (r *webhookSubscriptionRepository) Delete(ctx context.Context, f WebhookSubscriptionFilter) error {
+	return f.Mod(r.getDB(ctx)).Delete(model.WebhookSubscription{}).Error
+}
+
+func (r *webhookSubscriptionRepository) getDB(ctx context.Context) *gorm.DB {
+	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
+		return v.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func toWebhookSubscriptionDomain(sub *model.WebhookSubscription) *domain.WebhookSubscription {
+	var eventTypes []domain.WebhookEventType
+	if sub.EventTypes != "" {
+		for _, t := range strings.Split(sub.EventTypes, ",") {
+			eventTypes = append(eventTypes, domain.WebhookEventType(t))
+		}
+	}
+	return &domain.WebhookSubscription{
+		ID:          sub.ID,
+		Name:        sub.Name,
+		URL:         sub.URL,
+		Secret:      sub.Secret,
+		EventTypes:  eventTypes,
+		AccountType: domain.AccountType(sub.AccountType),
+		AccountID:   sub.AccountID,
+		Status:      domain.Status(sub.Status),
+		UpdatedAt:   sub.UpdatedAt,
+		CreatedAt:   sub.CreatedAt,
+	}
+}
+
+func toWebhookSubscriptionModel(sub *domain.WebhookSubscription) *model.WebhookSubscription {
+	eventTypes := make([]string, 0, len(sub.EventTypes))
+	for _, t := range sub.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+	return &model.WebhookSubscription{
+		Name:        sub.Name,
+		URL:         sub.URL,
+		Secret:      sub.Secret,
+		EventTypes:  strings.Join(eventTypes, ","),
+		AccountType: string(sub.AccountType),
+		AccountID:   sub.AccountID,
+		Status:      int(sub.Status),
+	}
+}
+
+type WebhookSubscriptionFilter struct {
+	ID     *int
+	Status *int
+	Limit  *int
+	Offset *int
+
+	PartialName   *string
+	OrderByIDDesc *bool
+}
+
+func (p *WebhookSubscriptionFilter) Mod(db *gorm.DB) *gorm.DB {
+	if p.ID != nil {
+		db = db.Where("id = ?", *p.ID)
+	}
+	if p.Status != nil {
+		db = db.Where("status = ?", *p.Status)
+	}
+	if p.PartialName != nil {
+		db = db.Where("name like ?", "%"+*p.PartialName+"%")
+	}
+	if p.OrderByIDDesc != nil {
+		db = db.Order("id desc")
+	}
+	if p.Limit != nil {
+		db = db.Limit(*p.Limit)
+		if p.Offset != nil {
+			db = db.Offset(*p.Offset)
+		}
+	}
+	return db
+}
@@ -36,17 +36,22 @@ func (r *wordpressGbpRepository) Get(ctx context.Context, f WordpressGbpFilter)
 		return nil, err
 	}
 	return &domain.WordpressGbp{
-		ID:              wg.ID,
-		Name:            wg.Name,
-		Memo:            wg.Memo,
-		WordpressDomain: wg.WordpressDomain,
-		BusinessName:    wg.BusinessName,
-		BusinessTitle:   wg.BusinessTitle,
-		MapsURL:         wg.MapsURL,
-		StartDate:       wg.StartDate,
-		Status:          domain.Status(wg.Status),
-		UpdatedAt:       wg.UpdatedAt,
-		CreatedAt:       wg.CreatedAt,
+		ID:                 wg.ID,
+		Name:               wg.Name,
+		Memo:               wg.Memo,
+		WordpressDomain:    wg.WordpressDomain,
+		BusinessName:       wg.BusinessName,
+		BusinessTitle:      wg.BusinessTitle,
+		CallToActionType:   domain.CallToActionType(wg.CallToActionType),
+		CallToActionURL:    wg.CallToActionURL,
+		MediaCategory:      domain.GbpMediaCategory(wg.MediaCategory),
+		MediaCategoryRules: decodeMediaCategoryRules(wg.MediaCategoryRules),
+		SanitizeConfig:     decodeSanitizeConfig(wg.SanitizeConfig),
+		MapsURL:            wg.MapsURL,
+		StartDate:          wg.StartDate,
+		Status:             domain.Status(wg.Status),
+		UpdatedAt:          wg.UpdatedAt,
+		CreatedAt:          wg.CreatedAt,
 	}, nil
 }
 
@@ -59,16 +64,21 @@ func (r *wordpressGbpRepository) FindAll(ctx context.Context, f WordpressGbpFilt
 	wordpressGbpList := make([]*domain.WordpressGbp, 0, len(wgList))
 	for _, wg := range wgList {
 		wordpressGbpList = append(wordpressGbpList, &domain.WordpressGbp{
-			ID:              wg.ID,
-			Name:            wg.Name,
-			Memo:            wg.Memo,
-			WordpressDomain: wg.WordpressDomain,
-			BusinessName:    wg.BusinessName,
-			BusinessTitle:   wg.BusinessTitle,
-			MapsURL:         wg.MapsURL,
-			Status:          domain.Status(wg.Status),
-			UpdatedAt:       wg.UpdatedAt,
-			CreatedAt:       wg.CreatedAt,
+			ID:                 wg.ID,
+			Name:               wg.Name,
+			Memo:               wg.Memo,
+			WordpressDomain:    wg.WordpressDomain,
+			BusinessName:       wg.BusinessName,
+			BusinessTitle:      wg.BusinessTitle,
+			CallToActionType:   domain.CallToActionType(wg.CallToActionType),
+			CallToActionURL:    wg.CallToActionURL,
+			MediaCategory:      domain.GbpMediaCategory(wg.MediaCategory),
+			MediaCategoryRules: decodeMediaCategoryRules(wg.MediaCategoryRules),
+			SanitizeConfig:     decodeSanitizeConfig(wg.SanitizeConfig),
+			MapsURL:            wg.MapsURL,
+			Status:             domain.Status(wg.Status),
+			UpdatedAt:          wg.UpdatedAt,
+			CreatedAt:          wg.CreatedAt,
 		})
 	}
 	return wordpressGbpList, nil
@@ -97,29 +107,39 @@ func (r *wordpressGbpRepository) Exists(ctx context.Context, f WordpressGbpFilte
 
 func (r *wordpressGbpRepository) Update(ctx context.Context, wordpressGbp *domain.WordpressGbp, f WordpressGbpFilter) error {
 	m := &model.WordpressGbp{
-		ID:              wordpressGbp.ID,
-		Name:            wordpressGbp.Name,
-		Memo:            wordpressGbp.Memo,
-		WordpressDomain: wordpressGbp.WordpressDomain,
-		BusinessName:    wordpressGbp.BusinessName,
-		BusinessTitle:   wordpressGbp.BusinessTitle,
-		MapsURL:         wordpressGbp.MapsURL,
-		StartDate:       wordpressGbp.StartDate,
-		Status:          int(wordpressGbp.Status),
+		ID:                 wordpressGbp.ID,
+		Name:               wordpressGbp.Name,
+		Memo:               wordpressGbp.Memo,
+		WordpressDomain:    wordpressGbp.WordpressDomain,
+		BusinessName:       wordpressGbp.BusinessName,
+		BusinessTitle:      wordpressGbp.BusinessTitle,
+		CallToActionType:   string(wordpressGbp.CallToActionType),
+		CallToActionURL:    wordpressGbp.CallToActionURL,
+		MediaCategory:      string(wordpressGbp.MediaCategory),
+		MediaCategoryRules: encodeMediaCategoryRules(wordpressGbp.MediaCategoryRules),
+		SanitizeConfig:     encodeSanitizeConfig(wordpressGbp.SanitizeConfig),
+		MapsURL:            wordpressGbp.MapsURL,
+		StartDate:          wordpressGbp.StartDate,
+		Status:             int(wordpressGbp.Status),
 	}
 	return r.getDB(ctx).Omit("created_at").Save(m).Error
 }
 
 func (r *wordpressGbpRepository) Create(ctx context.Context, wordpressGbp *domain.WordpressGbp) error {
 	m := model.WordpressGbp{
-		Name:            wordpressGbp.Name,
-		Memo:            wordpressGbp.Memo,
-		WordpressDomain: wordpressGbp.WordpressDomain,
-		BusinessName:    wordpressGbp.BusinessName,
-		BusinessTitle:   wordpressGbp.BusinessTitle,
-		MapsURL:         wordpressGbp.MapsURL,
-		StartDate:       wordpressGbp.StartDate,
-		Status:          int(wordpressGbp.Status),
+		Name:               wordpressGbp.Name,
+		Memo:               wordpressGbp.Memo,
+		WordpressDomain:    wordpressGbp.WordpressDomain,
+		BusinessName:       wordpressGbp.BusinessName,
+		BusinessTitle:      wordpressGbp.BusinessTitle,
+		CallToActionType:   string(wordpressGbp.CallToActionType),
+		CallToActionURL:    wordpressGbp.CallToActionURL,
+		MediaCategory:      string(wordpressGbp.MediaCategory),
+		MediaCategoryRules: encodeMediaCategoryRules(wordpressGbp.MediaCategoryRules),
+		SanitizeConfig:     encodeSanitizeConfig(wordpressGbp.SanitizeConfig),
+		MapsURL:            wordpressGbp.MapsURL,
+		StartDate:          wordpressGbp.StartDate,
+		Status:             int(wordpressGbp.Status),
 	}
 	if err := r.getDB(ctx).Create(&m).Error; err != nil {
 		return err
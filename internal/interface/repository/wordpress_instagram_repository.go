@@ -19,6 +19,7 @@ type WordpressInstagramRepository interface {
 	Update(ctx context.Context, item *domain.WordpressInstagram, f WordpressInstagramFilter) error
 	Create(ctx context.Context, wordpressInstagram *domain.WordpressInstagram) error
 	Delete(ctx context.Context, f WordpressInstagramFilter) error
+	UpdateSyncState(ctx context.Context, id int, syncedAt time.Time, syncErr string) error
 }
 
 type wordpressInstagramRepository struct {
@@ -49,6 +50,12 @@ func (r *wordpressInstagramRepository) Get(ctx context.Context, f WordpressInsta
 		Status:             domain.Status(wi.Status),
 		DeleteHash:         wi.DeleteHash,
 		Categories:         strings.Split(wi.Categories, ","),
+		APIMode:            domain.WordpressAPIMode(wi.APIMode),
+		WordpressUsername:  wi.WordpressUsername,
+		AppPassword:        wi.AppPassword,
+		Template:           wi.Template,
+		LastSyncedAt:       wi.LastSyncedAt,
+		LastSyncError:      wi.LastSyncError,
 		UpdatedAt:          wi.UpdatedAt,
 		CreatedAt:          wi.UpdatedAt,
 	}, nil
@@ -74,6 +81,12 @@ func (r *wordpressInstagramRepository) FindAll(ctx context.Context, f WordpressI
 			Status:             domain.Status(wi.Status),
 			DeleteHash:         wi.DeleteHash,
 			Categories:         strings.Split(wi.Categories, ","),
+			APIMode:            domain.WordpressAPIMode(wi.APIMode),
+			WordpressUsername:  wi.WordpressUsername,
+			AppPassword:        wi.AppPassword,
+			Template:           wi.Template,
+			LastSyncedAt:       wi.LastSyncedAt,
+			LastSyncError:      wi.LastSyncError,
 			UpdatedAt:          wi.UpdatedAt,
 			CreatedAt:          wi.CreatedAt,
 		})
@@ -116,8 +129,13 @@ func (r *wordpressInstagramRepository) Update(ctx context.Context, wordpressInst
 		Status:             int(wordpressInstagram.Status),
 		Categories:         strings.Join(wordpressInstagram.Categories, ","),
 		DeleteHash:         wordpressInstagram.DeleteHash,
+		APIMode:            string(wordpressInstagram.APIMode),
+		WordpressUsername:  wordpressInstagram.WordpressUsername,
+		AppPassword:        wordpressInstagram.AppPassword,
+		Template:           wordpressInstagram.Template,
 	}
-	return r.getDB(ctx).Omit("created_at").Save(m).Error
+	// 同期結果は UpdateSyncState でのみ更新する
+	return r.getDB(ctx).Omit("created_at", "last_synced_at", "last_sync_error").Save(m).Error
 }
 
 func (r *wordpressInstagramRepository) Create(ctx context.Context, wordpressInstagram *domain.WordpressInstagram) error {
@@ -133,6 +151,10 @@ func (r *wordpressInstagramRepository) Create(ctx context.Context, wordpressInst
 		Status:             int(wordpressInstagram.Status),
 		DeleteHash:         wordpressInstagram.DeleteHash,
 		Categories:         strings.Join(wordpressInstagram.Categories, ","),
+		APIMode:            string(wordpressInstagram.APIMode),
+		WordpressUsername:  wordpressInstagram.WordpressUsername,
+		AppPassword:        wordpressInstagram.AppPassword,
+		Template:           wordpressInstagram.Template,
 	}
 	if err := r.getDB(ctx).Create(&m).Error; err != nil {
 		return err
@@ -145,6 +167,14 @@ func (r *wordpressInstagramRepository) Delete(ctx context.Context, f WordpressIn
 	return f.Mod(r.getDB(ctx)).Delete(model.WordpressInstagram{}).Error
 }
 
+// UpdateSyncState は同期結果だけを更新する。同期中に管理画面で設定が変更されても上書きしないようにする。
+func (r *wordpressInstagramRepository) UpdateSyncState(ctx context.Context, id int, syncedAt time.Time, syncErr string) error {
+	return r.getDB(ctx).Model(&model.WordpressInstagram{}).Where("id = ?", id).UpdateColumns(map[string]interface{}{
+		"last_synced_at":  syncedAt,
+		"last_sync_error": syncErr,
+	}).Error
+}
+
 func (r *wordpressInstagramRepository) getDB(ctx context.Context) *gorm.DB {
 	if v, ok := ctx.Value(TxKey{}).(*gorm.DB); ok {
 		return v.WithContext(ctx)
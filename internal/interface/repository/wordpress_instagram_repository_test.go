@@ -186,6 +186,48 @@ func TestWordpressInstagramRepository_Update(t *testing.T) {
 	})
 }
 
+func TestWordpressInstagramRepository_UpdateSyncState(t *testing.T) {
+	repo := NewWordpressInstagramRepository(db)
+	ctx := context.Background()
+
+	// テストデータを作成
+	testWI := &domain.WordpressInstagram{
+		Name:               "Sync State Test Site",
+		WordpressDomain:    "https://syncstate.example.com",
+		WordpressSiteTitle: "Sync State Test Title",
+		InstagramID:        "121212121",
+		InstagramName:      "syncstateuser",
+		StartDate:          time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		Status:             1,
+		Template:           "{{content}}",
+	}
+	err := repo.Create(ctx, testWI)
+	assert.NoError(t, err)
+
+	t.Run("同期結果だけを更新", func(t *testing.T) {
+		syncedAt := time.Date(2025, 4, 2, 3, 0, 0, 0, time.UTC)
+		err := repo.UpdateSyncState(ctx, testWI.ID, syncedAt, "upload failed")
+		assert.NoError(t, err)
+
+		got, err := repo.Get(ctx, WordpressInstagramFilter{ID: &testWI.ID})
+		assert.NoError(t, err)
+		assert.NotNil(t, got.LastSyncedAt)
+		assert.Equal(t, "upload failed", got.LastSyncError)
+		assert.Equal(t, "{{content}}", got.Template)
+
+		// 設定の更新では同期結果を上書きしない
+		got.Memo = "updated"
+		got.LastSyncError = ""
+		err = repo.Update(ctx, got, WordpressInstagramFilter{ID: &testWI.ID})
+		assert.NoError(t, err)
+
+		got, err = repo.Get(ctx, WordpressInstagramFilter{ID: &testWI.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, "updated", got.Memo)
+		assert.Equal(t, "upload failed", got.LastSyncError)
+	})
+}
+
 func TestWordpressInstagramRepository_Delete(t *testing.T) {
 	repo := NewWordpressInstagramRepository(db)
 	ctx := context.Background()
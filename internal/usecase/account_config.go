@@ -0,0 +1,319 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+)
+
+// 連携の設定をCSV・JSONでまとめてインポート・エクスポートする。
+// 行の形式は作成APIのリクエストと同じで、CSVの列名はJSONのキーと同じ。
+// インポートは全ての行を作成APIと同じように検証してから、1つのトランザクションで全件を作成する。
+// 1行でも失敗した場合は何も作成しない。
+
+// accountConfigColumn はCSVの1列と、リクエストのフィールドの対応
+type accountConfigColumn[B any] struct {
+	name   string
+	format func(body *B) (string, error)
+	parse  func(body *B, value string) error
+}
+
+func stringColumn[B any](name string, field func(body *B) *string) accountConfigColumn[B] {
+	return accountConfigColumn[B]{
+		name: name,
+		format: func(body *B) (string, error) {
+			return *field(body), nil
+		},
+		parse: func(body *B, value string) error {
+			*field(body) = value
+			return nil
+		},
+	}
+}
+
+func intColumn[B any](name string, field func(body *B) *int) accountConfigColumn[B] {
+	return accountConfigColumn[B]{
+		name: name,
+		format: func(body *B) (string, error) {
+			return strconv.Itoa(*field(body)), nil
+		},
+		parse: func(body *B, value string) error {
+			if value == "" {
+				return nil
+			}
+			v, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("%w: %s は数値で指定してください: %s", domain.ErrBadRequest, name, value)
+			}
+			*field(body) = v
+			return nil
+		},
+	}
+}
+
+func boolColumn[B any](name string, field func(body *B) *bool) accountConfigColumn[B] {
+	return accountConfigColumn[B]{
+		name: name,
+		format: func(body *B) (string, error) {
+			return strconv.FormatBool(*field(body)), nil
+		},
+		parse: func(body *B, value string) error {
+			if value == "" {
+				return nil
+			}
+			v, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%w: %s は true または false で指定してください: %s", domain.ErrBadRequest, name, value)
+			}
+			*field(body) = v
+			return nil
+		},
+	}
+}
+
+// timeColumn はRFC3339の日時、または日本時間の日付（2006-01-02）を受け付ける
+func timeColumn[B any](name string, field func(body *B) *time.Time) accountConfigColumn[B] {
+	jst := time.FixedZone("Asia/Tokyo", 9*60*60)
+	return accountConfigColumn[B]{
+		name: name,
+		format: func(body *B) (string, error) {
+			if field(body).IsZero() {
+				return "", nil
+			}
+			return field(body).Format(time.RFC3339), nil
+		},
+		parse: func(body *B, value string) error {
+			if value == "" {
+				return nil
+			}
+			if t, err := time.Parse(time.RFC3339, value); err == nil {
+				*field(body) = t
+				return nil
+			}
+			t, err := time.ParseInLocation(time.DateOnly, value, jst)
+			if err != nil {
+				return fmt.Errorf("%w: %s はRFC3339の日時、または2006-01-02の形式で指定してください: %s", domain.ErrBadRequest, name, value)
+			}
+			*field(body) = t
+			return nil
+		},
+	}
+}
+
+// jsonColumn は配列やオブジェクトのフィールドを、セルにJSONで書く
+func jsonColumn[B any, V any](name string, field func(body *B) *V) accountConfigColumn[B] {
+	return accountConfigColumn[B]{
+		name: name,
+		format: func(body *B) (string, error) {
+			data, err := json.Marshal(field(body))
+			if err != nil {
+				return "", err
+			}
+			if string(data) == "null" {
+				return "", nil
+			}
+			return string(data), nil
+		},
+		parse: func(body *B, value string) error {
+			if value == "" {
+				return nil
+			}
+			if err := json.Unmarshal([]byte(value), field(body)); err != nil {
+				return fmt.Errorf("%w: %s はJSONで指定してください: %s", domain.ErrBadRequest, name, err.Error())
+			}
+			return nil
+		},
+	}
+}
+
+// accountConfigRow はインポートするファイルの1行。形式が不正な行は err を持つ
+type accountConfigRow[B any] struct {
+	body B
+	err  error
+}
+
+func decodeAccountConfigs[B any](format domain.AccountConfigFormat, data []byte, columns []accountConfigColumn[B]) ([]accountConfigRow[B], error) {
+	var rows []accountConfigRow[B]
+	switch format {
+	case domain.AccountConfigFormatCSV:
+		// Excelで保存したCSVはBOMが付いている
+		reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+		records, err := reader.ReadAll()
+		if err != nil {
+			return nil, fmt.Errorf("%w: CSVを読み込めません: %s", domain.ErrBadRequest, err.Error())
+		}
+		if len(records) == 0 {
+			return nil, fmt.Errorf("%w: CSVにヘッダーがありません", domain.ErrBadRequest)
+		}
+		header := make([]accountConfigColumn[B], 0, len(records[0]))
+		for _, name := range records[0] {
+			column, ok := findAccountConfigColumn(columns, name)
+			if !ok {
+				return nil, fmt.Errorf("%w: 不明な列です: %s", domain.ErrBadRequest, name)
+			}
+			header = append(header, column)
+		}
+		for _, record := range records[1:] {
+			var row accountConfigRow[B]
+			var errs []error
+			for i, value := range record {
+				errs = append(errs, header[i].parse(&row.body, value))
+			}
+			row.err = errors.Join(errs...)
+			rows = append(rows, row)
+		}
+	default:
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("%w: JSONの配列を読み込めません: %s", domain.ErrBadRequest, err.Error())
+		}
+		for _, item := range items {
+			var row accountConfigRow[B]
+			if err := json.Unmarshal(item, &row.body); err != nil {
+				row.err = fmt.Errorf("%w: %s", domain.ErrBadRequest, err.Error())
+			}
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: インポートする行がありません", domain.ErrBadRequest)
+	}
+	return rows, nil
+}
+
+func findAccountConfigColumn[B any](columns []accountConfigColumn[B], name string) (accountConfigColumn[B], bool) {
+	for _, column := range columns {
+		if column.name == name {
+			return column, true
+		}
+	}
+	return accountConfigColumn[B]{}, false
+}
+
+func encodeAccountConfigs[B any](format domain.AccountConfigFormat, bodies []B, columns []accountConfigColumn[B]) ([]byte, error) {
+	if format != domain.AccountConfigFormatCSV {
+		if bodies == nil {
+			bodies = []B{}
+		}
+		return json.MarshalIndent(bodies, "", "  ")
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	header := make([]string, 0, len(columns))
+	for _, column := range columns {
+		header = append(header, column.name)
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+	for i := range bodies {
+		record := make([]string, 0, len(columns))
+		for _, column := range columns {
+			value, err := column.format(&bodies[i])
+			if err != nil {
+				return nil, err
+			}
+			record = append(record, value)
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// importAccountConfigs は全ての行を検証し、dryRun でなく全ての行が成功した場合だけ、1つのトランザクションで全件を作成する。
+//
+//	key    ファイル内の重複を判定するキー
+//	build  作成APIと同じ検証と、外部APIでの確認（DBには保存しない）
+//	create DBへの保存。作成したIDを返す
+func importAccountConfigs[B any, T any](
+	ctx context.Context,
+	baseRepo repository.BaseRepository,
+	rows []accountConfigRow[B],
+	dryRun bool,
+	key func(body B) string,
+	build func(ctx context.Context, body B) (T, error),
+	create func(ctx context.Context, item T) (int, error),
+) (*res.AccountConfigImport, error) {
+	result := &res.AccountConfigImport{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   make([]res.AccountConfigImportRow, 0, len(rows)),
+	}
+
+	/*
+		各行の検証
+	*/
+	items := make([]T, len(rows))
+	seen := make(map[string]int, len(rows))
+	failed := false
+	for i, row := range rows {
+		err := row.err
+		if err == nil {
+			if prev, ok := seen[key(row.body)]; ok {
+				err = fmt.Errorf("%w: %d行目と重複しています", domain.ErrBadRequest, prev)
+			} else {
+				seen[key(row.body)] = i + 1
+				items[i], err = build(ctx, row.body)
+			}
+		}
+		item := res.AccountConfigImportRow{Row: i + 1, OK: err == nil}
+		if err != nil {
+			item.Error = err.Error()
+			failed = true
+		}
+		result.Rows = append(result.Rows, item)
+	}
+	if failed || dryRun {
+		result.OK = !failed
+		return result, nil
+	}
+
+	/*
+		全件の作成
+	*/
+	ids := make([]int, len(items))
+	failedRow := -1
+	err := baseRepo.WithTransaction(ctx, func(ctx context.Context) error {
+		for i, item := range items {
+			id, err := create(ctx, item)
+			if err != nil {
+				failedRow = i
+				return err
+			}
+			ids[i] = id
+		}
+		return nil
+	})
+	if err != nil {
+		if failedRow < 0 {
+			return nil, err
+		}
+		// ロールバックしたため、どの行も作成されていない
+		result.Rows[failedRow].OK = false
+		result.Rows[failedRow].Error = err.Error()
+		return result, nil
+	}
+
+	for i, id := range ids {
+		result.Rows[i].ID = id
+	}
+	result.OK = true
+	result.Created = len(items)
+	return result, nil
+}
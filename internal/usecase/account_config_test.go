@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+)
+
+type fakeBaseRepo struct {
+	committed  bool
+	rolledBack bool
+}
+
+func (f *fakeBaseRepo) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		f.rolledBack = true
+		return err
+	}
+	f.committed = true
+	return nil
+}
+
+func newImportWordpressGbpTest(repo *fakeWordpressGbpRepo, baseRepo *fakeBaseRepo) WordpressGbpUsecase {
+	// down.example.com は接続できない
+	wordpressAdapter := &fakeWordpressAdapter{titles: map[string]string{
+		"a.example.com": "テストサイト",
+		"b.example.com": "テストサイト",
+		"d.example.com": "テストサイト",
+	}}
+	return NewWordpressGbpUsecase(repo, nil, wordpressAdapter, &fakeGbpAdapter{}, &fakeGoogleBusinessRepo{}, newFakeGoogleAccountRepo(), nil, baseRepo)
+}
+
+// importSummary は各行の結果を "row:ok:id[:error]" の形式で並べる
+func importSummary(result *res.AccountConfigImport) []string {
+	var summary []string
+	for _, row := range result.Rows {
+		line := fmt.Sprintf("%d:%t:%d", row.Row, row.OK, row.ID)
+		if row.Error != "" {
+			line += ":" + row.Error
+		}
+		summary = append(summary, line)
+	}
+	return summary
+}
+
+func TestDecodeAccountConfigs(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		// Excelで保存したBOM付きのCSV
+		data := "\ufeffname,wordpress_domain,business_name,start_date,status,media_category_rules,sanitize_config\n" +
+			`店舗A,a.example.com,locations/1,2026-04-01,1,"[{""keyword"":""料理"",""category"":""FOOD_AND_DRINK""}]",` + "\n" +
+			`店舗B,b.example.com,locations/2,2026-04-01T10:00:00Z,x,,"{""rules"":[""hashtags""]}"` + "\n"
+
+		rows, err := decodeAccountConfigs(domain.AccountConfigFormatCSV, []byte(data), wordpressGbpColumns)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		require.NoError(t, rows[0].err)
+		assert.Equal(t, "店舗A", rows[0].body.Name)
+		assert.Equal(t, 1, rows[0].body.Status)
+		assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.FixedZone("Asia/Tokyo", 9*60*60)).Unix(), rows[0].body.StartDate.Unix())
+		assert.Equal(t, []req.GbpMediaCategoryRule{{Keyword: "料理", Category: "FOOD_AND_DRINK"}}, rows[0].body.MediaCategoryRules)
+		assert.Nil(t, rows[0].body.SanitizeConfig)
+
+		assert.ErrorIs(t, rows[1].err, domain.ErrBadRequest)
+		assert.Contains(t, rows[1].err.Error(), "status は数値で指定してください")
+		assert.Equal(t, []string{"hashtags"}, rows[1].body.SanitizeConfig.Rules)
+	})
+
+	t.Run("JSONの型が違う行だけ失敗する", func(t *testing.T) {
+		data := `[{"name":"店舗A","status":1},{"name":"店舗B","status":"active"}]`
+
+		rows, err := decodeAccountConfigs(domain.AccountConfigFormatJSON, []byte(data), wordpressGbpColumns)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.NoError(t, rows[0].err)
+		assert.ErrorIs(t, rows[1].err, domain.ErrBadRequest)
+	})
+
+	t.Run("ファイル全体が不正", func(t *testing.T) {
+		for name, tt := range map[string]struct {
+			format domain.AccountConfigFormat
+			data   string
+		}{
+			"不明な列":     {domain.AccountConfigFormatCSV, "name,unknown\na,b\n"},
+			"列数が違う":    {domain.AccountConfigFormatCSV, "name,memo\na\n"},
+			"行がない":     {domain.AccountConfigFormatCSV, "name,memo\n"},
+			"配列ではない":   {domain.AccountConfigFormatJSON, `{"name":"a"}`},
+			"空のJSON配列": {domain.AccountConfigFormatJSON, `[]`},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := decodeAccountConfigs(tt.format, []byte(tt.data), wordpressGbpColumns)
+				assert.ErrorIs(t, err, domain.ErrBadRequest)
+			})
+		}
+	})
+}
+
+func TestWordpressGbpUsecase_ImportWordpressGbp(t *testing.T) {
+	data := []byte("name,wordpress_domain,business_name,call_to_action_type\n" +
+		"店舗A,a.example.com,locations/1,LEARN_MORE\n" +
+		"店舗B,b.example.com,locations/2,\n")
+
+	t.Run("全ての行が成功した場合は全件を作成する", func(t *testing.T) {
+		repo := &fakeWordpressGbpRepo{}
+		baseRepo := &fakeBaseRepo{}
+		u := newImportWordpressGbpTest(repo, baseRepo)
+
+		result, err := u.ImportWordpressGbp(context.Background(), domain.AccountConfigFormatCSV, data, false)
+		require.NoError(t, err)
+		assert.True(t, result.OK)
+		assert.Equal(t, 2, result.Created)
+		assert.Equal(t, []string{"1:true:1", "2:true:2"}, importSummary(result))
+		assert.True(t, baseRepo.committed)
+		require.Len(t, repo.created, 2)
+		assert.Equal(t, "テスト店舗", repo.created[0].BusinessTitle)
+	})
+
+	t.Run("ドライランは検証だけして何も作成しない", func(t *testing.T) {
+		repo := &fakeWordpressGbpRepo{}
+		baseRepo := &fakeBaseRepo{}
+		u := newImportWordpressGbpTest(repo, baseRepo)
+
+		result, err := u.ImportWordpressGbp(context.Background(), domain.AccountConfigFormatCSV, data, true)
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.True(t, result.OK)
+		assert.Equal(t, 0, result.Created)
+		assert.Equal(t, []string{"1:true:0", "2:true:0"}, importSummary(result))
+		assert.False(t, baseRepo.committed)
+		assert.Empty(t, repo.created)
+	})
+
+	t.Run("1行でも失敗した場合は何も作成しない", func(t *testing.T) {
+		repo := &fakeWordpressGbpRepo{existing: map[string]bool{"b.example.com locations/2": true}}
+		baseRepo := &fakeBaseRepo{}
+		u := newImportWordpressGbpTest(repo, baseRepo)
+
+		result, err := u.ImportWordpressGbp(context.Background(), domain.AccountConfigFormatJSON, []byte(`[
+			{"name":"店舗A","wordpress_domain":"a.example.com","business_name":"locations/1"},
+			{"name":"店舗B","wordpress_domain":"b.example.com","business_name":"locations/2"},
+			{"name":"店舗A2","wordpress_domain":"a.example.com","business_name":"locations/1"},
+			{"name":"店舗C","wordpress_domain":"down.example.com","business_name":"locations/3"},
+			{"name":"店舗D","wordpress_domain":"d.example.com","business_name":"locations/4","call_to_action_type":"INVALID"}
+		]`), false)
+		require.NoError(t, err)
+		assert.False(t, result.OK)
+		assert.Equal(t, 5, result.Total)
+		assert.Equal(t, 0, result.Created)
+		assert.Equal(t, []string{
+			"1:true:0",
+			"2:false:0:bad request: 同じWordPressとビジネスの連携が既にあります",
+			"3:false:0:bad request: 1行目と重複しています",
+			"4:false:0:" + domain.ErrWordpressConnection.Error(),
+			"5:false:0:bad request: call_to_action_type が不正です: INVALID",
+		}, importSummary(result))
+		assert.False(t, baseRepo.committed)
+		assert.False(t, baseRepo.rolledBack)
+		assert.Empty(t, repo.created)
+	})
+
+	t.Run("保存に失敗した場合はロールバックする", func(t *testing.T) {
+		repo := &fakeWordpressGbpRepo{failName: "店舗B"}
+		baseRepo := &fakeBaseRepo{}
+		u := newImportWordpressGbpTest(repo, baseRepo)
+
+		result, err := u.ImportWordpressGbp(context.Background(), domain.AccountConfigFormatCSV, data, false)
+		require.NoError(t, err)
+		assert.False(t, result.OK)
+		assert.Equal(t, 0, result.Created)
+		assert.Equal(t, []string{"1:true:0", "2:false:0:duplicate entry"}, importSummary(result))
+		assert.True(t, baseRepo.rolledBack)
+	})
+}
+
+func TestWordpressGbpUsecase_ExportWordpressGbp(t *testing.T) {
+	repo := &fakeWordpressGbpRepo{wgList: []*domain.WordpressGbp{
+		{
+			ID:                 1,
+			Name:               "店舗A",
+			WordpressDomain:    "a.example.com",
+			BusinessName:       "locations/1",
+			BusinessTitle:      "テスト店舗",
+			CallToActionType:   domain.CallToActionLearnMore,
+			MediaCategory:      domain.GbpMediaCategory("FOOD_AND_DRINK"),
+			MediaCategoryRules: []domain.GbpMediaCategoryRule{{Keyword: "外観", Category: domain.GbpMediaCategory("EXTERIOR")}},
+			SanitizeConfig:     &domain.GbpSanitizeConfig{Rules: []domain.GbpSanitizeRule{"hashtags"}},
+			StartDate:          time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			Status:             domain.Status(1),
+		},
+		{ID: 2, Name: "店舗B", WordpressDomain: "b.example.com", BusinessName: "locations/2"},
+	}}
+	u := newImportWordpressGbpTest(repo, &fakeBaseRepo{})
+
+	t.Run("CSV", func(t *testing.T) {
+		data, err := u.ExportWordpressGbp(context.Background(), domain.AccountConfigFormatCSV)
+		require.NoError(t, err)
+		assert.Equal(t, "name,wordpress_domain,business_name,memo,call_to_action_type,call_to_action_url,media_category,media_category_rules,sanitize_config,start_date,status\n"+
+			`店舗A,a.example.com,locations/1,,LEARN_MORE,,FOOD_AND_DRINK,"[{""keyword"":""外観"",""category"":""EXTERIOR""}]","{""rules"":[""hashtags""],""patterns"":null}",2026-04-01T00:00:00Z,1`+"\n"+
+			"店舗B,b.example.com,locations/2,,,,,,,,0\n", string(data))
+
+		// エクスポートしたCSVはそのままインポートできる
+		rows, err := decodeAccountConfigs(domain.AccountConfigFormatCSV, data, wordpressGbpColumns)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.NoError(t, rows[0].err)
+		assert.Equal(t, "EXTERIOR", rows[0].body.MediaCategoryRules[0].Category)
+		assert.Equal(t, []string{"hashtags"}, rows[0].body.SanitizeConfig.Rules)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := u.ExportWordpressGbp(context.Background(), domain.AccountConfigFormatJSON)
+		require.NoError(t, err)
+
+		rows, err := decodeAccountConfigs(domain.AccountConfigFormatJSON, data, wordpressGbpColumns)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, "locations/1", rows[0].body.BusinessName)
+		assert.True(t, rows[0].body.StartDate.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)))
+	})
+}
@@ -2,9 +2,11 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"time"
 
-	"github.com/zuxt268/homing/internal/config"
 	"github.com/zuxt268/homing/internal/domain"
 	"github.com/zuxt268/homing/internal/interface/adapter"
 	"github.com/zuxt268/homing/internal/interface/dto/req"
@@ -16,10 +18,14 @@ import (
 type BusinessInstagramUsecase interface {
 	FetchGoogleBusinesses(ctx context.Context) error
 	GetGoogleBusinesses(ctx context.Context, limit, offset int) ([]*domain.GoogleBusinesses, int64, error)
+	GetGoogleAccounts(ctx context.Context) ([]*domain.GoogleAccount, error)
 
 	GetBusinessInstagram(ctx context.Context, id int, params req.GetBusinessInstagramDetail) (*res.BusinessInstagramDetail, error)
 	GetBusinessInstagramList(ctx context.Context, params req.GetBusinessInstagram) (*res.BusinessInstagramList, error)
 	CreateBusinessInstagram(ctx context.Context, body req.BusinessInstagram) (*res.BusinessInstagram, error)
+	ValidateBusinessInstagram(ctx context.Context, body req.BusinessInstagram) (*res.OnboardingCheckList, error)
+	ImportBusinessInstagram(ctx context.Context, format domain.AccountConfigFormat, data []byte, dryRun bool) (*res.AccountConfigImport, error)
+	ExportBusinessInstagram(ctx context.Context, format domain.AccountConfigFormat) ([]byte, error)
 	UpdateBusinessInstagram(ctx context.Context, id int, body req.BusinessInstagram) (*res.BusinessInstagram, error)
 	DeleteBusinessInstagram(ctx context.Context, id int) error
 }
@@ -31,6 +37,10 @@ type businessInstagramUsecase struct {
 	googlePostRepo        repository.GooglePostRepository
 	instagramAdapter      adapter.InstagramAdapter
 	gbpAdapter            adapter.GbpAdapter
+	googleAccountRepo     repository.GoogleAccountRepository
+	googleOAuthTokenRepo  repository.GoogleOAuthTokenRepository
+	webhookUsecase        WebhookUsecase
+	baseRepo              repository.BaseRepository
 }
 
 func NewBusinessInstagramUsecase(
@@ -40,6 +50,10 @@ func NewBusinessInstagramUsecase(
 	googlePostRepo repository.GooglePostRepository,
 	instagramAdapter adapter.InstagramAdapter,
 	gbpAdapter adapter.GbpAdapter,
+	googleAccountRepo repository.GoogleAccountRepository,
+	googleOAuthTokenRepo repository.GoogleOAuthTokenRepository,
+	webhookUsecase WebhookUsecase,
+	baseRepo repository.BaseRepository,
 ) BusinessInstagramUsecase {
 	return &businessInstagramUsecase{
 		googleBusinessRepo:    googleBusinessRepo,
@@ -48,34 +62,59 @@ func NewBusinessInstagramUsecase(
 		googlePostRepo:        googlePostRepo,
 		instagramAdapter:      instagramAdapter,
 		gbpAdapter:            gbpAdapter,
+		googleAccountRepo:     googleAccountRepo,
+		googleOAuthTokenRepo:  googleOAuthTokenRepo,
+		webhookUsecase:        webhookUsecase,
+		baseRepo:              baseRepo,
 	}
 }
 
 func (u *businessInstagramUsecase) FetchGoogleBusinesses(ctx context.Context) error {
-	businesses, err := u.gbpAdapter.GetAllBusinesses(ctx, config.Env.GoogleBusinessAccountName)
+	/*
+		認証済みのトークンから参照できるアカウントを同期
+	*/
+	tokens, err := u.googleOAuthTokenRepo.FindAll(ctx, repository.GoogleOAuthTokenFilter{})
 	if err != nil {
 		return err
 	}
-	for _, business := range businesses {
-		exists, err := u.googleBusinessRepo.Exists(ctx, repository.GoogleBusinessFilter{
-			Name: &business.Name,
-		})
-		if err != nil {
-			return err
+	for _, token := range tokens {
+		if err := syncGoogleAccounts(ctx, u.gbpAdapter, u.googleAccountRepo, token.ID); err != nil {
+			slog.Error("Googleアカウント同期エラー", "token_id", token.ID, "error", err.Error())
 		}
-		if exists {
+	}
+
+	accounts, err := u.googleAccountRepo.FindAll(ctx, repository.GoogleAccountFilter{
+		All: util.Pointer(true),
+	})
+	if err != nil {
+		return err
+	}
+	if len(accounts) == 0 {
+		return domain.ErrGoogleNotAuthorized
+	}
+
+	/*
+		アカウントごとにビジネスを取り込み、アカウントを紐づける
+	*/
+	for _, account := range accounts {
+		businesses, err := u.gbpAdapter.GetAllBusinesses(ctx, account)
+		if err != nil {
+			slog.Error("ビジネス取得エラー", "account", account.Name, "error", err.Error())
 			continue
 		}
-		if err := u.googleBusinessRepo.Create(ctx, &domain.GoogleBusinesses{
-			Name:  business.Name,
-			Title: business.Title,
-		}); err != nil {
+		if err := saveGoogleBusinesses(ctx, u.googleBusinessRepo, account, businesses); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+func (u *businessInstagramUsecase) GetGoogleAccounts(ctx context.Context) ([]*domain.GoogleAccount, error) {
+	return u.googleAccountRepo.FindAll(ctx, repository.GoogleAccountFilter{
+		All: util.Pointer(true),
+	})
+}
+
 func (u *businessInstagramUsecase) GetGoogleBusinesses(ctx context.Context, limit, offset int) ([]*domain.GoogleBusinesses, int64, error) {
 	// 総数を取得
 	total, err := u.googleBusinessRepo.Count(ctx, repository.GoogleBusinessFilter{
@@ -119,18 +158,23 @@ func (u *businessInstagramUsecase) GetBusinessInstagramList(ctx context.Context,
 	resBusinessInstagram := make([]res.BusinessInstagram, len(biList))
 	for i, business := range biList {
 		resBusinessInstagram[i] = res.BusinessInstagram{
-			ID:            business.ID,
-			Name:          business.Name,
-			BusinessName:  business.BusinessName,
-			BusinessTitle: business.BusinessTitle,
-			InstagramID:   business.InstagramID,
-			InstagramName: business.InstagramName,
-			Memo:          business.Memo,
-			MapsURL:       business.MapsURL,
-			StartDate:     business.StartDate,
-			Status:        int(business.Status),
-			CreatedAt:     business.CreatedAt,
-			UpdatedAt:     business.UpdatedAt,
+			ID:                 business.ID,
+			Name:               business.Name,
+			BusinessName:       business.BusinessName,
+			BusinessTitle:      business.BusinessTitle,
+			InstagramID:        business.InstagramID,
+			InstagramName:      business.InstagramName,
+			Memo:               business.Memo,
+			MapsURL:            business.MapsURL,
+			CallToActionType:   string(business.CallToActionType),
+			CallToActionURL:    business.CallToActionURL,
+			MediaCategory:      string(business.MediaCategory),
+			MediaCategoryRules: toMediaCategoryRulesResponse(business.MediaCategoryRules),
+			SanitizeConfig:     toSanitizeConfigResponse(business.SanitizeConfig),
+			StartDate:          business.StartDate,
+			Status:             int(business.Status),
+			CreatedAt:          business.CreatedAt,
+			UpdatedAt:          business.UpdatedAt,
 		}
 	}
 	return &res.BusinessInstagramList{
@@ -168,24 +212,87 @@ func (u *businessInstagramUsecase) GetBusinessInstagram(ctx context.Context, id
 		return nil, err
 	}
 	return &res.BusinessInstagramDetail{
-		ID:                bi.ID,
-		Name:              bi.Name,
-		BusinessName:      bi.BusinessName,
-		BusinessTitle:     bi.BusinessTitle,
-		InstagramID:       bi.InstagramID,
-		InstagramName:     bi.InstagramName,
-		Memo:              bi.Memo,
-		MapsURL:           bi.MapsURL,
-		StartDate:         bi.StartDate,
-		Status:            int(bi.Status),
-		GooglePhotosCount: googlePhotosCount,
-		GooglePostsCount:  googlePostsCount,
-		CreatedAt:         bi.CreatedAt,
-		UpdatedAt:         bi.UpdatedAt,
+		ID:                 bi.ID,
+		Name:               bi.Name,
+		BusinessName:       bi.BusinessName,
+		BusinessTitle:      bi.BusinessTitle,
+		InstagramID:        bi.InstagramID,
+		InstagramName:      bi.InstagramName,
+		Memo:               bi.Memo,
+		MapsURL:            bi.MapsURL,
+		CallToActionType:   string(bi.CallToActionType),
+		CallToActionURL:    bi.CallToActionURL,
+		MediaCategory:      string(bi.MediaCategory),
+		MediaCategoryRules: toMediaCategoryRulesResponse(bi.MediaCategoryRules),
+		SanitizeConfig:     toSanitizeConfigResponse(bi.SanitizeConfig),
+		StartDate:          bi.StartDate,
+		Status:             int(bi.Status),
+		GooglePhotosCount:  googlePhotosCount,
+		GooglePostsCount:   googlePostsCount,
+		CreatedAt:          bi.CreatedAt,
+		UpdatedAt:          bi.UpdatedAt,
 	}, nil
 }
 
+// ValidateBusinessInstagram は作成前に、設定値・Instagramアカウント・GBPのビジネスを確認する。何も作成・投稿しない。
+func (u *businessInstagramUsecase) ValidateBusinessInstagram(ctx context.Context, body req.BusinessInstagram) (*res.OnboardingCheckList, error) {
+	settingsErr := errors.Join(
+		domain.ValidateCallToAction(domain.CallToActionType(body.CallToActionType), body.CallToActionURL, false),
+		domain.ValidateGbpMediaCategory(domain.GbpMediaCategory(body.MediaCategory), toGbpMediaCategoryRules(body.MediaCategoryRules)),
+	)
+	if config := toGbpSanitizeConfig(body.SanitizeConfig); config != nil {
+		settingsErr = errors.Join(settingsErr, config.Validate())
+	}
+
+	return toOnboardingCheckList([]domain.OnboardingCheck{
+		checkSettings(settingsErr),
+		checkInstagramAccount(ctx, u.tokenRepo, u.instagramAdapter, body.InstagramID),
+		checkGoogleBusiness(ctx, u.googleBusinessRepo, u.googleAccountRepo, u.gbpAdapter, body.BusinessName),
+	}), nil
+}
+
 func (u *businessInstagramUsecase) CreateBusinessInstagram(ctx context.Context, body req.BusinessInstagram) (*res.BusinessInstagram, error) {
+	bi, err := u.newBusinessInstagram(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.businessInstagramRepo.Create(ctx, bi); err != nil {
+		return nil, err
+	}
+
+	return &res.BusinessInstagram{
+		ID:                 bi.ID,
+		Name:               bi.Name,
+		BusinessName:       bi.BusinessName,
+		InstagramID:        bi.InstagramID,
+		Memo:               bi.Memo,
+		MapsURL:            bi.MapsURL,
+		CallToActionType:   string(bi.CallToActionType),
+		CallToActionURL:    bi.CallToActionURL,
+		MediaCategory:      string(bi.MediaCategory),
+		MediaCategoryRules: toMediaCategoryRulesResponse(bi.MediaCategoryRules),
+		SanitizeConfig:     toSanitizeConfigResponse(bi.SanitizeConfig),
+		StartDate:          bi.StartDate,
+		Status:             int(bi.Status),
+		CreatedAt:          bi.CreatedAt,
+		UpdatedAt:          bi.UpdatedAt,
+	}, nil
+}
+
+// newBusinessInstagram は作成の入力を検証し、InstagramとGBPのビジネスが見えるかを確認する。DBには保存しない。
+func (u *businessInstagramUsecase) newBusinessInstagram(ctx context.Context, body req.BusinessInstagram) (*domain.BusinessInstagram, error) {
+	if err := domain.ValidateCallToAction(domain.CallToActionType(body.CallToActionType), body.CallToActionURL, false); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateGbpMediaCategory(domain.GbpMediaCategory(body.MediaCategory), toGbpMediaCategoryRules(body.MediaCategoryRules)); err != nil {
+		return nil, err
+	}
+	if config := toGbpSanitizeConfig(body.SanitizeConfig); config != nil {
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	token, err := u.tokenRepo.First(ctx)
 	if err != nil {
 		return nil, err
@@ -198,7 +305,11 @@ func (u *businessInstagramUsecase) CreateBusinessInstagram(ctx context.Context,
 		return nil, domain.ErrInstagramConnection
 	}
 
-	business, err := u.gbpAdapter.GetBusiness(ctx, body.BusinessName)
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, body.BusinessName)
+	if err != nil {
+		return nil, err
+	}
+	business, err := u.gbpAdapter.GetBusiness(ctx, account, body.BusinessName)
 	if err != nil {
 		return nil, err
 	}
@@ -207,36 +318,110 @@ func (u *businessInstagramUsecase) CreateBusinessInstagram(ctx context.Context,
 	}
 
 	bi := &domain.BusinessInstagram{
-		Name:          body.Name,
-		Memo:          body.Memo,
-		InstagramID:   instagram.InstagramAccountID,
-		InstagramName: instagram.InstagramAccountUserName,
-		BusinessName:  business.Name,
-		BusinessTitle: business.Title,
-		MapsURL:       business.MapsURL,
-		StartDate:     body.StartDate,
-		Status:        domain.Status(body.Status),
+		Name:               body.Name,
+		Memo:               body.Memo,
+		InstagramID:        instagram.InstagramAccountID,
+		InstagramName:      instagram.InstagramAccountUserName,
+		BusinessName:       business.Name,
+		BusinessTitle:      business.Title,
+		MapsURL:            business.MapsURL,
+		CallToActionType:   domain.CallToActionType(body.CallToActionType),
+		CallToActionURL:    body.CallToActionURL,
+		MediaCategory:      domain.GbpMediaCategory(body.MediaCategory),
+		MediaCategoryRules: toGbpMediaCategoryRules(body.MediaCategoryRules),
+		SanitizeConfig:     toGbpSanitizeConfig(body.SanitizeConfig),
+		StartDate:          body.StartDate,
+		Status:             domain.Status(body.Status),
 	}
 
-	if err := u.businessInstagramRepo.Create(ctx, bi); err != nil {
+	return bi, nil
+}
+
+// businessInstagramColumns はインポート・エクスポートするCSVの列
+var businessInstagramColumns = []accountConfigColumn[req.BusinessInstagram]{
+	stringColumn("name", func(b *req.BusinessInstagram) *string { return &b.Name }),
+	stringColumn("business_name", func(b *req.BusinessInstagram) *string { return &b.BusinessName }),
+	stringColumn("instagram_id", func(b *req.BusinessInstagram) *string { return &b.InstagramID }),
+	stringColumn("memo", func(b *req.BusinessInstagram) *string { return &b.Memo }),
+	stringColumn("call_to_action_type", func(b *req.BusinessInstagram) *string { return &b.CallToActionType }),
+	stringColumn("call_to_action_url", func(b *req.BusinessInstagram) *string { return &b.CallToActionURL }),
+	stringColumn("media_category", func(b *req.BusinessInstagram) *string { return &b.MediaCategory }),
+	jsonColumn("media_category_rules", func(b *req.BusinessInstagram) *[]req.GbpMediaCategoryRule { return &b.MediaCategoryRules }),
+	jsonColumn("sanitize_config", func(b *req.BusinessInstagram) **req.GbpSanitizeConfig { return &b.SanitizeConfig }),
+	timeColumn("start_date", func(b *req.BusinessInstagram) *time.Time { return &b.StartDate }),
+	intColumn("status", func(b *req.BusinessInstagram) *int { return &b.Status }),
+}
+
+// ImportBusinessInstagram はCSV・JSONの全ての行を作成APIと同じように検証し、全て成功した場合だけ1つのトランザクションで作成する。
+// 同じビジネスとInstagramの連携が既にある行は失敗にする。
+func (u *businessInstagramUsecase) ImportBusinessInstagram(ctx context.Context, format domain.AccountConfigFormat, data []byte, dryRun bool) (*res.AccountConfigImport, error) {
+	rows, err := decodeAccountConfigs(format, data, businessInstagramColumns)
+	if err != nil {
 		return nil, err
 	}
+	return importAccountConfigs(ctx, u.baseRepo, rows, dryRun,
+		func(body req.BusinessInstagram) string {
+			return body.BusinessName + " " + body.InstagramID
+		},
+		func(ctx context.Context, body req.BusinessInstagram) (*domain.BusinessInstagram, error) {
+			exists, err := u.businessInstagramRepo.Exists(ctx, repository.BusinessInstagramFilter{
+				BusinessName: &body.BusinessName,
+				InstagramID:  &body.InstagramID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, fmt.Errorf("%w: 同じビジネスとInstagramの連携が既にあります", domain.ErrBadRequest)
+			}
+			return u.newBusinessInstagram(ctx, body)
+		},
+		func(ctx context.Context, bi *domain.BusinessInstagram) (int, error) {
+			if err := u.businessInstagramRepo.Create(ctx, bi); err != nil {
+				return 0, err
+			}
+			return bi.ID, nil
+		},
+	)
+}
 
-	return &res.BusinessInstagram{
-		ID:           bi.ID,
-		Name:         bi.Name,
-		BusinessName: bi.BusinessName,
-		InstagramID:  bi.InstagramID,
-		Memo:         bi.Memo,
-		MapsURL:      bi.MapsURL,
-		StartDate:    bi.StartDate,
-		Status:       int(bi.Status),
-		CreatedAt:    bi.CreatedAt,
-		UpdatedAt:    bi.UpdatedAt,
-	}, nil
+// ExportBusinessInstagram は全ての連携を、インポートできる形式で出力する。
+func (u *businessInstagramUsecase) ExportBusinessInstagram(ctx context.Context, format domain.AccountConfigFormat) ([]byte, error) {
+	biList, err := u.businessInstagramRepo.FindAll(ctx, repository.BusinessInstagramFilter{})
+	if err != nil {
+		return nil, err
+	}
+	bodies := make([]req.BusinessInstagram, 0, len(biList))
+	for _, bi := range biList {
+		bodies = append(bodies, req.BusinessInstagram{
+			Name:               bi.Name,
+			BusinessName:       bi.BusinessName,
+			InstagramID:        bi.InstagramID,
+			Memo:               bi.Memo,
+			CallToActionType:   string(bi.CallToActionType),
+			CallToActionURL:    bi.CallToActionURL,
+			MediaCategory:      string(bi.MediaCategory),
+			MediaCategoryRules: toGbpMediaCategoryRulesRequest(bi.MediaCategoryRules),
+			SanitizeConfig:     toGbpSanitizeConfigRequest(bi.SanitizeConfig),
+			StartDate:          bi.StartDate,
+			Status:             int(bi.Status),
+		})
+	}
+	return encodeAccountConfigs(format, bodies, businessInstagramColumns)
 }
 
 func (u *businessInstagramUsecase) UpdateBusinessInstagram(ctx context.Context, id int, body req.BusinessInstagram) (*res.BusinessInstagram, error) {
+	if err := domain.ValidateCallToAction(domain.CallToActionType(body.CallToActionType), body.CallToActionURL, false); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateGbpMediaCategory(domain.GbpMediaCategory(body.MediaCategory), toGbpMediaCategoryRules(body.MediaCategoryRules)); err != nil {
+		return nil, err
+	}
+	if config := toGbpSanitizeConfig(body.SanitizeConfig); config != nil {
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	token, err := u.tokenRepo.First(ctx)
 	if err != nil {
 		return nil, err
@@ -246,7 +431,11 @@ func (u *businessInstagramUsecase) UpdateBusinessInstagram(ctx context.Context,
 		return nil, err
 	}
 
-	business, err := u.gbpAdapter.GetBusiness(ctx, body.BusinessName)
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, body.BusinessName)
+	if err != nil {
+		return nil, err
+	}
+	business, err := u.gbpAdapter.GetBusiness(ctx, account, body.BusinessName)
 	if err != nil {
 		return nil, err
 	}
@@ -257,6 +446,7 @@ func (u *businessInstagramUsecase) UpdateBusinessInstagram(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	beforeStatus := bi.Status
 	bi.Name = body.Name
 	bi.Memo = body.Memo
 	bi.InstagramID = instagram.InstagramAccountID
@@ -264,6 +454,11 @@ func (u *businessInstagramUsecase) UpdateBusinessInstagram(ctx context.Context,
 	bi.BusinessName = business.Name
 	bi.BusinessTitle = business.Title
 	bi.MapsURL = business.MapsURL
+	bi.CallToActionType = domain.CallToActionType(body.CallToActionType)
+	bi.CallToActionURL = body.CallToActionURL
+	bi.MediaCategory = domain.GbpMediaCategory(body.MediaCategory)
+	bi.MediaCategoryRules = toGbpMediaCategoryRules(body.MediaCategoryRules)
+	bi.SanitizeConfig = toGbpSanitizeConfig(body.SanitizeConfig)
 	bi.StartDate = body.StartDate
 	bi.Status = domain.Status(body.Status)
 	bi.UpdatedAt = time.Now()
@@ -274,17 +469,25 @@ func (u *businessInstagramUsecase) UpdateBusinessInstagram(ctx context.Context,
 		return nil, err
 	}
 
+	// 停止した場合はwebhookで通知
+	publishAccountDisabled(ctx, u.webhookUsecase, beforeStatus, bi.Status, bi.Account())
+
 	return &res.BusinessInstagram{
-		ID:           bi.ID,
-		Name:         bi.Name,
-		BusinessName: bi.BusinessName,
-		InstagramID:  bi.InstagramID,
-		Memo:         bi.Memo,
-		MapsURL:      bi.MapsURL,
-		StartDate:    bi.StartDate,
-		Status:       int(bi.Status),
-		CreatedAt:    bi.CreatedAt,
-		UpdatedAt:    bi.UpdatedAt,
+		ID:                 bi.ID,
+		Name:               bi.Name,
+		BusinessName:       bi.BusinessName,
+		InstagramID:        bi.InstagramID,
+		Memo:               bi.Memo,
+		MapsURL:            bi.MapsURL,
+		CallToActionType:   string(bi.CallToActionType),
+		CallToActionURL:    bi.CallToActionURL,
+		MediaCategory:      string(bi.MediaCategory),
+		MediaCategoryRules: toMediaCategoryRulesResponse(bi.MediaCategoryRules),
+		SanitizeConfig:     toSanitizeConfigResponse(bi.SanitizeConfig),
+		StartDate:          bi.StartDate,
+		Status:             int(bi.Status),
+		CreatedAt:          bi.CreatedAt,
+		UpdatedAt:          bi.UpdatedAt,
 	}, nil
 }
 
@@ -293,3 +496,80 @@ func (u *businessInstagramUsecase) DeleteBusinessInstagram(ctx context.Context,
 		ID: &id,
 	})
 }
+
+func toGbpMediaCategoryRules(rules []req.GbpMediaCategoryRule) []domain.GbpMediaCategoryRule {
+	result := make([]domain.GbpMediaCategoryRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, domain.GbpMediaCategoryRule{
+			Keyword:  rule.Keyword,
+			Category: domain.GbpMediaCategory(rule.Category),
+		})
+	}
+	return result
+}
+
+func toMediaCategoryRulesResponse(rules []domain.GbpMediaCategoryRule) []res.GbpMediaCategoryRule {
+	result := make([]res.GbpMediaCategoryRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, res.GbpMediaCategoryRule{
+			Keyword:  rule.Keyword,
+			Category: string(rule.Category),
+		})
+	}
+	return result
+}
+
+// toGbpMediaCategoryRulesRequest はエクスポート用に、保存済みのルールをリクエストの形式に戻す
+func toGbpMediaCategoryRulesRequest(rules []domain.GbpMediaCategoryRule) []req.GbpMediaCategoryRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	result := make([]req.GbpMediaCategoryRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, req.GbpMediaCategoryRule{
+			Keyword:  rule.Keyword,
+			Category: string(rule.Category),
+		})
+	}
+	return result
+}
+
+func toGbpSanitizeConfig(config *req.GbpSanitizeConfig) *domain.GbpSanitizeConfig {
+	if config == nil {
+		return nil
+	}
+	result := &domain.GbpSanitizeConfig{Patterns: config.Patterns}
+	for _, rule := range config.Rules {
+		result.Rules = append(result.Rules, domain.GbpSanitizeRule(rule))
+	}
+	return result
+}
+
+// toGbpSanitizeConfigRequest はエクスポート用に、保存済みの整形ルールをリクエストの形式に戻す
+func toGbpSanitizeConfigRequest(config *domain.GbpSanitizeConfig) *req.GbpSanitizeConfig {
+	if config == nil {
+		return nil
+	}
+	result := &req.GbpSanitizeConfig{
+		Rules:    make([]string, 0, len(config.Rules)),
+		Patterns: config.Patterns,
+	}
+	for _, rule := range config.Rules {
+		result.Rules = append(result.Rules, string(rule))
+	}
+	return result
+}
+
+func toSanitizeConfigResponse(config *domain.GbpSanitizeConfig) *res.GbpSanitizeConfig {
+	if config == nil {
+		return nil
+	}
+	result := &res.GbpSanitizeConfig{
+		Rules:    make([]string, 0, len(config.Rules)),
+		Patterns: config.Patterns,
+	}
+	for _, rule := range config.Rules {
+		result.Rules = append(result.Rules, string(rule))
+	}
+	return result
+}
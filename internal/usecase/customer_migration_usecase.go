@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+// CustomerMigrationUsecase は旧 customers を wordpress_instagrams に移行する
+type CustomerMigrationUsecase interface {
+	MigrateCustomers(ctx context.Context, dryRun bool) (*domain.CustomerMigrationReport, error)
+}
+
+type customerMigrationUsecase struct {
+	customerRepo           repository.CustomerRepository
+	wordpressInstagramRepo repository.WordpressInstagramRepository
+	postRepo               repository.PostRepository
+	baseRepo               repository.BaseRepository
+}
+
+func NewCustomerMigrationUsecase(
+	customerRepo repository.CustomerRepository,
+	wordpressInstagramRepo repository.WordpressInstagramRepository,
+	postRepo repository.PostRepository,
+	baseRepo repository.BaseRepository,
+) CustomerMigrationUsecase {
+	return &customerMigrationUsecase{
+		customerRepo:           customerRepo,
+		wordpressInstagramRepo: wordpressInstagramRepo,
+		postRepo:               postRepo,
+		baseRepo:               baseRepo,
+	}
+}
+
+// MigrateCustomers は旧 customers ごとに、Instagramアカウントの数だけ連携を作り、顧客の投稿を連携に紐付け直す。
+// 同じWordPressとInstagramの連携が既にある場合は新しく作らずにそれを使うため、何度実行しても同じ結果になる。
+// 自動で決められないものは移行せずに Conflicts に残す。dryRun の場合は何も書き込まない。
+func (u *customerMigrationUsecase) MigrateCustomers(ctx context.Context, dryRun bool) (*domain.CustomerMigrationReport, error) {
+	customers, err := u.customerRepo.FindAllCustomers(ctx, repository.CustomerFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.CustomerMigrationReport{DryRun: dryRun}
+	migrate := func(ctx context.Context) error {
+		now := time.Now()
+		for _, customer := range customers {
+			entry, err := u.migrateCustomer(ctx, customer, dryRun, now)
+			if err != nil {
+				return fmt.Errorf("customers.id=%d の移行に失敗しました: %w", customer.ID, err)
+			}
+			report.Customers = append(report.Customers, *entry)
+		}
+
+		/*
+			顧客も連携も存在しない投稿
+		*/
+		customerIDs, err := u.postRepo.FindCustomerIDs(ctx, repository.PostFilter{
+			WithoutWordpressInstagram: util.Pointer(true),
+		})
+		if err != nil {
+			return err
+		}
+		known := make(map[int]bool, len(customers))
+		for _, customer := range customers {
+			known[customer.ID] = true
+		}
+		for _, customerID := range customerIDs {
+			if !known[customerID] {
+				report.OrphanPostCustomerIDs = append(report.OrphanPostCustomerIDs, customerID)
+			}
+		}
+		return nil
+	}
+
+	if dryRun {
+		err = migrate(ctx)
+	} else {
+		err = u.baseRepo.WithTransaction(ctx, migrate)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (u *customerMigrationUsecase) migrateCustomer(ctx context.Context, customer *domain.Customer, dryRun bool, now time.Time) (*domain.CustomerMigrationEntry, error) {
+	entry := &domain.CustomerMigrationEntry{
+		CustomerID: customer.ID,
+		Name:       customer.Name,
+	}
+
+	wordpressDomain, err := customer.WordpressDomain()
+	if err != nil {
+		entry.Conflicts = append(entry.Conflicts, err.Error())
+		return entry, nil
+	}
+	entry.WordpressDomain = wordpressDomain
+
+	accounts := customer.InstagramAccounts()
+	if len(accounts) == 0 {
+		entry.Conflicts = append(entry.Conflicts, "Instagramアカウントが登録されていません")
+		return entry, nil
+	}
+
+	/*
+		連携の作成
+	*/
+	var wiList []*domain.WordpressInstagram
+	for _, account := range accounts {
+		wi, err := u.wordpressInstagramRepo.Get(ctx, repository.WordpressInstagramFilter{
+			WordpressDomain: util.Pointer(wordpressDomain),
+			InstagramID:     util.Pointer(account.ID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if wi.ID == 0 {
+			wi = customer.NewWordpressInstagram(wordpressDomain, account, now)
+			if !dryRun {
+				if err := u.wordpressInstagramRepo.Create(ctx, wi); err != nil {
+					return nil, err
+				}
+			}
+			entry.Created++
+		}
+		wiList = append(wiList, wi)
+		entry.WordpressInstagramIDs = append(entry.WordpressInstagramIDs, wi.ID)
+	}
+
+	/*
+		投稿の紐付け
+	*/
+	if len(wiList) > 1 {
+		entry.Conflicts = append(entry.Conflicts, "Instagramアカウントが複数あるため、投稿の連携先を決められません")
+		return entry, nil
+	}
+	wi := wiList[0]
+
+	legacyFilter := repository.PostFilter{
+		CustomerID:                util.Pointer(customer.ID),
+		WithoutWordpressInstagram: util.Pointer(true),
+	}
+	legacyMediaIDs, err := u.postRepo.FindMediaIDs(ctx, legacyFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(legacyMediaIDs) == 0 {
+		return entry, nil
+	}
+
+	// 連携に同じ投稿が既にある場合、紐付け直すと同じ投稿が2件になるため残す
+	var duplicated []string
+	if wi.ID != 0 {
+		duplicated, err = u.postRepo.FindMediaIDs(ctx, repository.PostFilter{
+			WordpressInstagramID: util.Pointer(wi.ID),
+		})
+		if err != nil {
+			return nil, err
+		}
+		duplicated = intersectStrings(legacyMediaIDs, duplicated)
+	}
+	if len(duplicated) > 0 {
+		entry.Conflicts = append(entry.Conflicts, fmt.Sprintf("連携先に同じ投稿が既にあるため移行しません: %d件", len(duplicated)))
+	}
+	legacyFilter.ExcludeMediaIDs = duplicated
+
+	if dryRun {
+		count, err := u.postRepo.CountPosts(ctx, legacyFilter)
+		if err != nil {
+			return nil, err
+		}
+		entry.MovedPosts = int(count)
+		return entry, nil
+	}
+	moved, err := u.postRepo.ReassignPosts(ctx, legacyFilter, 100000+wi.ID, wi.ID)
+	if err != nil {
+		return nil, err
+	}
+	entry.MovedPosts = int(moved)
+	return entry, nil
+}
+
+// intersectStrings は a のうち b にも含まれる値
+func intersectStrings(a, b []string) []string {
+	set := make(map[string]bool, len(b))
+	for _, v := range b {
+		set[v] = true
+	}
+	var result []string
+	for _, v := range a {
+		if set[v] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+type migrationCustomerRepo struct {
+	repository.CustomerRepository
+	customers []*domain.Customer
+}
+
+func (f *migrationCustomerRepo) FindAllCustomers(context.Context, repository.CustomerFilter) ([]*domain.Customer, error) {
+	return f.customers, nil
+}
+
+// legacyPost は移行前の（連携に紐付いていない）投稿の記録
+func legacyPost(customerID int, mediaID string) *model.Post {
+	return &model.Post{CustomerID: customerID, MediaID: mediaID}
+}
+
+func TestCustomerMigrationUsecase_MigrateCustomers(t *testing.T) {
+	customerRepo := &migrationCustomerRepo{customers: []*domain.Customer{
+		{ID: 1, Name: "カフェ", WordpressUrl: "https://cafe.example.com/", InstagramBusinessAccountID: []string{"ig-1"}},
+		// 既に同じWordPressとInstagramの連携があり、同じ投稿も登録されている
+		{ID: 2, Name: "パン屋", WordpressUrl: "https://bakery.example.com", InstagramBusinessAccountID: []string{"ig-2"}},
+		{ID: 3, Name: "花屋", WordpressUrl: "https://flower.example.com", InstagramBusinessAccountID: []string{"ig-3", "ig-4"}},
+		{ID: 4, Name: "未設定", WordpressUrl: "https://none.example.com"},
+	}}
+	wiRepo := &fakeWordpressInstagramRepo{wiList: []*domain.WordpressInstagram{
+		{ID: 1, WordpressDomain: "bakery.example.com", InstagramID: "ig-2"},
+	}}
+	postRepo := &fakePostRepo{records: []*model.Post{
+		legacyPost(1, "m1"),
+		legacyPost(1, "m2"),
+		legacyPost(2, "m3"),
+		legacyPost(2, "m4"),
+		{CustomerID: 100001, WordpressInstagramID: util.Pointer(1), MediaID: "m4"},
+		legacyPost(3, "m5"),
+		legacyPost(99, "m6"),
+	}}
+	baseRepo := &fakeBaseRepo{}
+	u := NewCustomerMigrationUsecase(customerRepo, wiRepo, postRepo, baseRepo)
+
+	/*
+		ドライランは何も作成・更新しない
+	*/
+	report, err := u.MigrateCustomers(context.Background(), true)
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.False(t, baseRepo.committed)
+	assert.Len(t, wiRepo.wiList, 1)
+	assert.Equal(t, legacyPost(1, "m1"), postRepo.records[0])
+
+	// 作る予定の連携はまだIDがないため 0 になる
+	assert.Equal(t, []int{0}, report.Customers[0].WordpressInstagramIDs)
+	assert.Equal(t, 1, report.Customers[0].Created)
+	assert.Equal(t, 2, report.Customers[0].MovedPosts)
+	assert.Equal(t, 1, report.Customers[1].MovedPosts)
+	assert.Equal(t, 4, report.ConflictCount())
+
+	/*
+		移行
+	*/
+	report, err = u.MigrateCustomers(context.Background(), false)
+	require.NoError(t, err)
+	assert.True(t, baseRepo.committed)
+	require.Len(t, report.Customers, 4)
+
+	cafe := report.Customers[0]
+	assert.Equal(t, "cafe.example.com", cafe.WordpressDomain)
+	assert.Equal(t, []int{2}, cafe.WordpressInstagramIDs)
+	assert.Equal(t, 1, cafe.Created)
+	assert.Equal(t, 2, cafe.MovedPosts)
+	assert.Empty(t, cafe.Conflicts)
+	assert.Equal(t, domain.Status(0), wiRepo.wiList[1].Status)
+	assert.Equal(t, "customers.id=1 から移行", wiRepo.wiList[1].Memo)
+
+	bakery := report.Customers[1]
+	assert.Equal(t, []int{1}, bakery.WordpressInstagramIDs)
+	assert.Equal(t, 0, bakery.Created)
+	assert.Equal(t, 1, bakery.MovedPosts)
+	assert.Equal(t, []string{"連携先に同じ投稿が既にあるため移行しません: 1件"}, bakery.Conflicts)
+
+	flower := report.Customers[2]
+	assert.Equal(t, 2, flower.Created)
+	assert.Equal(t, 0, flower.MovedPosts)
+	assert.Equal(t, []string{"Instagramアカウントが複数あるため、投稿の連携先を決められません"}, flower.Conflicts)
+
+	assert.Equal(t, []string{"Instagramアカウントが登録されていません"}, report.Customers[3].Conflicts)
+	assert.Equal(t, []int{99}, report.OrphanPostCustomerIDs)
+	assert.Equal(t, 4, report.ConflictCount())
+
+	// 紐付け直した投稿は新しい連携の customer_id と wordpress_instagram_id になる
+	assert.Equal(t, &model.Post{CustomerID: 100002, WordpressInstagramID: util.Pointer(2), MediaID: "m1"}, postRepo.records[0])
+	assert.Equal(t, &model.Post{CustomerID: 100001, WordpressInstagramID: util.Pointer(1), MediaID: "m3"}, postRepo.records[2])
+	assert.Equal(t, legacyPost(2, "m4"), postRepo.records[3])
+
+	// 2回目は連携を作らず、移行済みの投稿も動かさない
+	again, err := u.MigrateCustomers(context.Background(), false)
+	require.NoError(t, err)
+	assert.Equal(t, 0, again.Customers[0].Created)
+	assert.Equal(t, 0, again.Customers[0].MovedPosts)
+	assert.Len(t, wiRepo.wiList, 4)
+}
@@ -0,0 +1,564 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+// instagramItem はInstagramの投稿1件と、連携先の間で共有するデータ。
+type instagramItem struct {
+	post domain.InstagramPost
+	// localPaths はWordPressに連携するためにダウンロードしたメディア
+	localPaths []string
+	// firstImageSourceURL はGBPのPhotosにアップロードした最初の画像のS3のURL（Local Postで使い回す）
+	firstImageSourceURL string
+}
+
+// instagramSource はInstagramアカウントの投稿を古い順に返す連携元。
+type instagramSource struct {
+	u           *customerUsecase
+	token       string
+	instagramID string
+	// all が false の場合は直近25件のみを対象にする
+	all bool
+	// mediaID を指定した場合はその投稿だけを対象にする（リトライ用）
+	mediaID string
+	// fd を指定した場合は Prepare でメディアをダウンロードする（WordPress用）
+	fd adapter.FileDownloader
+}
+
+func (s *instagramSource) Fetch(ctx context.Context) ([]*instagramItem, error) {
+	/*
+		インスタグラムから投稿を取得する
+	*/
+	var posts []domain.InstagramPost
+	switch {
+	case s.mediaID != "":
+		post, err := s.u.instagramAdapter.GetMedia(ctx, s.token, s.mediaID)
+		if err != nil {
+			return nil, err
+		}
+		posts = []domain.InstagramPost{*post}
+	case s.all:
+		var err error
+		posts, err = s.u.instagramAdapter.GetPostsAll(ctx, s.token, s.instagramID)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		var err error
+		posts, err = s.u.instagramAdapter.GetPosts25(ctx, s.token, s.instagramID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].Timestamp < posts[j].Timestamp
+	})
+	items := make([]*instagramItem, 0, len(posts))
+	for _, post := range posts {
+		/*
+			メディアのリンクがない場合はスキップ
+		*/
+		if post.MediaURL == "" {
+			continue
+		}
+		items = append(items, &instagramItem{post: post})
+	}
+	return items, nil
+}
+
+func (s *instagramSource) Key(item *instagramItem) string {
+	return item.post.ID
+}
+
+func (s *instagramSource) Prepare(ctx context.Context, item *instagramItem) (func(), error) {
+	if s.fd == nil {
+		return func() {}, nil
+	}
+	/*
+		インスタグラムの投稿の画像、動画を一時ディレクトリにダウンロード
+	*/
+	localPaths, err := s.u.downloadInstagramMedia(ctx, s.token, &item.post, s.fd)
+	if err != nil {
+		return nil, err
+	}
+	item.localPaths = localPaths
+	return func() {
+		/*
+			ダウンロードファイルを都度削除
+		*/
+		for _, localPath := range localPaths {
+			if err := os.Remove(localPath); err != nil {
+				slog.Warn(err.Error())
+			}
+		}
+	}, nil
+}
+
+func (s *instagramSource) Close() {
+	if s.fd != nil {
+		_ = s.fd.DeleteTempDirectory()
+	}
+}
+
+// wordpressGbpSource はWordPressのGBP連携用の記事を返す連携元。
+type wordpressGbpSource struct {
+	u               *customerUsecase
+	wordpressDomain string
+}
+
+func (s *wordpressGbpSource) Fetch(ctx context.Context) ([]external.WordpressGbpPost, error) {
+	posts, err := s.u.wordpressAdapter.GetGbpPosts(ctx, s.wordpressDomain)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]external.WordpressGbpPost, 0, len(posts))
+	for _, post := range posts {
+		// メディアがない記事は連携しない
+		if len(post.MediaURLs) == 0 {
+			continue
+		}
+		items = append(items, post)
+	}
+	return items, nil
+}
+
+func (s *wordpressGbpSource) Key(item external.WordpressGbpPost) string {
+	return fmt.Sprintf("%d", item.PostID)
+}
+
+func (s *wordpressGbpSource) Prepare(context.Context, external.WordpressGbpPost) (func(), error) {
+	return func() {}, nil
+}
+
+func (s *wordpressGbpSource) Close() {}
+
+// wordpressDestination はInstagramの投稿を記事にするWordPressの連携先。
+type wordpressDestination struct {
+	u  *customerUsecase
+	wi *domain.WordpressInstagram
+}
+
+func (d *wordpressDestination) Account() domain.Account {
+	return d.wi.Account()
+}
+
+func (d *wordpressDestination) Needs(ctx context.Context, item *instagramItem) (bool, error) {
+	return d.u.needsWordpressPost(ctx, d.wi, item.post)
+}
+
+func (d *wordpressDestination) Publish(ctx context.Context, item *instagramItem) error {
+	return d.u.instagram2wordpress(ctx, d.wi, item.post, item.localPaths)
+}
+
+func (d *wordpressDestination) Preview(_ context.Context, item *instagramItem) ([]domain.SyncPreview, error) {
+	// アップロードしないため、本文のメディアはInstagramのURLのままにする
+	post := item.post
+	post.SourceURLs = nil
+	post.SetDeleteHashFlag(d.wi.DeleteHash)
+	mediaURLs := instagramMediaURLs(post)
+	for _, mediaURL := range mediaURLs {
+		post.AppendSourceURL(mediaURL)
+	}
+	return []domain.SyncPreview{{
+		Account:     d.wi.Account(),
+		Destination: domain.SyncDestinationWordpress,
+		PostKey:     post.ID,
+		SourceURL:   post.Permalink,
+		Title:       post.GetTitle(),
+		Content:     d.wi.RenderContent(post),
+		MediaURLs:   mediaURLs,
+	}}, nil
+}
+
+// instagramGbpPhotoDestination はInstagramの画像をGBPのPhotosにアップロードする連携先。
+// 画像ごとの重複は Publish で確認する。
+type instagramGbpPhotoDestination struct {
+	u       *customerUsecase
+	token   string
+	account *domain.GoogleAccount
+	bi      *domain.BusinessInstagram
+}
+
+func (d *instagramGbpPhotoDestination) Account() domain.Account {
+	return d.bi.Account()
+}
+
+func (d *instagramGbpPhotoDestination) Needs(_ context.Context, item *instagramItem) (bool, error) {
+	return !instagramPostedBefore(item.post, d.bi.StartDate), nil
+}
+
+func (d *instagramGbpPhotoDestination) Publish(ctx context.Context, item *instagramItem) error {
+	return d.u.instagramToGbpPhotos(ctx, d.token, d.account, d.bi, item)
+}
+
+func (d *instagramGbpPhotoDestination) Preview(ctx context.Context, item *instagramItem) ([]domain.SyncPreview, error) {
+	post := item.post
+	var mediaURLs []string
+	add := func(mediaID, mediaURL string) error {
+		exist, err := d.u.googlePostRepo.Exists(ctx, repository.GooglePostFilter{
+			MediaID:    &mediaID,
+			CustomerID: &d.bi.ID,
+			PostType:   util.Pointer(domain.PostTypePhoto),
+		})
+		if err != nil {
+			return err
+		}
+		if !exist {
+			mediaURLs = append(mediaURLs, mediaURL)
+		}
+		return nil
+	}
+
+	/*
+		まだPhotosにアップロードしていない画像（動画はアップロードしない）
+	*/
+	if len(post.Children) == 0 {
+		if post.MediaType == "IMAGE" {
+			if err := add(post.ID, post.MediaURL); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for _, child := range post.Children {
+			if child.MediaType != "IMAGE" {
+				continue
+			}
+			if err := add(child.ID, child.MediaURL); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(mediaURLs) == 0 {
+		return nil, nil
+	}
+	return []domain.SyncPreview{{
+		Account:     d.bi.Account(),
+		Destination: domain.SyncDestinationGbpPhoto,
+		PostKey:     post.ID,
+		SourceURL:   post.Permalink,
+		MediaURLs:   mediaURLs,
+	}}, nil
+}
+
+// instagramGbpLocalPostDestination はInstagramのキャプションをGBPのLocal Postにする連携先。
+type instagramGbpLocalPostDestination struct {
+	u       *customerUsecase
+	token   string
+	account *domain.GoogleAccount
+	bi      *domain.BusinessInstagram
+}
+
+func (d *instagramGbpLocalPostDestination) Account() domain.Account {
+	return d.bi.Account()
+}
+
+func (d *instagramGbpLocalPostDestination) Needs(ctx context.Context, item *instagramItem) (bool, error) {
+	/*
+		captionがある場合だけLocal Postsに投稿
+	*/
+	if item.post.Caption == "" || instagramPostedBefore(item.post, d.bi.StartDate) {
+		return false, nil
+	}
+	exist, err := d.u.googlePostRepo.Exists(ctx, repository.GooglePostFilter{
+		MediaID:    &item.post.ID,
+		CustomerID: &d.bi.ID,
+		PostType:   util.Pointer(domain.PostTypePost),
+	})
+	if err != nil {
+		return false, err
+	}
+	return !exist, nil
+}
+
+func (d *instagramGbpLocalPostDestination) Publish(ctx context.Context, item *instagramItem) error {
+	return d.u.instagramToGbpLocalPost(ctx, d.token, d.account, d.bi, item)
+}
+
+func (d *instagramGbpLocalPostDestination) Preview(_ context.Context, item *instagramItem) ([]domain.SyncPreview, error) {
+	// 画像がない場合（動画のみの投稿）はLocal Postを投稿しない
+	mediaURL := instagramFirstImageURL(item.post)
+	if mediaURL == "" {
+		return nil, nil
+	}
+	localPost := newInstagramGbpLocalPost(d.bi, item.post, mediaURL)
+	return []domain.SyncPreview{domain.NewGbpLocalPostPreview(d.bi.Account(), item.post.ID, item.post.Permalink, localPost)}, nil
+}
+
+// wordpressGbpPhotoDestination はWordPressの記事のメディアをGBPのPhotosにアップロードする連携先。
+// メディアごとの重複は Publish で確認する。
+type wordpressGbpPhotoDestination struct {
+	u       *customerUsecase
+	account *domain.GoogleAccount
+	wg      *domain.WordpressGbp
+}
+
+func (d *wordpressGbpPhotoDestination) Account() domain.Account {
+	return d.wg.Account()
+}
+
+func (d *wordpressGbpPhotoDestination) Needs(_ context.Context, post external.WordpressGbpPost) (bool, error) {
+	return !wordpressPostedBefore(post, d.wg.StartDate), nil
+}
+
+func (d *wordpressGbpPhotoDestination) Publish(ctx context.Context, post external.WordpressGbpPost) error {
+	return d.u.wordpressToGbpPhotos(ctx, d.account, d.wg, post)
+}
+
+func (d *wordpressGbpPhotoDestination) Preview(ctx context.Context, post external.WordpressGbpPost) ([]domain.SyncPreview, error) {
+	customerID := 300000 + d.wg.ID
+	var mediaURLs []string
+	for i, mediaURL := range post.MediaURLs {
+		// PDFとサイズ上限を超えるメディアはアップロードしない
+		if strings.ToLower(filepath.Ext(mediaURL)) == ".pdf" {
+			continue
+		}
+		exist, err := d.u.googlePostRepo.Exists(ctx, repository.GooglePostFilter{
+			MediaID:    util.Pointer(fmt.Sprintf("%d_%d", post.PostID, i)),
+			CustomerID: &customerID,
+			PostType:   util.Pointer(domain.PostTypePhoto),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if exist || mediaExceedsGbpLimit(ctx, mediaURL) {
+			continue
+		}
+		mediaURLs = append(mediaURLs, mediaURL)
+	}
+	if len(mediaURLs) == 0 {
+		return nil, nil
+	}
+	return []domain.SyncPreview{{
+		Account:     d.wg.Account(),
+		Destination: domain.SyncDestinationGbpPhoto,
+		PostKey:     fmt.Sprintf("%d", post.PostID),
+		SourceURL:   post.PostURL,
+		MediaURLs:   mediaURLs,
+	}}, nil
+}
+
+// wordpressGbpLocalPostDestination はWordPressの記事の本文をGBPのLocal Postにする連携先。
+type wordpressGbpLocalPostDestination struct {
+	u       *customerUsecase
+	account *domain.GoogleAccount
+	wg      *domain.WordpressGbp
+}
+
+func (d *wordpressGbpLocalPostDestination) Account() domain.Account {
+	return d.wg.Account()
+}
+
+func (d *wordpressGbpLocalPostDestination) Needs(ctx context.Context, post external.WordpressGbpPost) (bool, error) {
+	/*
+		contentが空でない場合だけLocal Post作成
+	*/
+	if post.Content == "" || wordpressPostedBefore(post, d.wg.StartDate) {
+		return false, nil
+	}
+	customerID := 300000 + d.wg.ID
+	exist, err := d.u.googlePostRepo.Exists(ctx, repository.GooglePostFilter{
+		MediaID:    util.Pointer(fmt.Sprintf("%d", post.PostID)),
+		CustomerID: &customerID,
+		PostType:   util.Pointer(domain.PostTypePost),
+	})
+	if err != nil {
+		return false, err
+	}
+	return !exist, nil
+}
+
+func (d *wordpressGbpLocalPostDestination) Publish(ctx context.Context, post external.WordpressGbpPost) error {
+	return d.u.wordpressToGbpLocalPost(ctx, d.account, d.wg, post)
+}
+
+func (d *wordpressGbpLocalPostDestination) Preview(_ context.Context, post external.WordpressGbpPost) ([]domain.SyncPreview, error) {
+	localPost := newWordpressGbpLocalPost(d.wg, post)
+	return []domain.SyncPreview{domain.NewGbpLocalPostPreview(d.wg.Account(), fmt.Sprintf("%d", post.PostID), post.PostURL, localPost)}, nil
+}
+
+// facebookDestination はInstagramの投稿をFacebookページに投稿する連携先。
+type facebookDestination struct {
+	u     *customerUsecase
+	token string
+	page  *domain.FacebookPage
+	fi    *domain.FacebookInstagram
+}
+
+func (d *facebookDestination) Account() domain.Account {
+	return d.fi.Account()
+}
+
+func (d *facebookDestination) Needs(ctx context.Context, item *instagramItem) (bool, error) {
+	return d.u.needsFacebookPost(ctx, d.fi, item.post)
+}
+
+func (d *facebookDestination) Publish(ctx context.Context, item *instagramItem) error {
+	return d.u.instagramToFacebook(ctx, d.token, d.page, d.fi, item.post)
+}
+
+func (d *facebookDestination) Preview(_ context.Context, item *instagramItem) ([]domain.SyncPreview, error) {
+	// アップロードしないため、メディアはInstagramのURLのままにする
+	post := item.post
+	pagePost, err := domain.ToFacebookPagePost(post, d.fi.DeleteHash, func(mediaURL string) (string, error) {
+		return mediaURL, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	mediaURLs := pagePost.PhotoURLs
+	if pagePost.IsVideo() {
+		mediaURLs = []string{pagePost.VideoURL}
+	}
+	return []domain.SyncPreview{{
+		Account:     d.fi.Account(),
+		Destination: domain.SyncDestinationFacebook,
+		PostKey:     post.ID,
+		SourceURL:   post.Permalink,
+		Content:     pagePost.Message,
+		MediaURLs:   mediaURLs,
+	}}, nil
+}
+
+// feedSource はフィードの記事を古い順に返す連携元。連携開始日前の記事と、投稿日時が取得できない記事は返さない。
+type feedSource struct {
+	u    *customerUsecase
+	feed *domain.Feed
+	// fd はWordPressのアイキャッチにする画像のダウンロードに使う
+	fd adapter.FileDownloader
+}
+
+func (s *feedSource) Fetch(ctx context.Context) ([]domain.FeedItem, error) {
+	channel, err := s.u.feedAdapter.Fetch(ctx, s.feed.FeedURL)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]domain.FeedItem, 0, len(channel.Items))
+	for _, item := range channel.Items {
+		if item.PublishedAt.Before(s.feed.StartDate) {
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].PublishedAt.Before(items[j].PublishedAt)
+	})
+	return items, nil
+}
+
+func (s *feedSource) Key(item domain.FeedItem) string {
+	return item.GUIDHash()
+}
+
+func (s *feedSource) Prepare(context.Context, domain.FeedItem) (func(), error) {
+	return func() {}, nil
+}
+
+func (s *feedSource) Close() {
+	_ = s.fd.DeleteTempDirectory()
+}
+
+// feedWordpressDestination はフィードの記事をWordPressに投稿する連携先。
+type feedWordpressDestination struct {
+	u    *customerUsecase
+	feed *domain.Feed
+	fd   adapter.FileDownloader
+}
+
+func (d *feedWordpressDestination) Account() domain.Account {
+	return d.feed.Account()
+}
+
+func (d *feedWordpressDestination) Needs(ctx context.Context, item domain.FeedItem) (bool, error) {
+	exist, err := d.u.feedPosted(ctx, d.feed, domain.FeedDestinationWordpress, item.GUIDHash())
+	if err != nil {
+		return false, err
+	}
+	return !exist, nil
+}
+
+func (d *feedWordpressDestination) Publish(ctx context.Context, item domain.FeedItem) error {
+	return d.u.feedToWordpress(ctx, d.feed, item, d.fd)
+}
+
+func (d *feedWordpressDestination) Preview(_ context.Context, item domain.FeedItem) ([]domain.SyncPreview, error) {
+	preview := domain.SyncPreview{
+		Account:     d.feed.Account(),
+		Destination: domain.SyncDestinationWordpress,
+		PostKey:     item.GUIDHash(),
+		SourceURL:   item.Link,
+		Title:       item.Title,
+		Content:     item.GetWordpressContent(),
+	}
+	// アイキャッチにする1枚目の画像
+	if len(item.ImageURLs) > 0 {
+		preview.MediaURLs = item.ImageURLs[:1]
+	}
+	return []domain.SyncPreview{preview}, nil
+}
+
+// feedGbpDestination はフィードの記事をGBPのLocal Postにする連携先。
+type feedGbpDestination struct {
+	u       *customerUsecase
+	account *domain.GoogleAccount
+	feed    *domain.Feed
+}
+
+func (d *feedGbpDestination) Account() domain.Account {
+	return d.feed.Account()
+}
+
+func (d *feedGbpDestination) Needs(ctx context.Context, item domain.FeedItem) (bool, error) {
+	exist, err := d.u.feedPosted(ctx, d.feed, domain.FeedDestinationGbp, item.GUIDHash())
+	if err != nil {
+		return false, err
+	}
+	return !exist, nil
+}
+
+func (d *feedGbpDestination) Publish(ctx context.Context, item domain.FeedItem) error {
+	return d.u.feedToGbp(ctx, d.account, d.feed, item)
+}
+
+func (d *feedGbpDestination) Preview(ctx context.Context, item domain.FeedItem) ([]domain.SyncPreview, error) {
+	localPost := newFeedGbpLocalPost(ctx, d.feed, item)
+	return []domain.SyncPreview{domain.NewGbpLocalPostPreview(d.feed.Account(), item.GUIDHash(), item.Link, localPost)}, nil
+}
+
+// instagramMediaURLs は投稿のメディアのURL（カルーセルの場合は子要素すべて）。
+func instagramMediaURLs(post domain.InstagramPost) []string {
+	if len(post.Children) == 0 {
+		return []string{post.MediaURL}
+	}
+	mediaURLs := make([]string, 0, len(post.Children))
+	for _, child := range post.Children {
+		mediaURLs = append(mediaURLs, child.MediaURL)
+	}
+	return mediaURLs
+}
+
+// instagramPostedBefore は連携開始日前の投稿かどうか。連携開始日前のデータは連携しない。
+func instagramPostedBefore(post domain.InstagramPost, startDate time.Time) bool {
+	instagramPost, _ := time.Parse("2006-01-02T15:04:05-0700", post.Timestamp)
+	return instagramPost.Before(startDate)
+}
+
+// wordpressPostedBefore は連携開始日前の記事かどうか。
+func wordpressPostedBefore(post external.WordpressGbpPost, startDate time.Time) bool {
+	publishedAt, _ := time.Parse(time.RFC3339, post.PublishedAt)
+	return publishedAt.Before(startDate)
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+	"github.com/zuxt268/homing/internal/usecase/pipeline"
+)
+
+func (u *customerUsecase) PreviewAllWordpressInstagram(ctx context.Context) (*res.SyncPreviewList, error) {
+	wiList, err := u.wordpressInstagramRepo.FindAll(ctx, repository.WordpressInstagramFilter{
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var jobs []pipeline.Job[*instagramItem]
+	for _, group := range groupByInstagramID(wiList) {
+		jobs = append(jobs, u.wordpressInstagramJob(group))
+	}
+	return toSyncPreviewList(u.wordpressInstagramPipeline(20).Preview(ctx, jobs...))
+}
+
+func (u *customerUsecase) PreviewOneWordpressInstagram(ctx context.Context, id int) (*res.SyncPreviewList, error) {
+	wi, err := u.wordpressInstagramRepo.Get(ctx, repository.WordpressInstagramFilter{
+		ID: util.Pointer(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if wi.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return toSyncPreviewList(u.wordpressInstagramPipeline(1).Preview(ctx, u.wordpressInstagramJob([]*domain.WordpressInstagram{wi})))
+}
+
+func (u *customerUsecase) PreviewAllGoogleBusinessInstagram(ctx context.Context) (*res.SyncPreviewList, error) {
+	biList, err := u.businessInstagramRepo.FindAll(ctx, repository.BusinessInstagramFilter{
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]pipeline.Job[*instagramItem], 0, len(biList))
+	for _, bi := range biList {
+		jobs = append(jobs, u.businessInstagramJob(bi, false, ""))
+	}
+	return toSyncPreviewList(u.businessInstagramPipeline(false).Preview(ctx, jobs...))
+}
+
+func (u *customerUsecase) PreviewOneGoogleBusinessInstagram(ctx context.Context, id int) (*res.SyncPreviewList, error) {
+	bi, err := u.businessInstagramRepo.Get(ctx, repository.BusinessInstagramFilter{
+		ID:     util.Pointer(id),
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if bi.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return toSyncPreviewList(u.businessInstagramPipeline(true).Preview(ctx, u.businessInstagramJob(bi, true, "")))
+}
+
+func (u *customerUsecase) PreviewAllWordpressGbp(ctx context.Context) (*res.SyncPreviewList, error) {
+	wgList, err := u.wordpressGbpRepo.FindAll(ctx, repository.WordpressGbpFilter{
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]pipeline.Job[external.WordpressGbpPost], 0, len(wgList))
+	for _, wg := range wgList {
+		jobs = append(jobs, u.wordpressGbpJob(wg))
+	}
+	return toSyncPreviewList(u.wordpressGbpPipeline(false).Preview(ctx, jobs...))
+}
+
+func (u *customerUsecase) PreviewOneWordpressGbp(ctx context.Context, id int) (*res.SyncPreviewList, error) {
+	wg, err := u.wordpressGbpRepo.Get(ctx, repository.WordpressGbpFilter{
+		ID: util.Pointer(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if wg.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	return toSyncPreviewList(u.wordpressGbpPipeline(true).Preview(ctx, u.wordpressGbpJob(wg)))
+}
+
+func (u *customerUsecase) PreviewAllFacebookInstagram(ctx context.Context) (*res.SyncPreviewList, error) {
+	fiList, err := u.facebookInstagramRepo.FindAll(ctx, repository.FacebookInstagramFilter{
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]pipeline.Job[*instagramItem], 0, len(fiList))
+	for _, fi := range fiList {
+		jobs = append(jobs, u.facebookInstagramJob(fi, false))
+	}
+	return toSyncPreviewList(u.facebookInstagramPipeline(false).Preview(ctx, jobs...))
+}
+
+func (u *customerUsecase) PreviewOneFacebookInstagram(ctx context.Context, id int) (*res.SyncPreviewList, error) {
+	fi, err := u.facebookInstagramRepo.Get(ctx, repository.FacebookInstagramFilter{
+		ID:     util.Pointer(id),
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fi.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return toSyncPreviewList(u.facebookInstagramPipeline(true).Preview(ctx, u.facebookInstagramJob(fi, true)))
+}
+
+func (u *customerUsecase) PreviewAllFeed(ctx context.Context) (*res.SyncPreviewList, error) {
+	feeds, err := u.feedRepo.FindAll(ctx, repository.FeedFilter{
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]pipeline.Job[domain.FeedItem], 0, len(feeds))
+	for _, feed := range feeds {
+		jobs = append(jobs, u.feedJob(feed))
+	}
+	return toSyncPreviewList(u.feedPipeline(false).Preview(ctx, jobs...))
+}
+
+func (u *customerUsecase) PreviewOneFeed(ctx context.Context, id int) (*res.SyncPreviewList, error) {
+	feed, err := u.feedRepo.Get(ctx, repository.FeedFilter{
+		ID:     util.Pointer(id),
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if feed.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return toSyncPreviewList(u.feedPipeline(true).Preview(ctx, u.feedJob(feed)))
+}
+
+func toSyncPreviewList(previews []domain.SyncPreview, err error) (*res.SyncPreviewList, error) {
+	if err != nil {
+		return nil, err
+	}
+	list := make([]res.SyncPreview, 0, len(previews))
+	for _, p := range previews {
+		preview := res.SyncPreview{
+			AccountType: string(p.Account.Type),
+			AccountID:   p.Account.ID,
+			AccountName: p.Account.Name,
+			Destination: string(p.Destination),
+			PostKey:     p.PostKey,
+			SourceURL:   p.SourceURL,
+			Title:       p.Title,
+			Content:     p.Content,
+			Summary:     p.Summary,
+			TopicType:   string(p.TopicType),
+			MediaURLs:   p.MediaURLs,
+			Error:       p.Error,
+		}
+		if p.CallToAction != nil {
+			preview.CallToActionType = string(p.CallToAction.ActionType)
+			preview.CallToActionURL = p.CallToAction.URL
+		}
+		if preview.MediaURLs == nil {
+			preview.MediaURLs = []string{}
+		}
+		list = append(list, preview)
+	}
+	return &res.SyncPreviewList{SyncPreviewList: list}, nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+// RepostGooglePost は google_posts の記録1件だけを、投稿元から取得し直してGBPに投稿し直す。
+// 同期は実行しないため、同じ連携の他の投稿は投稿しない。投稿元が見つからない場合は何もせずに domain.ErrBadRequest を返す。
+// 投稿できたら record を新しい投稿結果で更新する。GBP上の元の投稿は消さないため、呼び出し元で削除する。
+func (u *customerUsecase) RepostGooglePost(ctx context.Context, record *domain.GooglePost) error {
+	if record.CustomerID > 300000 {
+		return u.repostWordpressGbpPost(ctx, record)
+	}
+	return u.repostBusinessInstagramPost(ctx, record)
+}
+
+// repostBusinessInstagramPost はInstagramのメディアを media_id で取得して、GBPに投稿し直す。
+func (u *customerUsecase) repostBusinessInstagramPost(ctx context.Context, record *domain.GooglePost) error {
+	bi, err := u.businessInstagramRepo.Get(ctx, repository.BusinessInstagramFilter{
+		ID: util.Pointer(record.CustomerID),
+	})
+	if err != nil {
+		return err
+	}
+	if bi.ID == 0 {
+		return fmt.Errorf("%w: 投稿元の連携が見つかりません", domain.ErrBadRequest)
+	}
+	token, err := u.tokenRepo.First(ctx)
+	if err != nil {
+		return err
+	}
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, bi.BusinessName)
+	if err != nil {
+		return err
+	}
+
+	/*
+		投稿元のメディアを取得（写真の場合はカルーセルの子要素を直接取得する）
+	*/
+	post, err := u.instagramAdapter.GetMedia(ctx, token, record.MediaID)
+	if err != nil {
+		return err
+	}
+	if post.ID == "" {
+		return fmt.Errorf("%w: 投稿元のメディアが見つかりません (media_id=%s)", domain.ErrBadRequest, record.MediaID)
+	}
+
+	if record.PostType == domain.PostTypePhoto {
+		if post.MediaType != "IMAGE" {
+			return fmt.Errorf("%w: 画像ではないためPhotosに投稿できません (media_id=%s)", domain.ErrBadRequest, record.MediaID)
+		}
+		// カルーセルの子要素はキャプションを持たないため、アカウント設定のカテゴリになる
+		category := domain.ResolveGbpMediaCategory(bi.MediaCategory, bi.MediaCategoryRules, post.Caption)
+		_, err = u.publishGooglePost(ctx, record, func() error {
+			_, err := u.uploadInstagramGbpPhoto(ctx, token, account, bi, post, func() string { return post.MediaURL }, category, record)
+			return err
+		})
+		return err
+	}
+
+	if instagramFirstImageURL(*post) == "" {
+		return fmt.Errorf("%w: 画像がないためLocal Postに投稿できません (media_id=%s)", domain.ErrBadRequest, record.MediaID)
+	}
+	_, err = u.publishGooglePost(ctx, record, func() error {
+		return u.createInstagramGbpLocalPost(ctx, token, account, bi, post, "", record)
+	})
+	return err
+}
+
+// repostWordpressGbpPost はWordPressの記事を media_id の記事IDで探して、GBPに投稿し直す。
+// 写真の media_id は「記事ID_メディアの番号」、Local Postの media_id は記事ID。
+func (u *customerUsecase) repostWordpressGbpPost(ctx context.Context, record *domain.GooglePost) error {
+	wg, err := u.wordpressGbpRepo.Get(ctx, repository.WordpressGbpFilter{
+		ID: util.Pointer(record.CustomerID - 300000),
+	})
+	if err != nil {
+		return err
+	}
+	if wg.ID == 0 {
+		return fmt.Errorf("%w: 投稿元の連携が見つかりません", domain.ErrBadRequest)
+	}
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, wg.BusinessName)
+	if err != nil {
+		return err
+	}
+
+	/*
+		投稿元の記事を取得
+	*/
+	postID, index, _ := strings.Cut(record.MediaID, "_")
+	posts, err := u.wordpressAdapter.GetGbpPosts(ctx, wg.WordpressDomain)
+	if err != nil {
+		return err
+	}
+	var post *external.WordpressGbpPost
+	for i := range posts {
+		if strconv.Itoa(posts[i].PostID) == postID {
+			post = &posts[i]
+			break
+		}
+	}
+	if post == nil {
+		return fmt.Errorf("%w: 投稿元の記事が見つかりません (media_id=%s)", domain.ErrBadRequest, record.MediaID)
+	}
+
+	if record.PostType == domain.PostTypePhoto {
+		i, err := strconv.Atoi(index)
+		if err != nil || i < 0 || i >= len(post.MediaURLs) {
+			return fmt.Errorf("%w: 投稿元のメディアが見つかりません (media_id=%s)", domain.ErrBadRequest, record.MediaID)
+		}
+		mediaURL := post.MediaURLs[i]
+		_, err = u.publishGooglePost(ctx, record, func() error {
+			return u.uploadGbpPhoto(ctx, account, wg.BusinessName, mediaURL, wordpressGbpMediaFormat(mediaURL), wordpressGbpMediaCategory(wg, *post), record)
+		})
+		return err
+	}
+
+	_, err = u.publishGooglePost(ctx, record, func() error {
+		return u.createWordpressGbpLocalPost(ctx, account, wg, *post, record)
+	})
+	return err
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+	"github.com/zuxt268/homing/internal/usecase/pipeline"
+)
+
+// 同期の記録（posts・google_posts）は投稿先に送る前に reserved で作り、投稿できたら published にする。
+// 投稿と記録の保存の間で処理が止まっても記録が残るため、次回の同期で同じ投稿を二重に投稿しない。
+
+// reserveWordpressPost はWordPressに投稿する前に記録を予約する。
+// 同じ投稿の記録が既にある場合（別の同期が投稿中）は false を返す。
+func (u *customerUsecase) reserveWordpressPost(ctx context.Context, record *model.Post) (bool, error) {
+	record.Status = string(domain.SyncRecordReserved)
+	err := u.postRepo.CreatePost(ctx, record)
+	if errors.Is(err, domain.ErrDuplicate) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// releaseWordpressPost は投稿に失敗した予約を取り消す。
+// 投稿できたか確認できないエラー（sentError）の場合は取り消さずに unconfirmed にして、二重に投稿しないようにする。
+// 取り消せなかった予約は次回の同期で片付けるため、ここでのエラーはログに残すだけにする。
+func (u *customerUsecase) releaseWordpressPost(ctx context.Context, record *model.Post, cause error) {
+	filter := repository.PostFilter{ID: &record.ID}
+	if errors.Is(cause, domain.ErrSyncUnconfirmed) {
+		if _, err := u.postRepo.UpdatePostStatus(ctx, filter, domain.SyncRecordUnconfirmed); err != nil {
+			slog.Warn("投稿の予約を確認できない投稿にできませんでした", "id", record.ID, "media_id", record.MediaID, "error", err.Error())
+		}
+		return
+	}
+	if _, err := u.postRepo.DeletePosts(ctx, filter); err != nil {
+		slog.Warn("投稿の予約の取り消しに失敗", "id", record.ID, "media_id", record.MediaID, "error", err.Error())
+	}
+}
+
+// sentError は投稿先に送った後のエラーを、投稿できたか確認できないエラー（domain.ErrSyncUnconfirmed）にする。
+// タイムアウトや5xxでは投稿先に投稿が作られている可能性があるため、投稿先が受け付けなかったことが確かな
+// エラー（domain.ErrSyncRejected）の場合だけそのまま返す。
+func sentError(err error) error {
+	if err == nil || errors.Is(err, domain.ErrSyncRejected) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", domain.ErrSyncUnconfirmed, err)
+}
+
+// publishGooglePost は record を予約してから publish でGBPに投稿し、投稿できたら record を投稿済みにする。
+// publish は投稿結果（Name, GoogleURL, CreateTime）を record に設定する。
+// 同じ投稿の記録が既にある場合は publish せずに false を返す。publish に失敗した場合は予約を取り消すが、
+// GBPに送った後の投稿できたか確認できないエラー（sentError）の場合は unconfirmed にして残す。
+// 保存済みの record（ID がある。再投稿）は記録を予約に戻して投稿し、投稿できなかった場合は元の記録に戻す。
+func (u *customerUsecase) publishGooglePost(ctx context.Context, record *domain.GooglePost, publish func() error) (bool, error) {
+	/*
+		GBPに送る前に記録を予約
+	*/
+	previous := *record
+	record.Status = domain.SyncRecordReserved
+	if previous.ID != 0 {
+		if err := u.googlePostRepo.Update(ctx, record, repository.GooglePostFilter{ID: &record.ID}); err != nil {
+			return false, err
+		}
+	} else if err := u.googlePostRepo.Create(ctx, record); err != nil {
+		if errors.Is(err, domain.ErrDuplicate) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	/*
+		GBPに投稿
+	*/
+	if err := publish(); err != nil {
+		if errors.Is(err, domain.ErrSyncUnconfirmed) {
+			record.Status = domain.SyncRecordUnconfirmed
+			if err := u.googlePostRepo.Update(ctx, record, repository.GooglePostFilter{ID: &record.ID}); err != nil {
+				slog.Warn("投稿の予約を確認できない投稿にできませんでした", "id", record.ID, "media_id", record.MediaID, "error", err.Error())
+			}
+			return false, err
+		}
+		if previous.ID != 0 {
+			*record = previous
+			if err := u.googlePostRepo.Update(ctx, record, repository.GooglePostFilter{ID: &record.ID}); err != nil {
+				slog.Warn("再投稿の予約の取り消しに失敗", "id", record.ID, "media_id", record.MediaID, "error", err.Error())
+			}
+			return false, err
+		}
+		if err := u.googlePostRepo.Delete(ctx, repository.GooglePostFilter{ID: &record.ID}); err != nil {
+			slog.Warn("投稿の予約の取り消しに失敗", "id", record.ID, "media_id", record.MediaID, "error", err.Error())
+		}
+		return false, err
+	}
+
+	/*
+		投稿済みにする。失敗した場合は予約のまま残し、次回の同期で確認できない投稿として通知する
+	*/
+	record.Status = domain.SyncRecordPublished
+	// 再投稿した記録は投稿日時を新しい CreateTime から読み直し、削除済みでなくする
+	record.PublishedAt = nil
+	record.DeletedAt = nil
+	if err := u.googlePostRepo.Update(ctx, record, repository.GooglePostFilter{ID: &record.ID}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// reconcileWordpressPosts は前回までの同期で投稿の途中で止まったWordPressの投稿を片付ける。
+// rodut プラグインは冪等キーで投稿済みの記事を返すため、予約を消して同じキーで投稿し直す。
+// wp/v2 は投稿できたか確認できないため unconfirmed にして返す。
+func (u *customerUsecase) reconcileWordpressPosts(ctx context.Context, wiList []*domain.WordpressInstagram) ([]pipeline.Failure, error) {
+	var failures []pipeline.Failure
+	deadline := time.Now().Add(-domain.SyncReservationTimeout)
+	for _, wi := range wiList {
+		posts, err := u.postRepo.GetPosts(ctx, repository.PostFilter{
+			WordpressInstagramID: util.Pointer(wi.ID),
+			Status:               util.Pointer(string(domain.SyncRecordReserved)),
+			CreatedAtTo:          &deadline,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, post := range posts {
+			filter := repository.PostFilter{ID: util.Pointer(post.ID), Status: util.Pointer(string(domain.SyncRecordReserved))}
+			if !wi.IsV2() && post.IdempotencyKey != nil {
+				slog.Info("途中で止まった投稿を投稿し直します", "wordpress_instagram_id", wi.ID, "media_id", post.MediaID)
+				if _, err := u.postRepo.DeletePosts(ctx, filter); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if _, err := u.postRepo.UpdatePostStatus(ctx, filter, domain.SyncRecordUnconfirmed); err != nil {
+				return nil, err
+			}
+			failures = append(failures, pipeline.Failure{
+				Account: wi.Account(),
+				PostKey: post.MediaID,
+				Err:     fmt.Errorf("投稿の途中で処理が止まりました: %w (instagram_url=%s)", domain.ErrSyncUnconfirmed, post.InstagramURL),
+			})
+		}
+	}
+	return failures, nil
+}
+
+// reconcileGooglePosts は前回までの同期で投稿の途中で止まったGBPの投稿を unconfirmed にして返す。
+// GBPは投稿できたか確認できないため、投稿し直さずに通知する。
+func (u *customerUsecase) reconcileGooglePosts(ctx context.Context, account domain.Account, customerID int) ([]pipeline.Failure, error) {
+	gpList, err := u.googlePostRepo.FindAll(ctx, repository.GooglePostFilter{
+		CustomerID:  &customerID,
+		Status:      util.Pointer(string(domain.SyncRecordReserved)),
+		CreatedAtTo: util.Pointer(time.Now().Add(-domain.SyncReservationTimeout)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	failures := make([]pipeline.Failure, 0, len(gpList))
+	for _, gp := range gpList {
+		gp.Status = domain.SyncRecordUnconfirmed
+		if err := u.googlePostRepo.Update(ctx, gp, repository.GooglePostFilter{ID: &gp.ID}); err != nil {
+			return nil, err
+		}
+		failures = append(failures, pipeline.Failure{
+			Account: account,
+			PostKey: gp.MediaID,
+			Err:     fmt.Errorf("投稿の途中で処理が止まりました: %w (post_type=%s, media_id=%s)", domain.ErrSyncUnconfirmed, gp.PostType, gp.MediaID),
+		})
+	}
+	return failures, nil
+}
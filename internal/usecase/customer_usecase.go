@@ -2,13 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
-	"regexp"
-	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -18,24 +17,50 @@ import (
 	"github.com/zuxt268/homing/internal/interface/adapter"
 	"github.com/zuxt268/homing/internal/interface/dto/external"
 	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
 	"github.com/zuxt268/homing/internal/interface/repository"
 	"github.com/zuxt268/homing/internal/interface/util"
+	"github.com/zuxt268/homing/internal/usecase/pipeline"
 )
 
 type CustomerUsecase interface {
 	SyncAllWordpressInstagram(ctx context.Context) error
 	SyncOneWordpressInstagram(ctx context.Context, id int) error
+	RetryWordpressInstagramPost(ctx context.Context, id int, mediaID string) error
 
 	SyncAllGoogleBusinessInstagram(ctx context.Context) error
 	SyncOneGoogleBusinessInstagram(ctx context.Context, id int) error
+	RetryBusinessInstagramPost(ctx context.Context, id int, mediaID string) error
 
 	SyncAllWordpressGbp(ctx context.Context) error
 	SyncOneWordpressGbp(ctx context.Context, id int) error
+
+	// RepostGooglePost は google_posts の記録1件だけを投稿元から取得し直して、GBPに投稿し直す。
+	RepostGooglePost(ctx context.Context, record *domain.GooglePost) error
+
+	SyncAllFacebookInstagram(ctx context.Context) error
+	SyncOneFacebookInstagram(ctx context.Context, id int) error
+
+	SyncAllFeed(ctx context.Context) error
+	SyncOneFeed(ctx context.Context, id int) error
+
+	// Preview〜 は同期と同じ投稿の取得と判定だけをして、投稿される内容を返す（ドライラン）。
+	// 何もアップロード・投稿せず、DBにも保存しない。
+	PreviewAllWordpressInstagram(ctx context.Context) (*res.SyncPreviewList, error)
+	PreviewOneWordpressInstagram(ctx context.Context, id int) (*res.SyncPreviewList, error)
+	PreviewAllGoogleBusinessInstagram(ctx context.Context) (*res.SyncPreviewList, error)
+	PreviewOneGoogleBusinessInstagram(ctx context.Context, id int) (*res.SyncPreviewList, error)
+	PreviewAllWordpressGbp(ctx context.Context) (*res.SyncPreviewList, error)
+	PreviewOneWordpressGbp(ctx context.Context, id int) (*res.SyncPreviewList, error)
+	PreviewAllFacebookInstagram(ctx context.Context) (*res.SyncPreviewList, error)
+	PreviewOneFacebookInstagram(ctx context.Context, id int) (*res.SyncPreviewList, error)
+	PreviewAllFeed(ctx context.Context) (*res.SyncPreviewList, error)
+	PreviewOneFeed(ctx context.Context, id int) (*res.SyncPreviewList, error)
 }
 
 type customerUsecase struct {
 	instagramAdapter       adapter.InstagramAdapter
-	slack                  adapter.Slack
+	notificationUsecase    NotificationUsecase
 	wordpressAdapter       adapter.WordpressAdapter
 	gbpAdapter             adapter.GbpAdapter
 	postRepo               repository.PostRepository
@@ -45,12 +70,23 @@ type customerUsecase struct {
 	googlePostRepo         repository.GooglePostRepository
 	s3Adapter              adapter.S3Adapter
 	wordpressGbpRepo       repository.WordpressGbpRepository
+	googleBusinessRepo     repository.GoogleBusinessRepository
+	googleAccountRepo      repository.GoogleAccountRepository
+	gbpPostRejectionRepo   repository.GbpPostRejectionRepository
+	facebookAdapter        adapter.FacebookAdapter
+	facebookInstagramRepo  repository.FacebookInstagramRepository
+	facebookPostRepo       repository.FacebookPostRepository
+	feedAdapter            adapter.FeedAdapter
+	feedRepo               repository.FeedRepository
+	feedPostRepo           repository.FeedPostRepository
+	webhookUsecase         WebhookUsecase
+	syncActivityRepo       repository.SyncActivityRepository
 	customerLocks          sync.Map
 }
 
 func NewCustomerUsecase(
 	instagramAdapter adapter.InstagramAdapter,
-	slack adapter.Slack,
+	notificationUsecase NotificationUsecase,
 	wordpressAdapter adapter.WordpressAdapter,
 	gbpAdapter adapter.GbpAdapter,
 	postRepo repository.PostRepository,
@@ -60,10 +96,21 @@ func NewCustomerUsecase(
 	googlePostRepo repository.GooglePostRepository,
 	s3Adapter adapter.S3Adapter,
 	wordpressGbpRepo repository.WordpressGbpRepository,
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	googleAccountRepo repository.GoogleAccountRepository,
+	gbpPostRejectionRepo repository.GbpPostRejectionRepository,
+	facebookAdapter adapter.FacebookAdapter,
+	facebookInstagramRepo repository.FacebookInstagramRepository,
+	facebookPostRepo repository.FacebookPostRepository,
+	feedAdapter adapter.FeedAdapter,
+	feedRepo repository.FeedRepository,
+	feedPostRepo repository.FeedPostRepository,
+	webhookUsecase WebhookUsecase,
+	syncActivityRepo repository.SyncActivityRepository,
 ) CustomerUsecase {
 	return &customerUsecase{
 		instagramAdapter:       instagramAdapter,
-		slack:                  slack,
+		notificationUsecase:    notificationUsecase,
 		wordpressAdapter:       wordpressAdapter,
 		gbpAdapter:             gbpAdapter,
 		postRepo:               postRepo,
@@ -73,13 +120,20 @@ func NewCustomerUsecase(
 		googlePostRepo:         googlePostRepo,
 		s3Adapter:              s3Adapter,
 		wordpressGbpRepo:       wordpressGbpRepo,
+		googleBusinessRepo:     googleBusinessRepo,
+		googleAccountRepo:      googleAccountRepo,
+		gbpPostRejectionRepo:   gbpPostRejectionRepo,
+		facebookAdapter:        facebookAdapter,
+		facebookInstagramRepo:  facebookInstagramRepo,
+		facebookPostRepo:       facebookPostRepo,
+		feedAdapter:            feedAdapter,
+		feedRepo:               feedRepo,
+		feedPostRepo:           feedPostRepo,
+		webhookUsecase:         webhookUsecase,
+		syncActivityRepo:       syncActivityRepo,
 	}
 }
 
-const template = `<@U04P797HYPM>
-[%s]
-顧客 id=%d, name=%s`
-
 func (u *customerUsecase) SyncAllWordpressInstagram(ctx context.Context) error {
 	wiList, err := u.wordpressInstagramRepo.FindAll(ctx, repository.WordpressInstagramFilter{
 		Status: util.Pointer(1),
@@ -88,111 +142,202 @@ func (u *customerUsecase) SyncAllWordpressInstagram(ctx context.Context) error {
 		return err
 	}
 
+	// 同じInstagramの連携先はまとめて処理して、投稿の取得とメディアのダウンロードを1回にする
+	var jobs []pipeline.Job[*instagramItem]
+	for _, group := range groupByInstagramID(wiList) {
+		jobs = append(jobs, u.wordpressInstagramJob(group))
+	}
+
 	// 20件の並列処理
-	semaphore := make(chan struct{}, 20)
-	var wg sync.WaitGroup
+	return u.wordpressInstagramPipeline(20).Run(ctx, jobs...)
+}
 
+// groupByInstagramID は連携先をInstagramアカウントごとにまとめる（順序は元の一覧の順）。
+func groupByInstagramID(wiList []*domain.WordpressInstagram) [][]*domain.WordpressInstagram {
+	index := make(map[string]int)
+	var groups [][]*domain.WordpressInstagram
 	for _, wi := range wiList {
-		wg.Add(1)
-		semaphore <- struct{}{}
-
-		go func(wi *domain.WordpressInstagram) {
-			defer wg.Done()
-			defer func() { <-semaphore }()
-
-			// 各goroutine専用のFileDownloaderを作成
-			fd := adapter.NewFileDownloader()
-			defer func() {
-				_ = fd.DeleteTempDirectory()
-			}()
-
-			u.syncOne(ctx, wi, fd)
-		}(wi)
+		i, ok := index[wi.InstagramID]
+		if !ok {
+			i = len(groups)
+			index[wi.InstagramID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], wi)
 	}
-
-	wg.Wait()
-	return nil
+	return groups
 }
 
-func (u *customerUsecase) syncOne(ctx context.Context, wi *domain.WordpressInstagram, fd adapter.FileDownloader) {
-	// 顧客IDごとのロックを取得
-	lockInterface, _ := u.customerLocks.LoadOrStore(wi.ID, &sync.Mutex{})
-	mu := lockInterface.(*sync.Mutex)
+// wordpressInstagramPipeline はInstagramの投稿を複数のWordPressサイトに連携する。
+// 連携先ごとにカテゴリ・テンプレート・連携開始日が異なるため、投稿するかどうかは連携先ごとに判定する。
+// 失敗した連携先はそのInstagramの以降の投稿を連携しない（投稿の順序を崩さないため）。
+func (u *customerUsecase) wordpressInstagramPipeline(concurrency int) *pipeline.Pipeline[*instagramItem] {
+	return &pipeline.Pipeline[*instagramItem]{
+		Flow:                  "instagram => wordpress",
+		Concurrency:           concurrency,
+		StopFailedDestination: true,
+		Report:                u.reportSyncFailed,
+		Locks:                 &u.customerLocks,
+	}
+}
 
-	mu.Lock()
-	defer mu.Unlock()
+// wordpressInstagramJob は1つのInstagramアカウントの投稿を、そのアカウントの連携先に連携する。
+func (u *customerUsecase) wordpressInstagramJob(wiList []*domain.WordpressInstagram) pipeline.Job[*instagramItem] {
+	accounts := make([]domain.Account, 0, len(wiList))
+	for _, wi := range wiList {
+		accounts = append(accounts, wi.Account())
+	}
+	return pipeline.Job[*instagramItem]{
+		// Instagramアカウントごとのロックを取得
+		LockKey:  "wordpress_instagram:" + wiList[0].InstagramID,
+		Accounts: accounts,
+		Reconcile: func(ctx context.Context) ([]pipeline.Failure, error) {
+			return u.reconcileWordpressPosts(ctx, wiList)
+		},
+		Open: func(ctx context.Context) (pipeline.Source[*instagramItem], []pipeline.Destination[*instagramItem], error) {
+			/*
+				トークンを取得する
+			*/
+			token, err := u.tokenRepo.First(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			destinations := make([]pipeline.Destination[*instagramItem], 0, len(wiList))
+			for _, wi := range wiList {
+				destinations = append(destinations, &wordpressDestination{u: u, wi: wi})
+			}
+			// メディアは連携先の数によらず1回だけダウンロードする
+			return &instagramSource{
+				u:           u,
+				token:       token,
+				instagramID: wiList[0].InstagramID,
+				all:         true,
+				fd:          adapter.NewFileDownloader(),
+			}, destinations, nil
+		},
+		Done: func(ctx context.Context, errs map[domain.Account]error) {
+			/*
+				連携先ごとの同期結果を保存
+			*/
+			now := time.Now()
+			for _, wi := range wiList {
+				if err := u.wordpressInstagramRepo.UpdateSyncState(ctx, wi.ID, now, domain.SyncErrorMessage(errs[wi.Account()])); err != nil {
+					slog.Warn("同期結果の保存に失敗", "id", wi.ID, "error", err.Error())
+				}
+			}
+		},
+	}
+}
 
+// needsWordpressPost は連携先にまだ投稿していない、連携開始日以降の投稿かどうかを判定する。
+func (u *customerUsecase) needsWordpressPost(ctx context.Context, wi *domain.WordpressInstagram, post domain.InstagramPost) (bool, error) {
 	/*
-		トークンを取得する
+		すでに投稿しているものかどうかをチェック
 	*/
-	token, err := u.tokenRepo.First(ctx)
+	exist, err := u.postRepo.ExistPost(ctx, repository.PostFilter{
+		WordpressInstagramID: util.Pointer(wi.ID),
+		MediaID:              &post.ID,
+	})
 	if err != nil {
-		_ = u.slack.Error(ctx, "instagram => wordpress", err, wi.ID, wi.Name)
-		return
+		return false, err
 	}
-	/*
-		インスタグラムから投稿を一覧で取得する
-	*/
-	posts, err := u.instagramAdapter.GetPostsAll(ctx, token, wi.InstagramID)
-	if err != nil {
-		_ = u.slack.Error(ctx, "instagram => wordpress", err, wi.ID, wi.Name)
-		return
+	if exist {
+		return false, nil
 	}
 
 	/*
-		まだ連携していない投稿をWordpressに連携する
+		連携開始日前のデータは連携しない
 	*/
-	sort.Slice(posts, func(i, j int) bool {
-		return posts[i].Timestamp < posts[j].Timestamp
-	})
-	for _, post := range posts {
-		err := u.instagram2wordpress(ctx, wi, post, fd)
+	return !instagramPostedBefore(post, wi.StartDate), nil
+}
+
+// downloadInstagramMedia は投稿の画像、動画（カルーセルの場合は子要素すべて）をダウンロードしてパスを返す。
+func (u *customerUsecase) downloadInstagramMedia(ctx context.Context, token string, post *domain.InstagramPost, fd adapter.FileDownloader) ([]string, error) {
+	var localPaths []string
+	download := func(mediaURL func() string) error {
+		var localPath string
+		err := u.retryOnExpiredMedia(ctx, token, post, func() error {
+			var err error
+			localPath, err = fd.Download(ctx, mediaURL())
+			return err
+		})
 		if err != nil {
-			_ = u.slack.Error(ctx, "instagram => wordpress", err, wi.ID, wi.Name)
-			return
+			return err
+		}
+		localPaths = append(localPaths, localPath)
+		return nil
+	}
+
+	if len(post.Children) == 0 {
+		if err := download(func() string { return post.MediaURL }); err != nil {
+			return nil, err
+		}
+		return localPaths, nil
+	}
+	for i := range post.Children {
+		if err := download(func() string { return post.Children[i].MediaURL }); err != nil {
+			for _, localPath := range localPaths {
+				_ = os.Remove(localPath)
+			}
+			return nil, err
 		}
 	}
+	return localPaths, nil
 }
 
-func (u *customerUsecase) instagram2wordpress(ctx context.Context, wi *domain.WordpressInstagram, post domain.InstagramPost, fd adapter.FileDownloader) error {
+func (u *customerUsecase) instagram2wordpress(ctx context.Context, wi *domain.WordpressInstagram, post domain.InstagramPost, localPaths []string) error {
+	// アップロード結果は連携先ごとに異なるため、連携先ごとに設定し直す
+	post.SourceURLs = nil
+	post.SetDeleteHashFlag(wi.DeleteHash)
 
 	/*
-		メディアのリンクがない場合はスキップ
+		WordPressに送る前に投稿の記録を予約（途中で止まっても次回の同期で二重に投稿しないため）
 	*/
-	if post.MediaURL == "" {
-		return nil
+	customerID := 100000 + wi.ID
+	record := &model.Post{
+		MediaID:              post.ID,
+		CustomerID:           customerID,
+		WordpressInstagramID: util.Pointer(wi.ID),
+		IdempotencyKey:       util.Pointer(domain.NewSyncIdempotencyKey(domain.SyncDestinationWordpress, customerID, post.ID)),
+		Timestamp:            post.Timestamp,
+		MediaURL:             post.MediaURL,
+		Permalink:            post.Permalink,
+		CreatedAt:            time.Now(),
 	}
-
-	/*
-		すでに投稿しているものかどうかをチェック
-	*/
-	exist, err := u.postRepo.ExistPost(ctx, repository.PostFilter{
-		CustomerID: util.Pointer(100000 + wi.ID),
-		MediaID:    &post.ID,
-	})
+	reserved, err := u.reserveWordpressPost(ctx, record)
 	if err != nil {
 		return err
 	}
-	if exist {
+	if !reserved {
 		return nil
 	}
 
+	postResp, err := u.publishWordpressPost(ctx, wi, &post, localPaths, *record.IdempotencyKey)
+	if err != nil {
+		u.releaseWordpressPost(ctx, record, err)
+		return err
+	}
+
 	/*
-		連携開始日前のデータは連携しない
+		投稿したことをDBに保存。失敗した場合は予約のまま残し、次回の同期で同じ冪等キーで投稿し直す
 	*/
-	instagramPost, _ := time.Parse("2006-01-02T15:04:05-0700", post.Timestamp)
-	if instagramPost.Before(wi.StartDate) {
-		return nil
+	if err := u.postRepo.ConfirmPost(ctx, record.ID, postResp.WordpressURL); err != nil {
+		return err
 	}
 
-	if len(post.Children) == 0 {
-		/*
-			インスタグラムの投稿の画像、動画を一時ディレクトリにダウンロード
-		*/
-		localPath, err := fd.Download(ctx, post.MediaURL)
-		if err != nil {
-			return err
-		}
+	/*
+		Slack、webhookに通知
+	*/
+	u.notificationUsecase.Notify(ctx, domain.NewWordpressInstagramNotification(wi, postResp.WordpressURL, post.Permalink))
+	u.webhookUsecase.Publish(ctx, domain.WebhookEventWordpressPostPublished, wi.Account(), map[string]string{"wordpress_url": postResp.WordpressURL, "instagram_url": post.Permalink})
+	u.recordSyncActivity(ctx, wi.Account(), domain.SyncResultPublished, post.ID, "")
+
+	return nil
+}
+
+// publishWordpressPost はダウンロードしたメディアをアップロードし、連携先のテンプレートで記事を投稿する。
+func (u *customerUsecase) publishWordpressPost(ctx context.Context, wi *domain.WordpressInstagram, post *domain.InstagramPost, localPaths []string, idempotencyKey string) (*domain.Post, error) {
+	for i, localPath := range localPaths {
 		/*
 			ダウンロードしたファイルをWordpressにアップロード
 		*/
@@ -201,106 +346,127 @@ func (u *customerUsecase) instagram2wordpress(ctx context.Context, wi *domain.Wo
 			WordpressInstagram: *wi,
 		})
 		if err != nil {
-			return err
-		}
-		post.SetFeaturedMediaID(uploadResp.Id)
-		post.SetDeleteHashFlag(wi.DeleteHash)
-		post.AppendSourceURL(uploadResp.SourceUrl)
-
-		/*
-			ダウンロードファイルを都度削除
-		*/
-		err = os.Remove(localPath)
-		if err != nil {
-			slog.Warn(err.Error())
+			return nil, err
 		}
-
-	} else {
-		for i, child := range post.Children {
-			/*
-				インスタグラムの投稿の画像、動画を一時ディレクトリにダウンロード
-			*/
-			childLocalPath, err := fd.Download(ctx, child.MediaURL)
-			if err != nil {
-				return err
-			}
-
-			/*
-				ダウンロードしたファイルをWordpressにアップロード
-			*/
-			childUploadResp, err := u.wordpressAdapter.FileUpload(ctx, external.WordpressFileUploadInput{
-				Path:               childLocalPath,
-				WordpressInstagram: *wi,
-			})
-			if err != nil {
-				return err
-			}
-			if i == 0 {
-				post.SetFeaturedMediaID(childUploadResp.Id)
-				post.SetDeleteHashFlag(wi.DeleteHash)
-			}
-			post.AppendSourceURL(childUploadResp.SourceUrl)
-
-			/*
-				ダウンロードファイルを都度削除
-			*/
-			err = os.Remove(childLocalPath)
-			if err != nil {
-				slog.Warn(err.Error())
-			}
+		if i == 0 {
+			post.SetFeaturedMediaID(uploadResp.Id)
 		}
+		post.AppendSourceURL(uploadResp.SourceUrl)
 	}
 
 	/*
-		アップロードしたファイルをFeaturedに指定して、記事を投稿
+		アップロードしたファイルをFeaturedに指定して、連携先のテンプレートで記事を投稿
 	*/
-	postResp, err := u.wordpressAdapter.Post(ctx, external.WordpressPostInput{
+	postResp, err := u.wordpressAdapter.PostArticle(ctx, external.WordpressArticleInput{
 		WordpressInstagram: *wi,
-		Post:               post,
+		Title:              post.GetTitle(),
+		Content:            wi.RenderContent(*post),
+		PostDate:           post.GetPostDate(),
+		FeaturedMediaID:    post.FeaturedMediaID,
+		IdempotencyKey:     idempotencyKey,
 	})
-	if err != nil {
-		return err
+	// rodut プラグインは冪等キーで投稿済みの記事を返すため、予約を取り消して投稿し直せる。
+	// wp/v2 は投稿できたか確認できないため、受け付けなかったことが確かな場合を除いて投稿し直さない
+	if err != nil && wi.IsV2() {
+		return nil, sentError(err)
 	}
+	return postResp, err
+}
 
-	/*
-		投稿したことをDBに保存
-	*/
-	err = u.postRepo.CreatePost(ctx, &model.Post{
-		MediaID:       post.ID,
-		CustomerID:    100000 + wi.ID,
-		Timestamp:     post.Timestamp,
-		MediaURL:      post.MediaURL,
-		Permalink:     post.Permalink,
-		WordpressLink: postResp.WordpressURL,
-		CreatedAt:     time.Now(),
+func (u *customerUsecase) SyncOneWordpressInstagram(ctx context.Context, id int) error {
+	wi, err := u.wordpressInstagramRepo.Get(ctx, repository.WordpressInstagramFilter{
+		ID: util.Pointer(id),
 	})
 	if err != nil {
 		return err
 	}
 
-	/*
-		Slackに通知
-	*/
-	_ = u.slack.SuccessWI(ctx, wi, postResp.WordpressURL, post.Permalink)
-
-	return nil
+	return u.wordpressInstagramPipeline(1).Run(ctx, u.wordpressInstagramJob([]*domain.WordpressInstagram{wi}))
 }
 
-func (u *customerUsecase) SyncOneWordpressInstagram(ctx context.Context, id int) error {
+// RetryWordpressInstagramPost は連携に失敗したInstagramの投稿を1件だけWordPressに連携し直す。
+// すでに連携済み、または連携開始日前の投稿は何もしない。投稿できたか確認できなかった投稿は投稿し直す。
+func (u *customerUsecase) RetryWordpressInstagramPost(ctx context.Context, id int, mediaID string) error {
 	wi, err := u.wordpressInstagramRepo.Get(ctx, repository.WordpressInstagramFilter{
 		ID: util.Pointer(id),
 	})
 	if err != nil {
 		return err
 	}
+	if wi.ID == 0 {
+		return domain.ErrNotFound
+	}
 
-	fd := adapter.NewFileDownloader()
-	defer func() {
-		_ = fd.DeleteTempDirectory()
-	}()
+	p := u.wordpressInstagramPipeline(1)
+	p.AbortOnError = true
+	return p.Run(ctx, pipeline.Job[*instagramItem]{
+		// 同期処理と同じ投稿を二重に連携しないよう、Instagramアカウントごとのロックを取得
+		LockKey: "wordpress_instagram:" + wi.InstagramID,
+		Reconcile: func(ctx context.Context) ([]pipeline.Failure, error) {
+			/*
+				投稿できたか確認できなかった記録を消して、投稿し直せるようにする
+			*/
+			_, err := u.postRepo.DeletePosts(ctx, repository.PostFilter{
+				WordpressInstagramID: util.Pointer(wi.ID),
+				MediaID:              util.Pointer(mediaID),
+				Status:               util.Pointer(string(domain.SyncRecordUnconfirmed)),
+			})
+			return nil, err
+		},
+		Open: func(ctx context.Context) (pipeline.Source[*instagramItem], []pipeline.Destination[*instagramItem], error) {
+			token, err := u.tokenRepo.First(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			return &instagramSource{
+				u:       u,
+				token:   token,
+				mediaID: mediaID,
+				fd:      adapter.NewFileDownloader(),
+			}, []pipeline.Destination[*instagramItem]{&wordpressDestination{u: u, wi: wi}}, nil
+		},
+	})
+}
 
-	u.syncOne(ctx, wi, fd)
-	return nil
+// businessInstagramPipeline はInstagramの投稿をGBPのPhotosとLocal Postに連携する。
+// abortOnError が false の場合は失敗を通知して、次の投稿の連携を続ける。
+func (u *customerUsecase) businessInstagramPipeline(abortOnError bool) *pipeline.Pipeline[*instagramItem] {
+	return &pipeline.Pipeline[*instagramItem]{
+		Flow:         "instagram => google business profile",
+		AbortOnError: abortOnError,
+		Report:       u.reportSyncFailed,
+	}
+}
+
+// businessInstagramJob は1つの連携のInstagramの投稿をGBPに連携する。all が false の場合は直近25件のみを対象にする。
+func (u *customerUsecase) businessInstagramJob(bi *domain.BusinessInstagram, all bool, mediaID string) pipeline.Job[*instagramItem] {
+	return pipeline.Job[*instagramItem]{
+		Accounts: []domain.Account{bi.Account()},
+		Reconcile: func(ctx context.Context) ([]pipeline.Failure, error) {
+			return u.reconcileGooglePosts(ctx, bi.Account(), bi.ID)
+		},
+		Open: func(ctx context.Context) (pipeline.Source[*instagramItem], []pipeline.Destination[*instagramItem], error) {
+			token, err := u.tokenRepo.First(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, bi.BusinessName)
+			if err != nil {
+				return nil, nil, err
+			}
+			// Local PostはPhotosにアップロードした画像を使い回すため、Photosを先に連携する
+			return &instagramSource{
+				u:           u,
+				token:       token,
+				instagramID: bi.InstagramID,
+				all:         all,
+				mediaID:     mediaID,
+			}, []pipeline.Destination[*instagramItem]{
+				&instagramGbpPhotoDestination{u: u, token: token, account: account, bi: bi},
+				&instagramGbpLocalPostDestination{u: u, token: token, account: account, bi: bi},
+			}, nil
+		},
+	}
 }
 
 func (u *customerUsecase) SyncAllGoogleBusinessInstagram(ctx context.Context) error {
@@ -311,34 +477,11 @@ func (u *customerUsecase) SyncAllGoogleBusinessInstagram(ctx context.Context) er
 		return err
 	}
 
-	backGroundCtx := context.Background()
+	jobs := make([]pipeline.Job[*instagramItem], 0, len(biList))
 	for _, bi := range biList {
-		token, err := u.tokenRepo.First(backGroundCtx)
-		if err != nil {
-			_ = u.slack.Error(ctx, "instagram => google business profile", err, bi.ID, bi.BusinessTitle)
-			continue
-		}
-		/*
-			インスタグラムから投稿を一覧で取得する
-		*/
-		posts, err := u.instagramAdapter.GetPosts25(backGroundCtx, token, bi.InstagramID)
-		if err != nil {
-			_ = u.slack.Error(ctx, "instagram => google business profile", err, bi.ID, bi.BusinessTitle)
-			continue
-		}
-
-		sort.Slice(posts, func(i, j int) bool {
-			return posts[i].Timestamp < posts[j].Timestamp
-		})
-
-		for _, post := range posts {
-			if err := u.instagramToGbp(backGroundCtx, bi, post); err != nil {
-				_ = u.slack.Error(ctx, "instagram => google business profile", err, bi.ID, bi.BusinessTitle)
-				continue
-			}
-		}
+		jobs = append(jobs, u.businessInstagramJob(bi, false, ""))
 	}
-	return nil
+	return u.businessInstagramPipeline(false).Run(context.Background(), jobs...)
 }
 
 func (u *customerUsecase) SyncOneGoogleBusinessInstagram(ctx context.Context, id int) error {
@@ -350,50 +493,57 @@ func (u *customerUsecase) SyncOneGoogleBusinessInstagram(ctx context.Context, id
 		return err
 	}
 
-	backGroundCtx := context.Background()
-	token, err := u.tokenRepo.First(backGroundCtx)
+	return u.businessInstagramPipeline(true).Run(context.Background(), u.businessInstagramJob(bi, true, ""))
+}
+
+// RetryBusinessInstagramPost は連携に失敗したInstagramの投稿を1件だけGBPに連携し直す。
+// 投稿できたか確認できなかった投稿（カルーセルの場合は子要素の画像も）は投稿し直す。
+func (u *customerUsecase) RetryBusinessInstagramPost(ctx context.Context, id int, mediaID string) error {
+	bi, err := u.businessInstagramRepo.Get(ctx, repository.BusinessInstagramFilter{
+		ID: util.Pointer(id),
+	})
 	if err != nil {
 		return err
 	}
-	/*
-		インスタグラムから投稿を一覧で取得する
-	*/
-	posts, err := u.instagramAdapter.GetPostsAll(backGroundCtx, token, bi.InstagramID)
-	if err != nil {
-		return err
+	if bi.ID == 0 {
+		return domain.ErrNotFound
 	}
 
-	sort.Slice(posts, func(i, j int) bool {
-		return posts[i].Timestamp < posts[j].Timestamp
-	})
-
-	for _, post := range posts {
-		if err := u.instagramToGbp(backGroundCtx, bi, post); err != nil {
-			return err
+	job := u.businessInstagramJob(bi, false, mediaID)
+	job.Reconcile = func(ctx context.Context) ([]pipeline.Failure, error) {
+		/*
+			投稿できたか確認できなかった記録を消して、投稿し直せるようにする
+		*/
+		token, err := u.tokenRepo.First(ctx)
+		if err != nil {
+			return nil, err
+		}
+		post, err := u.instagramAdapter.GetMedia(ctx, token, mediaID)
+		if err != nil {
+			return nil, err
+		}
+		mediaIDs := []string{post.ID}
+		for _, child := range post.Children {
+			mediaIDs = append(mediaIDs, child.ID)
 		}
+		return nil, u.googlePostRepo.Delete(ctx, repository.GooglePostFilter{
+			CustomerID: &bi.ID,
+			MediaIDs:   mediaIDs,
+			Status:     util.Pointer(string(domain.SyncRecordUnconfirmed)),
+		})
 	}
-
-	return nil
+	return u.businessInstagramPipeline(true).Run(ctx, job)
 }
 
-func (u *customerUsecase) instagramToGbp(ctx context.Context, bi *domain.BusinessInstagram, post domain.InstagramPost) error {
-
-	/*
-		メディアのリンクがない場合はスキップ
-	*/
-	if post.MediaURL == "" {
-		return nil
-	}
+// instagramToGbpPhotos は投稿の画像をGBPのPhotosにアップロードする。動画はアップロードしない。
+// アップロードした最初の画像のURLは item に保存し、Local Postで使い回す。
+func (u *customerUsecase) instagramToGbpPhotos(ctx context.Context, token string, account *domain.GoogleAccount, bi *domain.BusinessInstagram, item *instagramItem) error {
+	post := &item.post
 
 	/*
-		連携開始日前のデータは連携しない
+		写真のカテゴリ（キャプションのキーワード > アカウント設定）
 	*/
-	instagramPost, _ := time.Parse("2006-01-02T15:04:05-0700", post.Timestamp)
-	if instagramPost.Before(bi.StartDate) {
-		return nil
-	}
-
-	var firstImageSourceURL string
+	category := domain.ResolveGbpMediaCategory(bi.MediaCategory, bi.MediaCategoryRules, post.Caption)
 
 	if len(post.Children) == 0 && post.MediaType == "IMAGE" {
 		/*
@@ -413,45 +563,38 @@ func (u *customerUsecase) instagramToGbp(ctx context.Context, bi *domain.Busines
 		}
 		if !exist {
 			/*
-				InstagramのメディアをS3にアップロードして公開URLを取得
+				記録を予約してPhotosにアップロードし、投稿したことをDBに保存（PostType=photo）
 			*/
-			sourceURL, err := u.s3Adapter.UploadFromURL(ctx, post.MediaURL)
-			if err != nil {
-				return err
+			record := &domain.GooglePost{
+				InstagramURL:        post.Permalink,
+				MediaID:             post.ID,
+				CustomerID:          bi.ID,
+				Source:              domain.SyncSourceInstagram,
+				BusinessInstagramID: &bi.ID,
+				PostType:            domain.PostTypePhoto,
 			}
-			firstImageSourceURL = sourceURL
-
-			/*
-				公開URLをGoogleBusinessに渡してPhotosにアップロード
-			*/
-			uploadResp, err := u.gbpAdapter.UploadMedia(ctx, config.Env.GoogleBusinessAccountName, bi.BusinessName, sourceURL, "PHOTO")
-			if err != nil {
+			published, err := u.publishGooglePost(ctx, record, func() error {
+				sourceURL, err := u.uploadInstagramGbpPhoto(ctx, token, account, bi, post, func() string { return post.MediaURL }, category, record)
+				if sourceURL != "" {
+					item.firstImageSourceURL = sourceURL
+				}
 				return err
-			}
-
-			/*
-				投稿したことをDBに保存（PostType=photo）
-			*/
-			err = u.googlePostRepo.Create(ctx, &domain.GooglePost{
-				InstagramURL: post.Permalink,
-				MediaID:      post.ID,
-				CustomerID:   bi.ID,
-				Name:         uploadResp.Name,
-				GoogleURL:    uploadResp.GoogleURL,
-				CreateTime:   uploadResp.CreateTime,
-				PostType:     domain.PostTypePhoto,
 			})
 			if err != nil {
 				return err
 			}
-			/*
-				Slackに通知
-			*/
-			_ = u.slack.SuccessBI(ctx, bi, post.Permalink, domain.PostTypePhoto)
+			if published {
+				/*
+					Slack、webhookに通知
+				*/
+				u.notificationUsecase.Notify(ctx, domain.NewBusinessInstagramNotification(bi, post.Permalink, domain.PostTypePhoto))
+				u.webhookUsecase.Publish(ctx, domain.WebhookEventGbpPhotoUploaded, bi.Account(), map[string]string{"google_url": record.GoogleURL, "instagram_url": post.Permalink})
+				u.recordSyncActivity(ctx, bi.Account(), domain.SyncResultPublished, post.ID, "")
+			}
 		}
 
 	} else {
-		for _, child := range post.Children {
+		for i, child := range post.Children {
 			/*
 				動画の場合はスキップ
 			*/
@@ -475,127 +618,200 @@ func (u *customerUsecase) instagramToGbp(ctx context.Context, bi *domain.Busines
 			}
 
 			/*
-				InstagramのメディアをS3にアップロードして公開URLを取得
+				記録を予約してPhotosにアップロードし、投稿したことをDBに保存（PostType=photo）
 			*/
-			childSourceURL, err := u.s3Adapter.UploadFromURL(ctx, child.MediaURL)
-			if err != nil {
-				return err
-			}
-
-			// 最初の画像のURLを保存（Local Post用）
-			if firstImageSourceURL == "" {
-				firstImageSourceURL = childSourceURL
+			record := &domain.GooglePost{
+				InstagramURL:        post.Permalink,
+				MediaID:             child.ID,
+				CustomerID:          bi.ID,
+				Source:              domain.SyncSourceInstagram,
+				BusinessInstagramID: &bi.ID,
+				PostType:            domain.PostTypePhoto,
 			}
-
-			/*
-				公開URLをGoogleBusinessに渡してPhotosにアップロード
-			*/
-			uploadResp, err := u.gbpAdapter.UploadMedia(ctx, config.Env.GoogleBusinessAccountName, bi.BusinessName, childSourceURL, "PHOTO")
-			if err != nil {
+			published, err := u.publishGooglePost(ctx, record, func() error {
+				childSourceURL, err := u.uploadInstagramGbpPhoto(ctx, token, account, bi, post, func() string { return post.Children[i].MediaURL }, category, record)
+				// 最初の画像のURLを保存（Local Post用）
+				if item.firstImageSourceURL == "" {
+					item.firstImageSourceURL = childSourceURL
+				}
 				return err
-			}
-
-			/*
-				投稿したことをDBに保存（PostType=photo）
-			*/
-			err = u.googlePostRepo.Create(ctx, &domain.GooglePost{
-				InstagramURL: post.Permalink,
-				MediaID:      child.ID,
-				CustomerID:   bi.ID,
-				Name:         uploadResp.Name,
-				GoogleURL:    uploadResp.GoogleURL,
-				CreateTime:   uploadResp.CreateTime,
-				PostType:     domain.PostTypePhoto,
 			})
 			if err != nil {
 				return err
 			}
+			if !published {
+				continue
+			}
 
 			/*
-				Slackに通知
+				Slack、webhookに通知
 			*/
-			_ = u.slack.SuccessBI(ctx, bi, post.Permalink, domain.PostTypePhoto)
+			u.notificationUsecase.Notify(ctx, domain.NewBusinessInstagramNotification(bi, post.Permalink, domain.PostTypePhoto))
+			u.webhookUsecase.Publish(ctx, domain.WebhookEventGbpPhotoUploaded, bi.Account(), map[string]string{"google_url": record.GoogleURL, "instagram_url": post.Permalink})
+			u.recordSyncActivity(ctx, bi.Account(), domain.SyncResultPublished, post.ID, "")
 		}
 	}
 
+	return nil
+}
+
+// uploadInstagramGbpPhoto はInstagramの画像をS3経由でGBPのPhotosにアップロードし、S3の公開URLを返す。
+// mediaURL はメディアURLの再取得後に読み直すため、post から画像のURLを返す関数で受け取る。
+func (u *customerUsecase) uploadInstagramGbpPhoto(ctx context.Context, token string, account *domain.GoogleAccount, bi *domain.BusinessInstagram, post *domain.InstagramPost, mediaURL func() string, category domain.GbpMediaCategory, record *domain.GooglePost) (string, error) {
 	/*
-		captionがある場合はLocal Postsに投稿
+		InstagramのメディアをS3にアップロードして公開URLを取得
 	*/
-	if post.Caption != "" {
-		localPostExist, err := u.googlePostRepo.Exists(ctx, repository.GooglePostFilter{
-			MediaID:    &post.ID,
-			CustomerID: &bi.ID,
-			PostType:   util.Pointer(domain.PostTypePost),
-		})
-		if err != nil {
-			return err
-		}
-		if !localPostExist {
-			// firstImageSourceURLがない場合（すべての画像が既にアップロード済みか、動画のみの場合）は最初の画像をS3にアップロード
-			if firstImageSourceURL == "" {
-				// 最初の画像を探す
-				var firstImageURL string
-				if len(post.Children) == 0 {
-					if post.MediaType == "IMAGE" {
-						firstImageURL = post.MediaURL
-					}
-				} else {
-					for _, child := range post.Children {
-						if child.MediaType == "IMAGE" {
-							firstImageURL = child.MediaURL
-							break
-						}
-					}
-				}
+	var sourceURL string
+	err := u.retryOnExpiredMedia(ctx, token, post, func() error {
+		var err error
+		sourceURL, err = u.s3Adapter.UploadFromURL(ctx, mediaURL())
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
 
-				// 画像がない場合（動画のみの投稿）はLocal Postをスキップ
-				if firstImageURL == "" {
-					return nil
-				}
+	/*
+		公開URLをGoogleBusinessに渡してPhotosにアップロード
+	*/
+	return sourceURL, u.uploadGbpPhoto(ctx, account, bi.BusinessName, sourceURL, "PHOTO", category, record)
+}
 
-				firstImageSourceURL, err = u.s3Adapter.UploadFromURL(ctx, firstImageURL)
-				if err != nil {
-					return err
-				}
-			}
+// instagramToGbpLocalPost は投稿のキャプションをGBPのLocal Postに投稿する。
+func (u *customerUsecase) instagramToGbpLocalPost(ctx context.Context, token string, account *domain.GoogleAccount, bi *domain.BusinessInstagram, item *instagramItem) error {
+	post := &item.post
+	firstImageSourceURL := item.firstImageSourceURL
 
-			// captionが1500文字を超える場合は切り詰める
-			summary := post.Caption
-			if len(summary) > 1500 {
-				summary = summary[:1500]
-			}
+	// 画像がない場合（動画のみの投稿）はLocal Postをスキップ
+	if firstImageSourceURL == "" && instagramFirstImageURL(*post) == "" {
+		return nil
+	}
 
-			localPostResp, err := u.gbpAdapter.CreateLocalPost(ctx, config.Env.GoogleBusinessAccountName, bi.BusinessName, summary, firstImageSourceURL, "")
-			if err != nil {
-				return err
-			}
+	/*
+		記録を予約してLocal Postを投稿し、投稿したことをDBに保存（PostType=post）
+	*/
+	record := &domain.GooglePost{
+		InstagramURL:        post.Permalink,
+		MediaID:             post.ID,
+		CustomerID:          bi.ID,
+		Source:              domain.SyncSourceInstagram,
+		BusinessInstagramID: &bi.ID,
+		PostType:            domain.PostTypePost,
+	}
+	published, err := u.publishGooglePost(ctx, record, func() error {
+		return u.createInstagramGbpLocalPost(ctx, token, account, bi, post, firstImageSourceURL, record)
+	})
+	if err != nil || !published {
+		return err
+	}
 
-			/*
-				Local Post投稿をDBに保存（PostType=post）
-			*/
-			err = u.googlePostRepo.Create(ctx, &domain.GooglePost{
-				InstagramURL: post.Permalink,
-				MediaID:      post.ID,
-				CustomerID:   bi.ID,
-				Name:         localPostResp.Name,
-				GoogleURL:    localPostResp.SearchURL,
-				CreateTime:   localPostResp.CreateTime,
-				PostType:     domain.PostTypePost,
-			})
-			if err != nil {
-				return err
-			}
+	/*
+		Slack、webhookに通知
+	*/
+	u.notificationUsecase.Notify(ctx, domain.NewBusinessInstagramNotification(bi, post.Permalink, domain.PostTypePost))
+	u.webhookUsecase.Publish(ctx, domain.WebhookEventGbpPostPublished, bi.Account(), map[string]string{"google_url": record.GoogleURL, "instagram_url": post.Permalink})
+	u.recordSyncActivity(ctx, bi.Account(), domain.SyncResultPublished, post.ID, "")
 
-			/*
-				Slackに通知
-			*/
-			_ = u.slack.SuccessBI(ctx, bi, post.Permalink, domain.PostTypePost)
+	return nil
+}
+
+// createInstagramGbpLocalPost は投稿のキャプションでLocal Postを作成し、投稿結果を record に設定する。
+// sourceURL はPhotosにアップロードした画像のURL。空の場合は最初の画像をS3にアップロードして使う。
+func (u *customerUsecase) createInstagramGbpLocalPost(ctx context.Context, token string, account *domain.GoogleAccount, bi *domain.BusinessInstagram, post *domain.InstagramPost, sourceURL string, record *domain.GooglePost) error {
+	// sourceURLがない場合（すべての画像が既にアップロード済みの場合）は最初の画像をS3にアップロード
+	if sourceURL == "" {
+		// URL再取得後の投稿から読み直す
+		err := u.retryOnExpiredMedia(ctx, token, post, func() error {
+			var err error
+			sourceURL, err = u.s3Adapter.UploadFromURL(ctx, instagramFirstImageURL(*post))
+			return err
+		})
+		if err != nil {
+			return err
 		}
 	}
 
+	localPost := newInstagramGbpLocalPost(bi, *post, sourceURL)
+	localPostResp, err := u.gbpAdapter.CreateLocalPost(ctx, account, bi.BusinessName, localPost)
+	if err != nil {
+		u.recordGbpRejection(ctx, err, bi.ID, post.ID, bi.BusinessName, localPost.Summary)
+		return sentError(err)
+	}
+	u.clearGbpRejection(ctx, bi.ID, post.ID)
+	record.Name = localPostResp.Name
+	record.GoogleURL = localPostResp.SearchURL
+	record.CreateTime = localPostResp.CreateTime
 	return nil
 }
 
+// instagramFirstImageURL は投稿の最初の画像のURL。画像がない場合（動画のみの投稿）は空文字。
+func instagramFirstImageURL(post domain.InstagramPost) string {
+	if len(post.Children) == 0 {
+		if post.MediaType == "IMAGE" {
+			return post.MediaURL
+		}
+		return ""
+	}
+	for _, child := range post.Children {
+		if child.MediaType == "IMAGE" {
+			return child.MediaURL
+		}
+	}
+	return ""
+}
+
+// newInstagramGbpLocalPost はInstagramの投稿からLocal Postの内容を作る。
+func newInstagramGbpLocalPost(bi *domain.BusinessInstagram, post domain.InstagramPost, mediaURL string) domain.GbpLocalPost {
+	/*
+		キャプションのマーカー（【イベント】【特典】）からEVENT/OFFER投稿を判定
+	*/
+	topic, summary := domain.ParseGbpTopicFromCaption(post.Caption)
+
+	// Instagramのキャプションはアカウントに除去ルールが設定されている場合のみ整形する
+	if bi.SanitizeConfig != nil {
+		summary = bi.SanitizeConfig.Sanitize(summary)
+	}
+	summary = domain.TruncateGbpSummary(summary, domain.GbpSummaryMaxLength)
+
+	localPost := domain.GbpLocalPost{
+		Summary:  summary,
+		MediaURL: mediaURL,
+	}
+	topic.Apply(&localPost)
+	localPost.SetCallToAction(bi.CallToActionType, bi.CallToActionURL)
+	return localPost
+}
+
+// wordpressGbpPipeline はWordPressの記事をGBPのPhotosとLocal Postに連携する。
+// abortOnError が false の場合は失敗を通知して、次の記事の連携を続ける。
+func (u *customerUsecase) wordpressGbpPipeline(abortOnError bool) *pipeline.Pipeline[external.WordpressGbpPost] {
+	return &pipeline.Pipeline[external.WordpressGbpPost]{
+		Flow:         "wordpress => google business profile",
+		AbortOnError: abortOnError,
+		Report:       u.reportSyncFailed,
+	}
+}
+
+func (u *customerUsecase) wordpressGbpJob(wg *domain.WordpressGbp) pipeline.Job[external.WordpressGbpPost] {
+	return pipeline.Job[external.WordpressGbpPost]{
+		Accounts: []domain.Account{wg.Account()},
+		Reconcile: func(ctx context.Context) ([]pipeline.Failure, error) {
+			return u.reconcileGooglePosts(ctx, wg.Account(), 300000+wg.ID)
+		},
+		Open: func(ctx context.Context) (pipeline.Source[external.WordpressGbpPost], []pipeline.Destination[external.WordpressGbpPost], error) {
+			account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, wg.BusinessName)
+			if err != nil {
+				return nil, nil, err
+			}
+			return &wordpressGbpSource{u: u, wordpressDomain: wg.WordpressDomain},
+				[]pipeline.Destination[external.WordpressGbpPost]{
+					&wordpressGbpPhotoDestination{u: u, account: account, wg: wg},
+					&wordpressGbpLocalPostDestination{u: u, account: account, wg: wg},
+				}, nil
+		},
+	}
+}
+
 func (u *customerUsecase) SyncAllWordpressGbp(ctx context.Context) error {
 	wgList, err := u.wordpressGbpRepo.FindAll(ctx, repository.WordpressGbpFilter{
 		Status: util.Pointer(1),
@@ -604,22 +820,11 @@ func (u *customerUsecase) SyncAllWordpressGbp(ctx context.Context) error {
 		return err
 	}
 
-	backGroundCtx := context.Background()
+	jobs := make([]pipeline.Job[external.WordpressGbpPost], 0, len(wgList))
 	for _, wg := range wgList {
-		posts, err := u.wordpressAdapter.GetGbpPosts(backGroundCtx, wg.WordpressDomain)
-		if err != nil {
-			_ = u.slack.Error(ctx, "wordpress => google business profile", err, wg.ID, wg.Name)
-			continue
-		}
-
-		for _, post := range posts {
-			if err := u.wordpressToGbp(backGroundCtx, wg, post); err != nil {
-				_ = u.slack.Error(ctx, "wordpress => google business profile", err, wg.ID, wg.Name)
-				continue
-			}
-		}
+		jobs = append(jobs, u.wordpressGbpJob(wg))
 	}
-	return nil
+	return u.wordpressGbpPipeline(false).Run(context.Background(), jobs...)
 }
 
 func (u *customerUsecase) SyncOneWordpressGbp(ctx context.Context, id int) error {
@@ -630,34 +835,15 @@ func (u *customerUsecase) SyncOneWordpressGbp(ctx context.Context, id int) error
 		return err
 	}
 
-	backGroundCtx := context.Background()
-	posts, err := u.wordpressAdapter.GetGbpPosts(backGroundCtx, wg.WordpressDomain)
-	if err != nil {
-		return err
-	}
-
-	for _, post := range posts {
-		if err := u.wordpressToGbp(backGroundCtx, wg, post); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return u.wordpressGbpPipeline(true).Run(context.Background(), u.wordpressGbpJob(wg))
 }
 
-func (u *customerUsecase) wordpressToGbp(ctx context.Context, wg *domain.WordpressGbp, post external.WordpressGbpPost) error {
-	if len(post.MediaURLs) == 0 {
-		return nil
-	}
-
-	// 連携開始日前のデータは連携しない
-	publishedAt, _ := time.Parse(time.RFC3339, post.PublishedAt)
-	if publishedAt.Before(wg.StartDate) {
-		return nil
-	}
-
+// wordpressToGbpPhotos は記事のメディアをGBPのPhotosにアップロードする。PDFとサイズ上限を超えるメディアはアップロードしない。
+func (u *customerUsecase) wordpressToGbpPhotos(ctx context.Context, account *domain.GoogleAccount, wg *domain.WordpressGbp, post external.WordpressGbpPost) error {
 	customerID := 300000 + wg.ID
 
+	category := wordpressGbpMediaCategory(wg, post)
+
 	// 各media_urlに対してPhotosアップロード
 	for i, mediaURL := range post.MediaURLs {
 		// PDFは連携対象外
@@ -682,102 +868,525 @@ func (u *customerUsecase) wordpressToGbp(ctx context.Context, wg *domain.Wordpre
 		// GBPはメディア取得サイズが25MBを超えると拒否するため、超過分はスキップ
 		if mediaExceedsGbpLimit(ctx, mediaURL) {
 			slog.Warn("メディアがGBPのサイズ上限を超えているためスキップ", "media_url", mediaURL)
+			u.recordSyncActivity(ctx, wg.Account(), domain.SyncResultSkipped, mediaID, "メディアがGBPのサイズ上限を超えています: "+mediaURL)
 			continue
 		}
 
-		// URLの拡張子でmediaFormatを判定
-		mediaFormat := "PHOTO"
-		ext := strings.ToLower(filepath.Ext(mediaURL))
-		if ext == ".mp4" || ext == ".mov" || ext == ".avi" || ext == ".wmv" || ext == ".webm" {
-			mediaFormat = "VIDEO"
-		}
+		mediaFormat := wordpressGbpMediaFormat(mediaURL)
 
-		uploadResp, err := u.gbpAdapter.UploadMedia(ctx, config.Env.GoogleBusinessAccountName, wg.BusinessName, mediaURL, mediaFormat)
+		record := &domain.GooglePost{
+			MediaID:        mediaID,
+			CustomerID:     customerID,
+			Source:         domain.SyncSourceWordpress,
+			WordpressGbpID: &wg.ID,
+			PostType:       domain.PostTypePhoto,
+		}
+		published, err := u.publishGooglePost(ctx, record, func() error {
+			return u.uploadGbpPhoto(ctx, account, wg.BusinessName, mediaURL, mediaFormat, category, record)
+		})
 		if err != nil {
 			// HEADでサイズが取得できずにアップロードした場合のフォールバック。
 			// GBPがサイズ超過で拒否した場合はエラー通知せずスキップする。
 			if isGbpMediaTooLargeErr(err) {
 				slog.Warn("GBPがメディアサイズ超過で拒否したためスキップ", "media_url", mediaURL)
+				u.recordSyncActivity(ctx, wg.Account(), domain.SyncResultSkipped, mediaID, "GBPがメディアサイズ超過で拒否しました: "+mediaURL)
 				continue
 			}
 			return err
 		}
-
-		err = u.googlePostRepo.Create(ctx, &domain.GooglePost{
-			MediaID:    mediaID,
-			CustomerID: customerID,
-			Name:       uploadResp.Name,
-			GoogleURL:  uploadResp.GoogleURL,
-			CreateTime: uploadResp.CreateTime,
-			PostType:   domain.PostTypePhoto,
-		})
-		if err != nil {
-			return err
+		if !published {
+			continue
 		}
 
-		_ = u.slack.SuccessWG(ctx, wg, domain.PostTypePhoto, mediaURL, post.PostURL)
+		u.notificationUsecase.Notify(ctx, domain.NewWordpressGbpNotification(wg, domain.PostTypePhoto, mediaURL, post.PostURL))
+		u.webhookUsecase.Publish(ctx, domain.WebhookEventGbpPhotoUploaded, wg.Account(), map[string]string{"google_url": record.GoogleURL, "media_url": mediaURL, "wordpress_url": post.PostURL})
+		u.recordSyncActivity(ctx, wg.Account(), domain.SyncResultPublished, mediaID, "")
 	}
 
-	// contentが空でない場合はLocal Post作成
-	if post.Content != "" {
-		mediaID := fmt.Sprintf("%d", post.PostID)
+	return nil
+}
 
-		localPostExist, err := u.googlePostRepo.Exists(ctx, repository.GooglePostFilter{
-			MediaID:    &mediaID,
-			CustomerID: &customerID,
-			PostType:   util.Pointer(domain.PostTypePost),
-		})
-		if err != nil {
-			return err
+// wordpressGbpMediaCategory は写真のカテゴリ。投稿のカスタムフィールド > 本文のキーワード > アカウント設定 の順で決定する。
+func wordpressGbpMediaCategory(wg *domain.WordpressGbp, post external.WordpressGbpPost) domain.GbpMediaCategory {
+	category := domain.ResolveGbpMediaCategory(wg.MediaCategory, wg.MediaCategoryRules, post.Content)
+	if c := domain.GbpMediaCategory(strings.ToUpper(strings.TrimSpace(post.CustomFields[domain.GbpFieldMediaCategory]))); c != domain.GbpMediaCategoryNone && c.Valid() {
+		category = c
+	}
+	return category
+}
+
+// wordpressGbpMediaFormat はURLの拡張子で判定したmediaFormat（PHOTO・VIDEO）。
+func wordpressGbpMediaFormat(mediaURL string) string {
+	ext := strings.ToLower(filepath.Ext(mediaURL))
+	if ext == ".mp4" || ext == ".mov" || ext == ".avi" || ext == ".wmv" || ext == ".webm" {
+		return "VIDEO"
+	}
+	return "PHOTO"
+}
+
+// wordpressToGbpLocalPost は記事の本文をGBPのLocal Postに投稿する。
+func (u *customerUsecase) wordpressToGbpLocalPost(ctx context.Context, account *domain.GoogleAccount, wg *domain.WordpressGbp, post external.WordpressGbpPost) error {
+	customerID := 300000 + wg.ID
+	mediaID := fmt.Sprintf("%d", post.PostID)
+
+	record := &domain.GooglePost{
+		MediaID:        mediaID,
+		CustomerID:     customerID,
+		Source:         domain.SyncSourceWordpress,
+		WordpressGbpID: &wg.ID,
+		PostType:       domain.PostTypePost,
+	}
+	published, err := u.publishGooglePost(ctx, record, func() error {
+		return u.createWordpressGbpLocalPost(ctx, account, wg, post, record)
+	})
+	if err != nil || !published {
+		return err
+	}
+	u.notificationUsecase.Notify(ctx, domain.NewWordpressGbpNotification(wg, domain.PostTypePost, record.GoogleURL, post.PostURL))
+	u.webhookUsecase.Publish(ctx, domain.WebhookEventGbpPostPublished, wg.Account(), map[string]string{"google_url": record.GoogleURL, "wordpress_url": post.PostURL})
+	u.recordSyncActivity(ctx, wg.Account(), domain.SyncResultPublished, mediaID, "")
+
+	return nil
+}
+
+// createWordpressGbpLocalPost は記事の本文でLocal Postを作成し、投稿結果を record に設定する。
+func (u *customerUsecase) createWordpressGbpLocalPost(ctx context.Context, account *domain.GoogleAccount, wg *domain.WordpressGbp, post external.WordpressGbpPost, record *domain.GooglePost) error {
+	localPost := newWordpressGbpLocalPost(wg, post)
+	localPostResp, err := u.gbpAdapter.CreateLocalPost(ctx, account, wg.BusinessName, localPost)
+	if err != nil {
+		u.recordGbpRejection(ctx, err, record.CustomerID, record.MediaID, wg.BusinessName, localPost.Summary)
+		return sentError(err)
+	}
+	u.clearGbpRejection(ctx, record.CustomerID, record.MediaID)
+	record.Name = localPostResp.Name
+	record.GoogleURL = localPostResp.SearchURL
+	record.CreateTime = localPostResp.CreateTime
+	return nil
+}
+
+// newWordpressGbpLocalPost はWordPressの記事からLocal Postの内容を作る。
+func newWordpressGbpLocalPost(wg *domain.WordpressGbp, post external.WordpressGbpPost) domain.GbpLocalPost {
+	/*
+		カスタムフィールドでEVENT/OFFERが指定されていればそれを優先し、無ければ本文のマーカーで判定
+	*/
+	content := post.Content
+	topic, ok := domain.ParseGbpTopicFromFields(post.CustomFields)
+	if !ok {
+		topic, content = domain.ParseGbpTopicFromCaption(content)
+	}
+
+	sanitizeConfig := domain.DefaultGbpSanitizeConfig()
+	if wg.SanitizeConfig != nil {
+		sanitizeConfig = *wg.SanitizeConfig
+	}
+	summary := domain.TruncateGbpSummary(sanitizeConfig.Sanitize(content), domain.GbpSummaryMaxLength)
+
+	// Local Postには動画を添付できないため画像のみ抽出（無ければmedia無しで投稿）
+	var sourceURL string
+	for _, m := range post.MediaURLs {
+		ext := strings.ToLower(filepath.Ext(m))
+		if ext != ".mp4" && ext != ".mov" && ext != ".avi" && ext != ".wmv" && ext != ".webm" {
+			sourceURL = m
+			break
 		}
-		if !localPostExist {
-			summary := sanitizeForGbp(post.Content)
-			if len(summary) > 1500 {
-				summary = summary[:1500]
-			}
+	}
 
-			// Local Postには動画を添付できないため画像のみ抽出（無ければmedia無しで投稿）
-			var sourceURL string
-			for _, m := range post.MediaURLs {
-				ext := strings.ToLower(filepath.Ext(m))
-				if ext != ".mp4" && ext != ".mov" && ext != ".avi" && ext != ".wmv" && ext != ".webm" {
-					sourceURL = m
-					break
-				}
-			}
+	localPost := domain.GbpLocalPost{
+		Summary:  summary,
+		MediaURL: sourceURL,
+	}
+	topic.Apply(&localPost)
+
+	/*
+		ボタンは投稿のカスタムフィールド > アカウント設定 > 「詳細」+投稿URL の順で決定。NONE の場合はボタンを付けない
+	*/
+	ctaType, ctaURL := wg.CallToActionType, wg.CallToActionURL
+	if ctaType == domain.CallToActionUnset {
+		ctaType = domain.CallToActionLearnMore
+	}
+	if t := domain.CallToActionType(post.CustomFields[domain.GbpFieldCTAType]); t != domain.CallToActionUnset && t.Valid() {
+		ctaType, ctaURL = t, post.CustomFields[domain.GbpFieldCTAURL]
+	}
+	if ctaURL == "" {
+		ctaURL = post.PostURL
+	}
+	localPost.SetCallToAction(ctaType, ctaURL)
+	return localPost
+}
+
+// facebookInstagramPipeline はInstagramの投稿をFacebookページに連携する。
+// abortOnError が false の場合は失敗を通知して、次の連携を続ける。
+// 失敗した連携はそのInstagramの以降の投稿を連携しない（投稿の順序を崩さないため）。
+func (u *customerUsecase) facebookInstagramPipeline(abortOnError bool) *pipeline.Pipeline[*instagramItem] {
+	return &pipeline.Pipeline[*instagramItem]{
+		Flow:                  "instagram => facebook",
+		AbortOnError:          abortOnError,
+		StopFailedDestination: true,
+		Report:                u.reportSyncFailed,
+		Locks:                 &u.customerLocks,
+	}
+}
 
-			localPostResp, err := u.gbpAdapter.CreateLocalPost(ctx,
-				config.Env.GoogleBusinessAccountName,
-				wg.BusinessName,
-				summary,
-				sourceURL,
-				post.PostURL,
-			)
+// facebookInstagramJob は1つの連携のInstagramの投稿をFacebookページに連携する。all が false の場合は直近25件のみを対象にする。
+// facebook_posts は投稿できた後にだけ保存し、投稿前に予約しないため、Reconcile で片付ける記録はない。
+func (u *customerUsecase) facebookInstagramJob(fi *domain.FacebookInstagram, all bool) pipeline.Job[*instagramItem] {
+	return pipeline.Job[*instagramItem]{
+		// 定期実行と手動実行が重なっても二重投稿しないよう、アカウントごとにロックする
+		LockKey:  fmt.Sprintf("facebook_instagram:%d", fi.ID),
+		Accounts: []domain.Account{fi.Account()},
+		Open: func(ctx context.Context) (pipeline.Source[*instagramItem], []pipeline.Destination[*instagramItem], error) {
+			/*
+				トークンを取得し、投稿先ページのアクセストークンを取得する
+			*/
+			token, err := u.tokenRepo.First(ctx)
 			if err != nil {
-				return err
+				return nil, nil, err
 			}
-
-			err = u.googlePostRepo.Create(ctx, &domain.GooglePost{
-				MediaID:    mediaID,
-				CustomerID: customerID,
-				Name:       localPostResp.Name,
-				GoogleURL:  localPostResp.SearchURL,
-				CreateTime: localPostResp.CreateTime,
-				PostType:   domain.PostTypePost,
-			})
+			page, err := u.facebookAdapter.GetPage(ctx, token, fi.FacebookPageID)
 			if err != nil {
-				return err
+				return nil, nil, err
 			}
-			_ = u.slack.SuccessWG(ctx, wg, domain.PostTypePost, localPostResp.SearchURL, post.PostURL)
+			return &instagramSource{
+				u:           u,
+				token:       token,
+				instagramID: fi.InstagramID,
+				all:         all,
+			}, []pipeline.Destination[*instagramItem]{&facebookDestination{u: u, token: token, page: page, fi: fi}}, nil
+		},
+	}
+}
+
+func (u *customerUsecase) SyncAllFacebookInstagram(ctx context.Context) error {
+	fiList, err := u.facebookInstagramRepo.FindAll(ctx, repository.FacebookInstagramFilter{
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return err
+	}
+
+	jobs := make([]pipeline.Job[*instagramItem], 0, len(fiList))
+	for _, fi := range fiList {
+		jobs = append(jobs, u.facebookInstagramJob(fi, false))
+	}
+	return u.facebookInstagramPipeline(false).Run(context.Background(), jobs...)
+}
+
+func (u *customerUsecase) SyncOneFacebookInstagram(ctx context.Context, id int) error {
+	fi, err := u.facebookInstagramRepo.Get(ctx, repository.FacebookInstagramFilter{
+		ID:     util.Pointer(id),
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return err
+	}
+	if fi.ID == 0 {
+		return domain.ErrNotFound
+	}
+	return u.facebookInstagramPipeline(true).Run(context.Background(), u.facebookInstagramJob(fi, true))
+}
+
+// needsFacebookPost はFacebookページにまだ投稿していない、連携開始日以降の投稿かどうかを判定する。
+func (u *customerUsecase) needsFacebookPost(ctx context.Context, fi *domain.FacebookInstagram, post domain.InstagramPost) (bool, error) {
+	/*
+		メディアのリンクがない場合はスキップ
+	*/
+	if post.MediaURL == "" && len(post.Children) == 0 {
+		return false, nil
+	}
+
+	/*
+		すでに投稿しているものかどうかをチェック
+	*/
+	exist, err := u.facebookPostRepo.Exists(ctx, repository.FacebookPostFilter{
+		FacebookInstagramID: &fi.ID,
+		MediaID:             &post.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+	if exist {
+		return false, nil
+	}
+
+	/*
+		連携開始日前のデータは連携しない
+	*/
+	return !instagramPostedBefore(post, fi.StartDate), nil
+}
+
+func (u *customerUsecase) instagramToFacebook(ctx context.Context, token string, page *domain.FacebookPage, fi *domain.FacebookInstagram, post domain.InstagramPost) error {
+	/*
+		InstagramのメディアをS3にアップロードして公開URLに差し替える
+	*/
+	var pagePost domain.FacebookPagePost
+	err := u.retryOnExpiredMedia(ctx, token, &post, func() error {
+		var err error
+		pagePost, err = domain.ToFacebookPagePost(post, fi.DeleteHash, func(mediaURL string) (string, error) {
+			return u.s3Adapter.UploadFromURL(ctx, mediaURL)
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	/*
+		Facebookページに投稿
+	*/
+	published, err := u.facebookAdapter.Publish(ctx, page, pagePost)
+	if err != nil {
+		return err
+	}
+
+	/*
+		投稿したことをDBに保存
+	*/
+	err = u.facebookPostRepo.Create(ctx, &domain.FacebookPost{
+		FacebookInstagramID: fi.ID,
+		MediaID:             post.ID,
+		Permalink:           post.Permalink,
+		FacebookPostID:      published.ID,
+		FacebookURL:         published.URL,
+	})
+	if err != nil {
+		return err
+	}
+
+	/*
+		Slack、webhookに通知
+	*/
+	u.notificationUsecase.Notify(ctx, domain.NewFacebookInstagramNotification(fi, published.URL, post.Permalink))
+	u.webhookUsecase.Publish(ctx, domain.WebhookEventFacebookPostPublished, fi.Account(), map[string]string{"facebook_url": published.URL, "instagram_url": post.Permalink})
+	u.recordSyncActivity(ctx, fi.Account(), domain.SyncResultPublished, post.ID, "")
+
+	return nil
+}
+
+// feedPipeline はフィードの記事をWordPressとGBPのLocal Postに連携する。
+// abortOnError が false の場合は失敗を通知して、次のフィードの連携を続ける。
+// 失敗したフィードは以降の記事を連携しない（投稿の順序を崩さないため）。
+func (u *customerUsecase) feedPipeline(abortOnError bool) *pipeline.Pipeline[domain.FeedItem] {
+	return &pipeline.Pipeline[domain.FeedItem]{
+		Flow:                  "feed => wordpress/google business profile",
+		AbortOnError:          abortOnError,
+		StopFailedDestination: true,
+		Report:                u.reportSyncFailed,
+		Locks:                 &u.customerLocks,
+	}
+}
+
+// feedJob は1つのフィードの記事のうち、まだ連携していないものをWordPressとGBPに投稿する。
+// feed_posts は投稿できた後にだけ保存し、投稿前に予約しないため、Reconcile で片付ける記録はない。
+func (u *customerUsecase) feedJob(feed *domain.Feed) pipeline.Job[domain.FeedItem] {
+	return pipeline.Job[domain.FeedItem]{
+		// 定期実行と手動実行が重なっても二重投稿しないよう、フィードごとにロックする
+		LockKey:  fmt.Sprintf("feed:%d", feed.ID),
+		Accounts: []domain.Account{feed.Account()},
+		Open: func(ctx context.Context) (pipeline.Source[domain.FeedItem], []pipeline.Destination[domain.FeedItem], error) {
+			source := &feedSource{u: u, feed: feed, fd: adapter.NewFileDownloader()}
+			var destinations []pipeline.Destination[domain.FeedItem]
+			if feed.HasWordpress() {
+				destinations = append(destinations, &feedWordpressDestination{u: u, feed: feed, fd: source.fd})
+			}
+			if feed.HasGbp() {
+				account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, feed.BusinessName)
+				if err != nil {
+					source.Close()
+					return nil, nil, err
+				}
+				destinations = append(destinations, &feedGbpDestination{u: u, account: account, feed: feed})
+			}
+			return source, destinations, nil
+		},
+	}
+}
+
+func (u *customerUsecase) SyncAllFeed(ctx context.Context) error {
+	feeds, err := u.feedRepo.FindAll(ctx, repository.FeedFilter{
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return err
+	}
+
+	jobs := make([]pipeline.Job[domain.FeedItem], 0, len(feeds))
+	for _, feed := range feeds {
+		jobs = append(jobs, u.feedJob(feed))
+	}
+	return u.feedPipeline(false).Run(context.Background(), jobs...)
+}
+
+func (u *customerUsecase) SyncOneFeed(ctx context.Context, id int) error {
+	feed, err := u.feedRepo.Get(ctx, repository.FeedFilter{
+		ID:     util.Pointer(id),
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		return err
+	}
+	if feed.ID == 0 {
+		return domain.ErrNotFound
+	}
+	return u.feedPipeline(true).Run(context.Background(), u.feedJob(feed))
+}
+
+// feedPosted はフィードの記事を投稿先にすでに投稿しているかどうかを判定する。
+func (u *customerUsecase) feedPosted(ctx context.Context, feed *domain.Feed, destination domain.FeedDestination, guidHash string) (bool, error) {
+	return u.feedPostRepo.Exists(ctx, repository.FeedPostFilter{
+		FeedID:      &feed.ID,
+		Destination: &destination,
+		GUIDHash:    &guidHash,
+	})
+}
+
+func (u *customerUsecase) feedToWordpress(ctx context.Context, feed *domain.Feed, item domain.FeedItem, fd adapter.FileDownloader) error {
+	wi := feed.WordpressInstagram()
+
+	/*
+		1枚目の画像をWordPressにアップロードしてアイキャッチにする
+	*/
+	var featuredMediaID int
+	if len(item.ImageURLs) > 0 {
+		localPath, err := fd.Download(ctx, item.ImageURLs[0])
+		if err != nil {
+			return err
+		}
+		uploadResp, err := u.wordpressAdapter.FileUpload(ctx, external.WordpressFileUploadInput{
+			Path:               localPath,
+			WordpressInstagram: wi,
+		})
+		if err != nil {
+			return err
 		}
+		featuredMediaID = uploadResp.Id
+
+		if err := os.Remove(localPath); err != nil {
+			slog.Warn(err.Error())
+		}
+	}
+
+	/*
+		記事を投稿
+	*/
+	postResp, err := u.wordpressAdapter.PostArticle(ctx, external.WordpressArticleInput{
+		WordpressInstagram: wi,
+		Title:              item.Title,
+		Content:            item.GetWordpressContent(),
+		PostDate:           item.GetPostDate(),
+		FeaturedMediaID:    featuredMediaID,
+	})
+	if err != nil {
+		return err
 	}
 
+	/*
+		投稿したことをDBに保存
+	*/
+	err = u.feedPostRepo.Create(ctx, &domain.FeedPost{
+		FeedID:      feed.ID,
+		Destination: domain.FeedDestinationWordpress,
+		GUID:        item.GUID,
+		Link:        item.Link,
+		URL:         postResp.WordpressURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	/*
+		Slack、webhookに通知
+	*/
+	u.notificationUsecase.Notify(ctx, domain.NewFeedNotification(feed, domain.FeedDestinationWordpress, postResp.WordpressURL, item.Link))
+	u.webhookUsecase.Publish(ctx, domain.WebhookEventWordpressPostPublished, feed.Account(), map[string]string{"wordpress_url": postResp.WordpressURL, "link": item.Link})
+	u.recordSyncActivity(ctx, feed.Account(), domain.SyncResultPublished, item.GUIDHash(), "")
+
+	return nil
+}
+
+func (u *customerUsecase) feedToGbp(ctx context.Context, account *domain.GoogleAccount, feed *domain.Feed, item domain.FeedItem) error {
+	guidHash := item.GUIDHash()
+	localPost := newFeedGbpLocalPost(ctx, feed, item)
+
+	// 拒否理由の記録はフィードの連携として区別できるよう 400000 + id を顧客IDにする
+	customerID := 400000 + feed.ID
+	localPostResp, err := u.gbpAdapter.CreateLocalPost(ctx, account, feed.BusinessName, localPost)
+	if err != nil {
+		u.recordGbpRejection(ctx, err, customerID, guidHash, feed.BusinessName, localPost.Summary)
+		return err
+	}
+	u.clearGbpRejection(ctx, customerID, guidHash)
+
+	/*
+		投稿したことをDBに保存
+	*/
+	err = u.feedPostRepo.Create(ctx, &domain.FeedPost{
+		FeedID:      feed.ID,
+		Destination: domain.FeedDestinationGbp,
+		GUID:        item.GUID,
+		Link:        item.Link,
+		URL:         localPostResp.SearchURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	/*
+		Slack、webhookに通知
+	*/
+	u.notificationUsecase.Notify(ctx, domain.NewFeedNotification(feed, domain.FeedDestinationGbp, localPostResp.SearchURL, item.Link))
+	u.webhookUsecase.Publish(ctx, domain.WebhookEventGbpPostPublished, feed.Account(), map[string]string{"google_url": localPostResp.SearchURL, "link": item.Link})
+	u.recordSyncActivity(ctx, feed.Account(), domain.SyncResultPublished, guidHash, "")
+
 	return nil
 }
 
+// newFeedGbpLocalPost はフィードの記事からLocal Postの内容を作る。
+func newFeedGbpLocalPost(ctx context.Context, feed *domain.Feed, item domain.FeedItem) domain.GbpLocalPost {
+	/*
+		タイトルと本文をテキストにしてLocal Postを作成
+	*/
+	summary := domain.TruncateGbpSummary(domain.DefaultGbpSanitizeConfig().Sanitize(item.GetPlainText()), domain.GbpSummaryMaxLength)
+
+	// GBPはメディア取得サイズが25MBを超えると拒否するため、超過する場合は画像無しで投稿する
+	var mediaURL string
+	if len(item.ImageURLs) > 0 && !mediaExceedsGbpLimit(ctx, item.ImageURLs[0]) {
+		mediaURL = item.ImageURLs[0]
+	}
+
+	localPost := domain.GbpLocalPost{
+		Summary:  summary,
+		MediaURL: mediaURL,
+	}
+
+	/*
+		ボタンはアカウント設定 > 「詳細」+記事のURL の順で決定。NONE の場合はボタンを付けない
+	*/
+	ctaType, ctaURL := feed.CallToActionType, feed.CallToActionURL
+	if ctaType == domain.CallToActionUnset {
+		ctaType = domain.CallToActionLearnMore
+	}
+	if ctaURL == "" {
+		ctaURL = item.Link
+	}
+	localPost.SetCallToAction(ctaType, ctaURL)
+	return localPost
+}
+
 // gbpMaxMediaBytes はGBPがメディア取得時に許容する最大バイト数（25MB）。
 const gbpMaxMediaBytes = 26214400
 
+// uploadGbpPhoto はメディアをGBPのPhotosにアップロードし、結果を record に設定する。
+func (u *customerUsecase) uploadGbpPhoto(ctx context.Context, account *domain.GoogleAccount, businessName, mediaURL, mediaFormat string, category domain.GbpMediaCategory, record *domain.GooglePost) error {
+	uploadResp, err := u.gbpAdapter.UploadMedia(ctx, account, businessName, mediaURL, mediaFormat, category)
+	if err != nil {
+		return sentError(err)
+	}
+	record.Name = uploadResp.Name
+	record.GoogleURL = uploadResp.GoogleURL
+	record.CreateTime = uploadResp.CreateTime
+	return nil
+}
+
 // mediaExceedsGbpLimit はメディアURLのサイズがGBPの取得上限を超えるかをHEADリクエストで判定する。
 // Content-Lengthが取得できない場合やリクエストに失敗した場合は false（=超過とみなさない）を返し、
 // 実際のアップロード時のエラー(isGbpMediaTooLargeErr)でフォールバックする。
@@ -804,29 +1413,66 @@ func isGbpMediaTooLargeErr(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "Media fetch response bytes too large")
 }
 
-// GBPは投稿本文の電話番号・住所・URL・ハッシュタグをポリシー違反として自動拒否するため、送信前に除去する
-var (
-	gbpURLPattern     = regexp.MustCompile(`https?://\S+`)
-	gbpPhonePattern   = regexp.MustCompile(`☎️\s*[\d\-\s]+`)
-	gbpAddressPattern = regexp.MustCompile(`📍[^📍🚃⏰🗓️☎️🚗\n]+`)
-	gbpHashtagPattern = regexp.MustCompile(`#\S+(?:[ \t　]+#\S+)*`)
-	gbpSpacesPattern  = regexp.MustCompile(`[ \t　]{2,}`)
-)
+// recordGbpRejection はGBPがポリシー違反で投稿を拒否した場合に理由を記録する。
+// 記録の失敗で同期のエラーを上書きしないよう、ここでのエラーは無視する。
+func (u *customerUsecase) recordGbpRejection(ctx context.Context, err error, customerID int, mediaID, businessName, summary string) {
+	var rejected *domain.GbpPostRejectedError
+	if !errors.As(err, &rejected) {
+		return
+	}
+	_ = u.gbpPostRejectionRepo.Upsert(ctx, &domain.GbpPostRejection{
+		CustomerID:   customerID,
+		MediaID:      mediaID,
+		BusinessName: businessName,
+		Reason:       rejected.Reason,
+		Field:        rejected.Field,
+		Message:      rejected.Message,
+		Summary:      summary,
+	})
+}
 
-func sanitizeForGbp(content string) string {
-	content = gbpURLPattern.ReplaceAllString(content, "")
-	content = gbpPhonePattern.ReplaceAllString(content, "")
-	content = gbpAddressPattern.ReplaceAllString(content, "")
-	content = gbpHashtagPattern.ReplaceAllString(content, "")
-
-	lines := strings.Split(content, "\n")
-	out := make([]string, 0, len(lines))
-	for _, line := range lines {
-		line = gbpSpacesPattern.ReplaceAllString(line, " ")
-		line = strings.TrimSpace(line)
-		if line != "" {
-			out = append(out, line)
-		}
+// clearGbpRejection は投稿に成功した場合に過去の拒否の記録を削除する。
+func (u *customerUsecase) clearGbpRejection(ctx context.Context, customerID int, mediaID string) {
+	_ = u.gbpPostRejectionRepo.Delete(ctx, repository.GbpPostRejectionFilter{
+		CustomerID: &customerID,
+		MediaID:    &mediaID,
+	})
+}
+
+// reportSyncFailed は連携の失敗を通知し、webhookで送ってダイジェスト用に記録する。
+// postKey は失敗した投稿が分かる場合に指定し、通知から投稿だけをリトライできるようにする。
+func (u *customerUsecase) reportSyncFailed(ctx context.Context, flow string, err error, account domain.Account, postKey string) {
+	n := domain.NewSyncFailedNotification(flow, err, account)
+	n.Actions = domain.NewSyncFailedActions(account, postKey, config.Env.AdminURL)
+	u.notificationUsecase.Notify(ctx, n)
+	u.webhookUsecase.Publish(ctx, domain.WebhookEventSyncFailed, account, map[string]string{
+		"flow":  flow,
+		"error": err.Error(),
+	})
+	u.recordSyncActivity(ctx, account, domain.SyncResultFailed, postKey, fmt.Sprintf("[%s] %s", flow, err.Error()))
+}
+
+// recordSyncActivity は連携の結果をダイジェスト用に記録する。
+// 記録の失敗で同期を止めないよう、ここでのエラーはログに残すだけにする。
+func (u *customerUsecase) recordSyncActivity(ctx context.Context, account domain.Account, result domain.SyncResult, postKey, detail string) {
+	err := u.syncActivityRepo.Create(ctx, domain.NewSyncActivity(account, result, postKey, detail))
+	if err != nil {
+		slog.Warn("連携結果の記録に失敗", "account_type", account.Type, "account_id", account.ID, "error", err.Error())
+	}
+}
+
+// retryOnExpiredMedia はメディアのダウンロード処理を実行し、CDN URLの期限切れで失敗した場合は
+// 投稿を再取得してURLを差し替えたうえで一度だけ再実行する。
+func (u *customerUsecase) retryOnExpiredMedia(ctx context.Context, token string, post *domain.InstagramPost, fn func() error) error {
+	err := fn()
+	if !errors.Is(err, domain.ErrMediaURLExpired) {
+		return err
+	}
+	slog.Info("メディアURLの期限切れのため再取得します", "media_id", post.ID)
+	fresh, err := u.instagramAdapter.GetMedia(ctx, token, post.ID)
+	if err != nil {
+		return err
 	}
-	return strings.Join(out, "\n")
+	post.RefreshMediaURLs(fresh)
+	return fn()
 }
@@ -0,0 +1,1575 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+	"github.com/zuxt268/homing/internal/interface/dto/model"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+)
+
+// syncRecorder は同期で外部に見える結果（投稿・通知・webhook・連携結果の記録）を集める。
+type syncRecorder struct {
+	mu         sync.Mutex
+	published  []string
+	failed     []string
+	events     []domain.WebhookEventType
+	syncStates map[int]string
+}
+
+func (r *syncRecorder) add(list *[]string, format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	*list = append(*list, fmt.Sprintf(format, args...))
+}
+
+type fakeTokenRepo struct {
+	repository.TokenRepository
+	err error
+}
+
+func (f *fakeTokenRepo) First(context.Context) (string, error) {
+	return "token", f.err
+}
+
+type fakeInstagramAdapter struct {
+	adapter.InstagramAdapter
+	posts    map[string][]domain.InstagramPost
+	errs     map[string]error
+	calls    []string
+	callsMux sync.Mutex
+	// usernames は instagram_id ごとのアカウントのユーザー名
+	usernames map[string]string
+}
+
+func (f *fakeInstagramAdapter) getPosts(method, instagramID string) ([]domain.InstagramPost, error) {
+	f.callsMux.Lock()
+	f.calls = append(f.calls, method+":"+instagramID)
+	f.callsMux.Unlock()
+	if err := f.errs[instagramID]; err != nil {
+		return nil, err
+	}
+	// 呼び出し元で並べ替えても他の呼び出しに影響しないようコピーを返す
+	return append([]domain.InstagramPost(nil), f.posts[instagramID]...), nil
+}
+
+func (f *fakeInstagramAdapter) GetPostsAll(_ context.Context, _, instagramID string) ([]domain.InstagramPost, error) {
+	return f.getPosts("all", instagramID)
+}
+
+func (f *fakeInstagramAdapter) GetPosts25(_ context.Context, _, instagramID string) ([]domain.InstagramPost, error) {
+	return f.getPosts("25", instagramID)
+}
+
+func (f *fakeInstagramAdapter) GetMedia(_ context.Context, _, mediaID string) (*domain.InstagramPost, error) {
+	for _, posts := range f.posts {
+		for _, post := range posts {
+			if post.ID == mediaID {
+				return &post, nil
+			}
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (f *fakeInstagramAdapter) GetAccount(_ context.Context, _, instagramID string) (*domain.InstagramAccount, error) {
+	username, ok := f.usernames[instagramID]
+	if !ok {
+		return nil, errors.New("instagram account not found")
+	}
+	return &domain.InstagramAccount{InstagramAccountUserName: username}, nil
+}
+
+type fakePostRepo struct {
+	repository.PostRepository
+	r       *syncRecorder
+	mu      sync.Mutex
+	existed map[string]bool
+	records []*model.Post
+	deleted map[int]bool
+	// confirmErr は投稿済みにする時のエラー（投稿してから記録するまでに止まった場合）
+	confirmErr error
+}
+
+func (f *fakePostRepo) ExistPost(_ context.Context, filter repository.PostFilter) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.existed[fmt.Sprintf("%d:%s", *filter.WordpressInstagramID, *filter.MediaID)], nil
+}
+
+func (f *fakePostRepo) CreatePost(_ context.Context, post *model.Post) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := fmt.Sprintf("%d:%s", *post.WordpressInstagramID, post.MediaID)
+	if f.existed[key] {
+		return domain.ErrDuplicate
+	}
+	f.existed[key] = true
+	post.ID = len(f.records) + 1
+	f.records = append(f.records, post)
+	return nil
+}
+
+func (f *fakePostRepo) ConfirmPost(_ context.Context, id int, wordpressLink string) error {
+	if f.confirmErr != nil {
+		return f.confirmErr
+	}
+	f.mu.Lock()
+	post := f.records[id-1]
+	post.Status = string(domain.SyncRecordPublished)
+	post.WordpressLink = wordpressLink
+	f.mu.Unlock()
+	f.r.add(&f.r.published, "wordpress:%d:%s", *post.WordpressInstagramID, post.MediaID)
+	return nil
+}
+
+// match は記録が条件に一致するか。削除した記録は一致しない
+func (f *fakePostRepo) match(filter repository.PostFilter, post *model.Post) bool {
+	return !f.deleted[post.ID] &&
+		(filter.ID == nil || *filter.ID == post.ID) &&
+		(filter.CustomerID == nil || *filter.CustomerID == post.CustomerID) &&
+		(filter.WordpressInstagramID == nil || post.WordpressInstagramID != nil && *filter.WordpressInstagramID == *post.WordpressInstagramID) &&
+		(filter.WithoutWordpressInstagram == nil || post.WordpressInstagramID == nil) &&
+		(filter.MediaID == nil || *filter.MediaID == post.MediaID) &&
+		!slices.Contains(filter.ExcludeMediaIDs, post.MediaID) &&
+		(filter.Status == nil || *filter.Status == post.Status) &&
+		(filter.CreatedAtTo == nil || post.CreatedAt.Before(*filter.CreatedAtTo))
+}
+
+func (f *fakePostRepo) GetPosts(_ context.Context, filter repository.PostFilter) ([]domain.Post, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var posts []domain.Post
+	for _, post := range f.records {
+		if f.match(filter, post) {
+			posts = append(posts, domain.Post{
+				ID:                   post.ID,
+				MediaID:              post.MediaID,
+				WordpressInstagramID: post.WordpressInstagramID,
+				Status:               domain.SyncRecordStatus(post.Status),
+				IdempotencyKey:       post.IdempotencyKey,
+				InstagramURL:         post.Permalink,
+			})
+		}
+	}
+	return posts, nil
+}
+
+func (f *fakePostRepo) UpdatePostStatus(_ context.Context, filter repository.PostFilter, status domain.SyncRecordStatus) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var updated int64
+	for _, post := range f.records {
+		if f.match(filter, post) {
+			post.Status = string(status)
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func (f *fakePostRepo) DeletePosts(_ context.Context, filter repository.PostFilter) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var deleted int64
+	for _, post := range f.records {
+		if f.match(filter, post) {
+			delete(f.existed, fmt.Sprintf("%d:%s", *post.WordpressInstagramID, post.MediaID))
+			f.deleted[post.ID] = true
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (f *fakePostRepo) FindMediaIDs(_ context.Context, filter repository.PostFilter) ([]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var mediaIDs []string
+	for _, post := range f.records {
+		if f.match(filter, post) {
+			mediaIDs = append(mediaIDs, post.MediaID)
+		}
+	}
+	return mediaIDs, nil
+}
+
+func (f *fakePostRepo) FindCustomerIDs(_ context.Context, filter repository.PostFilter) ([]int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var customerIDs []int
+	for _, post := range f.records {
+		if f.match(filter, post) && !slices.Contains(customerIDs, post.CustomerID) {
+			customerIDs = append(customerIDs, post.CustomerID)
+		}
+	}
+	return customerIDs, nil
+}
+
+func (f *fakePostRepo) CountPosts(ctx context.Context, filter repository.PostFilter) (int64, error) {
+	mediaIDs, err := f.FindMediaIDs(ctx, filter)
+	return int64(len(mediaIDs)), err
+}
+
+func (f *fakePostRepo) ReassignPosts(_ context.Context, filter repository.PostFilter, customerID, wordpressInstagramID int) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var moved int64
+	for _, post := range f.records {
+		if f.match(filter, post) {
+			post.CustomerID = customerID
+			post.WordpressInstagramID = &wordpressInstagramID
+			moved++
+		}
+	}
+	return moved, nil
+}
+
+type fakeWordpressAdapter struct {
+	adapter.WordpressAdapter
+	// failTitle は wordpress_instagram の id ごとに、投稿に失敗する記事のタイトル
+	failTitle map[int]string
+	gbpPosts  map[string][]external.WordpressGbpPost
+	gbpErrs   map[string]error
+	// postErr は記事の投稿に失敗させるエラー
+	postErr error
+	// idempotencyKeys は投稿した記事の冪等キー
+	idempotencyKeys []string
+	mu              sync.Mutex
+	// titles はドメインごとのサイトのタイトル
+	titles    map[string]string
+	verifyErr error
+}
+
+func (f *fakeWordpressAdapter) GetTitle(_ context.Context, wordpressDomain string) (string, error) {
+	title, ok := f.titles[wordpressDomain]
+	if !ok {
+		return "", errors.New("connection refused")
+	}
+	return title, nil
+}
+
+func (f *fakeWordpressAdapter) GetSiteTitle(_ context.Context, wi domain.WordpressInstagram) (string, error) {
+	title, ok := f.titles[wi.WordpressDomain]
+	if !ok {
+		return "", errors.New("site not found")
+	}
+	return title, nil
+}
+
+func (f *fakeWordpressAdapter) VerifySignature(context.Context, domain.WordpressInstagram) error {
+	return f.verifyErr
+}
+
+func (f *fakeWordpressAdapter) FileUpload(context.Context, external.WordpressFileUploadInput) (*external.WordpressFileUploadResponse, error) {
+	return &external.WordpressFileUploadResponse{Id: 1, SourceUrl: "https://example.com/wp-content/uploads/a.jpg"}, nil
+}
+
+func (f *fakeWordpressAdapter) PostArticle(_ context.Context, in external.WordpressArticleInput) (*domain.Post, error) {
+	if title, ok := f.failTitle[in.WordpressInstagram.ID]; ok && title == in.Title {
+		return nil, errors.New("wordpress error")
+	}
+	if f.postErr != nil {
+		return nil, f.postErr
+	}
+	f.mu.Lock()
+	f.idempotencyKeys = append(f.idempotencyKeys, in.IdempotencyKey)
+	f.mu.Unlock()
+	return &domain.Post{WordpressURL: "https://example.com/?p=1"}, nil
+}
+
+func (f *fakeWordpressAdapter) GetGbpPosts(_ context.Context, wordpressDomain string) ([]external.WordpressGbpPost, error) {
+	if err := f.gbpErrs[wordpressDomain]; err != nil {
+		return nil, err
+	}
+	return f.gbpPosts[wordpressDomain], nil
+}
+
+type fakeWordpressInstagramRepo struct {
+	repository.WordpressInstagramRepository
+	r      *syncRecorder
+	wiList []*domain.WordpressInstagram
+}
+
+func (f *fakeWordpressInstagramRepo) FindAll(context.Context, repository.WordpressInstagramFilter) ([]*domain.WordpressInstagram, error) {
+	return f.wiList, nil
+}
+
+func (f *fakeWordpressInstagramRepo) Get(_ context.Context, filter repository.WordpressInstagramFilter) (*domain.WordpressInstagram, error) {
+	for _, wi := range f.wiList {
+		if (filter.ID == nil || *filter.ID == wi.ID) &&
+			(filter.WordpressDomain == nil || *filter.WordpressDomain == wi.WordpressDomain) &&
+			(filter.InstagramID == nil || *filter.InstagramID == wi.InstagramID) {
+			return wi, nil
+		}
+	}
+	return &domain.WordpressInstagram{}, nil
+}
+
+func (f *fakeWordpressInstagramRepo) Create(_ context.Context, wi *domain.WordpressInstagram) error {
+	wi.ID = len(f.wiList) + 1
+	f.wiList = append(f.wiList, wi)
+	return nil
+}
+
+func (f *fakeWordpressInstagramRepo) UpdateSyncState(_ context.Context, id int, _ time.Time, syncErr string) error {
+	f.r.mu.Lock()
+	defer f.r.mu.Unlock()
+	f.r.syncStates[id] = syncErr
+	return nil
+}
+
+type fakeNotificationUsecase struct {
+	NotificationUsecase
+	mu            sync.Mutex
+	notifications []domain.Notification
+}
+
+func (f *fakeNotificationUsecase) Notify(_ context.Context, n domain.Notification) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notifications = append(f.notifications, n)
+}
+
+type fakeWebhookUsecase struct {
+	WebhookUsecase
+	r *syncRecorder
+}
+
+func (f *fakeWebhookUsecase) Publish(_ context.Context, eventType domain.WebhookEventType, _ domain.Account, _ any) {
+	f.r.mu.Lock()
+	defer f.r.mu.Unlock()
+	f.r.events = append(f.r.events, eventType)
+}
+
+type fakeSyncActivityRepo struct {
+	repository.SyncActivityRepository
+	r *syncRecorder
+}
+
+func (f *fakeSyncActivityRepo) Create(_ context.Context, activity *domain.SyncActivity) error {
+	if activity.Result == domain.SyncResultFailed {
+		f.r.add(&f.r.failed, "%s:%d:%s", activity.Account.Type, activity.Account.ID, activity.PostKey)
+	}
+	return nil
+}
+
+type fakeGoogleBusinessRepo struct {
+	repository.GoogleBusinessRepository
+	// businesses が空の場合は、locations/unknown 以外のビジネスをアカウントが紐づいたビジネスとして返す
+	businesses []*domain.GoogleBusinesses
+}
+
+func (f *fakeGoogleBusinessRepo) Get(_ context.Context, filter repository.GoogleBusinessFilter) (*domain.GoogleBusinesses, error) {
+	if f.businesses == nil {
+		if *filter.Name == "locations/unknown" {
+			return &domain.GoogleBusinesses{}, nil
+		}
+		return &domain.GoogleBusinesses{ID: 1, GoogleAccountID: 1, Name: *filter.Name}, nil
+	}
+	for _, business := range f.businesses {
+		if (filter.ID != nil && *filter.ID == business.ID) || (filter.Name != nil && *filter.Name == business.Name) {
+			b := *business
+			return &b, nil
+		}
+	}
+	return &domain.GoogleBusinesses{}, nil
+}
+
+func (f *fakeGoogleBusinessRepo) FindAll(context.Context, repository.GoogleBusinessFilter) ([]*domain.GoogleBusinesses, error) {
+	businesses := make([]*domain.GoogleBusinesses, 0, len(f.businesses))
+	for _, business := range f.businesses {
+		b := *business
+		businesses = append(businesses, &b)
+	}
+	return businesses, nil
+}
+
+func (f *fakeGoogleBusinessRepo) Create(_ context.Context, business *domain.GoogleBusinesses) error {
+	business.ID = len(f.businesses) + 1
+	f.businesses = append(f.businesses, business)
+	return nil
+}
+
+func (f *fakeGoogleBusinessRepo) Update(_ context.Context, business *domain.GoogleBusinesses, filter repository.GoogleBusinessFilter) error {
+	*f.businesses[*filter.ID-1] = *business
+	return nil
+}
+
+type fakeGoogleAccountRepo struct {
+	repository.GoogleAccountRepository
+	accounts []*domain.GoogleAccount
+}
+
+// newFakeGoogleAccountRepo はビジネスに紐づくアカウント（ID: 1）だけがあるリポジトリ
+func newFakeGoogleAccountRepo() *fakeGoogleAccountRepo {
+	return &fakeGoogleAccountRepo{accounts: []*domain.GoogleAccount{{ID: 1, Name: "accounts/a"}}}
+}
+
+func (f *fakeGoogleAccountRepo) Get(_ context.Context, filter repository.GoogleAccountFilter) (*domain.GoogleAccount, error) {
+	for _, account := range f.accounts {
+		if (filter.ID != nil && *filter.ID == account.ID) || (filter.Name != nil && *filter.Name == account.Name) {
+			return account, nil
+		}
+	}
+	return &domain.GoogleAccount{}, nil
+}
+
+func (f *fakeGoogleAccountRepo) FindAll(_ context.Context, filter repository.GoogleAccountFilter) ([]*domain.GoogleAccount, error) {
+	var accounts []*domain.GoogleAccount
+	for _, account := range f.accounts {
+		if filter.GoogleOAuthTokenID == nil || *filter.GoogleOAuthTokenID == account.GoogleOAuthTokenID {
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts, nil
+}
+
+func (f *fakeGoogleAccountRepo) Create(_ context.Context, account *domain.GoogleAccount) error {
+	account.ID = len(f.accounts) + 1
+	f.accounts = append(f.accounts, account)
+	return nil
+}
+
+func (f *fakeGoogleAccountRepo) Update(_ context.Context, account *domain.GoogleAccount, filter repository.GoogleAccountFilter) error {
+	*f.accounts[*filter.ID-1] = *account
+	return nil
+}
+
+type fakeGooglePostRepo struct {
+	repository.GooglePostRepository
+	r       *syncRecorder
+	existed map[string]bool
+	records []*domain.GooglePost
+	deleted map[int]bool
+	// updateErr は投稿済みにする時のエラー（投稿してから記録するまでに止まった場合）
+	updateErr error
+}
+
+func googlePostKey(post *domain.GooglePost) string {
+	return fmt.Sprintf("%s:%d:%s", post.PostType, post.CustomerID, post.MediaID)
+}
+
+func (f *fakeGooglePostRepo) Exists(_ context.Context, filter repository.GooglePostFilter) (bool, error) {
+	return f.existed[fmt.Sprintf("%s:%d:%s", *filter.PostType, *filter.CustomerID, *filter.MediaID)], nil
+}
+
+func (f *fakeGooglePostRepo) Create(_ context.Context, post *domain.GooglePost) error {
+	// 投稿元の連携は customer_id（ビジネスInstagram連携は id、WordPress-GBP連携は 300000 + id）と一致する
+	switch {
+	case post.Source == domain.SyncSourceInstagram && post.BusinessInstagramID != nil && *post.BusinessInstagramID == post.CustomerID:
+	case post.Source == domain.SyncSourceWordpress && post.WordpressGbpID != nil && 300000+*post.WordpressGbpID == post.CustomerID:
+	default:
+		return fmt.Errorf("投稿元の連携が customer_id と一致しません: %+v", post)
+	}
+	key := googlePostKey(post)
+	if f.existed[key] {
+		return domain.ErrDuplicate
+	}
+	f.existed[key] = true
+	record := *post
+	record.ID = len(f.records) + 1
+	record.CreatedAt = time.Now()
+	f.records = append(f.records, &record)
+	post.ID = record.ID
+	return nil
+}
+
+func (f *fakeGooglePostRepo) Update(_ context.Context, post *domain.GooglePost, _ repository.GooglePostFilter) error {
+	if f.updateErr != nil {
+		return f.updateErr
+	}
+	record := f.records[post.ID-1]
+	if record.Status != domain.SyncRecordPublished && post.Status == domain.SyncRecordPublished {
+		f.r.add(&f.r.published, "%s", googlePostKey(post))
+	}
+	createdAt := record.CreatedAt
+	*record = *post
+	record.CreatedAt = createdAt
+	return nil
+}
+
+// match は記録が条件に一致するか。削除した記録は一致しない
+func (f *fakeGooglePostRepo) match(filter repository.GooglePostFilter, post *domain.GooglePost) bool {
+	return !f.deleted[post.ID] &&
+		(filter.ID == nil || *filter.ID == post.ID) &&
+		(filter.CustomerID == nil || *filter.CustomerID == post.CustomerID) &&
+		(filter.CustomerIDs == nil || slices.Contains(filter.CustomerIDs, post.CustomerID)) &&
+		(filter.MediaIDs == nil || slices.Contains(filter.MediaIDs, post.MediaID)) &&
+		(filter.PostType == nil || *filter.PostType == post.PostType) &&
+		(filter.Status == nil || *filter.Status == string(post.Status)) &&
+		(filter.Deleted == nil || *filter.Deleted == (post.DeletedAt != nil)) &&
+		(filter.CreatedAtFrom == nil || !post.CreatedAt.Before(*filter.CreatedAtFrom)) &&
+		(filter.CreatedAtTo == nil || post.CreatedAt.Before(*filter.CreatedAtTo))
+}
+
+func (f *fakeGooglePostRepo) Count(_ context.Context, filter repository.GooglePostFilter) (int64, error) {
+	var count int64
+	for _, record := range f.records {
+		if f.match(filter, record) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeGooglePostRepo) Get(_ context.Context, filter repository.GooglePostFilter) (*domain.GooglePost, error) {
+	for _, record := range f.records {
+		if f.match(filter, record) {
+			post := *record
+			return &post, nil
+		}
+	}
+	return &domain.GooglePost{}, nil
+}
+
+func (f *fakeGooglePostRepo) FindAll(_ context.Context, filter repository.GooglePostFilter) ([]*domain.GooglePost, error) {
+	var posts []*domain.GooglePost
+	for _, record := range f.records {
+		if f.match(filter, record) {
+			post := *record
+			posts = append(posts, &post)
+		}
+	}
+	return posts, nil
+}
+
+func (f *fakeGooglePostRepo) Delete(_ context.Context, filter repository.GooglePostFilter) error {
+	for _, record := range f.records {
+		if f.match(filter, record) {
+			delete(f.existed, googlePostKey(record))
+			f.deleted[record.ID] = true
+		}
+	}
+	return nil
+}
+
+// rejectedError は投稿先が受け付けなかった（4xx）エラー。投稿は作られていない
+type rejectedError string
+
+func (e rejectedError) Error() string { return string(e) }
+
+func (e rejectedError) Is(target error) bool { return target == domain.ErrSyncRejected }
+
+type fakeGbpAdapter struct {
+	adapter.GbpAdapter
+	// failSource は投稿先が受け付けずに失敗させるアップロード元のURLやLocal Postの本文
+	failSource map[string]bool
+	// timeoutSource は投稿できたか分からないエラー（タイムアウト）にするアップロード元のURL
+	timeoutSource map[string]bool
+	// rejectSource はポリシー違反として拒否するLocal Postの本文
+	rejectSource map[string]*domain.GbpPostRejectedError
+	localPosts   []domain.GbpLocalPost
+	// deleted はGBPから削除したメディア・Local Postの名前
+	deleted []string
+	// businessErr はビジネスの取得に失敗させるエラー
+	businessErr error
+	// reviews はビジネスごとの口コミ、replies は口コミごとの返信
+	reviews map[string][]external.GoogleBusinessReview
+	replies map[string]string
+	// metricRanges は指標を取得した "ビジネス:開始日~終了日"
+	metricRanges []string
+	// accounts はトークンIDごとに参照できるアカウント名、businesses はアカウント名ごとに参照できるビジネス
+	accounts      map[int][]string
+	businesses    map[string][]adapter.Business
+	accountsErr   error
+	accountsCalls int
+}
+
+func (f *fakeGbpAdapter) ListAccounts(_ context.Context, tokenID int) ([]*domain.GoogleAccount, error) {
+	f.accountsCalls++
+	if f.accountsErr != nil {
+		return nil, f.accountsErr
+	}
+	var accounts []*domain.GoogleAccount
+	for _, name := range f.accounts[tokenID] {
+		accounts = append(accounts, &domain.GoogleAccount{Name: name, GoogleOAuthTokenID: tokenID})
+	}
+	return accounts, nil
+}
+
+func (f *fakeGbpAdapter) GetAllBusinesses(_ context.Context, account *domain.GoogleAccount) ([]adapter.Business, error) {
+	return f.businesses[account.Name], nil
+}
+
+// FetchDailyMetrics は取得した期間を記録し、期間の初日の指標を返す
+func (f *fakeGbpAdapter) FetchDailyMetrics(_ context.Context, _ *domain.GoogleAccount, businessName string, from, to time.Time) ([]*domain.GoogleBusinessMetric, error) {
+	f.metricRanges = append(f.metricRanges, businessName+":"+from.Format("2006-01-02")+"~"+to.Format("2006-01-02"))
+	return []*domain.GoogleBusinessMetric{{BusinessName: businessName, Metric: domain.MetricCallClicks, Date: from, Value: 1}}, nil
+}
+
+func (f *fakeGbpAdapter) ListReviews(_ context.Context, _ *domain.GoogleAccount, businessName string) ([]external.GoogleBusinessReview, error) {
+	return f.reviews[businessName], nil
+}
+
+func (f *fakeGbpAdapter) UpdateReviewReply(_ context.Context, _ *domain.GoogleAccount, reviewName, comment string) (*external.GoogleBusinessReviewReply, error) {
+	if f.replies == nil {
+		f.replies = map[string]string{}
+	}
+	f.replies[reviewName] = comment
+	return &external.GoogleBusinessReviewReply{Comment: comment, UpdateTime: "2026-03-02T00:00:00Z"}, nil
+}
+
+func (f *fakeGbpAdapter) DeleteReviewReply(_ context.Context, _ *domain.GoogleAccount, reviewName string) error {
+	delete(f.replies, reviewName)
+	return nil
+}
+
+func (f *fakeGbpAdapter) GetBusiness(_ context.Context, _ *domain.GoogleAccount, businessName string) (adapter.Business, error) {
+	if f.businessErr != nil {
+		return adapter.Business{}, f.businessErr
+	}
+	return adapter.Business{Name: businessName, Title: "テスト店舗"}, nil
+}
+
+func (f *fakeGbpAdapter) UploadMedia(_ context.Context, _ *domain.GoogleAccount, _, sourceURL, _ string, _ domain.GbpMediaCategory) (*external.GoogleBusinessMediaUploadResponse, error) {
+	if f.failSource[sourceURL] {
+		return nil, rejectedError("gbp media error")
+	}
+	if f.timeoutSource[sourceURL] {
+		return nil, context.DeadlineExceeded
+	}
+	return &external.GoogleBusinessMediaUploadResponse{Name: "media", GoogleURL: "https://maps.example.com/photo"}, nil
+}
+
+func (f *fakeGbpAdapter) CreateLocalPost(_ context.Context, _ *domain.GoogleAccount, _ string, post domain.GbpLocalPost) (*external.GoogleBusinessLocalPostResponse, error) {
+	if f.failSource[post.Summary] {
+		return nil, rejectedError("gbp local post error")
+	}
+	if rejected, ok := f.rejectSource[post.Summary]; ok {
+		return nil, rejected
+	}
+	f.localPosts = append(f.localPosts, post)
+	return &external.GoogleBusinessLocalPostResponse{Name: "post", SearchURL: "https://maps.example.com/post"}, nil
+}
+
+func (f *fakeGbpAdapter) DeleteMedia(_ context.Context, _ *domain.GoogleAccount, name string) error {
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+func (f *fakeGbpAdapter) DeleteLocalPost(_ context.Context, _ *domain.GoogleAccount, name string) error {
+	f.deleted = append(f.deleted, name)
+	return nil
+}
+
+type fakeS3Adapter struct {
+	adapter.S3Adapter
+	uploads int
+}
+
+func (f *fakeS3Adapter) UploadFromURL(_ context.Context, sourceURL string) (string, error) {
+	f.uploads++
+	return "https://s3.example.com/" + sourceURL, nil
+}
+
+type fakeGbpPostRejectionRepo struct {
+	repository.GbpPostRejectionRepository
+	upserted []*domain.GbpPostRejection
+	// cleared は削除された記録の "customer_id:media_id"
+	cleared []string
+}
+
+func (f *fakeGbpPostRejectionRepo) Upsert(_ context.Context, rejection *domain.GbpPostRejection) error {
+	f.upserted = append(f.upserted, rejection)
+	return nil
+}
+
+func (f *fakeGbpPostRejectionRepo) Delete(_ context.Context, filter repository.GbpPostRejectionFilter) error {
+	f.cleared = append(f.cleared, fmt.Sprintf("%d:%s", *filter.CustomerID, *filter.MediaID))
+	return nil
+}
+
+func newTestCustomerUsecase(r *syncRecorder) *customerUsecase {
+	return &customerUsecase{
+		tokenRepo:            &fakeTokenRepo{},
+		instagramAdapter:     &fakeInstagramAdapter{},
+		notificationUsecase:  &fakeNotificationUsecase{},
+		webhookUsecase:       &fakeWebhookUsecase{r: r},
+		syncActivityRepo:     &fakeSyncActivityRepo{r: r},
+		postRepo:             &fakePostRepo{r: r, existed: map[string]bool{}, deleted: map[int]bool{}},
+		wordpressAdapter:     &fakeWordpressAdapter{},
+		googleBusinessRepo:   &fakeGoogleBusinessRepo{},
+		googleAccountRepo:    newFakeGoogleAccountRepo(),
+		googlePostRepo:       &fakeGooglePostRepo{r: r, existed: map[string]bool{}, deleted: map[int]bool{}},
+		gbpAdapter:           &fakeGbpAdapter{},
+		s3Adapter:            &fakeS3Adapter{},
+		gbpPostRejectionRepo: &fakeGbpPostRejectionRepo{},
+	}
+}
+
+// newMediaServer はメディアのダウンロード回数を数えるサーバー
+func newMediaServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			downloads.Add(1)
+		}
+		_, _ = w.Write([]byte("image"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &downloads
+}
+
+func instagramPost(id, timestamp, mediaURL string) domain.InstagramPost {
+	return domain.InstagramPost{
+		ID:        id,
+		Caption:   id,
+		Timestamp: timestamp,
+		MediaType: "IMAGE",
+		MediaURL:  mediaURL,
+		Permalink: "https://www.instagram.com/p/" + id,
+	}
+}
+
+func TestCustomerUsecase_SyncAllWordpressInstagram(t *testing.T) {
+	server, downloads := newMediaServer(t)
+	r := &syncRecorder{syncStates: map[int]string{}}
+	u := newTestCustomerUsecase(r)
+
+	startDate := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	wi1 := &domain.WordpressInstagram{ID: 1, InstagramID: "ig_a"}
+	wi2 := &domain.WordpressInstagram{ID: 2, InstagramID: "ig_a", StartDate: startDate}
+	wi3 := &domain.WordpressInstagram{ID: 3, InstagramID: "ig_b"}
+	u.wordpressInstagramRepo = &fakeWordpressInstagramRepo{r: r, wiList: []*domain.WordpressInstagram{wi1, wi2, wi3}}
+	u.instagramAdapter = &fakeInstagramAdapter{
+		posts: map[string][]domain.InstagramPost{
+			"ig_a": {
+				instagramPost("m3", "2026-01-03T00:00:00+0000", server.URL+"/m3.jpg"),
+				instagramPost("m1", "2026-01-01T00:00:00+0000", server.URL+"/m1.jpg"),
+				instagramPost("m0", "2026-01-01T00:00:00+0000", ""),
+				instagramPost("m2", "2026-01-02T00:00:00+0000", server.URL+"/m2.jpg"),
+			},
+		},
+		errs: map[string]error{"ig_b": errors.New("instagram error")},
+	}
+	u.wordpressAdapter = &fakeWordpressAdapter{failTitle: map[int]string{1: "m2"}}
+	u.postRepo.(*fakePostRepo).existed["2:m3"] = true
+
+	assert.NoError(t, u.SyncAllWordpressInstagram(context.Background()))
+
+	// wi1 は m2 で失敗したので m3 を連携しない。wi2 は連携開始日前の m1 と連携済みの m3 を連携しない
+	assert.ElementsMatch(t, []string{"wordpress:1:m1", "wordpress:2:m2"}, r.published)
+	assert.ElementsMatch(t, []string{"wordpress_instagram:1:m2", "wordpress_instagram:3:"}, r.failed)
+	assert.Equal(t, map[int]string{1: "wordpress error", 2: "", 3: "instagram error"}, r.syncStates)
+	// 同じInstagramの連携先が複数あっても、メディアのダウンロードは投稿ごとに1回
+	assert.Equal(t, int32(2), downloads.Load())
+	assert.ElementsMatch(t, []string{"all:ig_a", "all:ig_b"}, u.instagramAdapter.(*fakeInstagramAdapter).calls)
+}
+
+func TestCustomerUsecase_RetryWordpressInstagramPost(t *testing.T) {
+	server, downloads := newMediaServer(t)
+	r := &syncRecorder{syncStates: map[int]string{}}
+	u := newTestCustomerUsecase(r)
+
+	wi := &domain.WordpressInstagram{ID: 1, InstagramID: "ig_a"}
+	u.wordpressInstagramRepo = &fakeWordpressInstagramRepoGet{wi: wi}
+	u.instagramAdapter = &fakeInstagramAdapter{posts: map[string][]domain.InstagramPost{
+		"ig_a": {
+			instagramPost("m1", "2026-01-01T00:00:00+0000", server.URL+"/m1.jpg"),
+			instagramPost("m2", "2026-01-02T00:00:00+0000", server.URL+"/m2.jpg"),
+		},
+	}}
+	u.wordpressAdapter = &fakeWordpressAdapter{failTitle: map[int]string{1: "m2"}}
+
+	assert.NoError(t, u.RetryWordpressInstagramPost(context.Background(), 1, "m1"))
+	assert.Equal(t, []string{"wordpress:1:m1"}, r.published)
+
+	// 連携済みの投稿は何もしない
+	assert.NoError(t, u.RetryWordpressInstagramPost(context.Background(), 1, "m1"))
+	assert.Equal(t, int32(1), downloads.Load())
+
+	// 失敗は通知せずに返す。同期結果も保存しない
+	assert.EqualError(t, u.RetryWordpressInstagramPost(context.Background(), 1, "m2"), "wordpress error")
+	assert.Empty(t, r.failed)
+	assert.Empty(t, r.syncStates)
+}
+
+func TestCustomerUsecase_RetryOnExpiredMedia(t *testing.T) {
+	u := newTestCustomerUsecase(&syncRecorder{})
+	fresh := domain.InstagramPost{
+		ID:       "m1",
+		MediaURL: "https://cdn.example.com/new.jpg",
+		Children: []domain.InstagramPostChildren{{ID: "c1", MediaURL: "https://cdn.example.com/c1_new.jpg"}},
+	}
+	u.instagramAdapter = &fakeInstagramAdapter{posts: map[string][]domain.InstagramPost{"ig_a": {fresh}}}
+	newPost := func() *domain.InstagramPost {
+		return &domain.InstagramPost{
+			ID:       "m1",
+			MediaURL: "https://cdn.example.com/old.jpg",
+			Children: []domain.InstagramPostChildren{{ID: "c1", MediaURL: "https://cdn.example.com/c1_old.jpg"}},
+		}
+	}
+
+	t.Run("期限切れの場合はURLを差し替えて一度だけ再実行する", func(t *testing.T) {
+		post := newPost()
+		var urls []string
+		err := u.retryOnExpiredMedia(context.Background(), "token", post, func() error {
+			urls = append(urls, post.MediaURL+" "+post.Children[0].MediaURL)
+			return domain.ErrMediaURLExpired
+		})
+		assert.ErrorIs(t, err, domain.ErrMediaURLExpired)
+		assert.Equal(t, []string{
+			"https://cdn.example.com/old.jpg https://cdn.example.com/c1_old.jpg",
+			"https://cdn.example.com/new.jpg https://cdn.example.com/c1_new.jpg",
+		}, urls)
+	})
+
+	t.Run("期限切れ以外のエラーは再実行しない", func(t *testing.T) {
+		post := newPost()
+		calls := 0
+		err := u.retryOnExpiredMedia(context.Background(), "token", post, func() error {
+			calls++
+			return errors.New("download error")
+		})
+		assert.EqualError(t, err, "download error")
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, "https://cdn.example.com/old.jpg", post.MediaURL)
+	})
+
+	t.Run("投稿を再取得できない場合はそのエラーを返す", func(t *testing.T) {
+		post := newPost()
+		post.ID = "deleted"
+		calls := 0
+		err := u.retryOnExpiredMedia(context.Background(), "token", post, func() error {
+			calls++
+			return domain.ErrMediaURLExpired
+		})
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestCustomerUsecase_WordpressInstagramReservation(t *testing.T) {
+	server, _ := newMediaServer(t)
+	r := &syncRecorder{syncStates: map[int]string{}}
+	u := newTestCustomerUsecase(r)
+
+	wi := &domain.WordpressInstagram{ID: 1, InstagramID: "ig_a"}
+	u.wordpressInstagramRepo = &fakeWordpressInstagramRepo{r: r, wiList: []*domain.WordpressInstagram{wi}}
+	u.instagramAdapter = &fakeInstagramAdapter{posts: map[string][]domain.InstagramPost{
+		"ig_a": {instagramPost("m1", "2026-01-01T00:00:00+0000", server.URL+"/m1.jpg")},
+	}}
+	wordpress := &fakeWordpressAdapter{}
+	u.wordpressAdapter = wordpress
+	postRepo := u.postRepo.(*fakePostRepo)
+
+	// 投稿してから記録するまでに失敗した場合、予約が残るので次の同期でも投稿しない
+	postRepo.confirmErr = errors.New("db error")
+	assert.NoError(t, u.SyncAllWordpressInstagram(context.Background()))
+	postRepo.confirmErr = nil
+	assert.NoError(t, u.SyncAllWordpressInstagram(context.Background()))
+	assert.Len(t, wordpress.idempotencyKeys, 1)
+	assert.Empty(t, r.published)
+	assert.Equal(t, []string{"wordpress_instagram:1:m1"}, r.failed)
+
+	// 止まったままの予約は、同じ冪等キーで投稿し直す（rodut プラグインは投稿済みの記事を返す）
+	postRepo.records[0].CreatedAt = time.Now().Add(-time.Hour)
+	assert.NoError(t, u.SyncAllWordpressInstagram(context.Background()))
+	assert.Equal(t, []string{"wordpress:1:m1"}, r.published)
+	assert.Len(t, wordpress.idempotencyKeys, 2)
+	assert.Equal(t, domain.NewSyncIdempotencyKey(domain.SyncDestinationWordpress, 100001, "m1"), wordpress.idempotencyKeys[0])
+	assert.Equal(t, wordpress.idempotencyKeys[0], wordpress.idempotencyKeys[1])
+	assert.Equal(t, "", r.syncStates[1])
+}
+
+func TestCustomerUsecase_WordpressInstagramReservationV2(t *testing.T) {
+	server, _ := newMediaServer(t)
+	r := &syncRecorder{syncStates: map[int]string{}}
+	u := newTestCustomerUsecase(r)
+
+	wi := &domain.WordpressInstagram{ID: 1, InstagramID: "ig_a", APIMode: domain.WordpressAPIModeV2}
+	u.wordpressInstagramRepo = &fakeWordpressInstagramRepo{r: r, wiList: []*domain.WordpressInstagram{wi}}
+	u.instagramAdapter = &fakeInstagramAdapter{posts: map[string][]domain.InstagramPost{
+		"ig_a": {instagramPost("m1", "2026-01-01T00:00:00+0000", server.URL+"/m1.jpg")},
+	}}
+	wordpress := &fakeWordpressAdapter{}
+	u.wordpressAdapter = wordpress
+	postRepo := u.postRepo.(*fakePostRepo)
+
+	postRepo.confirmErr = errors.New("db error")
+	assert.NoError(t, u.SyncAllWordpressInstagram(context.Background()))
+	postRepo.confirmErr = nil
+	r.failed = nil
+
+	// wp/v2 は投稿できたか確認できないので、投稿し直さずに通知する
+	postRepo.records[0].CreatedAt = time.Now().Add(-time.Hour)
+	assert.NoError(t, u.SyncAllWordpressInstagram(context.Background()))
+	assert.Len(t, wordpress.idempotencyKeys, 1)
+	assert.Empty(t, r.published)
+	assert.Equal(t, []string{"wordpress_instagram:1:m1"}, r.failed)
+	assert.Equal(t, string(domain.SyncRecordUnconfirmed), postRepo.records[0].Status)
+	assert.Contains(t, r.syncStates[1], domain.ErrSyncUnconfirmed.Error())
+
+	// 次の同期では通知しない。リトライすると投稿し直す
+	assert.NoError(t, u.SyncAllWordpressInstagram(context.Background()))
+	assert.Len(t, r.failed, 1)
+	u.wordpressInstagramRepo = &fakeWordpressInstagramRepoGet{wi: wi}
+	assert.NoError(t, u.RetryWordpressInstagramPost(context.Background(), 1, "m1"))
+	assert.Equal(t, []string{"wordpress:1:m1"}, r.published)
+	assert.Len(t, wordpress.idempotencyKeys, 2)
+}
+
+func TestCustomerUsecase_WordpressInstagramPostErrorV2(t *testing.T) {
+	server, _ := newMediaServer(t)
+	r := &syncRecorder{syncStates: map[int]string{}}
+	u := newTestCustomerUsecase(r)
+
+	wi := &domain.WordpressInstagram{ID: 1, InstagramID: "ig_a", APIMode: domain.WordpressAPIModeV2}
+	u.wordpressInstagramRepo = &fakeWordpressInstagramRepo{r: r, wiList: []*domain.WordpressInstagram{wi}}
+	u.instagramAdapter = &fakeInstagramAdapter{posts: map[string][]domain.InstagramPost{
+		"ig_a": {instagramPost("m1", "2026-01-01T00:00:00+0000", server.URL+"/m1.jpg")},
+	}}
+	wordpress := &fakeWordpressAdapter{}
+	u.wordpressAdapter = wordpress
+	postRepo := u.postRepo.(*fakePostRepo)
+
+	// WordPressが受け付けなかった場合は予約を取り消し、次の同期で投稿し直す
+	wordpress.postErr = rejectedError("ステータス: 400: rest_invalid_param")
+	assert.NoError(t, u.SyncAllWordpressInstagram(context.Background()))
+	assert.True(t, postRepo.deleted[1])
+	assert.Equal(t, "ステータス: 400: rest_invalid_param", r.syncStates[1])
+
+	// タイムアウトなど投稿できたか分からない場合は unconfirmed にして通知し、投稿し直さない
+	wordpress.postErr = context.DeadlineExceeded
+	r.failed = nil
+	assert.NoError(t, u.SyncAllWordpressInstagram(context.Background()))
+	assert.Equal(t, string(domain.SyncRecordUnconfirmed), postRepo.records[1].Status)
+	assert.Equal(t, []string{"wordpress_instagram:1:m1"}, r.failed)
+	assert.Contains(t, r.syncStates[1], domain.ErrSyncUnconfirmed.Error())
+
+	wordpress.postErr = nil
+	assert.NoError(t, u.SyncAllWordpressInstagram(context.Background()))
+	assert.Empty(t, r.published)
+	assert.Empty(t, wordpress.idempotencyKeys)
+}
+
+type fakeWordpressInstagramRepoGet struct {
+	repository.WordpressInstagramRepository
+	wi *domain.WordpressInstagram
+}
+
+func (f *fakeWordpressInstagramRepoGet) Get(context.Context, repository.WordpressInstagramFilter) (*domain.WordpressInstagram, error) {
+	return f.wi, nil
+}
+
+type fakeBusinessInstagramRepo struct {
+	repository.BusinessInstagramRepository
+	biList []*domain.BusinessInstagram
+}
+
+func (f *fakeBusinessInstagramRepo) FindAll(context.Context, repository.BusinessInstagramFilter) ([]*domain.BusinessInstagram, error) {
+	return f.biList, nil
+}
+
+func (f *fakeBusinessInstagramRepo) Get(_ context.Context, filter repository.BusinessInstagramFilter) (*domain.BusinessInstagram, error) {
+	for _, bi := range f.biList {
+		if bi.ID == *filter.ID {
+			return bi, nil
+		}
+	}
+	return &domain.BusinessInstagram{}, nil
+}
+
+func newBusinessInstagramTest(t *testing.T) (*customerUsecase, *syncRecorder) {
+	r := &syncRecorder{syncStates: map[int]string{}}
+	u := newTestCustomerUsecase(r)
+	u.businessInstagramRepo = &fakeBusinessInstagramRepo{biList: []*domain.BusinessInstagram{
+		{ID: 1, InstagramID: "ig_a", BusinessName: "locations/1"},
+		{ID: 2, InstagramID: "ig_b", BusinessName: "locations/unknown"},
+		{ID: 3, InstagramID: "ig_c", BusinessName: "locations/3"},
+	}}
+	carousel := instagramPost("m2", "2026-01-02T00:00:00+0000", "https://cdn.example.com/m2.jpg")
+	carousel.MediaType = "CAROUSEL_ALBUM"
+	carousel.Children = []domain.InstagramPostChildren{
+		{ID: "m2_1", MediaType: "VIDEO", MediaURL: "https://cdn.example.com/m2_1.mp4"},
+		{ID: "m2_2", MediaType: "IMAGE", MediaURL: "https://cdn.example.com/m2_2.jpg"},
+	}
+	u.instagramAdapter = &fakeInstagramAdapter{posts: map[string][]domain.InstagramPost{
+		"ig_a": {
+			instagramPost("m3", "2026-01-03T00:00:00+0000", "https://cdn.example.com/m3.jpg"),
+			carousel,
+			instagramPost("m1", "2026-01-01T00:00:00+0000", "https://cdn.example.com/m1.jpg"),
+		},
+		"ig_c": {
+			instagramPost("m4", "2026-01-04T00:00:00+0000", "https://cdn.example.com/m4.jpg"),
+		},
+	}}
+	// m1 はPhotosへのアップロードに失敗する
+	u.gbpAdapter = &fakeGbpAdapter{failSource: map[string]bool{"https://s3.example.com/https://cdn.example.com/m1.jpg": true}}
+	return u, r
+}
+
+func TestCustomerUsecase_SyncAllGoogleBusinessInstagram(t *testing.T) {
+	u, r := newBusinessInstagramTest(t)
+
+	assert.NoError(t, u.SyncAllGoogleBusinessInstagram(context.Background()))
+
+	// Photosに失敗した投稿はLocal Postも投稿せず、次の投稿と次の連携は続ける
+	assert.Equal(t, []string{
+		"photo:1:m2_2", "post:1:m2",
+		"photo:1:m3", "post:1:m3",
+		"photo:3:m4", "post:3:m4",
+	}, r.published)
+	assert.Equal(t, []string{"business_instagram:1:m1", "business_instagram:2:"}, r.failed)
+	// Local PostはPhotosにアップロードした画像を使い回す
+	gbp := u.gbpAdapter.(*fakeGbpAdapter)
+	assert.Equal(t, "https://s3.example.com/https://cdn.example.com/m2_2.jpg", gbp.localPosts[0].MediaURL)
+	assert.Equal(t, 4, u.s3Adapter.(*fakeS3Adapter).uploads)
+	// 定期実行は直近25件
+	assert.Equal(t, []string{"25:ig_a", "25:ig_c"}, u.instagramAdapter.(*fakeInstagramAdapter).calls)
+	assert.Equal(t, []domain.WebhookEventType{
+		domain.WebhookEventSyncFailed,
+		domain.WebhookEventGbpPhotoUploaded, domain.WebhookEventGbpPostPublished,
+		domain.WebhookEventGbpPhotoUploaded, domain.WebhookEventGbpPostPublished,
+		domain.WebhookEventSyncFailed,
+		domain.WebhookEventGbpPhotoUploaded, domain.WebhookEventGbpPostPublished,
+	}, r.events)
+
+	// 2回目は連携済みなので何もしない（Local Postの画像も取り直さない）
+	r.published = nil
+	u.gbpAdapter = &fakeGbpAdapter{}
+	assert.NoError(t, u.SyncAllGoogleBusinessInstagram(context.Background()))
+	assert.Equal(t, []string{"photo:1:m1", "post:1:m1"}, r.published)
+}
+
+func TestCustomerUsecase_SyncOneGoogleBusinessInstagram(t *testing.T) {
+	u, r := newBusinessInstagramTest(t)
+
+	// 手動同期は最初のエラーで止めて、通知せずに返す
+	assert.EqualError(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 1), "gbp media error")
+	assert.Empty(t, r.published)
+	assert.Empty(t, r.failed)
+	assert.Equal(t, []string{"all:ig_a"}, u.instagramAdapter.(*fakeInstagramAdapter).calls)
+
+	assert.ErrorIs(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 2), domain.ErrGoogleAccountNotFound)
+
+	assert.NoError(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 3))
+	assert.Equal(t, []string{"photo:3:m4", "post:3:m4"}, r.published)
+}
+
+func TestCustomerUsecase_GoogleBusinessInstagramReservation(t *testing.T) {
+	u, r := newBusinessInstagramTest(t)
+	googlePostRepo := u.googlePostRepo.(*fakeGooglePostRepo)
+
+	// Photosにアップロードしてから記録するまでに失敗した場合、予約が残るので次の同期でもアップロードしない
+	googlePostRepo.updateErr = errors.New("db error")
+	assert.EqualError(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 3), "db error")
+	googlePostRepo.updateErr = nil
+	assert.NoError(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 3))
+	assert.Equal(t, []string{"post:3:m4"}, r.published)
+	assert.Equal(t, 2, u.s3Adapter.(*fakeS3Adapter).uploads)
+
+	// 止まったままの予約は投稿できたか確認できないので、unconfirmed にして返す
+	googlePostRepo.records[0].CreatedAt = time.Now().Add(-time.Hour)
+	assert.ErrorIs(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 3), domain.ErrSyncUnconfirmed)
+	assert.Equal(t, domain.SyncRecordUnconfirmed, googlePostRepo.records[0].Status)
+	assert.NoError(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 3))
+
+	// リトライすると投稿し直す
+	assert.NoError(t, u.RetryBusinessInstagramPost(context.Background(), 3, "m4"))
+	assert.Equal(t, []string{"post:3:m4", "photo:3:m4"}, r.published)
+}
+
+func TestCustomerUsecase_GoogleBusinessInstagramReleaseOnFailure(t *testing.T) {
+	u, r := newBusinessInstagramTest(t)
+
+	// 投稿に失敗した予約は取り消すので、次の同期で投稿し直す
+	assert.EqualError(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 1), "gbp media error")
+	assert.False(t, u.googlePostRepo.(*fakeGooglePostRepo).existed["photo:1:m1"])
+	u.gbpAdapter = &fakeGbpAdapter{}
+	assert.NoError(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 1))
+	assert.Equal(t, []string{"photo:1:m1", "post:1:m1"}, r.published[:2])
+}
+
+func TestCustomerUsecase_GoogleBusinessInstagramUnconfirmedOnTimeout(t *testing.T) {
+	u, r := newBusinessInstagramTest(t)
+	googlePostRepo := u.googlePostRepo.(*fakeGooglePostRepo)
+
+	// GBPに送った後のタイムアウトは投稿できたか分からないため、予約を unconfirmed にして返す
+	u.gbpAdapter = &fakeGbpAdapter{timeoutSource: map[string]bool{"https://s3.example.com/https://cdn.example.com/m4.jpg": true}}
+	err := u.SyncOneGoogleBusinessInstagram(context.Background(), 3)
+	assert.ErrorIs(t, err, domain.ErrSyncUnconfirmed)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, domain.SyncRecordUnconfirmed, googlePostRepo.records[0].Status)
+	assert.False(t, googlePostRepo.deleted[1])
+
+	// 次の同期ではアップロードし直さない。リトライすると投稿し直す
+	u.gbpAdapter = &fakeGbpAdapter{}
+	assert.NoError(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 3))
+	assert.Equal(t, []string{"post:3:m4"}, r.published)
+	assert.NoError(t, u.RetryBusinessInstagramPost(context.Background(), 3, "m4"))
+	assert.Equal(t, []string{"post:3:m4", "photo:3:m4"}, r.published)
+}
+
+func TestCustomerUsecase_RepostBusinessInstagramPost(t *testing.T) {
+	u, r := newBusinessInstagramTest(t)
+	googlePostRepo := u.googlePostRepo.(*fakeGooglePostRepo)
+	assert.NoError(t, u.SyncOneGoogleBusinessInstagram(context.Background(), 3))
+	r.published = nil
+
+	// 同じ記録で1件だけ投稿し直し、同じ連携の新しい投稿は投稿しない
+	instagram := u.instagramAdapter.(*fakeInstagramAdapter)
+	instagram.posts["ig_c"] = append(instagram.posts["ig_c"], instagramPost("m5", "2026-01-05T00:00:00+0000", "https://cdn.example.com/m5.jpg"))
+	deletedAt := time.Now()
+	photo := *googlePostRepo.records[0]
+	photo.DeletedAt = &deletedAt
+	assert.NoError(t, u.RepostGooglePost(context.Background(), &photo))
+	assert.Equal(t, []string{"photo:3:m4"}, r.published)
+	assert.Len(t, googlePostRepo.records, 2)
+	assert.Nil(t, googlePostRepo.records[0].DeletedAt)
+
+	post := *googlePostRepo.records[1]
+	assert.NoError(t, u.RepostGooglePost(context.Background(), &post))
+	assert.Equal(t, []string{"photo:3:m4", "post:3:m4"}, r.published)
+
+	// GBPが受け付けなかった場合は元の記録に戻す
+	u.gbpAdapter = &fakeGbpAdapter{failSource: map[string]bool{"https://s3.example.com/https://cdn.example.com/m4.jpg": true}}
+	photo = *googlePostRepo.records[0]
+	photo.Name = "accounts/a/locations/3/media/old"
+	googlePostRepo.records[0].Name = photo.Name
+	assert.EqualError(t, u.RepostGooglePost(context.Background(), &photo), "gbp media error")
+	assert.Equal(t, domain.SyncRecordPublished, googlePostRepo.records[0].Status)
+	assert.Equal(t, "accounts/a/locations/3/media/old", googlePostRepo.records[0].Name)
+
+	// 投稿元から取得できない場合は記録を変えない
+	gone := domain.GooglePost{ID: 1, CustomerID: 3, MediaID: "gone", PostType: domain.PostTypePhoto}
+	assert.ErrorIs(t, u.RepostGooglePost(context.Background(), &gone), domain.ErrNotFound)
+	assert.Equal(t, "m4", googlePostRepo.records[0].MediaID)
+}
+
+type fakeWordpressGbpRepo struct {
+	repository.WordpressGbpRepository
+	wgList []*domain.WordpressGbp
+	// existing は作成済みの連携の "wordpress_domain business_name"
+	existing map[string]bool
+	// failName は保存に失敗させる連携の名前
+	failName string
+	created  []*domain.WordpressGbp
+}
+
+func (f *fakeWordpressGbpRepo) Exists(_ context.Context, filter repository.WordpressGbpFilter) (bool, error) {
+	return f.existing[*filter.WordpressDomain+" "+*filter.BusinessName], nil
+}
+
+func (f *fakeWordpressGbpRepo) Create(_ context.Context, wg *domain.WordpressGbp) error {
+	if wg.Name == f.failName {
+		return errors.New("duplicate entry")
+	}
+	wg.ID = len(f.created) + 1
+	f.created = append(f.created, wg)
+	return nil
+}
+
+func (f *fakeWordpressGbpRepo) FindAll(context.Context, repository.WordpressGbpFilter) ([]*domain.WordpressGbp, error) {
+	return f.wgList, nil
+}
+
+func (f *fakeWordpressGbpRepo) Get(_ context.Context, filter repository.WordpressGbpFilter) (*domain.WordpressGbp, error) {
+	for _, wg := range f.wgList {
+		if wg.ID == *filter.ID {
+			return wg, nil
+		}
+	}
+	return &domain.WordpressGbp{}, nil
+}
+
+func newWordpressGbpTest(t *testing.T) (*customerUsecase, *syncRecorder) {
+	server, _ := newMediaServer(t)
+	r := &syncRecorder{syncStates: map[int]string{}}
+	u := newTestCustomerUsecase(r)
+	u.wordpressGbpRepo = &fakeWordpressGbpRepo{wgList: []*domain.WordpressGbp{
+		{ID: 1, WordpressDomain: "a.example.com", BusinessName: "locations/1", StartDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, WordpressDomain: "b.example.com", BusinessName: "locations/2"},
+	}}
+	u.wordpressAdapter = &fakeWordpressAdapter{
+		gbpPosts: map[string][]external.WordpressGbpPost{
+			"a.example.com": {
+				{PostID: 10, PostURL: "https://a.example.com/?p=10", PublishedAt: "2026-01-02T00:00:00Z", Content: "お知らせ", MediaURLs: []string{server.URL + "/a.pdf", server.URL + "/a.jpg"}},
+				// 連携開始日前
+				{PostID: 9, PublishedAt: "2025-12-31T00:00:00Z", Content: "古い記事", MediaURLs: []string{server.URL + "/old.jpg"}},
+				// メディアが無い記事は連携しない
+				{PostID: 11, PublishedAt: "2026-01-03T00:00:00Z", Content: "本文だけ"},
+				{PostID: 12, PublishedAt: "2026-01-04T00:00:00Z", Content: "失敗する記事", MediaURLs: []string{server.URL + "/b.jpg"}},
+				{PostID: 13, PublishedAt: "2026-01-05T00:00:00Z", MediaURLs: []string{server.URL + "/c.jpg"}},
+			},
+		},
+		gbpErrs: map[string]error{"b.example.com": errors.New("wordpress error")},
+	}
+	u.gbpAdapter = &fakeGbpAdapter{failSource: map[string]bool{"失敗する記事": true}}
+	return u, r
+}
+
+func TestCustomerUsecase_SyncAllWordpressGbp(t *testing.T) {
+	u, r := newWordpressGbpTest(t)
+
+	assert.NoError(t, u.SyncAllWordpressGbp(context.Background()))
+
+	// PDFはアップロードしない。Local Postに失敗した記事があっても次の記事を続ける
+	assert.Equal(t, []string{
+		"photo:300001:10_1", "post:300001:10",
+		"photo:300001:12_0",
+		"photo:300001:13_0",
+	}, r.published)
+	assert.Equal(t, []string{"wordpress_gbp:1:12", "wordpress_gbp:2:"}, r.failed)
+}
+
+func TestCustomerUsecase_SyncOneWordpressGbp(t *testing.T) {
+	u, r := newWordpressGbpTest(t)
+
+	assert.EqualError(t, u.SyncOneWordpressGbp(context.Background(), 1), "gbp local post error")
+	assert.Equal(t, []string{"photo:300001:10_1", "post:300001:10", "photo:300001:12_0"}, r.published)
+	assert.Empty(t, r.failed)
+
+	assert.EqualError(t, u.SyncOneWordpressGbp(context.Background(), 2), "wordpress error")
+}
+
+func TestCustomerUsecase_RecordGbpRejection(t *testing.T) {
+	base, _ := newWordpressGbpTest(t)
+	rejected := &domain.GbpPostRejectedError{Reason: domain.GbpRejectionPhone, Field: "summary", Message: "phone number is not allowed"}
+	base.gbpAdapter.(*fakeGbpAdapter).rejectSource = map[string]*domain.GbpPostRejectedError{"失敗する記事": rejected}
+	base.gbpAdapter.(*fakeGbpAdapter).failSource = nil
+	rejections := &fakeGbpPostRejectionRepo{}
+
+	// DIと同じ NewCustomerUsecase で組み立て、拒否の記録まで繋がっていることを確認する
+	u := NewCustomerUsecase(
+		base.instagramAdapter, base.notificationUsecase, base.wordpressAdapter, base.gbpAdapter,
+		base.postRepo, nil, base.tokenRepo, nil, base.googlePostRepo, base.s3Adapter,
+		base.wordpressGbpRepo, base.googleBusinessRepo, base.googleAccountRepo, rejections,
+		nil, nil, nil, nil, nil, nil,
+		base.webhookUsecase, base.syncActivityRepo,
+	)
+
+	err := u.SyncOneWordpressGbp(context.Background(), 1)
+	assert.ErrorIs(t, err, rejected)
+
+	// 投稿できた記事は過去の拒否の記録を消し、拒否された記事は理由を記録する
+	assert.Equal(t, []string{"300001:10"}, rejections.cleared)
+	if assert.Len(t, rejections.upserted, 1) {
+		got := rejections.upserted[0]
+		assert.Equal(t, 300001, got.CustomerID)
+		assert.Equal(t, "12", got.MediaID)
+		assert.Equal(t, "locations/1", got.BusinessName)
+		assert.Equal(t, domain.GbpRejectionPhone, got.Reason)
+		assert.Equal(t, "summary", got.Field)
+		assert.Equal(t, "失敗する記事", got.Summary)
+	}
+}
+
+func TestCustomerUsecase_RepostWordpressGbpPost(t *testing.T) {
+	u, r := newWordpressGbpTest(t)
+	googlePostRepo := u.googlePostRepo.(*fakeGooglePostRepo)
+	assert.Error(t, u.SyncOneWordpressGbp(context.Background(), 1))
+	r.published = nil
+	records := len(googlePostRepo.records)
+
+	// 写真は media_id の番号のメディアを、Local Postは記事を投稿し直す
+	photo := *googlePostRepo.records[0]
+	assert.Equal(t, "10_1", photo.MediaID)
+	assert.NoError(t, u.RepostGooglePost(context.Background(), &photo))
+	post := *googlePostRepo.records[1]
+	assert.Equal(t, "10", post.MediaID)
+	assert.NoError(t, u.RepostGooglePost(context.Background(), &post))
+	assert.Equal(t, []string{"photo:300001:10_1", "post:300001:10"}, r.published)
+	assert.Len(t, googlePostRepo.records, records)
+
+	// 投稿元の記事やメディアが見つからない場合は投稿しない
+	for _, mediaID := range []string{"99_0", "10_5"} {
+		missing := domain.GooglePost{ID: 1, CustomerID: 300001, MediaID: mediaID, PostType: domain.PostTypePhoto}
+		assert.ErrorIs(t, u.RepostGooglePost(context.Background(), &missing), domain.ErrBadRequest)
+	}
+	assert.Equal(t, []string{"photo:300001:10_1", "post:300001:10"}, r.published)
+}
+
+// previewKeys はドライランの結果を比較しやすい形にする
+func previewKeys(list []res.SyncPreview) []string {
+	keys := make([]string, 0, len(list))
+	for _, p := range list {
+		key := fmt.Sprintf("%s:%d:%s:%s", p.AccountType, p.AccountID, p.Destination, p.PostKey)
+		if p.Error != "" {
+			key += ":" + p.Error
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestCustomerUsecase_PreviewAllWordpressInstagram(t *testing.T) {
+	server, downloads := newMediaServer(t)
+	r := &syncRecorder{syncStates: map[int]string{}}
+	u := newTestCustomerUsecase(r)
+
+	wi1 := &domain.WordpressInstagram{ID: 1, InstagramID: "ig_a", Template: "<p>PR</p>{{content}}"}
+	wi2 := &domain.WordpressInstagram{ID: 2, InstagramID: "ig_a", StartDate: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+	wi3 := &domain.WordpressInstagram{ID: 3, InstagramID: "ig_b"}
+	u.wordpressInstagramRepo = &fakeWordpressInstagramRepo{r: r, wiList: []*domain.WordpressInstagram{wi1, wi2, wi3}}
+	u.instagramAdapter = &fakeInstagramAdapter{
+		posts: map[string][]domain.InstagramPost{
+			"ig_a": {
+				instagramPost("m2", "2026-01-02T00:00:00+0000", server.URL+"/m2.jpg"),
+				instagramPost("m1", "2026-01-01T00:00:00+0000", server.URL+"/m1.jpg"),
+			},
+		},
+		errs: map[string]error{"ig_b": errors.New("instagram error")},
+	}
+	u.postRepo.(*fakePostRepo).existed["2:m2"] = true
+
+	preview, err := u.PreviewAllWordpressInstagram(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"wordpress_instagram:1:wordpress:m1",
+		"wordpress_instagram:1:wordpress:m2",
+		"wordpress_instagram:3:::instagram error",
+	}, previewKeys(preview.SyncPreviewList))
+
+	// 本文は連携先のテンプレートで、メディアはInstagramのURLのまま
+	first := preview.SyncPreviewList[0]
+	assert.Equal(t, "m1", first.Title)
+	assert.Contains(t, first.Content, "<p>PR</p><div style='text-align: center;'><img src='"+server.URL+"/m1.jpg'")
+	assert.Equal(t, []string{server.URL + "/m1.jpg"}, first.MediaURLs)
+
+	// 何もダウンロード・投稿・保存しない
+	assert.Equal(t, int32(0), downloads.Load())
+	assert.Empty(t, r.published)
+	assert.Empty(t, r.failed)
+	assert.Empty(t, r.events)
+	assert.Empty(t, r.syncStates)
+}
+
+func TestCustomerUsecase_PreviewAllGoogleBusinessInstagram(t *testing.T) {
+	u, r := newBusinessInstagramTest(t)
+	u.googlePostRepo.(*fakeGooglePostRepo).existed["photo:1:m3"] = true
+
+	preview, err := u.PreviewAllGoogleBusinessInstagram(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"business_instagram:1:gbp_photo:m1", "business_instagram:1:gbp_post:m1",
+		"business_instagram:1:gbp_photo:m2", "business_instagram:1:gbp_post:m2",
+		"business_instagram:1:gbp_post:m3",
+		"business_instagram:2:::" + domain.ErrGoogleAccountNotFound.Error(),
+		"business_instagram:3:gbp_photo:m4", "business_instagram:3:gbp_post:m4",
+	}, previewKeys(preview.SyncPreviewList))
+
+	// カルーセルの動画はアップロードせず、Local Postには最初の画像を添付する
+	carouselPhoto, carouselPost := preview.SyncPreviewList[2], preview.SyncPreviewList[3]
+	assert.Equal(t, []string{"https://cdn.example.com/m2_2.jpg"}, carouselPhoto.MediaURLs)
+	assert.Equal(t, []string{"https://cdn.example.com/m2_2.jpg"}, carouselPost.MediaURLs)
+	assert.Equal(t, "m2", carouselPost.Summary)
+
+	assert.Equal(t, 0, u.s3Adapter.(*fakeS3Adapter).uploads)
+	assert.Empty(t, u.gbpAdapter.(*fakeGbpAdapter).localPosts)
+	assert.Empty(t, r.published)
+	assert.Empty(t, r.failed)
+}
+
+func TestCustomerUsecase_PreviewOneWordpressGbp(t *testing.T) {
+	u, r := newWordpressGbpTest(t)
+
+	preview, err := u.PreviewOneWordpressGbp(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"wordpress_gbp:1:gbp_photo:10", "wordpress_gbp:1:gbp_post:10",
+		"wordpress_gbp:1:gbp_photo:12", "wordpress_gbp:1:gbp_post:12",
+		"wordpress_gbp:1:gbp_photo:13",
+	}, previewKeys(preview.SyncPreviewList))
+
+	// PDFはアップロードせず、ボタンは記事のURLの「詳細」になる
+	photo, post := preview.SyncPreviewList[0], preview.SyncPreviewList[1]
+	assert.Len(t, photo.MediaURLs, 1)
+	assert.Contains(t, photo.MediaURLs[0], "/a.jpg")
+	assert.Equal(t, "お知らせ", post.Summary)
+	assert.Equal(t, string(domain.CallToActionLearnMore), post.CallToActionType)
+	assert.Equal(t, "https://a.example.com/?p=10", post.CallToActionURL)
+	assert.Empty(t, r.published)
+
+	// 失敗する連携はエラーとして返し、通知しない
+	preview, err = u.PreviewOneWordpressGbp(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"wordpress_gbp:2:::wordpress error"}, previewKeys(preview.SyncPreviewList))
+	assert.Empty(t, r.failed)
+
+	_, err = u.PreviewOneWordpressGbp(context.Background(), 3)
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func TestNewGbpLocalPostCallToAction(t *testing.T) {
+	post := external.WordpressGbpPost{PostURL: "https://a.example.com/?p=10", Content: "お知らせ"}
+	item := domain.FeedItem{Link: "https://example.com/news/1", Title: "お知らせ"}
+
+	// 未設定は記事のURLの「詳細」ボタンを付ける
+	wgPost := newWordpressGbpLocalPost(&domain.WordpressGbp{}, post)
+	assert.Equal(t, &domain.GbpCallToAction{ActionType: domain.CallToActionLearnMore, URL: post.PostURL}, wgPost.CallToAction)
+	feedPost := newFeedGbpLocalPost(context.Background(), &domain.Feed{}, item)
+	assert.Equal(t, &domain.GbpCallToAction{ActionType: domain.CallToActionLearnMore, URL: item.Link}, feedPost.CallToAction)
+
+	// NONE はボタンを付けない
+	wgPost = newWordpressGbpLocalPost(&domain.WordpressGbp{CallToActionType: domain.CallToActionNone}, post)
+	assert.Nil(t, wgPost.CallToAction)
+	feedPost = newFeedGbpLocalPost(context.Background(), &domain.Feed{CallToActionType: domain.CallToActionNone}, item)
+	assert.Nil(t, feedPost.CallToAction)
+
+	// 投稿のカスタムフィールドはアカウント設定より優先する
+	post.CustomFields = external.WordpressCustomFields{domain.GbpFieldCTAType: string(domain.CallToActionNone)}
+	wgPost = newWordpressGbpLocalPost(&domain.WordpressGbp{CallToActionType: domain.CallToActionBook}, post)
+	assert.Nil(t, wgPost.CallToAction)
+}
+
+type fakeFacebookInstagramRepo struct {
+	repository.FacebookInstagramRepository
+	fiList []*domain.FacebookInstagram
+}
+
+func (f *fakeFacebookInstagramRepo) FindAll(context.Context, repository.FacebookInstagramFilter) ([]*domain.FacebookInstagram, error) {
+	return f.fiList, nil
+}
+
+type fakeFacebookPostRepo struct {
+	repository.FacebookPostRepository
+	r *syncRecorder
+	// existed は連携済みの "facebook_instagram_id:media_id"
+	existed map[string]bool
+}
+
+func (f *fakeFacebookPostRepo) Exists(_ context.Context, filter repository.FacebookPostFilter) (bool, error) {
+	return f.existed[fmt.Sprintf("%d:%s", *filter.FacebookInstagramID, *filter.MediaID)], nil
+}
+
+func (f *fakeFacebookPostRepo) Create(_ context.Context, post *domain.FacebookPost) error {
+	f.existed[fmt.Sprintf("%d:%s", post.FacebookInstagramID, post.MediaID)] = true
+	f.r.add(&f.r.published, "facebook:%d:%s", post.FacebookInstagramID, post.MediaID)
+	return nil
+}
+
+type fakeFacebookAdapter struct {
+	adapter.FacebookAdapter
+	// failMessage は投稿に失敗する本文
+	failMessage string
+	pageErrs    map[string]error
+}
+
+func (f *fakeFacebookAdapter) GetPage(_ context.Context, _, pageID string) (*domain.FacebookPage, error) {
+	if err := f.pageErrs[pageID]; err != nil {
+		return nil, err
+	}
+	return &domain.FacebookPage{ID: pageID}, nil
+}
+
+func (f *fakeFacebookAdapter) Publish(_ context.Context, page *domain.FacebookPage, post domain.FacebookPagePost) (*domain.FacebookPublished, error) {
+	if post.Message == f.failMessage {
+		return nil, errors.New("facebook error")
+	}
+	return &domain.FacebookPublished{ID: page.ID + "_1", URL: "https://www.facebook.com/" + page.ID}, nil
+}
+
+func TestCustomerUsecase_SyncAllFacebookInstagram(t *testing.T) {
+	r := &syncRecorder{}
+	u := newTestCustomerUsecase(r)
+	fi1 := &domain.FacebookInstagram{ID: 1, InstagramID: "ig_a", FacebookPageID: "page_a", StartDate: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+	fi2 := &domain.FacebookInstagram{ID: 2, InstagramID: "ig_b", FacebookPageID: "page_b"}
+	u.facebookInstagramRepo = &fakeFacebookInstagramRepo{fiList: []*domain.FacebookInstagram{fi1, fi2}}
+	u.facebookPostRepo = &fakeFacebookPostRepo{r: r, existed: map[string]bool{"1:m2": true}}
+	u.facebookAdapter = &fakeFacebookAdapter{failMessage: "m4", pageErrs: map[string]error{"page_b": errors.New("page error")}}
+	u.instagramAdapter = &fakeInstagramAdapter{
+		posts: map[string][]domain.InstagramPost{
+			"ig_a": {
+				instagramPost("m5", "2026-01-05T00:00:00+0000", "https://cdn.example.com/m5.jpg"),
+				instagramPost("m4", "2026-01-04T00:00:00+0000", "https://cdn.example.com/m4.jpg"),
+				instagramPost("m3", "2026-01-03T00:00:00+0000", "https://cdn.example.com/m3.jpg"),
+				instagramPost("m2", "2026-01-02T00:00:00+0000", "https://cdn.example.com/m2.jpg"),
+				instagramPost("m1", "2026-01-01T00:00:00+0000", "https://cdn.example.com/m1.jpg"),
+			},
+		},
+	}
+
+	preview, err := u.PreviewAllFacebookInstagram(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"facebook_instagram:1:facebook:m3", "facebook_instagram:1:facebook:m4", "facebook_instagram:1:facebook:m5",
+		"facebook_instagram:2:::page error",
+	}, previewKeys(preview.SyncPreviewList))
+	assert.Empty(t, r.published)
+
+	assert.NoError(t, u.SyncAllFacebookInstagram(context.Background()))
+
+	// 連携開始日前の m1 と連携済みの m2 は連携しない。m4 で失敗したので m5 は連携しない
+	assert.Equal(t, []string{"facebook:1:m3"}, r.published)
+	assert.Equal(t, []string{"facebook_instagram:1:m4", "facebook_instagram:2:"}, r.failed)
+}
+
+type fakeFeedRepo struct {
+	repository.FeedRepository
+	feed *domain.Feed
+}
+
+func (f *fakeFeedRepo) Get(context.Context, repository.FeedFilter) (*domain.Feed, error) {
+	return f.feed, nil
+}
+
+type fakeFeedPostRepo struct {
+	repository.FeedPostRepository
+	r *syncRecorder
+	// existed は連携済みの "destination:guid_hash"
+	existed map[string]bool
+}
+
+func (f *fakeFeedPostRepo) Exists(_ context.Context, filter repository.FeedPostFilter) (bool, error) {
+	return f.existed[fmt.Sprintf("%s:%s", *filter.Destination, *filter.GUIDHash)], nil
+}
+
+func (f *fakeFeedPostRepo) Create(_ context.Context, post *domain.FeedPost) error {
+	f.existed[fmt.Sprintf("%s:%s", post.Destination, domain.FeedGUIDHash(post.GUID))] = true
+	f.r.add(&f.r.published, "%s:%d:%s", post.Destination, post.FeedID, post.GUID)
+	return nil
+}
+
+type fakeFeedAdapter struct {
+	adapter.FeedAdapter
+	items []domain.FeedItem
+}
+
+func (f *fakeFeedAdapter) Fetch(context.Context, string) (*domain.FeedChannel, error) {
+	return &domain.FeedChannel{Items: f.items}, nil
+}
+
+func TestCustomerUsecase_SyncOneFeed(t *testing.T) {
+	r := &syncRecorder{}
+	u := newTestCustomerUsecase(r)
+	feed := &domain.Feed{ID: 1, WordpressDomain: "a.example.com", BusinessName: "locations/1", StartDate: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)}
+	item := func(guid string, day int) domain.FeedItem {
+		return domain.FeedItem{GUID: guid, Title: guid, Link: "https://example.com/" + guid, PublishedAt: time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC)}
+	}
+	u.feedRepo = &fakeFeedRepo{feed: feed}
+	u.feedPostRepo = &fakeFeedPostRepo{r: r, existed: map[string]bool{
+		fmt.Sprintf("%s:%s", domain.FeedDestinationWordpress, domain.FeedGUIDHash("a2")): true,
+	}}
+	u.feedAdapter = &fakeFeedAdapter{items: []domain.FeedItem{item("a4", 4), item("a3", 3), item("a2", 2), item("a1", 1)}}
+	u.gbpAdapter = &fakeGbpAdapter{failSource: map[string]bool{
+		newFeedGbpLocalPost(context.Background(), feed, item("a3", 3)).Summary: true,
+	}}
+
+	preview, err := u.PreviewOneFeed(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"feed:1:gbp_post:" + domain.FeedGUIDHash("a2"),
+		"feed:1:wordpress:" + domain.FeedGUIDHash("a3"), "feed:1:gbp_post:" + domain.FeedGUIDHash("a3"),
+		"feed:1:wordpress:" + domain.FeedGUIDHash("a4"), "feed:1:gbp_post:" + domain.FeedGUIDHash("a4"),
+	}, previewKeys(preview.SyncPreviewList))
+
+	// 連携開始日前の a1 と連携済みの投稿先は連携しない。a3 のGBPで失敗したので a4 は連携しない
+	err = u.SyncOneFeed(context.Background(), 1)
+	assert.ErrorIs(t, err, domain.ErrSyncRejected)
+	assert.Equal(t, []string{"gbp:1:a2", "wordpress:1:a3"}, r.published)
+}
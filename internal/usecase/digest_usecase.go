@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/zuxt268/homing/internal/config"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+type DigestUsecase interface {
+	// SendDigest はダイジェストを通知ルールに従って送る（定期実行用）。
+	SendDigest(ctx context.Context, params req.GetDigest) error
+	GetDigest(ctx context.Context, params req.GetDigest) (*res.Digest, error)
+	GetDigestHTML(ctx context.Context, params req.GetDigest) (string, error)
+}
+
+type digestUsecase struct {
+	syncActivityRepo       repository.SyncActivityRepository
+	wordpressInstagramRepo repository.WordpressInstagramRepository
+	businessInstagramRepo  repository.BusinessInstagramRepository
+	wordpressGbpRepo       repository.WordpressGbpRepository
+	facebookInstagramRepo  repository.FacebookInstagramRepository
+	feedRepo               repository.FeedRepository
+	tokenRepo              repository.TokenRepository
+	instagramAdapter       adapter.InstagramAdapter
+	notificationUsecase    NotificationUsecase
+}
+
+func NewDigestUsecase(
+	syncActivityRepo repository.SyncActivityRepository,
+	wordpressInstagramRepo repository.WordpressInstagramRepository,
+	businessInstagramRepo repository.BusinessInstagramRepository,
+	wordpressGbpRepo repository.WordpressGbpRepository,
+	facebookInstagramRepo repository.FacebookInstagramRepository,
+	feedRepo repository.FeedRepository,
+	tokenRepo repository.TokenRepository,
+	instagramAdapter adapter.InstagramAdapter,
+	notificationUsecase NotificationUsecase,
+) DigestUsecase {
+	return &digestUsecase{
+		syncActivityRepo:       syncActivityRepo,
+		wordpressInstagramRepo: wordpressInstagramRepo,
+		businessInstagramRepo:  businessInstagramRepo,
+		wordpressGbpRepo:       wordpressGbpRepo,
+		facebookInstagramRepo:  facebookInstagramRepo,
+		feedRepo:               feedRepo,
+		tokenRepo:              tokenRepo,
+		instagramAdapter:       instagramAdapter,
+		notificationUsecase:    notificationUsecase,
+	}
+}
+
+func (u *digestUsecase) SendDigest(ctx context.Context, params req.GetDigest) error {
+	d, err := u.buildDigest(ctx, params)
+	if err != nil {
+		return err
+	}
+	n, err := domain.NewDigestNotification(d)
+	if err != nil {
+		return err
+	}
+	u.notificationUsecase.Notify(ctx, n)
+	return nil
+}
+
+func (u *digestUsecase) GetDigest(ctx context.Context, params req.GetDigest) (*res.Digest, error) {
+	d, err := u.buildDigest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return toDigestResponse(d), nil
+}
+
+func (u *digestUsecase) GetDigestHTML(ctx context.Context, params req.GetDigest) (string, error) {
+	d, err := u.buildDigest(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	return d.HTML()
+}
+
+func (u *digestUsecase) buildDigest(ctx context.Context, params req.GetDigest) (*domain.Digest, error) {
+	period := domain.DigestPeriodDaily
+	if params.Period != "" {
+		period = domain.DigestPeriod(params.Period)
+	}
+	if !period.Valid() {
+		return nil, fmt.Errorf("%w: period が不正です: %s", domain.ErrBadRequest, params.Period)
+	}
+
+	/*
+		期間内の連携結果を集計
+	*/
+	now := time.Now()
+	activities, err := u.syncActivityRepo.FindAll(ctx, repository.SyncActivityFilter{
+		CreatedAtFrom: util.Pointer(now.Add(-period.Duration())),
+		CreatedAtTo:   &now,
+	})
+	if err != nil {
+		return nil, err
+	}
+	d := domain.NewDigest(period, now, activities)
+
+	/*
+		有効な連携のうち、しばらく投稿が無いものを探す
+	*/
+	accounts, err := u.activeAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	lastPublished, err := u.syncActivityRepo.FindLatest(ctx, repository.SyncActivityFilter{
+		Result: util.Pointer(domain.SyncResultPublished),
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.SetInactiveAccounts(accounts, lastPublished, config.Env.DigestInactiveDays)
+
+	/*
+		トークンの有効期限。取得できなくてもダイジェストは送る
+	*/
+	expiresAt, err := u.tokenExpiresAt(ctx)
+	if err != nil {
+		slog.Warn("トークンの有効期限の取得に失敗", "error", err.Error())
+	} else {
+		d.TokenExpiresAt = &expiresAt
+	}
+	return d, nil
+}
+
+func (u *digestUsecase) activeAccounts(ctx context.Context) ([]domain.Account, error) {
+	var accounts []domain.Account
+
+	wiList, err := u.wordpressInstagramRepo.FindAll(ctx, repository.WordpressInstagramFilter{Status: util.Pointer(1)})
+	if err != nil {
+		return nil, err
+	}
+	for _, wi := range wiList {
+		accounts = append(accounts, wi.Account())
+	}
+
+	biList, err := u.businessInstagramRepo.FindAll(ctx, repository.BusinessInstagramFilter{Status: util.Pointer(1)})
+	if err != nil {
+		return nil, err
+	}
+	for _, bi := range biList {
+		accounts = append(accounts, bi.Account())
+	}
+
+	wgList, err := u.wordpressGbpRepo.FindAll(ctx, repository.WordpressGbpFilter{Status: util.Pointer(1)})
+	if err != nil {
+		return nil, err
+	}
+	for _, wg := range wgList {
+		accounts = append(accounts, wg.Account())
+	}
+
+	fiList, err := u.facebookInstagramRepo.FindAll(ctx, repository.FacebookInstagramFilter{Status: util.Pointer(1)})
+	if err != nil {
+		return nil, err
+	}
+	for _, fi := range fiList {
+		accounts = append(accounts, fi.Account())
+	}
+
+	feedList, err := u.feedRepo.FindAll(ctx, repository.FeedFilter{Status: util.Pointer(1)})
+	if err != nil {
+		return nil, err
+	}
+	for _, feed := range feedList {
+		accounts = append(accounts, feed.Account())
+	}
+	return accounts, nil
+}
+
+func (u *digestUsecase) tokenExpiresAt(ctx context.Context) (time.Time, error) {
+	token, err := u.tokenRepo.First(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	debug, err := u.instagramAdapter.DebugToken(ctx, token)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(debug.Data.ExpiresAt, 0), nil
+}
+
+func toDigestResponse(d *domain.Digest) *res.Digest {
+	accounts := make([]res.DigestAccountSummary, 0, len(d.Accounts))
+	for _, s := range d.Accounts {
+		accounts = append(accounts, res.DigestAccountSummary{
+			AccountType: string(s.Account.Type),
+			AccountID:   s.Account.ID,
+			AccountName: s.Account.Name,
+			Published:   s.Published,
+			Skipped:     s.Skipped,
+			Failed:      s.Failed,
+			LastError:   s.LastError,
+		})
+	}
+	inactive := make([]res.DigestInactiveAccount, 0, len(d.InactiveAccounts))
+	for _, a := range d.InactiveAccounts {
+		inactive = append(inactive, res.DigestInactiveAccount{
+			AccountType:     string(a.Account.Type),
+			AccountID:       a.Account.ID,
+			AccountName:     a.Account.Name,
+			LastPublishedAt: a.LastPublishedAt,
+		})
+	}
+	return &res.Digest{
+		Period:           string(d.Period),
+		From:             d.From,
+		To:               d.To,
+		Published:        d.Published,
+		Skipped:          d.Skipped,
+		Failed:           d.Failed,
+		Accounts:         accounts,
+		InactiveDays:     d.InactiveDays,
+		InactiveAccounts: inactive,
+		TokenExpiresAt:   d.TokenExpiresAt,
+		TokenExpiring:    d.TokenExpiring(),
+	}
+}
@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+type FacebookInstagramUsecase interface {
+	GetFacebookInstagramCount(ctx context.Context) (*res.FacebookInstagramCount, error)
+	GetFacebookInstagramList(ctx context.Context, params req.GetFacebookInstagram) (*res.FacebookInstagramList, error)
+	GetFacebookInstagram(ctx context.Context, id int, params req.GetFacebookInstagramDetail) (*res.FacebookInstagramDetail, error)
+	CreateFacebookInstagram(ctx context.Context, body req.CreateFacebookInstagram) (*res.FacebookInstagram, error)
+	UpdateFacebookInstagram(ctx context.Context, id int, body req.UpdateFacebookInstagram) (*res.FacebookInstagram, error)
+	DeleteFacebookInstagram(ctx context.Context, id int) error
+}
+
+type facebookInstagramUsecase struct {
+	facebookInstagramRepo repository.FacebookInstagramRepository
+	facebookPostRepo      repository.FacebookPostRepository
+	tokenRepo             repository.TokenRepository
+	instagramAdapter      adapter.InstagramAdapter
+	facebookAdapter       adapter.FacebookAdapter
+	webhookUsecase        WebhookUsecase
+}
+
+func NewFacebookInstagramUsecase(
+	facebookInstagramRepo repository.FacebookInstagramRepository,
+	facebookPostRepo repository.FacebookPostRepository,
+	tokenRepo repository.TokenRepository,
+	instagramAdapter adapter.InstagramAdapter,
+	facebookAdapter adapter.FacebookAdapter,
+	webhookUsecase WebhookUsecase,
+) FacebookInstagramUsecase {
+	return &facebookInstagramUsecase{
+		facebookInstagramRepo: facebookInstagramRepo,
+		facebookPostRepo:      facebookPostRepo,
+		tokenRepo:             tokenRepo,
+		instagramAdapter:      instagramAdapter,
+		facebookAdapter:       facebookAdapter,
+		webhookUsecase:        webhookUsecase,
+	}
+}
+
+func (u *facebookInstagramUsecase) GetFacebookInstagramCount(ctx context.Context) (*res.FacebookInstagramCount, error) {
+	total, err := u.facebookInstagramRepo.Count(ctx, repository.FacebookInstagramFilter{})
+	if err != nil {
+		return nil, err
+	}
+	return &res.FacebookInstagramCount{
+		Count: total,
+	}, nil
+}
+
+func (u *facebookInstagramUsecase) GetFacebookInstagramList(ctx context.Context, params req.GetFacebookInstagram) (*res.FacebookInstagramList, error) {
+	filter := repository.FacebookInstagramFilter{
+		PartialName:             params.Name,
+		PartialInstagramName:    params.InstagramName,
+		PartialFacebookPageName: params.FacebookPageName,
+		Status:                  params.Status,
+		Limit:                   params.Limit,
+		Offset:                  params.Offset,
+		OrderByIDDesc:           util.Pointer(true),
+	}
+
+	fiList, err := u.facebookInstagramRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.facebookInstagramRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]res.FacebookInstagram, 0, len(fiList))
+	for _, fi := range fiList {
+		result = append(result, toFacebookInstagramResponse(fi))
+	}
+	return &res.FacebookInstagramList{
+		FacebookInstagramList: result,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(fiList),
+		},
+	}, nil
+}
+
+func (u *facebookInstagramUsecase) GetFacebookInstagram(ctx context.Context, id int, params req.GetFacebookInstagramDetail) (*res.FacebookInstagramDetail, error) {
+	fi, err := u.getFacebookInstagram(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.FacebookPostFilter{
+		FacebookInstagramID: &fi.ID,
+		Limit:               params.Limit,
+		Offset:              params.Offset,
+	}
+	posts, err := u.facebookPostRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.facebookPostRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	respPosts := make([]res.FacebookPost, 0, len(posts))
+	for _, post := range posts {
+		respPosts = append(respPosts, res.FacebookPost{
+			ID:           post.ID,
+			MediaID:      post.MediaID,
+			InstagramURL: post.Permalink,
+			FacebookURL:  post.FacebookURL,
+			CreatedAt:    post.CreatedAt,
+		})
+	}
+
+	return &res.FacebookInstagramDetail{
+		ID:               fi.ID,
+		Name:             fi.Name,
+		Memo:             fi.Memo,
+		InstagramID:      fi.InstagramID,
+		InstagramName:    fi.InstagramName,
+		FacebookPageID:   fi.FacebookPageID,
+		FacebookPageName: fi.FacebookPageName,
+		StartDate:        fi.StartDate,
+		Status:           int(fi.Status),
+		DeleteHash:       fi.DeleteHash,
+		Posts: res.FacebookPosts{
+			Posts: respPosts,
+			Paginate: res.Paginate{
+				Total: total,
+				Count: len(posts),
+			},
+		},
+	}, nil
+}
+
+func (u *facebookInstagramUsecase) CreateFacebookInstagram(ctx context.Context, body req.CreateFacebookInstagram) (*res.FacebookInstagram, error) {
+	token, err := u.tokenRepo.First(ctx)
+	if err != nil {
+		return nil, domain.ErrTokenNotFound
+	}
+
+	// システムユーザーでInstagramとページを取得できるか確認
+	instagramName, err := u.getInstagramName(ctx, token, body.InstagramID)
+	if err != nil {
+		return nil, err
+	}
+	pageName, err := u.getPageName(ctx, token, body.FacebookPageID)
+	if err != nil {
+		return nil, err
+	}
+
+	fi := &domain.FacebookInstagram{
+		Name:             body.Name,
+		Memo:             body.Memo,
+		InstagramID:      body.InstagramID,
+		InstagramName:    instagramName,
+		FacebookPageID:   body.FacebookPageID,
+		FacebookPageName: pageName,
+		StartDate:        body.StartDate,
+		Status:           domain.Status(body.Status),
+		DeleteHash:       body.DeleteHash,
+	}
+	if err := u.facebookInstagramRepo.Create(ctx, fi); err != nil {
+		return nil, err
+	}
+
+	resp := toFacebookInstagramResponse(fi)
+	return &resp, nil
+}
+
+func (u *facebookInstagramUsecase) UpdateFacebookInstagram(ctx context.Context, id int, body req.UpdateFacebookInstagram) (*res.FacebookInstagram, error) {
+	fi, err := u.getFacebookInstagram(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	beforeStatus := fi.Status
+
+	if body.InstagramID != nil || body.FacebookPageID != nil {
+		token, err := u.tokenRepo.First(ctx)
+		if err != nil {
+			return nil, domain.ErrTokenNotFound
+		}
+		if body.InstagramID != nil {
+			fi.InstagramID = *body.InstagramID
+			fi.InstagramName, err = u.getInstagramName(ctx, token, fi.InstagramID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if body.FacebookPageID != nil {
+			fi.FacebookPageID = *body.FacebookPageID
+			fi.FacebookPageName, err = u.getPageName(ctx, token, fi.FacebookPageID)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if body.Name != nil {
+		fi.Name = *body.Name
+	}
+	if body.Memo != nil {
+		fi.Memo = *body.Memo
+	}
+	if body.StartDate != nil {
+		fi.StartDate = *body.StartDate
+	}
+	if body.Status != nil {
+		fi.Status = domain.Status(*body.Status)
+	}
+	if body.DeleteHash != nil {
+		fi.DeleteHash = *body.DeleteHash
+	}
+
+	err = u.facebookInstagramRepo.Update(ctx, fi, repository.FacebookInstagramFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 停止した場合はwebhookで通知
+	publishAccountDisabled(ctx, u.webhookUsecase, beforeStatus, fi.Status, fi.Account())
+
+	resp := toFacebookInstagramResponse(fi)
+	return &resp, nil
+}
+
+func (u *facebookInstagramUsecase) DeleteFacebookInstagram(ctx context.Context, id int) error {
+	return u.facebookInstagramRepo.Delete(ctx, repository.FacebookInstagramFilter{
+		ID: &id,
+	})
+}
+
+func (u *facebookInstagramUsecase) getFacebookInstagram(ctx context.Context, id int) (*domain.FacebookInstagram, error) {
+	fi, err := u.facebookInstagramRepo.Get(ctx, repository.FacebookInstagramFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fi.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return fi, nil
+}
+
+func (u *facebookInstagramUsecase) getInstagramName(ctx context.Context, token, instagramID string) (string, error) {
+	account, err := u.instagramAdapter.GetAccount(ctx, token, instagramID)
+	if err != nil {
+		return "", domain.ErrInstagramConnection
+	}
+	if account.InstagramAccountUserName == "" {
+		return "", domain.ErrInstagramConnection
+	}
+	return account.InstagramAccountUserName, nil
+}
+
+// getPageName はページを取得する。投稿にはページのアクセストークンが必要なため、取得できない場合もエラーにする。
+func (u *facebookInstagramUsecase) getPageName(ctx context.Context, token, pageID string) (string, error) {
+	page, err := u.facebookAdapter.GetPage(ctx, token, pageID)
+	if err != nil {
+		return "", domain.ErrFacebookConnection
+	}
+	if page.AccessToken == "" {
+		return "", domain.ErrFacebookConnection
+	}
+	return page.Name, nil
+}
+
+func toFacebookInstagramResponse(fi *domain.FacebookInstagram) res.FacebookInstagram {
+	return res.FacebookInstagram{
+		ID:               fi.ID,
+		Name:             fi.Name,
+		Memo:             fi.Memo,
+		InstagramID:      fi.InstagramID,
+		InstagramName:    fi.InstagramName,
+		FacebookPageID:   fi.FacebookPageID,
+		FacebookPageName: fi.FacebookPageName,
+		StartDate:        fi.StartDate,
+		Status:           int(fi.Status),
+		DeleteHash:       fi.DeleteHash,
+	}
+}
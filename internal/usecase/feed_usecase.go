@@ -0,0 +1,328 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+type FeedUsecase interface {
+	GetFeedList(ctx context.Context, params req.GetFeed) (*res.FeedList, error)
+	GetFeed(ctx context.Context, id int, params req.GetFeedDetail) (*res.FeedDetail, error)
+	CreateFeed(ctx context.Context, body req.CreateFeed) (*res.Feed, error)
+	UpdateFeed(ctx context.Context, id int, body req.UpdateFeed) (*res.Feed, error)
+	DeleteFeed(ctx context.Context, id int) error
+}
+
+type feedUsecase struct {
+	feedRepo           repository.FeedRepository
+	feedPostRepo       repository.FeedPostRepository
+	googleBusinessRepo repository.GoogleBusinessRepository
+	googleAccountRepo  repository.GoogleAccountRepository
+	feedAdapter        adapter.FeedAdapter
+	wordpressAdapter   adapter.WordpressAdapter
+	gbpAdapter         adapter.GbpAdapter
+	webhookUsecase     WebhookUsecase
+}
+
+func NewFeedUsecase(
+	feedRepo repository.FeedRepository,
+	feedPostRepo repository.FeedPostRepository,
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	googleAccountRepo repository.GoogleAccountRepository,
+	feedAdapter adapter.FeedAdapter,
+	wordpressAdapter adapter.WordpressAdapter,
+	gbpAdapter adapter.GbpAdapter,
+	webhookUsecase WebhookUsecase,
+) FeedUsecase {
+	return &feedUsecase{
+		feedRepo:           feedRepo,
+		feedPostRepo:       feedPostRepo,
+		googleBusinessRepo: googleBusinessRepo,
+		googleAccountRepo:  googleAccountRepo,
+		feedAdapter:        feedAdapter,
+		wordpressAdapter:   wordpressAdapter,
+		gbpAdapter:         gbpAdapter,
+		webhookUsecase:     webhookUsecase,
+	}
+}
+
+func (u *feedUsecase) GetFeedList(ctx context.Context, params req.GetFeed) (*res.FeedList, error) {
+	filter := repository.FeedFilter{
+		PartialName:    params.Name,
+		PartialFeedURL: params.FeedURL,
+		Status:         params.Status,
+		Limit:          params.Limit,
+		Offset:         params.Offset,
+		OrderByIDDesc:  util.Pointer(true),
+	}
+
+	feeds, err := u.feedRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.feedRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]res.Feed, 0, len(feeds))
+	for _, feed := range feeds {
+		result = append(result, toFeedResponse(feed))
+	}
+	return &res.FeedList{
+		FeedList: result,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(feeds),
+		},
+	}, nil
+}
+
+func (u *feedUsecase) GetFeed(ctx context.Context, id int, params req.GetFeedDetail) (*res.FeedDetail, error) {
+	feed, err := u.getFeed(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repository.FeedPostFilter{
+		FeedID: &feed.ID,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+	posts, err := u.feedPostRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.feedPostRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	respPosts := make([]res.FeedPost, 0, len(posts))
+	for _, post := range posts {
+		respPosts = append(respPosts, res.FeedPost{
+			ID:          post.ID,
+			Destination: string(post.Destination),
+			GUID:        post.GUID,
+			Link:        post.Link,
+			URL:         post.URL,
+			CreatedAt:   post.CreatedAt,
+		})
+	}
+
+	return &res.FeedDetail{
+		ID:                 feed.ID,
+		Name:               feed.Name,
+		Memo:               feed.Memo,
+		FeedURL:            feed.FeedURL,
+		FeedTitle:          feed.FeedTitle,
+		StartDate:          feed.StartDate,
+		Status:             int(feed.Status),
+		WordpressDomain:    feed.WordpressDomain,
+		WordpressSiteTitle: feed.WordpressSiteTitle,
+		APIMode:            string(feed.APIMode),
+		WordpressUsername:  feed.WordpressUsername,
+		Categories:         feed.Categories,
+		BusinessName:       feed.BusinessName,
+		BusinessTitle:      feed.BusinessTitle,
+		CallToActionType:   string(feed.CallToActionType),
+		CallToActionURL:    feed.CallToActionURL,
+		Posts: res.FeedPosts{
+			Posts: respPosts,
+			Paginate: res.Paginate{
+				Total: total,
+				Count: len(posts),
+			},
+		},
+	}, nil
+}
+
+func (u *feedUsecase) CreateFeed(ctx context.Context, body req.CreateFeed) (*res.Feed, error) {
+	feed := &domain.Feed{
+		Name:              body.Name,
+		Memo:              body.Memo,
+		FeedURL:           body.FeedURL,
+		StartDate:         body.StartDate,
+		Status:            domain.Status(body.Status),
+		WordpressDomain:   body.WordpressDomain,
+		APIMode:           domain.WordpressAPIMode(body.APIMode),
+		WordpressUsername: body.WordpressUsername,
+		AppPassword:       body.AppPassword,
+		Categories:        body.Categories,
+		BusinessName:      body.BusinessName,
+		CallToActionType:  domain.CallToActionType(body.CallToActionType),
+		CallToActionURL:   body.CallToActionURL,
+	}
+	if err := u.connect(ctx, feed, true, true, true); err != nil {
+		return nil, err
+	}
+
+	if err := u.feedRepo.Create(ctx, feed); err != nil {
+		return nil, err
+	}
+
+	resp := toFeedResponse(feed)
+	return &resp, nil
+}
+
+func (u *feedUsecase) UpdateFeed(ctx context.Context, id int, body req.UpdateFeed) (*res.Feed, error) {
+	feed, err := u.getFeed(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	beforeStatus := feed.Status
+
+	if body.Name != nil {
+		feed.Name = *body.Name
+	}
+	if body.Memo != nil {
+		feed.Memo = *body.Memo
+	}
+	if body.FeedURL != nil {
+		feed.FeedURL = *body.FeedURL
+	}
+	if body.StartDate != nil {
+		feed.StartDate = *body.StartDate
+	}
+	if body.Status != nil {
+		feed.Status = domain.Status(*body.Status)
+	}
+	if body.WordpressDomain != nil {
+		feed.WordpressDomain = *body.WordpressDomain
+	}
+	if body.APIMode != nil {
+		feed.APIMode = domain.WordpressAPIMode(*body.APIMode)
+	}
+	if body.WordpressUsername != nil {
+		feed.WordpressUsername = *body.WordpressUsername
+	}
+	if body.AppPassword != nil {
+		feed.AppPassword = *body.AppPassword
+	}
+	if body.Categories != nil {
+		feed.Categories = body.Categories
+	}
+	if body.BusinessName != nil {
+		feed.BusinessName = *body.BusinessName
+	}
+	if body.CallToActionType != nil {
+		feed.CallToActionType = domain.CallToActionType(*body.CallToActionType)
+	}
+	if body.CallToActionURL != nil {
+		feed.CallToActionURL = *body.CallToActionURL
+	}
+
+	// 疎通確認は変更があった接続先だけ行う
+	checkFeed := body.FeedURL != nil
+	checkWordpress := body.WordpressDomain != nil || body.APIMode != nil || body.WordpressUsername != nil || body.AppPassword != nil
+	checkGbp := body.BusinessName != nil
+	if err := u.connect(ctx, feed, checkFeed, checkWordpress, checkGbp); err != nil {
+		return nil, err
+	}
+
+	err = u.feedRepo.Update(ctx, feed, repository.FeedFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 停止した場合はwebhookで通知
+	publishAccountDisabled(ctx, u.webhookUsecase, beforeStatus, feed.Status, feed.Account())
+
+	resp := toFeedResponse(feed)
+	return &resp, nil
+}
+
+func (u *feedUsecase) DeleteFeed(ctx context.Context, id int) error {
+	return u.feedRepo.Delete(ctx, repository.FeedFilter{
+		ID: &id,
+	})
+}
+
+// connect は設定を検証し、フィード・WordPress・GBPと疎通できるか確認してタイトルを設定する。
+func (u *feedUsecase) connect(ctx context.Context, feed *domain.Feed, checkFeed, checkWordpress, checkGbp bool) error {
+	if feed.HasWordpress() && feed.APIMode == "" {
+		feed.APIMode = domain.WordpressAPIModeRodut
+	}
+	if err := feed.Validate(); err != nil {
+		return err
+	}
+
+	if checkFeed {
+		channel, err := u.feedAdapter.Fetch(ctx, feed.FeedURL)
+		if err != nil {
+			return domain.ErrFeedConnection
+		}
+		feed.FeedTitle = channel.Title
+	}
+
+	if !feed.HasWordpress() {
+		feed.WordpressSiteTitle = ""
+	} else if checkWordpress {
+		title, err := u.wordpressAdapter.GetSiteTitle(ctx, feed.WordpressInstagram())
+		if err != nil {
+			return domain.ErrWordpressConnection
+		}
+		feed.WordpressSiteTitle = title
+	}
+
+	if !feed.HasGbp() {
+		feed.BusinessTitle = ""
+	} else if checkGbp {
+		account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, feed.BusinessName)
+		if err != nil {
+			return err
+		}
+		business, err := u.gbpAdapter.GetBusiness(ctx, account, feed.BusinessName)
+		if err != nil {
+			return domain.ErrBusinessConnection
+		}
+		if business.Title == "" {
+			return domain.ErrBusinessConnection
+		}
+		feed.BusinessName = business.Name
+		feed.BusinessTitle = business.Title
+	}
+	return nil
+}
+
+func (u *feedUsecase) getFeed(ctx context.Context, id int) (*domain.Feed, error) {
+	feed, err := u.feedRepo.Get(ctx, repository.FeedFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if feed.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return feed, nil
+}
+
+func toFeedResponse(feed *domain.Feed) res.Feed {
+	return res.Feed{
+		ID:                 feed.ID,
+		Name:               feed.Name,
+		Memo:               feed.Memo,
+		FeedURL:            feed.FeedURL,
+		FeedTitle:          feed.FeedTitle,
+		StartDate:          feed.StartDate,
+		Status:             int(feed.Status),
+		WordpressDomain:    feed.WordpressDomain,
+		WordpressSiteTitle: feed.WordpressSiteTitle,
+		APIMode:            string(feed.APIMode),
+		WordpressUsername:  feed.WordpressUsername,
+		Categories:         feed.Categories,
+		BusinessName:       feed.BusinessName,
+		BusinessTitle:      feed.BusinessTitle,
+		CallToActionType:   string(feed.CallToActionType),
+		CallToActionURL:    feed.CallToActionURL,
+	}
+}
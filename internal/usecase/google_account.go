@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/repository"
+)
+
+// findGoogleAccount はビジネス（locations/xxx）に紐づくGoogleアカウントを返す。
+func findGoogleAccount(
+	ctx context.Context,
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	googleAccountRepo repository.GoogleAccountRepository,
+	businessName string,
+) (*domain.GoogleAccount, error) {
+	business, err := googleBusinessRepo.Get(ctx, repository.GoogleBusinessFilter{
+		Name: &businessName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if business.ID == 0 || business.GoogleAccountID == 0 {
+		return nil, domain.ErrGoogleAccountNotFound
+	}
+	account, err := googleAccountRepo.Get(ctx, repository.GoogleAccountFilter{
+		ID: &business.GoogleAccountID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if account.ID == 0 {
+		return nil, domain.ErrGoogleAccountNotFound
+	}
+	return account, nil
+}
+
+// syncGoogleAccounts はトークンで参照できるGBPアカウントを保存し、アカウントとトークンを紐づける。
+func syncGoogleAccounts(
+	ctx context.Context,
+	gbpAdapter adapter.GbpAdapter,
+	googleAccountRepo repository.GoogleAccountRepository,
+	tokenID int,
+) error {
+	accounts, err := gbpAdapter.ListAccounts(ctx, tokenID)
+	if err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		stored, err := googleAccountRepo.Get(ctx, repository.GoogleAccountFilter{
+			Name: &account.Name,
+		})
+		if err != nil {
+			return err
+		}
+		if stored.ID == 0 {
+			if err := googleAccountRepo.Create(ctx, account); err != nil {
+				return err
+			}
+			continue
+		}
+		account.ID = stored.ID
+		if err := googleAccountRepo.Update(ctx, account, repository.GoogleAccountFilter{
+			ID: &stored.ID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveGoogleBusinesses はアカウントで参照できるビジネスを保存し、アカウントが紐づいていないビジネスに紐づける。
+func saveGoogleBusinesses(
+	ctx context.Context,
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	account *domain.GoogleAccount,
+	businesses []adapter.Business,
+) error {
+	for _, business := range businesses {
+		stored, err := googleBusinessRepo.Get(ctx, repository.GoogleBusinessFilter{
+			Name: &business.Name,
+		})
+		if err != nil {
+			return err
+		}
+		if stored.ID == 0 {
+			if err := googleBusinessRepo.Create(ctx, &domain.GoogleBusinesses{
+				GoogleAccountID: account.ID,
+				Name:            business.Name,
+				Title:           business.Title,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		// 複数のアカウントから参照できるビジネスは最初に紐づいたアカウントを使う
+		if stored.GoogleAccountID != 0 {
+			continue
+		}
+		stored.GoogleAccountID = account.ID
+		stored.Title = business.Title
+		if err := googleBusinessRepo.Update(ctx, stored, repository.GoogleBusinessFilter{
+			ID: &stored.ID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
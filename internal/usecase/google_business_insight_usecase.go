@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+const (
+	// insightsInitialDays は初回取り込み時に遡る日数
+	insightsInitialDays = 90
+	// insightsRefetchDays はGBPの指標が確定するまでの猶予として再取得する日数
+	insightsRefetchDays = 7
+)
+
+type GoogleBusinessInsightUsecase interface {
+	SyncInsights(ctx context.Context) error
+	GetInsights(ctx context.Context, id int, params req.GetGoogleBusinessInsights) (*res.GoogleBusinessInsights, error)
+}
+
+type googleBusinessInsightUsecase struct {
+	googleBusinessRepo       repository.GoogleBusinessRepository
+	googleBusinessMetricRepo repository.GoogleBusinessMetricRepository
+	googleAccountRepo        repository.GoogleAccountRepository
+	googlePostRepo           repository.GooglePostRepository
+	businessInstagramRepo    repository.BusinessInstagramRepository
+	wordpressGbpRepo         repository.WordpressGbpRepository
+	gbpAdapter               adapter.GbpAdapter
+	notificationUsecase      NotificationUsecase
+}
+
+func NewGoogleBusinessInsightUsecase(
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	googleBusinessMetricRepo repository.GoogleBusinessMetricRepository,
+	googleAccountRepo repository.GoogleAccountRepository,
+	googlePostRepo repository.GooglePostRepository,
+	businessInstagramRepo repository.BusinessInstagramRepository,
+	wordpressGbpRepo repository.WordpressGbpRepository,
+	gbpAdapter adapter.GbpAdapter,
+	notificationUsecase NotificationUsecase,
+) GoogleBusinessInsightUsecase {
+	return &googleBusinessInsightUsecase{
+		googleBusinessRepo:       googleBusinessRepo,
+		googleBusinessMetricRepo: googleBusinessMetricRepo,
+		googleAccountRepo:        googleAccountRepo,
+		googlePostRepo:           googlePostRepo,
+		businessInstagramRepo:    businessInstagramRepo,
+		wordpressGbpRepo:         wordpressGbpRepo,
+		gbpAdapter:               gbpAdapter,
+		notificationUsecase:      notificationUsecase,
+	}
+}
+
+func (u *googleBusinessInsightUsecase) SyncInsights(ctx context.Context) error {
+	businesses, err := u.googleBusinessRepo.FindAll(ctx, repository.GoogleBusinessFilter{
+		All: util.Pointer(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	// 当日分は集計中のため前日までを取得する
+	today := truncateToDate(time.Now())
+	to := today.AddDate(0, 0, -1)
+
+	for _, business := range businesses {
+		/*
+			保存済みの最新日付から取得範囲を決める
+		*/
+		from := today.AddDate(0, 0, -insightsInitialDays)
+		latest, err := u.googleBusinessMetricRepo.LatestDate(ctx, business.Name)
+		if err != nil {
+			return err
+		}
+		if latest != nil {
+			refetchFrom := truncateToDate(*latest).AddDate(0, 0, -insightsRefetchDays)
+			if refetchFrom.After(from) {
+				from = refetchFrom
+			}
+		}
+		if from.After(to) {
+			continue
+		}
+
+		/*
+			GBPから日次指標を取得して保存
+		*/
+		account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, business.Name)
+		if err != nil {
+			u.notificationUsecase.Notify(ctx, domain.NewSyncFailedNotification("google business insights", err, business.Account()))
+			continue
+		}
+		metrics, err := u.gbpAdapter.FetchDailyMetrics(ctx, account, business.Name, from, to)
+		if err != nil {
+			u.notificationUsecase.Notify(ctx, domain.NewSyncFailedNotification("google business insights", err, business.Account()))
+			continue
+		}
+		if err := u.googleBusinessMetricRepo.Upsert(ctx, metrics); err != nil {
+			return err
+		}
+		slog.Info("指標取り込み完了", "business", business.Name, "count", len(metrics))
+	}
+	return nil
+}
+
+func (u *googleBusinessInsightUsecase) GetInsights(ctx context.Context, id int, params req.GetGoogleBusinessInsights) (*res.GoogleBusinessInsights, error) {
+	business, err := u.googleBusinessRepo.Get(ctx, repository.GoogleBusinessFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if business.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	/*
+		期間を決める（未指定の場合は当月）
+	*/
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	to := truncateToDate(now)
+	if params.From != nil {
+		if from, err = time.ParseInLocation("2006-01-02", *params.From, time.Local); err != nil {
+			return nil, fmt.Errorf("%w: from の形式が不正です", domain.ErrBadRequest)
+		}
+	}
+	if params.To != nil {
+		if to, err = time.ParseInLocation("2006-01-02", *params.To, time.Local); err != nil {
+			return nil, fmt.Errorf("%w: to の形式が不正です", domain.ErrBadRequest)
+		}
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to は from 以降を指定してください", domain.ErrBadRequest)
+	}
+
+	metrics, err := u.googleBusinessMetricRepo.FindAll(ctx, repository.GoogleBusinessMetricFilter{
+		BusinessName:   &business.Name,
+		DateFrom:       &from,
+		DateTo:         &to,
+		OrderByDateAsc: util.Pointer(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	/*
+		日次・月次・期間合計に集計
+	*/
+	var summary domain.GoogleBusinessMetricSummary
+	monthlySummary := map[string]*domain.GoogleBusinessMetricSummary{}
+	dailyIndex := map[string]int{}
+	var daily []res.GoogleBusinessInsightsDaily
+	for _, metric := range metrics {
+		summary.Add(metric.Metric, metric.Value)
+
+		month := metric.Date.Format("2006-01")
+		if _, ok := monthlySummary[month]; !ok {
+			monthlySummary[month] = &domain.GoogleBusinessMetricSummary{}
+		}
+		monthlySummary[month].Add(metric.Metric, metric.Value)
+
+		date := metric.Date.Format("2006-01-02")
+		idx, ok := dailyIndex[date]
+		if !ok {
+			idx = len(daily)
+			dailyIndex[date] = idx
+			daily = append(daily, res.GoogleBusinessInsightsDaily{
+				Date:    date,
+				Metrics: map[string]int64{},
+			})
+		}
+		daily[idx].Metrics[metric.Metric] = metric.Value
+	}
+
+	/*
+		homingの投稿数（このビジネスに紐づくBusinessInstagram / WordpressGbpの合計）
+	*/
+	customerIDs, err := googlePostCustomerIDs(ctx, u.businessInstagramRepo, u.wordpressGbpRepo, business.Name)
+	if err != nil {
+		return nil, err
+	}
+	toExclusive := to.AddDate(0, 0, 1)
+	photosCount, postsCount, err := u.countPosts(ctx, customerIDs, from, toExclusive)
+	if err != nil {
+		return nil, err
+	}
+
+	var monthly []res.GoogleBusinessInsightsMonthly
+	for monthStart := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.Local); monthStart.Before(toExclusive); monthStart = monthStart.AddDate(0, 1, 0) {
+		rangeFrom, rangeTo := monthStart, monthStart.AddDate(0, 1, 0)
+		if rangeFrom.Before(from) {
+			rangeFrom = from
+		}
+		if rangeTo.After(toExclusive) {
+			rangeTo = toExclusive
+		}
+		monthPhotos, monthPosts, err := u.countPosts(ctx, customerIDs, rangeFrom, rangeTo)
+		if err != nil {
+			return nil, err
+		}
+
+		month := monthStart.Format("2006-01")
+		monthSummary := domain.GoogleBusinessMetricSummary{}
+		if s, ok := monthlySummary[month]; ok {
+			monthSummary = *s
+		}
+		monthly = append(monthly, res.GoogleBusinessInsightsMonthly{
+			Month:             month,
+			Summary:           toMetricSummaryResponse(monthSummary),
+			GooglePhotosCount: monthPhotos,
+			GooglePostsCount:  monthPosts,
+		})
+	}
+
+	return &res.GoogleBusinessInsights{
+		ID:                business.ID,
+		Name:              business.Name,
+		Title:             business.Title,
+		From:              from.Format("2006-01-02"),
+		To:                to.Format("2006-01-02"),
+		Summary:           toMetricSummaryResponse(summary),
+		GooglePhotosCount: photosCount,
+		GooglePostsCount:  postsCount,
+		Monthly:           monthly,
+		Daily:             daily,
+	}, nil
+}
+
+// countPosts は期間内にhomingが投稿し、GBP上に残っている写真とLocal Postの数を返す。
+// 投稿中・投稿できたか確認できない記録と、保持期間で削除した投稿は数えない。
+func (u *googleBusinessInsightUsecase) countPosts(ctx context.Context, customerIDs []int, from, to time.Time) (int64, int64, error) {
+	photosCount, err := u.googlePostRepo.Count(ctx, repository.GooglePostFilter{
+		CustomerIDs:   customerIDs,
+		PostType:      util.Pointer(domain.PostTypePhoto),
+		Status:        util.Pointer(string(domain.SyncRecordPublished)),
+		Deleted:       util.Pointer(false),
+		CreatedAtFrom: &from,
+		CreatedAtTo:   &to,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	postsCount, err := u.googlePostRepo.Count(ctx, repository.GooglePostFilter{
+		CustomerIDs:   customerIDs,
+		PostType:      util.Pointer(domain.PostTypePost),
+		Status:        util.Pointer(string(domain.SyncRecordPublished)),
+		Deleted:       util.Pointer(false),
+		CreatedAtFrom: &from,
+		CreatedAtTo:   &to,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return photosCount, postsCount, nil
+}
+
+func toMetricSummaryResponse(s domain.GoogleBusinessMetricSummary) res.GoogleBusinessMetricSummary {
+	return res.GoogleBusinessMetricSummary{
+		Views:             s.Views,
+		Searches:          s.Searches,
+		Calls:             s.Calls,
+		WebsiteClicks:     s.WebsiteClicks,
+		DirectionRequests: s.DirectionRequests,
+		Conversations:     s.Conversations,
+		Bookings:          s.Bookings,
+	}
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+type insightMetricRepo struct {
+	repository.GoogleBusinessMetricRepository
+	latest  map[string]time.Time
+	metrics []*domain.GoogleBusinessMetric
+}
+
+func (f *insightMetricRepo) LatestDate(_ context.Context, businessName string) (*time.Time, error) {
+	if latest, ok := f.latest[businessName]; ok {
+		return &latest, nil
+	}
+	return nil, nil
+}
+
+func (f *insightMetricRepo) Upsert(_ context.Context, metrics []*domain.GoogleBusinessMetric) error {
+	f.metrics = append(f.metrics, metrics...)
+	return nil
+}
+
+func (f *insightMetricRepo) FindAll(_ context.Context, filter repository.GoogleBusinessMetricFilter) ([]*domain.GoogleBusinessMetric, error) {
+	var metrics []*domain.GoogleBusinessMetric
+	for _, metric := range f.metrics {
+		if metric.BusinessName == *filter.BusinessName && !metric.Date.Before(*filter.DateFrom) && !metric.Date.After(*filter.DateTo) {
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics, nil
+}
+
+// insightBusinesses は指標を取り込むビジネス
+func insightBusinesses() []*domain.GoogleBusinesses {
+	return []*domain.GoogleBusinesses{
+		{ID: 1, GoogleAccountID: 1, Name: "locations/1", Title: "本店"},
+		{ID: 2, GoogleAccountID: 1, Name: "locations/2", Title: "支店"},
+		// アカウントが紐づいていない
+		{ID: 3, Name: "locations/3", Title: "新店"},
+	}
+}
+
+func TestGoogleBusinessInsightUsecase_SyncInsights(t *testing.T) {
+	metricRepo := &insightMetricRepo{latest: map[string]time.Time{}}
+	gbp := &fakeGbpAdapter{}
+	notification := &fakeNotificationUsecase{}
+	u := NewGoogleBusinessInsightUsecase(
+		&fakeGoogleBusinessRepo{businesses: insightBusinesses()}, metricRepo, newFakeGoogleAccountRepo(), &fakeGooglePostRepo{},
+		&fakeBusinessInstagramRepo{}, &fakeWordpressGbpRepo{}, gbp, notification,
+	)
+	today := truncateToDate(time.Now())
+	yesterday := today.AddDate(0, 0, -1)
+	date := func(d time.Time) string { return d.Format("2006-01-02") }
+
+	// 取り込み済みのビジネスは、指標が確定するまでの7日分を取り直す
+	metricRepo.latest["locations/2"] = yesterday
+
+	require.NoError(t, u.SyncInsights(context.Background()))
+	assert.Equal(t, []string{
+		"locations/1:" + date(today.AddDate(0, 0, -insightsInitialDays)) + "~" + date(yesterday),
+		"locations/2:" + date(yesterday.AddDate(0, 0, -insightsRefetchDays)) + "~" + date(yesterday),
+	}, gbp.metricRanges)
+	assert.Len(t, metricRepo.metrics, 2)
+
+	// アカウントが無いビジネスは通知して次のビジネスを続ける
+	require.Len(t, notification.notifications, 1)
+	assert.Equal(t, 3, notification.notifications[0].Account.ID)
+}
+
+func TestGoogleBusinessInsightUsecase_GetInsights(t *testing.T) {
+	metricRepo := &insightMetricRepo{}
+	postRepo := &fakeGooglePostRepo{}
+	u := NewGoogleBusinessInsightUsecase(
+		&fakeGoogleBusinessRepo{businesses: insightBusinesses()}, metricRepo, newFakeGoogleAccountRepo(), postRepo,
+		&fakeBusinessInstagramRepo{biList: []*domain.BusinessInstagram{{ID: 1}}},
+		&fakeWordpressGbpRepo{wgList: []*domain.WordpressGbp{{ID: 2}}},
+		&fakeGbpAdapter{}, &fakeNotificationUsecase{},
+	)
+	day := func(month time.Month, d int) time.Time { return time.Date(2026, month, d, 0, 0, 0, 0, time.Local) }
+	metricRepo.metrics = []*domain.GoogleBusinessMetric{
+		{BusinessName: "locations/1", Metric: domain.MetricImpressionsMobileMaps, Date: day(1, 31), Value: 10},
+		{BusinessName: "locations/1", Metric: domain.MetricImpressionsDesktopMaps, Date: day(1, 31), Value: 5},
+		{BusinessName: "locations/1", Metric: domain.MetricCallClicks, Date: day(2, 1), Value: 2},
+		{BusinessName: "locations/1", Metric: domain.MetricCallClicks, Date: day(2, 2), Value: 3},
+		// 期間外・別のビジネス
+		{BusinessName: "locations/1", Metric: domain.MetricCallClicks, Date: day(1, 29), Value: 100},
+		{BusinessName: "locations/2", Metric: domain.MetricCallClicks, Date: day(2, 1), Value: 100},
+	}
+	deletedAt := day(2, 10)
+	postRepo.records = []*domain.GooglePost{
+		{CustomerID: 1, PostType: domain.PostTypePhoto, Status: domain.SyncRecordPublished, CreatedAt: day(1, 31).Add(time.Hour)},
+		{CustomerID: 300002, PostType: domain.PostTypePost, Status: domain.SyncRecordPublished, CreatedAt: day(2, 2).Add(23 * time.Hour)},
+		{CustomerID: 1, PostType: domain.PostTypePost, Status: domain.SyncRecordPublished, CreatedAt: day(2, 1)},
+		// 投稿中・投稿できたか確認できない記録と、保持期間で削除した投稿は数えない
+		{CustomerID: 1, PostType: domain.PostTypePhoto, Status: domain.SyncRecordReserved, CreatedAt: day(2, 1)},
+		{CustomerID: 1, PostType: domain.PostTypePhoto, Status: domain.SyncRecordUnconfirmed, CreatedAt: day(2, 1)},
+		{CustomerID: 1, PostType: domain.PostTypePhoto, Status: domain.SyncRecordPublished, DeletedAt: &deletedAt, CreatedAt: day(2, 1)},
+		// 期間外・別の連携
+		{CustomerID: 1, PostType: domain.PostTypePhoto, Status: domain.SyncRecordPublished, CreatedAt: day(2, 3)},
+		{CustomerID: 9, PostType: domain.PostTypePhoto, Status: domain.SyncRecordPublished, CreatedAt: day(2, 1)},
+	}
+
+	insights, err := u.GetInsights(context.Background(), 1, req.GetGoogleBusinessInsights{
+		From: util.Pointer("2026-01-30"),
+		To:   util.Pointer("2026-02-02"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(15), insights.Summary.Views)
+	assert.Equal(t, int64(5), insights.Summary.Calls)
+	assert.Equal(t, int64(1), insights.GooglePhotosCount)
+	assert.Equal(t, int64(2), insights.GooglePostsCount)
+
+	// 月次は期間で区切って集計する
+	require.Len(t, insights.Monthly, 2)
+	assert.Equal(t, "2026-01", insights.Monthly[0].Month)
+	assert.Equal(t, int64(15), insights.Monthly[0].Summary.Views)
+	assert.Equal(t, int64(0), insights.Monthly[0].Summary.Calls)
+	assert.Equal(t, int64(1), insights.Monthly[0].GooglePhotosCount)
+	assert.Equal(t, int64(0), insights.Monthly[0].GooglePostsCount)
+	assert.Equal(t, "2026-02", insights.Monthly[1].Month)
+	assert.Equal(t, int64(5), insights.Monthly[1].Summary.Calls)
+	assert.Equal(t, int64(0), insights.Monthly[1].GooglePhotosCount)
+	assert.Equal(t, int64(2), insights.Monthly[1].GooglePostsCount)
+
+	// 日次は指標がある日だけ返す
+	require.Len(t, insights.Daily, 3)
+	assert.Equal(t, "2026-01-31", insights.Daily[0].Date)
+	assert.Equal(t, map[string]int64{domain.MetricImpressionsMobileMaps: 10, domain.MetricImpressionsDesktopMaps: 5}, insights.Daily[0].Metrics)
+
+	_, err = u.GetInsights(context.Background(), 1, req.GetGoogleBusinessInsights{
+		From: util.Pointer("2026-02-02"),
+		To:   util.Pointer("2026-02-01"),
+	})
+	assert.ErrorIs(t, err, domain.ErrBadRequest)
+	_, err = u.GetInsights(context.Background(), 9, req.GetGoogleBusinessInsights{})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+}
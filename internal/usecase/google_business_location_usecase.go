@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+)
+
+type GoogleBusinessLocationUsecase interface {
+	GetLocation(ctx context.Context, id int) (*res.GoogleBusinessLocation, error)
+	PreviewLocation(ctx context.Context, id int, body req.UpdateGoogleBusinessLocation) (*res.GoogleBusinessLocationPreview, error)
+	UpdateLocation(ctx context.Context, id int, body req.UpdateGoogleBusinessLocation) (*res.GoogleBusinessLocationPreview, error)
+	GetLocationChanges(ctx context.Context, id int, params req.GetGoogleBusinessLocationChanges) (*res.GoogleBusinessLocationChangeList, error)
+}
+
+type googleBusinessLocationUsecase struct {
+	googleBusinessRepo repository.GoogleBusinessRepository
+	googleAccountRepo  repository.GoogleAccountRepository
+	locationChangeRepo repository.GoogleBusinessLocationChangeRepository
+	gbpAdapter         adapter.GbpAdapter
+}
+
+func NewGoogleBusinessLocationUsecase(
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	googleAccountRepo repository.GoogleAccountRepository,
+	locationChangeRepo repository.GoogleBusinessLocationChangeRepository,
+	gbpAdapter adapter.GbpAdapter,
+) GoogleBusinessLocationUsecase {
+	return &googleBusinessLocationUsecase{
+		googleBusinessRepo: googleBusinessRepo,
+		googleAccountRepo:  googleAccountRepo,
+		locationChangeRepo: locationChangeRepo,
+		gbpAdapter:         gbpAdapter,
+	}
+}
+
+func (u *googleBusinessLocationUsecase) GetLocation(ctx context.Context, id int) (*res.GoogleBusinessLocation, error) {
+	business, account, err := u.getBusiness(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	info, err := u.gbpAdapter.GetLocationInfo(ctx, account, business.Name)
+	if err != nil {
+		return nil, err
+	}
+	resp := toGoogleBusinessLocationResponse(business.ID, info)
+	return &resp, nil
+}
+
+func (u *googleBusinessLocationUsecase) PreviewLocation(ctx context.Context, id int, body req.UpdateGoogleBusinessLocation) (*res.GoogleBusinessLocationPreview, error) {
+	business, account, update, changes, current, err := u.diff(ctx, id, body)
+	if err != nil {
+		return nil, err
+	}
+
+	/*
+		変更がある場合はGBP側でも検証する（更新はしない）
+	*/
+	if len(changes) > 0 {
+		if err := u.gbpAdapter.UpdateLocationInfo(ctx, account, business.Name, update.Apply(current), changedFields(changes), true); err != nil {
+			return nil, err
+		}
+	}
+	return toGoogleBusinessLocationPreview(business, changes)
+}
+
+func (u *googleBusinessLocationUsecase) UpdateLocation(ctx context.Context, id int, body req.UpdateGoogleBusinessLocation) (*res.GoogleBusinessLocationPreview, error) {
+	business, account, update, changes, current, err := u.diff(ctx, id, body)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return toGoogleBusinessLocationPreview(business, changes)
+	}
+
+	/*
+		変更のある項目のみGBPに反映
+	*/
+	if err := u.gbpAdapter.UpdateLocationInfo(ctx, account, business.Name, update.Apply(current), changedFields(changes), false); err != nil {
+		return nil, err
+	}
+
+	/*
+		変更履歴を保存
+	*/
+	preview, err := toGoogleBusinessLocationPreview(business, changes)
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]*domain.GoogleBusinessLocationChange, 0, len(preview.Changes))
+	for _, c := range preview.Changes {
+		logs = append(logs, &domain.GoogleBusinessLocationChange{
+			BusinessName: business.Name,
+			Field:        c.Field,
+			Before:       string(c.Before),
+			After:        string(c.After),
+		})
+	}
+	if err := u.locationChangeRepo.BulkCreate(ctx, logs); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+func (u *googleBusinessLocationUsecase) GetLocationChanges(ctx context.Context, id int, params req.GetGoogleBusinessLocationChanges) (*res.GoogleBusinessLocationChangeList, error) {
+	business, err := u.googleBusinessRepo.Get(ctx, repository.GoogleBusinessFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if business.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	filter := repository.GoogleBusinessLocationChangeFilter{
+		BusinessName: &business.Name,
+		Limit:        params.Limit,
+		Offset:       params.Offset,
+	}
+	changes, err := u.locationChangeRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.locationChangeRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]res.GoogleBusinessLocationChange, len(changes))
+	for i, c := range changes {
+		list[i] = res.GoogleBusinessLocationChange{
+			ID:        c.ID,
+			Field:     c.Field,
+			Before:    json.RawMessage(c.Before),
+			After:     json.RawMessage(c.After),
+			CreatedAt: c.CreatedAt,
+		}
+	}
+	return &res.GoogleBusinessLocationChangeList{
+		GoogleBusinessLocationChangeList: list,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(changes),
+		},
+	}, nil
+}
+
+// diff は現在のビジネス情報を取得し、更新内容との差分を返す。
+func (u *googleBusinessLocationUsecase) diff(ctx context.Context, id int, body req.UpdateGoogleBusinessLocation) (
+	*domain.GoogleBusinesses, *domain.GoogleAccount, domain.GbpLocationUpdate, []domain.GbpLocationChange, domain.GbpLocationInfo, error,
+) {
+	update := toGbpLocationUpdate(body)
+	if err := update.Validate(); err != nil {
+		return nil, nil, update, nil, domain.GbpLocationInfo{}, err
+	}
+	business, account, err := u.getBusiness(ctx, id)
+	if err != nil {
+		return nil, nil, update, nil, domain.GbpLocationInfo{}, err
+	}
+	current, err := u.gbpAdapter.GetLocationInfo(ctx, account, business.Name)
+	if err != nil {
+		return nil, nil, update, nil, domain.GbpLocationInfo{}, err
+	}
+	return business, account, update, update.Diff(current), current, nil
+}
+
+func (u *googleBusinessLocationUsecase) getBusiness(ctx context.Context, id int) (*domain.GoogleBusinesses, *domain.GoogleAccount, error) {
+	business, err := u.googleBusinessRepo.Get(ctx, repository.GoogleBusinessFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if business.ID == 0 {
+		return nil, nil, domain.ErrNotFound
+	}
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, business.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return business, account, nil
+}
+
+func changedFields(changes []domain.GbpLocationChange) []string {
+	fields := make([]string, 0, len(changes))
+	for _, c := range changes {
+		fields = append(fields, c.Field)
+	}
+	return fields
+}
+
+func toGbpLocationUpdate(body req.UpdateGoogleBusinessLocation) domain.GbpLocationUpdate {
+	update := domain.GbpLocationUpdate{
+		Description:  body.Description,
+		PrimaryPhone: body.PrimaryPhone,
+		WebsiteURI:   body.WebsiteURI,
+	}
+	if body.RegularHours != nil {
+		periods := make([]domain.GbpTimePeriod, 0, len(*body.RegularHours))
+		for _, p := range *body.RegularHours {
+			periods = append(periods, domain.GbpTimePeriod{
+				OpenDay:   p.OpenDay,
+				OpenTime:  p.OpenTime,
+				CloseDay:  p.CloseDay,
+				CloseTime: p.CloseTime,
+			})
+		}
+		update.RegularHours = &periods
+	}
+	if body.SpecialHours != nil {
+		specialHours := toGbpSpecialHours(*body.SpecialHours)
+		update.SpecialHours = &specialHours
+	}
+	update.AddSpecialHours = toGbpSpecialHours(body.AddSpecialHours)
+	for _, a := range body.Attributes {
+		update.Attributes = append(update.Attributes, domain.GbpAttribute{
+			Name:   a.Name,
+			Values: a.Values,
+			URIs:   a.URIs,
+		})
+	}
+	return update
+}
+
+func toGbpSpecialHours(periods []req.GoogleBusinessSpecialHours) []domain.GbpSpecialHours {
+	specialHours := make([]domain.GbpSpecialHours, 0, len(periods))
+	for _, p := range periods {
+		specialHours = append(specialHours, domain.GbpSpecialHours{
+			StartDate: p.StartDate,
+			EndDate:   p.EndDate,
+			OpenTime:  p.OpenTime,
+			CloseTime: p.CloseTime,
+			Closed:    p.Closed,
+		})
+	}
+	return specialHours
+}
+
+func toGoogleBusinessLocationResponse(id int, info domain.GbpLocationInfo) res.GoogleBusinessLocation {
+	return res.GoogleBusinessLocation{
+		ID:               id,
+		Name:             info.Name,
+		Title:            info.Title,
+		Description:      info.Description,
+		PrimaryPhone:     info.PrimaryPhone,
+		AdditionalPhones: info.AdditionalPhones,
+		WebsiteURI:       info.WebsiteURI,
+		RegularHours:     toTimePeriodResponse(info.RegularHours),
+		SpecialHours:     toSpecialHoursResponse(info.SpecialHours),
+		Attributes:       toAttributeResponse(info.Attributes),
+	}
+}
+
+func toTimePeriodResponse(periods []domain.GbpTimePeriod) []res.GoogleBusinessTimePeriod {
+	resp := make([]res.GoogleBusinessTimePeriod, 0, len(periods))
+	for _, p := range periods {
+		resp = append(resp, res.GoogleBusinessTimePeriod{
+			OpenDay:   p.OpenDay,
+			OpenTime:  p.OpenTime,
+			CloseDay:  p.CloseDay,
+			CloseTime: p.CloseTime,
+		})
+	}
+	return resp
+}
+
+func toSpecialHoursResponse(periods []domain.GbpSpecialHours) []res.GoogleBusinessSpecialHours {
+	resp := make([]res.GoogleBusinessSpecialHours, 0, len(periods))
+	for _, p := range periods {
+		resp = append(resp, res.GoogleBusinessSpecialHours{
+			StartDate: p.StartDate,
+			EndDate:   p.EndDate,
+			OpenTime:  p.OpenTime,
+			CloseTime: p.CloseTime,
+			Closed:    p.Closed,
+		})
+	}
+	return resp
+}
+
+func toAttributeResponse(attrs []domain.GbpAttribute) []res.GoogleBusinessAttribute {
+	resp := make([]res.GoogleBusinessAttribute, 0, len(attrs))
+	for _, a := range attrs {
+		resp = append(resp, res.GoogleBusinessAttribute{
+			Name:   a.Name,
+			Values: a.Values,
+			URIs:   a.URIs,
+		})
+	}
+	return resp
+}
+
+// toGoogleBusinessLocationPreview は差分をレスポンスの形式（JSON）に変換する。
+func toGoogleBusinessLocationPreview(business *domain.GoogleBusinesses, changes []domain.GbpLocationChange) (*res.GoogleBusinessLocationPreview, error) {
+	diffs := make([]res.GoogleBusinessLocationDiff, 0, len(changes))
+	for _, c := range changes {
+		before, err := json.Marshal(toLocationValueResponse(c.Before))
+		if err != nil {
+			return nil, err
+		}
+		after, err := json.Marshal(toLocationValueResponse(c.After))
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, res.GoogleBusinessLocationDiff{
+			Field:  c.Field,
+			Before: before,
+			After:  after,
+		})
+	}
+	return &res.GoogleBusinessLocationPreview{
+		ID:      business.ID,
+		Name:    business.Name,
+		Changes: diffs,
+	}, nil
+}
+
+func toLocationValueResponse(v any) any {
+	switch value := v.(type) {
+	case []domain.GbpTimePeriod:
+		return toTimePeriodResponse(value)
+	case []domain.GbpSpecialHours:
+		return toSpecialHoursResponse(value)
+	case domain.GbpAttribute:
+		return toAttributeResponse([]domain.GbpAttribute{value})[0]
+	default:
+		return v
+	}
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// googleOAuthStateTTL は認証開始からコールバックまでの有効期限
+	googleOAuthStateTTL = 10 * time.Minute
+	// legacyGoogleTokenFile は cmd/gbp で保存していたトークンファイル（DBが空の場合のみ取り込む）
+	legacyGoogleTokenFile = "./credentials/token.json"
+)
+
+type GoogleOAuthUsecase interface {
+	LoadToken(ctx context.Context) error
+	StartAuthorization(ctx context.Context) (string, error)
+	Callback(ctx context.Context, params req.GoogleOAuthCallback) error
+}
+
+type googleOAuthPending struct {
+	verifier  string
+	expiresAt time.Time
+}
+
+type googleOAuthUsecase struct {
+	googleOAuthTokenRepo repository.GoogleOAuthTokenRepository
+	googleAccountRepo    repository.GoogleAccountRepository
+	googleBusinessRepo   repository.GoogleBusinessRepository
+	googleOAuth          adapter.GoogleOAuth
+	gbpAdapter           adapter.GbpAdapter
+
+	mu      sync.Mutex
+	pending map[string]googleOAuthPending
+}
+
+func NewGoogleOAuthUsecase(
+	googleOAuthTokenRepo repository.GoogleOAuthTokenRepository,
+	googleAccountRepo repository.GoogleAccountRepository,
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	googleOAuth adapter.GoogleOAuth,
+	gbpAdapter adapter.GbpAdapter,
+) GoogleOAuthUsecase {
+	return &googleOAuthUsecase{
+		googleOAuthTokenRepo: googleOAuthTokenRepo,
+		googleAccountRepo:    googleAccountRepo,
+		googleBusinessRepo:   googleBusinessRepo,
+		googleOAuth:          googleOAuth,
+		gbpAdapter:           gbpAdapter,
+		pending:              map[string]googleOAuthPending{},
+	}
+}
+
+// LoadToken はDBに保存されたトークンをすべてOAuthクライアントに設定する。起動時に一度呼ぶ。
+func (u *googleOAuthUsecase) LoadToken(ctx context.Context) error {
+	/*
+		自動更新されたトークンはDBに書き戻す
+	*/
+	u.googleOAuth.OnTokenRefresh(func(token *domain.GoogleOAuthToken) {
+		if err := u.updateToken(context.Background(), token); err != nil {
+			slog.Error("Googleトークン保存エラー", "error", err.Error())
+		}
+	})
+
+	tokens, err := u.googleOAuthTokenRepo.FindAll(ctx, repository.GoogleOAuthTokenFilter{})
+	if err != nil {
+		return err
+	}
+
+	/*
+		DBが空の場合は従来のtoken.jsonを取り込む
+	*/
+	if len(tokens) == 0 {
+		legacy, err := readLegacyGoogleToken(legacyGoogleTokenFile)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				slog.Warn("Googleアカウントが未認証です。/api/oauth/google/start から認証してください")
+				return nil
+			}
+			return err
+		}
+		if err := u.googleOAuthTokenRepo.Create(ctx, legacy); err != nil {
+			return err
+		}
+		slog.Info("token.json をDBに取り込みました")
+		tokens = append(tokens, legacy)
+	}
+
+	for _, token := range tokens {
+		u.googleOAuth.SetToken(token)
+	}
+
+	/*
+		Googleアカウントが無い場合（複数アカウント対応前のDB、token.jsonを取り込んだ直後）は
+		トークンで参照できるアカウントを保存し、既存のビジネスに紐づける
+	*/
+	accounts, err := u.googleAccountRepo.FindAll(ctx, repository.GoogleAccountFilter{
+		All: util.Pointer(true),
+	})
+	if err != nil {
+		return err
+	}
+	if len(accounts) == 0 {
+		u.bootstrapGoogleAccounts(ctx, tokens)
+	}
+	return nil
+}
+
+// bootstrapGoogleAccounts はトークンで参照できるアカウントを保存し、ビジネスにアカウントを紐づける。
+// GBP APIに接続できなくても起動は続け、/api/google-business/fetch で後から紐づけられるようエラーはログに残すだけにする。
+func (u *googleOAuthUsecase) bootstrapGoogleAccounts(ctx context.Context, tokens []*domain.GoogleOAuthToken) {
+	for _, token := range tokens {
+		if err := syncGoogleAccounts(ctx, u.gbpAdapter, u.googleAccountRepo, token.ID); err != nil {
+			slog.Error("Googleアカウント同期エラー", "token_id", token.ID, "error", err.Error())
+		}
+	}
+	accounts, err := u.googleAccountRepo.FindAll(ctx, repository.GoogleAccountFilter{
+		All: util.Pointer(true),
+	})
+	if err != nil {
+		slog.Error("Googleアカウント取得エラー", "error", err.Error())
+		return
+	}
+	for _, account := range accounts {
+		businesses, err := u.gbpAdapter.GetAllBusinesses(ctx, account)
+		if err != nil {
+			slog.Error("ビジネス取得エラー", "account", account.Name, "error", err.Error())
+			continue
+		}
+		if err := saveGoogleBusinesses(ctx, u.googleBusinessRepo, account, businesses); err != nil {
+			slog.Error("ビジネス保存エラー", "account", account.Name, "error", err.Error())
+		}
+	}
+	if len(accounts) > 0 {
+		slog.Info("Googleアカウントをビジネスに紐づけました", "accounts", len(accounts))
+	}
+}
+
+func (u *googleOAuthUsecase) StartAuthorization(ctx context.Context) (string, error) {
+	state, err := randomState()
+	if err != nil {
+		return "", err
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	u.mu.Lock()
+	now := time.Now()
+	for s, p := range u.pending {
+		if now.After(p.expiresAt) {
+			delete(u.pending, s)
+		}
+	}
+	u.pending[state] = googleOAuthPending{
+		verifier:  verifier,
+		expiresAt: now.Add(googleOAuthStateTTL),
+	}
+	u.mu.Unlock()
+
+	return u.googleOAuth.AuthCodeURL(state, verifier), nil
+}
+
+func (u *googleOAuthUsecase) Callback(ctx context.Context, params req.GoogleOAuthCallback) error {
+	/*
+		stateを検証（一度使ったstateは破棄する）
+	*/
+	u.mu.Lock()
+	pending, ok := u.pending[params.State]
+	delete(u.pending, params.State)
+	u.mu.Unlock()
+	if !ok || time.Now().After(pending.expiresAt) {
+		return fmt.Errorf("%w: stateが不正か有効期限切れです。認証をやり直してください", domain.ErrBadRequest)
+	}
+	if params.Error != "" {
+		return fmt.Errorf("%w: Google認証が拒否されました: %s", domain.ErrBadRequest, params.Error)
+	}
+	if params.Code == "" {
+		return fmt.Errorf("%w: codeがありません", domain.ErrBadRequest)
+	}
+
+	/*
+		認証コードをトークンに交換して保存
+	*/
+	token, err := u.googleOAuth.Exchange(ctx, params.Code, pending.verifier)
+	if err != nil {
+		return err
+	}
+	if token.RefreshToken == "" {
+		return errors.New("refresh_tokenが取得できませんでした。Googleアカウントのアクセス権を削除してから再認証してください")
+	}
+	if err := u.googleOAuthTokenRepo.Create(ctx, token); err != nil {
+		return err
+	}
+
+	/*
+		稼働中のOAuthクライアントにトークンを追加し、参照できるアカウントを紐づける
+	*/
+	u.googleOAuth.SetToken(token)
+	if err := syncGoogleAccounts(ctx, u.gbpAdapter, u.googleAccountRepo, token.ID); err != nil {
+		return err
+	}
+
+	/*
+		再認証でどのアカウントからも使われなくなったトークンを削除
+	*/
+	tokens, err := u.googleOAuthTokenRepo.FindAll(ctx, repository.GoogleOAuthTokenFilter{})
+	if err != nil {
+		return err
+	}
+	for _, t := range tokens {
+		if t.ID == token.ID {
+			continue
+		}
+		accounts, err := u.googleAccountRepo.FindAll(ctx, repository.GoogleAccountFilter{
+			GoogleOAuthTokenID: &t.ID,
+		})
+		if err != nil {
+			return err
+		}
+		if len(accounts) > 0 {
+			continue
+		}
+		if err := u.googleOAuthTokenRepo.Delete(ctx, repository.GoogleOAuthTokenFilter{
+			ID: &t.ID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("Googleアカウントの認証が完了しました")
+	return nil
+}
+
+// updateToken は自動更新されたトークンを保存する。refresh_token が空の場合は保存済みのものを引き継ぐ。
+func (u *googleOAuthUsecase) updateToken(ctx context.Context, token *domain.GoogleOAuthToken) error {
+	stored, err := u.googleOAuthTokenRepo.Get(ctx, repository.GoogleOAuthTokenFilter{
+		ID: &token.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if stored.ID == 0 {
+		return domain.ErrNotFound
+	}
+	if token.RefreshToken == "" {
+		token.RefreshToken = stored.RefreshToken
+	}
+	return u.googleOAuthTokenRepo.Update(ctx, token, repository.GoogleOAuthTokenFilter{
+		ID: &stored.ID,
+	})
+}
+
+func readLegacyGoogleToken(path string) (*domain.GoogleOAuthToken, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tok oauth2.Token
+	if err := json.Unmarshal(data, &tok); err != nil {
+		return nil, fmt.Errorf("token.json パースエラー: %w", err)
+	}
+	return &domain.GoogleOAuthToken{
+		AccessToken:  tok.AccessToken,
+		RefreshToken: tok.RefreshToken,
+		TokenType:    tok.TokenType,
+		Expiry:       tok.Expiry,
+	}, nil
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/repository"
+)
+
+type googleOAuthTokenRepo struct {
+	repository.GoogleOAuthTokenRepository
+	tokens []*domain.GoogleOAuthToken
+}
+
+func (f *googleOAuthTokenRepo) FindAll(context.Context, repository.GoogleOAuthTokenFilter) ([]*domain.GoogleOAuthToken, error) {
+	return f.tokens, nil
+}
+
+func (f *googleOAuthTokenRepo) Create(_ context.Context, token *domain.GoogleOAuthToken) error {
+	token.ID = len(f.tokens) + 1
+	f.tokens = append(f.tokens, token)
+	return nil
+}
+
+func (f *googleOAuthTokenRepo) Delete(_ context.Context, filter repository.GoogleOAuthTokenFilter) error {
+	var kept []*domain.GoogleOAuthToken
+	for _, token := range f.tokens {
+		if token.ID != *filter.ID {
+			kept = append(kept, token)
+		}
+	}
+	f.tokens = kept
+	return nil
+}
+
+type googleOAuthClient struct {
+	adapter.GoogleOAuth
+	token  *domain.GoogleOAuthToken
+	tokens []int
+}
+
+func (f *googleOAuthClient) OnTokenRefresh(func(token *domain.GoogleOAuthToken)) {}
+
+func (f *googleOAuthClient) SetToken(token *domain.GoogleOAuthToken) {
+	f.tokens = append(f.tokens, token.ID)
+}
+
+func (f *googleOAuthClient) Exchange(context.Context, string, string) (*domain.GoogleOAuthToken, error) {
+	return f.token, nil
+}
+
+func TestGoogleOAuthUsecase_LoadToken(t *testing.T) {
+	tokenRepo := &googleOAuthTokenRepo{tokens: []*domain.GoogleOAuthToken{{ID: 1}}}
+	accountRepo := &fakeGoogleAccountRepo{}
+	// 複数アカウント対応前に取り込んだビジネスはアカウントが紐づいていない
+	businessRepo := &fakeGoogleBusinessRepo{businesses: []*domain.GoogleBusinesses{
+		{ID: 1, Name: "locations/1", Title: "本店"},
+		{ID: 2, Name: "locations/2", Title: "支店"},
+	}}
+	oauth := &googleOAuthClient{}
+	gbp := &fakeGbpAdapter{
+		accounts: map[int][]string{1: {"accounts/a"}},
+		businesses: map[string][]adapter.Business{
+			"accounts/a": {{Name: "locations/1", Title: "本店"}, {Name: "locations/3", Title: "新店"}},
+		},
+	}
+	u := NewGoogleOAuthUsecase(tokenRepo, accountRepo, businessRepo, oauth, gbp)
+
+	// Googleアカウントが無い場合は、トークンで参照できるアカウントを保存してビジネスに紐づける
+	require.NoError(t, u.LoadToken(context.Background()))
+	assert.Equal(t, []int{1}, oauth.tokens)
+	require.Len(t, accountRepo.accounts, 1)
+	assert.Equal(t, "accounts/a", accountRepo.accounts[0].Name)
+	assert.Equal(t, 1, accountRepo.accounts[0].GoogleOAuthTokenID)
+	assert.Equal(t, 1, businessRepo.businesses[0].GoogleAccountID)
+	assert.Equal(t, 0, businessRepo.businesses[1].GoogleAccountID)
+	assert.Equal(t, &domain.GoogleBusinesses{ID: 3, GoogleAccountID: 1, Name: "locations/3", Title: "新店"}, businessRepo.businesses[2])
+
+	// 紐づけたビジネスはアカウントを解決できる
+	account, err := findGoogleAccount(context.Background(), businessRepo, accountRepo, "locations/1")
+	require.NoError(t, err)
+	assert.Equal(t, "accounts/a", account.Name)
+
+	// アカウントがある場合は起動のたびにGBP APIを呼ばない
+	require.NoError(t, u.LoadToken(context.Background()))
+	assert.Equal(t, 1, gbp.accountsCalls)
+}
+
+func TestGoogleOAuthUsecase_LoadTokenGbpError(t *testing.T) {
+	tokenRepo := &googleOAuthTokenRepo{tokens: []*domain.GoogleOAuthToken{{ID: 1}}}
+	accountRepo := &fakeGoogleAccountRepo{}
+	gbp := &fakeGbpAdapter{accountsErr: errors.New("gbp error")}
+	u := NewGoogleOAuthUsecase(tokenRepo, accountRepo, &fakeGoogleBusinessRepo{}, &googleOAuthClient{}, gbp)
+
+	// GBP APIに接続できなくても起動は続け、次の起動でもう一度紐づける
+	assert.NoError(t, u.LoadToken(context.Background()))
+	assert.Empty(t, accountRepo.accounts)
+	assert.NoError(t, u.LoadToken(context.Background()))
+	assert.Equal(t, 2, gbp.accountsCalls)
+}
+
+func TestGoogleOAuthUsecase_Callback(t *testing.T) {
+	// token 1 は accounts/a、token 2 は accounts/b・accounts/c を参照できる
+	tokenRepo := &googleOAuthTokenRepo{tokens: []*domain.GoogleOAuthToken{{ID: 1}, {ID: 2}}}
+	accountRepo := &fakeGoogleAccountRepo{accounts: []*domain.GoogleAccount{
+		{ID: 1, Name: "accounts/a", GoogleOAuthTokenID: 1},
+		{ID: 2, Name: "accounts/b", GoogleOAuthTokenID: 2},
+		{ID: 3, Name: "accounts/c", GoogleOAuthTokenID: 2},
+	}}
+	oauth := &googleOAuthClient{token: &domain.GoogleOAuthToken{RefreshToken: "refresh"}}
+	// 再認証したトークンは accounts/a・accounts/b を参照できる
+	gbp := &fakeGbpAdapter{accounts: map[int][]string{3: {"accounts/a", "accounts/b"}}}
+	u := NewGoogleOAuthUsecase(tokenRepo, accountRepo, &fakeGoogleBusinessRepo{}, oauth, gbp).(*googleOAuthUsecase)
+	u.pending["state"] = googleOAuthPending{verifier: "verifier", expiresAt: time.Now().Add(time.Minute)}
+
+	require.NoError(t, u.Callback(context.Background(), req.GoogleOAuthCallback{State: "state", Code: "code"}))
+
+	// アカウントは新しいトークンに付け替え、どのアカウントからも使われなくなったトークンだけ削除する
+	assert.Equal(t, []int{3}, oauth.tokens)
+	assert.Equal(t, 3, accountRepo.accounts[0].GoogleOAuthTokenID)
+	assert.Equal(t, 3, accountRepo.accounts[1].GoogleOAuthTokenID)
+	assert.Equal(t, 2, accountRepo.accounts[2].GoogleOAuthTokenID)
+	var tokenIDs []int
+	for _, token := range tokenRepo.tokens {
+		tokenIDs = append(tokenIDs, token.ID)
+	}
+	assert.Equal(t, []int{2, 3}, tokenIDs)
+
+	// 一度使ったstateは使えない
+	assert.ErrorIs(t, u.Callback(context.Background(), req.GoogleOAuthCallback{State: "state", Code: "code"}), domain.ErrBadRequest)
+}
+
+func TestFindGoogleAccount(t *testing.T) {
+	accountRepo := &fakeGoogleAccountRepo{accounts: []*domain.GoogleAccount{{ID: 1, Name: "accounts/a"}}}
+	businessRepo := &fakeGoogleBusinessRepo{businesses: []*domain.GoogleBusinesses{
+		{ID: 1, GoogleAccountID: 1, Name: "locations/1"},
+		// アカウントが紐づいていない
+		{ID: 2, Name: "locations/2"},
+		// 紐づいたアカウントが削除されている
+		{ID: 3, GoogleAccountID: 9, Name: "locations/3"},
+	}}
+
+	account, err := findGoogleAccount(context.Background(), businessRepo, accountRepo, "locations/1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, account.ID)
+
+	for _, name := range []string{"locations/2", "locations/3", "locations/unknown"} {
+		_, err := findGoogleAccount(context.Background(), businessRepo, accountRepo, name)
+		assert.ErrorIs(t, err, domain.ErrGoogleAccountNotFound, name)
+	}
+}
@@ -0,0 +1,401 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+type GooglePostUsecase interface {
+	GetGooglePosts(ctx context.Context, businessID int, params req.GetGooglePosts) (*res.GooglePostList, error)
+	DeleteGooglePost(ctx context.Context, id int) (*res.GooglePost, error)
+	RepostGooglePost(ctx context.Context, id int) (*res.GooglePost, error)
+	UpdateRetention(ctx context.Context, businessID int, body req.UpdateGoogleBusinessRetention) (*res.GoogleBusiness, error)
+	ApplyRetention(ctx context.Context) error
+	GetGbpPostRejections(ctx context.Context, params req.GetGbpPostRejections) (*res.GbpPostRejectionList, error)
+}
+
+type googlePostUsecase struct {
+	googlePostRepo        repository.GooglePostRepository
+	googleBusinessRepo    repository.GoogleBusinessRepository
+	googleAccountRepo     repository.GoogleAccountRepository
+	businessInstagramRepo repository.BusinessInstagramRepository
+	wordpressGbpRepo      repository.WordpressGbpRepository
+	gbpAdapter            adapter.GbpAdapter
+	customerUsecase       CustomerUsecase
+	notificationUsecase   NotificationUsecase
+	gbpPostRejectionRepo  repository.GbpPostRejectionRepository
+}
+
+func NewGooglePostUsecase(
+	googlePostRepo repository.GooglePostRepository,
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	googleAccountRepo repository.GoogleAccountRepository,
+	businessInstagramRepo repository.BusinessInstagramRepository,
+	wordpressGbpRepo repository.WordpressGbpRepository,
+	gbpAdapter adapter.GbpAdapter,
+	customerUsecase CustomerUsecase,
+	notificationUsecase NotificationUsecase,
+	gbpPostRejectionRepo repository.GbpPostRejectionRepository,
+) GooglePostUsecase {
+	return &googlePostUsecase{
+		googlePostRepo:        googlePostRepo,
+		googleBusinessRepo:    googleBusinessRepo,
+		googleAccountRepo:     googleAccountRepo,
+		businessInstagramRepo: businessInstagramRepo,
+		wordpressGbpRepo:      wordpressGbpRepo,
+		gbpAdapter:            gbpAdapter,
+		customerUsecase:       customerUsecase,
+		notificationUsecase:   notificationUsecase,
+		gbpPostRejectionRepo:  gbpPostRejectionRepo,
+	}
+}
+
+func (u *googlePostUsecase) GetGooglePosts(ctx context.Context, businessID int, params req.GetGooglePosts) (*res.GooglePostList, error) {
+	business, err := u.getBusiness(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	customerIDs, err := googlePostCustomerIDs(ctx, u.businessInstagramRepo, u.wordpressGbpRepo, business.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(customerIDs) == 0 {
+		return &res.GooglePostList{GooglePostList: []res.GooglePost{}}, nil
+	}
+
+	filter := repository.GooglePostFilter{
+		CustomerIDs:   customerIDs,
+		PostType:      params.PostType,
+		Deleted:       params.Deleted,
+		Limit:         params.Limit,
+		Offset:        params.Offset,
+		OrderByIDDesc: util.Pointer(true),
+	}
+	posts, err := u.googlePostRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.googlePostRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]res.GooglePost, 0, len(posts))
+	for _, gp := range posts {
+		list = append(list, toGooglePostResponse(gp))
+	}
+	return &res.GooglePostList{
+		GooglePostList: list,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(posts),
+		},
+	}, nil
+}
+
+func (u *googlePostUsecase) GetGbpPostRejections(ctx context.Context, params req.GetGbpPostRejections) (*res.GbpPostRejectionList, error) {
+	filter := repository.GbpPostRejectionFilter{
+		CustomerID: params.CustomerID,
+		Reason:     params.Reason,
+		Limit:      params.Limit,
+		Offset:     params.Offset,
+	}
+	rejections, err := u.gbpPostRejectionRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.gbpPostRejectionRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]res.GbpPostRejection, 0, len(rejections))
+	for _, r := range rejections {
+		list = append(list, res.GbpPostRejection{
+			ID:           r.ID,
+			CustomerID:   r.CustomerID,
+			MediaID:      r.MediaID,
+			BusinessName: r.BusinessName,
+			Reason:       string(r.Reason),
+			Field:        r.Field,
+			Message:      r.Message,
+			Summary:      r.Summary,
+			Count:        r.Count,
+			UpdatedAt:    r.UpdatedAt,
+			CreatedAt:    r.CreatedAt,
+		})
+	}
+	return &res.GbpPostRejectionList{
+		GbpPostRejectionList: list,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(rejections),
+		},
+	}, nil
+}
+
+func (u *googlePostUsecase) DeleteGooglePost(ctx context.Context, id int) (*res.GooglePost, error) {
+	gp, err := u.getGooglePost(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if gp.DeletedAt == nil {
+		if err := u.deleteFromGbp(ctx, gp); err != nil {
+			return nil, err
+		}
+	}
+	resp := toGooglePostResponse(gp)
+	return &resp, nil
+}
+
+func (u *googlePostUsecase) RepostGooglePost(ctx context.Context, id int) (*res.GooglePost, error) {
+	gp, err := u.getGooglePost(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	/*
+		投稿元から取得し直して、同じレコードでこの投稿だけを投稿し直す
+	*/
+	previous := *gp
+	if err := u.customerUsecase.RepostGooglePost(ctx, gp); err != nil {
+		return nil, err
+	}
+
+	/*
+		投稿し直せたら、GBP上に残っている元の投稿を削除する。
+		レコードは新しい投稿を指しているため、削除できなくても再投稿は失敗にせず通知する
+	*/
+	if previous.DeletedAt == nil {
+		if err := u.deleteGbpResource(ctx, &previous); err != nil {
+			slog.Warn("再投稿した元の投稿をGBPから削除できませんでした", "id", gp.ID, "name", previous.Name, "error", err.Error())
+			u.notificationUsecase.Notify(ctx, domain.NewSyncFailedNotification("google post repost", fmt.Errorf("元の投稿（%s）をGBPから削除できませんでした: %w", previous.Name, err), u.businessAccount(ctx, gp.BusinessName())))
+		}
+	}
+	resp := toGooglePostResponse(gp)
+	return &resp, nil
+}
+
+func (u *googlePostUsecase) UpdateRetention(ctx context.Context, businessID int, body req.UpdateGoogleBusinessRetention) (*res.GoogleBusiness, error) {
+	business, err := u.getBusiness(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if body.PhotoRetentionCount != nil {
+		if *body.PhotoRetentionCount < 0 {
+			return nil, fmt.Errorf("%w: photo_retention_count は0以上を指定してください", domain.ErrBadRequest)
+		}
+		business.PhotoRetentionCount = *body.PhotoRetentionCount
+	}
+	if body.LocalPostRetentionDays != nil {
+		if *body.LocalPostRetentionDays < 0 {
+			return nil, fmt.Errorf("%w: local_post_retention_days は0以上を指定してください", domain.ErrBadRequest)
+		}
+		business.LocalPostRetentionDays = *body.LocalPostRetentionDays
+	}
+	if err := u.googleBusinessRepo.Update(ctx, business, repository.GoogleBusinessFilter{
+		ID: &business.ID,
+	}); err != nil {
+		return nil, err
+	}
+	return &res.GoogleBusiness{
+		ID:                     business.ID,
+		GoogleAccountID:        business.GoogleAccountID,
+		Name:                   business.Name,
+		Title:                  business.Title,
+		PhotoRetentionCount:    business.PhotoRetentionCount,
+		LocalPostRetentionDays: business.LocalPostRetentionDays,
+		CreatedAt:              business.CreatedAt,
+	}, nil
+}
+
+func (u *googlePostUsecase) ApplyRetention(ctx context.Context) error {
+	businesses, err := u.googleBusinessRepo.FindAll(ctx, repository.GoogleBusinessFilter{
+		HasRetention: util.Pointer(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, business := range businesses {
+		customerIDs, err := googlePostCustomerIDs(ctx, u.businessInstagramRepo, u.wordpressGbpRepo, business.Name)
+		if err != nil {
+			return err
+		}
+		if len(customerIDs) == 0 {
+			continue
+		}
+
+		var expired []*domain.GooglePost
+
+		/*
+			最新N件より古い写真
+		*/
+		if business.PhotoRetentionCount > 0 {
+			photos, err := u.googlePostRepo.FindAll(ctx, repository.GooglePostFilter{
+				CustomerIDs:   customerIDs,
+				PostType:      util.Pointer(domain.PostTypePhoto),
+				Status:        util.Pointer(string(domain.SyncRecordPublished)),
+				Deleted:       util.Pointer(false),
+				OrderByIDDesc: util.Pointer(true),
+			})
+			if err != nil {
+				return err
+			}
+			if len(photos) > business.PhotoRetentionCount {
+				expired = append(expired, photos[business.PhotoRetentionCount:]...)
+			}
+		}
+
+		/*
+			N日を経過したLocal Post
+		*/
+		if business.LocalPostRetentionDays > 0 {
+			posts, err := u.googlePostRepo.FindAll(ctx, repository.GooglePostFilter{
+				CustomerIDs: customerIDs,
+				PostType:    util.Pointer(domain.PostTypePost),
+				Status:      util.Pointer(string(domain.SyncRecordPublished)),
+				Deleted:     util.Pointer(false),
+				CreatedAtTo: util.Pointer(time.Now().AddDate(0, 0, -business.LocalPostRetentionDays)),
+			})
+			if err != nil {
+				return err
+			}
+			expired = append(expired, posts...)
+		}
+
+		for _, gp := range expired {
+			if err := u.deleteFromGbp(ctx, gp); err != nil {
+				u.notificationUsecase.Notify(ctx, domain.NewSyncFailedNotification("google post retention", err, business.Account()))
+				continue
+			}
+		}
+		if len(expired) > 0 {
+			slog.Info("保持期間を過ぎた投稿を削除", "business", business.Name, "count", len(expired))
+		}
+	}
+	return nil
+}
+
+// deleteFromGbp はGBP上のメディア・Local Postを削除し、レコードを削除済みにする。
+func (u *googlePostUsecase) deleteFromGbp(ctx context.Context, gp *domain.GooglePost) error {
+	if err := u.deleteGbpResource(ctx, gp); err != nil {
+		return err
+	}
+	gp.DeletedAt = util.Pointer(time.Now())
+	return u.googlePostRepo.Update(ctx, gp, repository.GooglePostFilter{
+		ID: &gp.ID,
+	})
+}
+
+// deleteGbpResource はGBP上のメディア・Local Postを削除する。レコードは変えない。
+// 投稿の途中で止まった（GBPの名前が無い）レコードはGBPで削除できないため、何もしない。
+func (u *googlePostUsecase) deleteGbpResource(ctx context.Context, gp *domain.GooglePost) error {
+	if gp.Name == "" {
+		return nil
+	}
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, gp.BusinessName())
+	if err != nil {
+		return err
+	}
+	switch gp.PostType {
+	case domain.PostTypePost:
+		return u.gbpAdapter.DeleteLocalPost(ctx, account, gp.Name)
+	default:
+		return u.gbpAdapter.DeleteMedia(ctx, account, gp.Name)
+	}
+}
+
+// businessAccount は通知に使うビジネスの連携。ビジネスが見つからない場合は名前だけの連携を返す。
+func (u *googlePostUsecase) businessAccount(ctx context.Context, businessName string) domain.Account {
+	business, err := u.googleBusinessRepo.Get(ctx, repository.GoogleBusinessFilter{
+		Name: &businessName,
+	})
+	if err != nil || business.ID == 0 {
+		return domain.Account{Type: domain.AccountTypeGoogleBusiness, Name: businessName}
+	}
+	return business.Account()
+}
+
+func (u *googlePostUsecase) getGooglePost(ctx context.Context, id int) (*domain.GooglePost, error) {
+	gp, err := u.googlePostRepo.Get(ctx, repository.GooglePostFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if gp.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return gp, nil
+}
+
+func (u *googlePostUsecase) getBusiness(ctx context.Context, id int) (*domain.GoogleBusinesses, error) {
+	business, err := u.googleBusinessRepo.Get(ctx, repository.GoogleBusinessFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if business.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return business, nil
+}
+
+// googlePostCustomerIDs はビジネスに紐づく google_posts.customer_id の一覧を返す。
+func googlePostCustomerIDs(
+	ctx context.Context,
+	businessInstagramRepo repository.BusinessInstagramRepository,
+	wordpressGbpRepo repository.WordpressGbpRepository,
+	businessName string,
+) ([]int, error) {
+	biList, err := businessInstagramRepo.FindAll(ctx, repository.BusinessInstagramFilter{
+		BusinessName: &businessName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	wgList, err := wordpressGbpRepo.FindAll(ctx, repository.WordpressGbpFilter{
+		BusinessName: &businessName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	customerIDs := make([]int, 0, len(biList)+len(wgList))
+	for _, bi := range biList {
+		customerIDs = append(customerIDs, bi.ID)
+	}
+	for _, wg := range wgList {
+		customerIDs = append(customerIDs, 300000+wg.ID)
+	}
+	return customerIDs, nil
+}
+
+func toGooglePostResponse(gp *domain.GooglePost) res.GooglePost {
+	return res.GooglePost{
+		ID:           gp.ID,
+		CustomerID:   gp.CustomerID,
+		Source:       string(gp.Source),
+		Destination:  string(gp.Destination),
+		Status:       string(gp.Status),
+		PostType:     gp.PostType,
+		InstagramURL: gp.InstagramURL,
+		MediaID:      gp.MediaID,
+		Name:         gp.Name,
+		GoogleURL:    gp.GoogleURL,
+		CreateTime:   gp.CreateTime,
+		PublishedAt:  gp.PublishedAt,
+		DeletedAt:    gp.DeletedAt,
+		CreatedAt:    gp.CreatedAt,
+	}
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGooglePostUsecase_RepostGooglePost(t *testing.T) {
+	customer, _ := newBusinessInstagramTest(t)
+	gbp := &fakeGbpAdapter{}
+	customer.gbpAdapter = gbp
+	require.NoError(t, customer.SyncOneGoogleBusinessInstagram(context.Background(), 3))
+	googlePostRepo := customer.googlePostRepo.(*fakeGooglePostRepo)
+	googlePostRepo.records[0].Name = "accounts/a/locations/3/media/old"
+	googlePostRepo.records[1].Name = "accounts/a/locations/3/localPosts/old"
+	u := NewGooglePostUsecase(
+		googlePostRepo, customer.googleBusinessRepo, customer.googleAccountRepo, customer.businessInstagramRepo, nil,
+		gbp, customer, customer.notificationUsecase, nil,
+	)
+
+	// 投稿し直せてから、GBP上の元の投稿を削除する
+	resp, err := u.RepostGooglePost(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resp.ID)
+	assert.Equal(t, []string{"accounts/a/locations/3/media/old"}, gbp.deleted)
+	assert.Equal(t, "media", googlePostRepo.records[0].Name)
+	assert.Nil(t, googlePostRepo.records[0].DeletedAt)
+
+	// 投稿元から取得できない場合は、GBP上の元の投稿を削除しない
+	customer.instagramAdapter = &fakeInstagramAdapter{}
+	_, err = u.RepostGooglePost(context.Background(), 2)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"accounts/a/locations/3/media/old"}, gbp.deleted)
+	assert.Equal(t, "accounts/a/locations/3/localPosts/old", googlePostRepo.records[1].Name)
+}
@@ -0,0 +1,295 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/zuxt268/homing/internal/config"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+type GoogleReviewUsecase interface {
+	SyncReviews(ctx context.Context) error
+	GetReviewList(ctx context.Context, params req.GetGoogleReview) (*res.GoogleReviewList, error)
+	GetReview(ctx context.Context, id int) (*res.GoogleReview, error)
+	ReplyReview(ctx context.Context, id int, body req.GoogleReviewReply) (*res.GoogleReview, error)
+	DeleteReviewReply(ctx context.Context, id int) error
+}
+
+type googleReviewUsecase struct {
+	googleReviewRepo    repository.GoogleReviewRepository
+	googleBusinessRepo  repository.GoogleBusinessRepository
+	googleAccountRepo   repository.GoogleAccountRepository
+	gbpAdapter          adapter.GbpAdapter
+	notificationUsecase NotificationUsecase
+}
+
+func NewGoogleReviewUsecase(
+	googleReviewRepo repository.GoogleReviewRepository,
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	googleAccountRepo repository.GoogleAccountRepository,
+	gbpAdapter adapter.GbpAdapter,
+	notificationUsecase NotificationUsecase,
+) GoogleReviewUsecase {
+	return &googleReviewUsecase{
+		googleReviewRepo:    googleReviewRepo,
+		googleBusinessRepo:  googleBusinessRepo,
+		googleAccountRepo:   googleAccountRepo,
+		gbpAdapter:          gbpAdapter,
+		notificationUsecase: notificationUsecase,
+	}
+}
+
+func (u *googleReviewUsecase) SyncReviews(ctx context.Context) error {
+	businesses, err := u.googleBusinessRepo.FindAll(ctx, repository.GoogleBusinessFilter{
+		All: util.Pointer(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, business := range businesses {
+		if err := u.syncBusinessReviews(ctx, business); err != nil {
+			u.notificationUsecase.Notify(ctx, domain.NewSyncFailedNotification("google review sync", err, business.Account()))
+			continue
+		}
+	}
+	return nil
+}
+
+func (u *googleReviewUsecase) syncBusinessReviews(ctx context.Context, business *domain.GoogleBusinesses) error {
+	/*
+		初回取り込み時は過去の口コミが大量に通知されるのを防ぐため通知しない。
+		口コミが0件のビジネスも取り込み済みとして記録し、その後の最初の口コミは通知する
+	*/
+	initialImport := business.ReviewsImportedAt == nil
+
+	/*
+		GBPから口コミを取得
+	*/
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, business.Name)
+	if err != nil {
+		return err
+	}
+	reviews, err := u.gbpAdapter.ListReviews(ctx, account, business.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, review := range reviews {
+		stored, err := u.googleReviewRepo.Get(ctx, repository.GoogleReviewFilter{
+			ReviewName: &review.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		/*
+			新しい口コミは保存して通知
+		*/
+		if stored.ID == 0 {
+			gr := toGoogleReview(business.Name, review)
+			if err := u.googleReviewRepo.Create(ctx, gr); err != nil {
+				return err
+			}
+			if !initialImport {
+				u.notificationUsecase.Notify(ctx, domain.NewGoogleReviewNotification(business, gr, gr.IsLowRated(config.Env.GoogleReviewLowRating)))
+			}
+			continue
+		}
+
+		/*
+			更新されていない口コミはスキップ
+		*/
+		if stored.UpdateTime == review.UpdateTime {
+			continue
+		}
+
+		/*
+			口コミが編集されて低評価になった場合は通知
+		*/
+		gr := toGoogleReview(business.Name, review)
+		gr.ID = stored.ID
+		if err := u.googleReviewRepo.Update(ctx, gr, repository.GoogleReviewFilter{
+			ID: &stored.ID,
+		}); err != nil {
+			return err
+		}
+		lowRated := gr.IsLowRated(config.Env.GoogleReviewLowRating)
+		if lowRated && (stored.StarRating != gr.StarRating || stored.Comment != gr.Comment) {
+			u.notificationUsecase.Notify(ctx, domain.NewGoogleReviewNotification(business, gr, lowRated))
+		}
+	}
+
+	if initialImport {
+		business.ReviewsImportedAt = util.Pointer(time.Now())
+		if err := u.googleBusinessRepo.Update(ctx, business, repository.GoogleBusinessFilter{
+			ID: &business.ID,
+		}); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("口コミ同期完了", "business", business.Name, "count", len(reviews))
+	return nil
+}
+
+func (u *googleReviewUsecase) GetReviewList(ctx context.Context, params req.GetGoogleReview) (*res.GoogleReviewList, error) {
+	filter := repository.GoogleReviewFilter{
+		Limit:                 params.Limit,
+		Offset:                params.Offset,
+		BusinessName:          params.BusinessName,
+		MaxStarRating:         params.MaxStarRating,
+		Unreplied:             params.Unreplied,
+		OrderByReviewedAtDesc: util.Pointer(true),
+	}
+	reviews, err := u.googleReviewRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.googleReviewRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resReviews := make([]res.GoogleReview, len(reviews))
+	for i, review := range reviews {
+		resReviews[i] = toGoogleReviewResponse(review)
+	}
+	return &res.GoogleReviewList{
+		GoogleReviewList: resReviews,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(reviews),
+		},
+	}, nil
+}
+
+func (u *googleReviewUsecase) GetReview(ctx context.Context, id int) (*res.GoogleReview, error) {
+	review, err := u.getReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resp := toGoogleReviewResponse(review)
+	return &resp, nil
+}
+
+func (u *googleReviewUsecase) ReplyReview(ctx context.Context, id int, body req.GoogleReviewReply) (*res.GoogleReview, error) {
+	comment := strings.TrimSpace(body.Comment)
+	if comment == "" {
+		return nil, fmt.Errorf("%w: 返信内容が空です", domain.ErrBadRequest)
+	}
+
+	review, err := u.getReview(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	/*
+		GBPに返信を投稿（既に返信がある場合は上書き）
+	*/
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, review.BusinessName)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := u.gbpAdapter.UpdateReviewReply(ctx, account, review.ReviewName, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	review.ReplyComment = reply.Comment
+	review.ReplyUpdatedAt = parseGbpTime(reply.UpdateTime)
+	if err := u.googleReviewRepo.Update(ctx, review, repository.GoogleReviewFilter{
+		ID: &review.ID,
+	}); err != nil {
+		return nil, err
+	}
+
+	resp := toGoogleReviewResponse(review)
+	return &resp, nil
+}
+
+func (u *googleReviewUsecase) DeleteReviewReply(ctx context.Context, id int) error {
+	review, err := u.getReview(ctx, id)
+	if err != nil {
+		return err
+	}
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, review.BusinessName)
+	if err != nil {
+		return err
+	}
+	if err := u.gbpAdapter.DeleteReviewReply(ctx, account, review.ReviewName); err != nil {
+		return err
+	}
+	review.ReplyComment = ""
+	review.ReplyUpdatedAt = nil
+	return u.googleReviewRepo.Update(ctx, review, repository.GoogleReviewFilter{
+		ID: &review.ID,
+	})
+}
+
+func (u *googleReviewUsecase) getReview(ctx context.Context, id int) (*domain.GoogleReview, error) {
+	review, err := u.googleReviewRepo.Get(ctx, repository.GoogleReviewFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if review.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return review, nil
+}
+
+func toGoogleReview(businessName string, review external.GoogleBusinessReview) *domain.GoogleReview {
+	gr := &domain.GoogleReview{
+		BusinessName: businessName,
+		ReviewName:   review.Name,
+		ReviewerName: review.Reviewer.DisplayName,
+		StarRating:   domain.StarRatingToInt(review.StarRating),
+		Comment:      review.Comment,
+		UpdateTime:   review.UpdateTime,
+	}
+	if t := parseGbpTime(review.CreateTime); t != nil {
+		gr.ReviewedAt = *t
+	}
+	if review.ReviewReply != nil {
+		gr.ReplyComment = review.ReviewReply.Comment
+		gr.ReplyUpdatedAt = parseGbpTime(review.ReviewReply.UpdateTime)
+	}
+	return gr
+}
+
+func toGoogleReviewResponse(review *domain.GoogleReview) res.GoogleReview {
+	return res.GoogleReview{
+		ID:             review.ID,
+		BusinessName:   review.BusinessName,
+		ReviewName:     review.ReviewName,
+		ReviewerName:   review.ReviewerName,
+		StarRating:     review.StarRating,
+		Comment:        review.Comment,
+		ReplyComment:   review.ReplyComment,
+		ReplyUpdatedAt: review.ReplyUpdatedAt,
+		ReviewedAt:     review.ReviewedAt,
+		CreatedAt:      review.CreatedAt,
+		UpdatedAt:      review.UpdatedAt,
+	}
+}
+
+// parseGbpTime はGBP APIの日時（RFC3339）をパースする。パースできない場合は nil を返す。
+func parseGbpTime(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
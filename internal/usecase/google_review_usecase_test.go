@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zuxt268/homing/internal/config"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/repository"
+)
+
+type googleReviewRepo struct {
+	repository.GoogleReviewRepository
+	reviews []*domain.GoogleReview
+}
+
+func (f *googleReviewRepo) Get(_ context.Context, filter repository.GoogleReviewFilter) (*domain.GoogleReview, error) {
+	for _, review := range f.reviews {
+		if (filter.ID != nil && *filter.ID == review.ID) || (filter.ReviewName != nil && *filter.ReviewName == review.ReviewName) {
+			r := *review
+			return &r, nil
+		}
+	}
+	return &domain.GoogleReview{}, nil
+}
+
+func (f *googleReviewRepo) Create(_ context.Context, review *domain.GoogleReview) error {
+	review.ID = len(f.reviews) + 1
+	r := *review
+	f.reviews = append(f.reviews, &r)
+	return nil
+}
+
+func (f *googleReviewRepo) Update(_ context.Context, review *domain.GoogleReview, filter repository.GoogleReviewFilter) error {
+	r := *review
+	f.reviews[*filter.ID-1] = &r
+	return nil
+}
+
+func googleBusinessReview(name, starRating, comment, updateTime string) external.GoogleBusinessReview {
+	review := external.GoogleBusinessReview{
+		Name:       name,
+		StarRating: starRating,
+		Comment:    comment,
+		CreateTime: "2026-03-01T00:00:00Z",
+		UpdateTime: updateTime,
+	}
+	review.Reviewer.DisplayName = "山田"
+	return review
+}
+
+// setGoogleReviewLowRating は低評価として通知する星の数をテストの間だけ変える
+func setGoogleReviewLowRating(t *testing.T, rating int) {
+	lowRating := config.Env.GoogleReviewLowRating
+	config.Env.GoogleReviewLowRating = rating
+	t.Cleanup(func() { config.Env.GoogleReviewLowRating = lowRating })
+}
+
+func TestGoogleReviewUsecase_SyncReviews(t *testing.T) {
+	setGoogleReviewLowRating(t, 2)
+	reviewRepo := &googleReviewRepo{}
+	businessRepo := &fakeGoogleBusinessRepo{businesses: []*domain.GoogleBusinesses{
+		{ID: 1, GoogleAccountID: 1, Name: "locations/1", Title: "本店"},
+	}}
+	gbp := &fakeGbpAdapter{reviews: map[string][]external.GoogleBusinessReview{}}
+	notification := &fakeNotificationUsecase{}
+	u := NewGoogleReviewUsecase(reviewRepo, businessRepo, newFakeGoogleAccountRepo(), gbp, notification)
+
+	// 口コミが無いビジネスも取り込み済みとして記録する
+	require.NoError(t, u.SyncReviews(context.Background()))
+	require.NotNil(t, businessRepo.businesses[0].ReviewsImportedAt)
+	assert.Empty(t, notification.notifications)
+
+	// 取り込み済みのビジネスは最初の口コミから通知する
+	gbp.reviews["locations/1"] = []external.GoogleBusinessReview{
+		googleBusinessReview("reviews/1", "FIVE", "おいしい", "2026-03-01T00:00:00Z"),
+	}
+	require.NoError(t, u.SyncReviews(context.Background()))
+	require.Len(t, notification.notifications, 1)
+	assert.Equal(t, domain.NotificationEventReviewReceived, notification.notifications[0].EventType)
+	assert.Equal(t, 5, reviewRepo.reviews[0].StarRating)
+
+	// 更新されていない口コミは通知しない
+	require.NoError(t, u.SyncReviews(context.Background()))
+	assert.Len(t, notification.notifications, 1)
+
+	// 編集されて低評価になった口コミは通知する。低評価の新しい口コミも通知する
+	gbp.reviews["locations/1"] = []external.GoogleBusinessReview{
+		googleBusinessReview("reviews/1", "TWO", "残念", "2026-03-02T00:00:00Z"),
+		googleBusinessReview("reviews/2", "ONE", "ひどい", "2026-03-02T00:00:00Z"),
+	}
+	require.NoError(t, u.SyncReviews(context.Background()))
+	require.Len(t, notification.notifications, 3)
+	assert.Equal(t, domain.NotificationEventReviewLowRated, notification.notifications[1].EventType)
+	assert.Contains(t, notification.notifications[1].Text, "残念")
+	assert.Equal(t, domain.NotificationEventReviewLowRated, notification.notifications[2].EventType)
+	assert.Equal(t, "残念", reviewRepo.reviews[0].Comment)
+	assert.Equal(t, 2, reviewRepo.reviews[0].StarRating)
+
+	// 低評価のまま返信だけ更新された場合は通知しない
+	edited := googleBusinessReview("reviews/2", "ONE", "ひどい", "2026-03-03T00:00:00Z")
+	edited.ReviewReply = &external.GoogleBusinessReviewReply{Comment: "申し訳ありません", UpdateTime: "2026-03-03T00:00:00Z"}
+	gbp.reviews["locations/1"] = []external.GoogleBusinessReview{edited}
+	require.NoError(t, u.SyncReviews(context.Background()))
+	assert.Len(t, notification.notifications, 3)
+	assert.Equal(t, "申し訳ありません", reviewRepo.reviews[1].ReplyComment)
+}
+
+func TestGoogleReviewUsecase_SyncReviewsInitialImport(t *testing.T) {
+	setGoogleReviewLowRating(t, 2)
+	reviewRepo := &googleReviewRepo{}
+	businessRepo := &fakeGoogleBusinessRepo{businesses: []*domain.GoogleBusinesses{
+		{ID: 1, GoogleAccountID: 1, Name: "locations/1", Title: "本店"},
+	}}
+	gbp := &fakeGbpAdapter{reviews: map[string][]external.GoogleBusinessReview{}}
+	notification := &fakeNotificationUsecase{}
+	u := NewGoogleReviewUsecase(reviewRepo, businessRepo, newFakeGoogleAccountRepo(), gbp, notification)
+	gbp.reviews["locations/1"] = []external.GoogleBusinessReview{
+		googleBusinessReview("reviews/1", "ONE", "ひどい", "2026-03-01T00:00:00Z"),
+		googleBusinessReview("reviews/2", "FIVE", "おいしい", "2026-03-01T00:00:00Z"),
+	}
+
+	// 初回取り込みは過去の口コミを通知しない
+	require.NoError(t, u.SyncReviews(context.Background()))
+	assert.Len(t, reviewRepo.reviews, 2)
+	assert.Empty(t, notification.notifications)
+	importedAt := businessRepo.businesses[0].ReviewsImportedAt
+	require.NotNil(t, importedAt)
+
+	// 取り込んだ日時は変えない
+	require.NoError(t, u.SyncReviews(context.Background()))
+	assert.Equal(t, importedAt, businessRepo.businesses[0].ReviewsImportedAt)
+
+	// 取り込み済みの口コミを消しても、初回取り込みには戻らない
+	reviewRepo.reviews = nil
+	require.NoError(t, u.SyncReviews(context.Background()))
+	assert.Len(t, notification.notifications, 2)
+}
+
+func TestGoogleReviewUsecase_ReplyReview(t *testing.T) {
+	reviewRepo := &googleReviewRepo{}
+	businessRepo := &fakeGoogleBusinessRepo{businesses: []*domain.GoogleBusinesses{
+		{ID: 1, GoogleAccountID: 1, Name: "locations/1", Title: "本店"},
+	}}
+	gbp := &fakeGbpAdapter{}
+	u := NewGoogleReviewUsecase(reviewRepo, businessRepo, newFakeGoogleAccountRepo(), gbp, &fakeNotificationUsecase{})
+	require.NoError(t, reviewRepo.Create(context.Background(), &domain.GoogleReview{
+		BusinessName: "locations/1",
+		ReviewName:   "reviews/1",
+		StarRating:   1,
+	}))
+
+	_, err := u.ReplyReview(context.Background(), 1, req.GoogleReviewReply{Comment: "  "})
+	assert.ErrorIs(t, err, domain.ErrBadRequest)
+	_, err = u.ReplyReview(context.Background(), 9, req.GoogleReviewReply{Comment: "ありがとうございます"})
+	assert.ErrorIs(t, err, domain.ErrNotFound)
+
+	replied, err := u.ReplyReview(context.Background(), 1, req.GoogleReviewReply{Comment: " ありがとうございます\n"})
+	require.NoError(t, err)
+	assert.Equal(t, "ありがとうございます", replied.ReplyComment)
+	require.NotNil(t, replied.ReplyUpdatedAt)
+	assert.Equal(t, map[string]string{"reviews/1": "ありがとうございます"}, gbp.replies)
+	assert.Equal(t, "ありがとうございます", reviewRepo.reviews[0].ReplyComment)
+
+	require.NoError(t, u.DeleteReviewReply(context.Background(), 1))
+	assert.Empty(t, gbp.replies)
+	assert.Equal(t, "", reviewRepo.reviews[0].ReplyComment)
+	assert.Nil(t, reviewRepo.reviews[0].ReplyUpdatedAt)
+}
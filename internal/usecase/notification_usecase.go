@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/zuxt268/homing/internal/config"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+type NotificationUsecase interface {
+	// Notify は通知ルールに従って通知する。失敗しても呼び出し元の処理は止めない。
+	Notify(ctx context.Context, n domain.Notification)
+	TestNotificationRule(ctx context.Context, id int) error
+
+	GetNotificationRuleList(ctx context.Context, params req.GetNotificationRule) (*res.NotificationRuleList, error)
+	GetNotificationRule(ctx context.Context, id int) (*res.NotificationRule, error)
+	CreateNotificationRule(ctx context.Context, body req.CreateNotificationRule) (*res.NotificationRule, error)
+	UpdateNotificationRule(ctx context.Context, id int, body req.UpdateNotificationRule) (*res.NotificationRule, error)
+	DeleteNotificationRule(ctx context.Context, id int) error
+}
+
+type notificationUsecase struct {
+	notificationRuleRepo repository.NotificationRuleRepository
+	notifiers            map[domain.NotificationChannel]adapter.Notifier
+}
+
+func NewNotificationUsecase(
+	notificationRuleRepo repository.NotificationRuleRepository,
+	slackNotifier adapter.Notifier,
+	emailNotifier adapter.Notifier,
+	lineNotifier adapter.Notifier,
+	webhookNotifier adapter.Notifier,
+) NotificationUsecase {
+	return &notificationUsecase{
+		notificationRuleRepo: notificationRuleRepo,
+		notifiers: map[domain.NotificationChannel]adapter.Notifier{
+			domain.NotificationChannelSlack:   slackNotifier,
+			domain.NotificationChannelEmail:   emailNotifier,
+			domain.NotificationChannelLine:    lineNotifier,
+			domain.NotificationChannelWebhook: webhookNotifier,
+		},
+	}
+}
+
+func (u *notificationUsecase) Notify(ctx context.Context, n domain.Notification) {
+	rules, err := u.notificationRuleRepo.FindAll(ctx, repository.NotificationRuleFilter{
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		slog.Warn("通知ルールの取得に失敗", "error", err.Error())
+	}
+
+	matched := 0
+	for _, rule := range rules {
+		if !rule.Matches(&n) {
+			continue
+		}
+		matched++
+		if err := u.send(ctx, rule.Channel, rule.Destination, rule.Mention, n); err != nil {
+			slog.Warn("通知に失敗", "rule_id", rule.ID, "channel", rule.Channel, "event_type", n.EventType, "error", err.Error())
+		}
+	}
+
+	/*
+		どのルールにも当てはまらない通知は既定のSlackチャンネルに送る。
+		ダイジェストを使う場合は投稿ごとの成功通知は送らない。
+	*/
+	if matched > 0 || config.Env.NoticeWebAppChannelUrl == "" {
+		return
+	}
+	if config.Env.NoticeSuccessDigest && n.EventType == domain.NotificationEventSyncSucceeded {
+		return
+	}
+	mention := ""
+	if n.EventType.Urgent() {
+		mention = config.Env.NoticeMention
+	}
+	if err := u.send(ctx, domain.NotificationChannelSlack, config.Env.NoticeWebAppChannelUrl, mention, n); err != nil {
+		slog.Warn("通知に失敗", "channel", domain.NotificationChannelSlack, "event_type", n.EventType, "error", err.Error())
+	}
+}
+
+func (u *notificationUsecase) send(ctx context.Context, channel domain.NotificationChannel, destination, mention string, n domain.Notification) error {
+	notifier, ok := u.notifiers[channel]
+	if !ok {
+		return fmt.Errorf("通知の送信手段が不正です: %s", channel)
+	}
+	return notifier.Notify(ctx, destination, mention, n)
+}
+
+// TestNotificationRule はルールの送り先にテスト通知を送る。設定の確認用なので失敗した場合はエラーを返す。
+func (u *notificationUsecase) TestNotificationRule(ctx context.Context, id int) error {
+	rule, err := u.getNotificationRule(ctx, id)
+	if err != nil {
+		return err
+	}
+	n := domain.Notification{
+		EventType: domain.NotificationEventTest,
+		Subject:   "[homing] テスト通知",
+		Text:      fmt.Sprintf("通知ルール「%s」のテスト通知です", rule.Name),
+	}
+	return u.send(ctx, rule.Channel, rule.Destination, rule.Mention, n)
+}
+
+func (u *notificationUsecase) GetNotificationRuleList(ctx context.Context, params req.GetNotificationRule) (*res.NotificationRuleList, error) {
+	filter := repository.NotificationRuleFilter{
+		PartialName:   params.Name,
+		Channel:       params.Channel,
+		Status:        params.Status,
+		Limit:         params.Limit,
+		Offset:        params.Offset,
+		OrderByIDDesc: util.Pointer(true),
+	}
+
+	rules, err := u.notificationRuleRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.notificationRuleRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]res.NotificationRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, toNotificationRuleResponse(rule))
+	}
+	return &res.NotificationRuleList{
+		NotificationRuleList: result,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(rules),
+		},
+	}, nil
+}
+
+func (u *notificationUsecase) GetNotificationRule(ctx context.Context, id int) (*res.NotificationRule, error) {
+	rule, err := u.getNotificationRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resp := toNotificationRuleResponse(rule)
+	return &resp, nil
+}
+
+func (u *notificationUsecase) CreateNotificationRule(ctx context.Context, body req.CreateNotificationRule) (*res.NotificationRule, error) {
+	rule := &domain.NotificationRule{
+		Name:        body.Name,
+		EventTypes:  toNotificationEventTypes(body.EventTypes),
+		AccountType: domain.AccountType(body.AccountType),
+		AccountID:   body.AccountID,
+		Channel:     domain.NotificationChannel(body.Channel),
+		Destination: body.Destination,
+		Mention:     body.Mention,
+		Status:      domain.Status(body.Status),
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.notificationRuleRepo.Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	resp := toNotificationRuleResponse(rule)
+	return &resp, nil
+}
+
+func (u *notificationUsecase) UpdateNotificationRule(ctx context.Context, id int, body req.UpdateNotificationRule) (*res.NotificationRule, error) {
+	rule, err := u.getNotificationRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if body.Name != nil {
+		rule.Name = *body.Name
+	}
+	if body.EventTypes != nil {
+		rule.EventTypes = toNotificationEventTypes(body.EventTypes)
+	}
+	if body.AccountType != nil {
+		rule.AccountType = domain.AccountType(*body.AccountType)
+	}
+	if body.AccountID != nil {
+		rule.AccountID = *body.AccountID
+	}
+	if body.Channel != nil {
+		rule.Channel = domain.NotificationChannel(*body.Channel)
+	}
+	if body.Destination != nil {
+		rule.Destination = *body.Destination
+	}
+	if body.Mention != nil {
+		rule.Mention = *body.Mention
+	}
+	if body.Status != nil {
+		rule.Status = domain.Status(*body.Status)
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	err = u.notificationRuleRepo.Update(ctx, rule, repository.NotificationRuleFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toNotificationRuleResponse(rule)
+	return &resp, nil
+}
+
+func (u *notificationUsecase) DeleteNotificationRule(ctx context.Context, id int) error {
+	return u.notificationRuleRepo.Delete(ctx, repository.NotificationRuleFilter{
+		ID: &id,
+	})
+}
+
+func (u *notificationUsecase) getNotificationRule(ctx context.Context, id int) (*domain.NotificationRule, error) {
+	rule, err := u.notificationRuleRepo.Get(ctx, repository.NotificationRuleFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rule.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return rule, nil
+}
+
+func toNotificationEventTypes(eventTypes []string) []domain.NotificationEventType {
+	result := make([]domain.NotificationEventType, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		result = append(result, domain.NotificationEventType(t))
+	}
+	return result
+}
+
+func toNotificationRuleResponse(rule *domain.NotificationRule) res.NotificationRule {
+	eventTypes := make([]string, 0, len(rule.EventTypes))
+	for _, t := range rule.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+	return res.NotificationRule{
+		ID:          rule.ID,
+		Name:        rule.Name,
+		EventTypes:  eventTypes,
+		AccountType: string(rule.AccountType),
+		AccountID:   rule.AccountID,
+		Channel:     string(rule.Channel),
+		Destination: rule.Destination,
+		Mention:     rule.Mention,
+		Status:      int(rule.Status),
+		UpdatedAt:   rule.UpdatedAt,
+		CreatedAt:   rule.CreatedAt,
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+)
+
+// 連携の作成前に、初回の同期まで分からない問題（プラグイン未導入、鍵の不一致、見えないアカウントなど）を確認する。
+// どの確認も何も投稿・保存しない。
+
+// checkSettings は入力値の検証結果を確認項目にする。
+func checkSettings(err error) domain.OnboardingCheck {
+	if err != nil {
+		return domain.NewOnboardingFailed(domain.OnboardingCheckSettings, err, "エラーの項目を修正してください")
+	}
+	return domain.NewOnboardingPassed(domain.OnboardingCheckSettings, "")
+}
+
+// checkInstagramAccount はトークンからInstagramアカウントが見えるかを確認する。
+func checkInstagramAccount(ctx context.Context, tokenRepo repository.TokenRepository, instagramAdapter adapter.InstagramAdapter, instagramID string) domain.OnboardingCheck {
+	token, err := tokenRepo.First(ctx)
+	if err != nil || token == "" {
+		return domain.NewOnboardingFailed(domain.OnboardingCheckInstagramAccount, domain.ErrTokenNotFound, "POST /api/token でInstagramのトークンを登録してください")
+	}
+	account, err := instagramAdapter.GetAccount(ctx, token, instagramID)
+	if err != nil {
+		return domain.NewOnboardingFailed(domain.OnboardingCheckInstagramAccount, err, "Instagram IDが正しいか、POST /api/token/check でトークンの権限と有効期限を確認してください")
+	}
+	if account.InstagramAccountUserName == "" {
+		return domain.NewOnboardingFailed(domain.OnboardingCheckInstagramAccount, domain.ErrInstagramConnection, "Instagramアカウントがトークンのビジネスポートフォリオ（Facebookページ）に追加されているか確認してください")
+	}
+	return domain.NewOnboardingPassed(domain.OnboardingCheckInstagramAccount, account.InstagramAccountUserName)
+}
+
+// checkWordpressSite はWordPressのサイトにプラグイン（wp_v2 の場合はREST API）で接続できるかを確認する。
+func checkWordpressSite(ctx context.Context, wordpressAdapter adapter.WordpressAdapter, wi domain.WordpressInstagram) domain.OnboardingCheck {
+	title, err := wordpressAdapter.GetSiteTitle(ctx, wi)
+	if err != nil {
+		action := "ドメインが正しいか、WordPressにrodutプラグインがインストール・有効化されているか確認してください"
+		if wi.IsV2() {
+			action = "ドメインが正しいか、ユーザー名とアプリケーションパスワード、REST APIが無効化されていないかを確認してください"
+		}
+		return domain.NewOnboardingFailed(domain.OnboardingCheckWordpressSite, err, action)
+	}
+	return domain.NewOnboardingPassed(domain.OnboardingCheckWordpressSite, title)
+}
+
+// checkWordpressSignature はプラグインに署名付きの何もしないリクエストを送り、HMACの鍵が一致するかを確認する。
+func checkWordpressSignature(ctx context.Context, wordpressAdapter adapter.WordpressAdapter, wi domain.WordpressInstagram) domain.OnboardingCheck {
+	err := wordpressAdapter.VerifySignature(ctx, wi)
+	switch {
+	case err == nil:
+		return domain.NewOnboardingPassed(domain.OnboardingCheckWordpressSignature, "")
+	case errors.Is(err, domain.ErrWordpressSignature):
+		// 鍵は SECRET_PHRASE とドメインから作るため、www の有無などドメインの表記揺れでも一致しない
+		return domain.NewOnboardingFailed(domain.OnboardingCheckWordpressSignature, err, "プラグインに設定した SECRET_PHRASE と、ドメインの表記（www の有無など）がWordPress側と一致しているか確認してください")
+	case errors.Is(err, domain.ErrWordpressPluginOld):
+		return domain.NewOnboardingFailed(domain.OnboardingCheckWordpressSignature, err, "rodutプラグインを最新版に更新してください")
+	}
+	return domain.NewOnboardingFailed(domain.OnboardingCheckWordpressSignature, err, "WordPressのサーバーのエラーログを確認してください")
+}
+
+// checkWordpressGbpPosts はプラグインからGBP連携用の記事一覧を取得できるかを確認する。
+func checkWordpressGbpPosts(ctx context.Context, wordpressAdapter adapter.WordpressAdapter, wordpressDomain string) domain.OnboardingCheck {
+	if _, err := wordpressAdapter.GetGbpPosts(ctx, wordpressDomain); err != nil {
+		return domain.NewOnboardingFailed(domain.OnboardingCheckWordpressGbpPosts, err, "rodutプラグインを最新版に更新し、GBP連携の記事一覧（rodut/v1/posts）が有効か確認してください")
+	}
+	return domain.NewOnboardingPassed(domain.OnboardingCheckWordpressGbpPosts, "")
+}
+
+// checkGoogleBusiness はビジネスに紐づくGoogleアカウントから、ビジネスが見えるかを確認する。
+func checkGoogleBusiness(
+	ctx context.Context,
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	googleAccountRepo repository.GoogleAccountRepository,
+	gbpAdapter adapter.GbpAdapter,
+	businessName string,
+) domain.OnboardingCheck {
+	account, err := findGoogleAccount(ctx, googleBusinessRepo, googleAccountRepo, businessName)
+	if err != nil {
+		return domain.NewOnboardingFailed(domain.OnboardingCheckGoogleBusiness, err, "business_name（locations/〜）が正しいか確認し、POST /api/google-business/fetch でビジネスの一覧を更新してください")
+	}
+	business, err := gbpAdapter.GetBusiness(ctx, account, businessName)
+	if err != nil {
+		action := "Googleアカウントにビジネスのオーナーまたは管理者の権限があるか確認してください"
+		if errors.Is(err, domain.ErrGoogleNotAuthorized) {
+			action = "GET /api/oauth/google/start からGoogleアカウントを認証し直してください"
+		}
+		return domain.NewOnboardingFailed(domain.OnboardingCheckGoogleBusiness, err, action)
+	}
+	if business.Title == "" {
+		return domain.NewOnboardingFailed(domain.OnboardingCheckGoogleBusiness, domain.ErrBusinessConnection, "ビジネスがGoogleアカウントから削除されていないか、GBPの管理画面で確認してください")
+	}
+	return domain.NewOnboardingPassed(domain.OnboardingCheckGoogleBusiness, business.Title)
+}
+
+func toOnboardingCheckList(checks []domain.OnboardingCheck) *res.OnboardingCheckList {
+	list := make([]res.OnboardingCheck, 0, len(checks))
+	for _, check := range checks {
+		list = append(list, res.OnboardingCheck{
+			Name:   string(check.Name),
+			OK:     check.OK,
+			Detail: check.Detail,
+			Error:  check.Error,
+			Action: check.Action,
+		})
+	}
+	return &res.OnboardingCheckList{
+		OK:     domain.OnboardingPassed(checks),
+		Checks: list,
+	}
+}
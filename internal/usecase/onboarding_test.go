@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+)
+
+// checkSummary は確認結果を "name:ok:detail" の形式で並べる
+func checkSummary(list *res.OnboardingCheckList) []string {
+	var summary []string
+	for _, check := range list.Checks {
+		summary = append(summary, fmt.Sprintf("%s:%t:%s", check.Name, check.OK, check.Detail))
+	}
+	return summary
+}
+
+func TestWordpressInstagramUsecase_ValidateWordpressInstagram(t *testing.T) {
+	instagramAdapter := &fakeInstagramAdapter{usernames: map[string]string{"ig-1": "homing_test"}}
+
+	t.Run("全て成功", func(t *testing.T) {
+		wordpressAdapter := &fakeWordpressAdapter{titles: map[string]string{"a.example.com": "テストサイト"}}
+		u := NewWordpressInstagramUsecase(nil, &fakeTokenRepo{}, nil, instagramAdapter, wordpressAdapter, nil, nil)
+
+		list, err := u.ValidateWordpressInstagram(context.Background(), req.CreateWordpressInstagram{
+			WordpressDomain: "a.example.com",
+			InstagramID:     "ig-1",
+		})
+		require.NoError(t, err)
+		assert.True(t, list.OK)
+		assert.Equal(t, []string{
+			"settings:true:",
+			"wordpress_site:true:テストサイト",
+			"wordpress_signature:true:",
+			"instagram_account:true:homing_test",
+		}, checkSummary(list))
+	})
+
+	t.Run("署名の不一致は対処方法を返す", func(t *testing.T) {
+		wordpressAdapter := &fakeWordpressAdapter{
+			titles:    map[string]string{"a.example.com": "テストサイト"},
+			verifyErr: fmt.Errorf("%w: ステータス: 403", domain.ErrWordpressSignature),
+		}
+		u := NewWordpressInstagramUsecase(nil, &fakeTokenRepo{}, nil, instagramAdapter, wordpressAdapter, nil, nil)
+
+		list, err := u.ValidateWordpressInstagram(context.Background(), req.CreateWordpressInstagram{
+			WordpressDomain: "a.example.com",
+			InstagramID:     "ig-unknown",
+		})
+		require.NoError(t, err)
+		assert.False(t, list.OK)
+		assert.False(t, list.Checks[2].OK)
+		assert.Contains(t, list.Checks[2].Action, "SECRET_PHRASE")
+		assert.False(t, list.Checks[3].OK)
+		assert.Equal(t, "instagram account not found", list.Checks[3].Error)
+	})
+
+	t.Run("トークン未登録", func(t *testing.T) {
+		wordpressAdapter := &fakeWordpressAdapter{titles: map[string]string{"a.example.com": "テストサイト"}}
+		u := NewWordpressInstagramUsecase(nil, &fakeTokenRepo{err: errors.New("no token")}, nil, instagramAdapter, wordpressAdapter, nil, nil)
+
+		list, err := u.ValidateWordpressInstagram(context.Background(), req.CreateWordpressInstagram{
+			WordpressDomain: "a.example.com",
+			InstagramID:     "ig-1",
+		})
+		require.NoError(t, err)
+		assert.False(t, list.OK)
+		assert.Equal(t, domain.ErrTokenNotFound.Error(), list.Checks[3].Error)
+	})
+}
+
+func TestBusinessInstagramUsecase_ValidateBusinessInstagram(t *testing.T) {
+	instagramAdapter := &fakeInstagramAdapter{usernames: map[string]string{"ig-1": "homing_test"}}
+
+	t.Run("全て成功", func(t *testing.T) {
+		u := NewBusinessInstagramUsecase(&fakeGoogleBusinessRepo{}, &fakeTokenRepo{}, nil, nil, instagramAdapter, &fakeGbpAdapter{}, newFakeGoogleAccountRepo(), nil, nil, nil)
+
+		list, err := u.ValidateBusinessInstagram(context.Background(), req.BusinessInstagram{
+			BusinessName: "locations/1",
+			InstagramID:  "ig-1",
+		})
+		require.NoError(t, err)
+		assert.True(t, list.OK)
+		assert.Equal(t, []string{
+			"settings:true:",
+			"instagram_account:true:homing_test",
+			"google_business:true:テスト店舗",
+		}, checkSummary(list))
+	})
+
+	t.Run("設定値の誤りと未登録のビジネス", func(t *testing.T) {
+		u := NewBusinessInstagramUsecase(&fakeGoogleBusinessRepo{}, &fakeTokenRepo{}, nil, nil, instagramAdapter, &fakeGbpAdapter{}, newFakeGoogleAccountRepo(), nil, nil, nil)
+
+		list, err := u.ValidateBusinessInstagram(context.Background(), req.BusinessInstagram{
+			BusinessName:     "locations/unknown",
+			InstagramID:      "ig-1",
+			CallToActionType: string(domain.CallToActionBook),
+		})
+		require.NoError(t, err)
+		assert.False(t, list.OK)
+		assert.Equal(t, []string{
+			"settings:false:",
+			"instagram_account:true:homing_test",
+			"google_business:false:",
+		}, checkSummary(list))
+		assert.Contains(t, list.Checks[0].Error, "call_to_action_url")
+	})
+
+	t.Run("Googleアカウントの認証切れ", func(t *testing.T) {
+		gbpAdapter := &fakeGbpAdapter{businessErr: fmt.Errorf("%w: invalid_grant", domain.ErrGoogleNotAuthorized)}
+		u := NewBusinessInstagramUsecase(&fakeGoogleBusinessRepo{}, &fakeTokenRepo{}, nil, nil, instagramAdapter, gbpAdapter, newFakeGoogleAccountRepo(), nil, nil, nil)
+
+		list, err := u.ValidateBusinessInstagram(context.Background(), req.BusinessInstagram{
+			BusinessName: "locations/1",
+			InstagramID:  "ig-1",
+		})
+		require.NoError(t, err)
+		assert.False(t, list.Checks[2].OK)
+		assert.Contains(t, list.Checks[2].Action, "/api/oauth/google/start")
+	})
+}
+
+func TestWordpressGbpUsecase_ValidateWordpressGbp(t *testing.T) {
+	t.Run("全て成功", func(t *testing.T) {
+		wordpressAdapter := &fakeWordpressAdapter{titles: map[string]string{"a.example.com": "テストサイト"}}
+		u := NewWordpressGbpUsecase(nil, nil, wordpressAdapter, &fakeGbpAdapter{}, &fakeGoogleBusinessRepo{}, newFakeGoogleAccountRepo(), nil, nil)
+
+		// URL未設定のボタンはWordPressの投稿URLを使うため成功する
+		list, err := u.ValidateWordpressGbp(context.Background(), req.WordpressGbp{
+			WordpressDomain:  "a.example.com",
+			BusinessName:     "locations/1",
+			CallToActionType: string(domain.CallToActionLearnMore),
+		})
+		require.NoError(t, err)
+		assert.True(t, list.OK)
+		assert.Equal(t, []string{
+			"settings:true:",
+			"wordpress_site:true:テストサイト",
+			"wordpress_gbp_posts:true:",
+			"google_business:true:テスト店舗",
+		}, checkSummary(list))
+	})
+
+	t.Run("プラグインが古い", func(t *testing.T) {
+		wordpressAdapter := &fakeWordpressAdapter{
+			titles:  map[string]string{"a.example.com": "テストサイト"},
+			gbpErrs: map[string]error{"a.example.com": errors.New("ステータス: 404")},
+		}
+		u := NewWordpressGbpUsecase(nil, nil, wordpressAdapter, &fakeGbpAdapter{}, &fakeGoogleBusinessRepo{}, newFakeGoogleAccountRepo(), nil, nil)
+
+		list, err := u.ValidateWordpressGbp(context.Background(), req.WordpressGbp{
+			WordpressDomain: "a.example.com",
+			BusinessName:    "locations/1",
+		})
+		require.NoError(t, err)
+		assert.False(t, list.OK)
+		assert.False(t, list.Checks[2].OK)
+		assert.Contains(t, list.Checks[2].Action, "rodut/v1/posts")
+	})
+}
@@ -0,0 +1,292 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zuxt268/homing/internal/domain"
+)
+
+// Source は連携元（Instagram、WordPressなど）。
+type Source[T any] interface {
+	// Fetch は連携する投稿を連携する順に返す。
+	Fetch(ctx context.Context) ([]T, error)
+	// Key は投稿を識別するキー。失敗の通知や記録に使う。
+	Key(item T) string
+	// Prepare は連携先に共通の準備（メディアのダウンロードなど）をする。
+	// 投稿を連携する連携先がある場合だけ、連携先の数によらず1回呼ぶ。戻り値の関数で後片付けする。
+	Prepare(ctx context.Context, item T) (func(), error)
+	// Close は同期の終了時に一時ファイルなどを片付ける。
+	Close()
+}
+
+// Destination は連携先（WordPress、GBPのPhotos、GBPのLocal Postなど）。
+// 同じ連携（Account）の連携先は渡した順に投稿し、どれかが失敗した投稿は残りの連携先に投稿しない。
+type Destination[T any] interface {
+	Account() domain.Account
+	// Needs は投稿を連携するかどうか。連携済みの投稿や連携開始日前の投稿は false を返す。
+	Needs(ctx context.Context, item T) (bool, error)
+	Publish(ctx context.Context, item T) error
+	// Preview は Publish で投稿する内容を、何も投稿・保存せずに返す（ドライラン）。
+	Preview(ctx context.Context, item T) ([]domain.SyncPreview, error)
+}
+
+// Job は1つの連携元から連携先への同期。
+type Job[T any] struct {
+	// LockKey が空でない場合、同じキーの同期は同時に実行しない（定期実行と手動実行が重なった場合の二重投稿を防ぐ）。
+	LockKey string
+	// Accounts は連携元の準備や投稿の取得に失敗した場合に失敗とする連携。
+	Accounts []domain.Account
+	// Open は連携元と連携先を用意する（トークンやGoogleアカウントの取得など）。
+	Open func(ctx context.Context) (Source[T], []Destination[T], error)
+	// Reconcile は前回までの同期で投稿の途中で止まった投稿を片付け、投稿できたか確認できない投稿を返す。
+	// 返した投稿は失敗として通知するが、StopFailedDestination でも連携を止めない。
+	// ロックを取ってから Open の前に呼ぶ。ドライランでは呼ばない。
+	Reconcile func(ctx context.Context) ([]Failure, error)
+	// Done は同期の終了時に連携ごとの最後のエラーを受け取る（失敗していない連携は含まない）。
+	Done func(ctx context.Context, errs map[domain.Account]error)
+}
+
+// Failure は投稿ごとの失敗。
+type Failure struct {
+	Account domain.Account
+	PostKey string
+	Err     error
+}
+
+// ReportFunc は連携の失敗を通知する。postKey は投稿によらない失敗の場合は空になる。
+type ReportFunc func(ctx context.Context, flow string, err error, account domain.Account, postKey string)
+
+// Pipeline は連携元の投稿を連携先に投稿する同期処理の共通部分。
+// 連携済み・対象外の投稿の除外、並列実行、エラー処理、失敗の通知をまとめて行う。
+type Pipeline[T any] struct {
+	// Flow は通知に使う連携の名前（例: "instagram => wordpress"）
+	Flow string
+	// Concurrency は同時に実行する Job の数。1以下の場合は順番に実行する。
+	Concurrency int
+	// AbortOnError が true の場合は最初のエラーで同期をやめてエラーを返す（1件ずつの手動同期用）。
+	// false の場合は失敗を Report で通知して続ける。
+	AbortOnError bool
+	// StopFailedDestination が true の場合、失敗した連携にはそれ以降の投稿を連携しない（投稿の順序を崩さないため）。
+	StopFailedDestination bool
+	Report                ReportFunc
+	// Locks は LockKey ごとのロック。同じ連携の同期をまたいで共有する。
+	Locks *sync.Map
+}
+
+// Run は Job を実行する。AbortOnError が false の場合、失敗は通知済みなので nil を返す。
+func (p *Pipeline[T]) Run(ctx context.Context, jobs ...Job[T]) error {
+	_, err := p.run(ctx, false, jobs)
+	return err
+}
+
+// Preview は何も投稿・保存せずに、Run で投稿される内容を Job の順に返す（ドライラン）。
+// 失敗は通知せず、AbortOnError によらず Error を設定した SyncPreview として返す。Done も呼ばない。
+func (p *Pipeline[T]) Preview(ctx context.Context, jobs ...Job[T]) ([]domain.SyncPreview, error) {
+	return p.run(ctx, true, jobs)
+}
+
+func (p *Pipeline[T]) run(ctx context.Context, dryRun bool, jobs []Job[T]) ([]domain.SyncPreview, error) {
+	results := make([][]domain.SyncPreview, len(jobs))
+	if p.Concurrency <= 1 {
+		for i, job := range jobs {
+			previews, err := p.runJob(ctx, job, dryRun)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = previews
+		}
+		return flatten(results), nil
+	}
+
+	semaphore := make(chan struct{}, p.Concurrency)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for i, job := range jobs {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int, job Job[T]) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			previews, err := p.runJob(ctx, job, dryRun)
+			if err != nil {
+				once.Do(func() { firstErr = err })
+			}
+			results[i] = previews
+		}(i, job)
+	}
+
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return flatten(results), nil
+}
+
+func flatten(results [][]domain.SyncPreview) []domain.SyncPreview {
+	var previews []domain.SyncPreview
+	for _, r := range results {
+		previews = append(previews, r...)
+	}
+	return previews
+}
+
+func (p *Pipeline[T]) runJob(ctx context.Context, job Job[T], dryRun bool) ([]domain.SyncPreview, error) {
+	// ドライランは何も投稿しないので、実行中の同期を待たない
+	if job.LockKey != "" && p.Locks != nil && !dryRun {
+		lockInterface, _ := p.Locks.LoadOrStore(job.LockKey, &sync.Mutex{})
+		mu := lockInterface.(*sync.Mutex)
+		mu.Lock()
+		defer mu.Unlock()
+	}
+
+	var previews []domain.SyncPreview
+	errs := make(map[domain.Account]error)
+	// 投稿に失敗した連携。StopFailedDestination の場合はそれ以降の投稿を連携しない
+	stopped := make(map[domain.Account]bool)
+	if job.Done != nil && !dryRun {
+		defer func() {
+			job.Done(ctx, errs)
+		}()
+	}
+	fail := func(account domain.Account, postKey string, err error) error {
+		errs[account] = err
+		if dryRun {
+			previews = append(previews, domain.SyncPreview{Account: account, PostKey: postKey, Error: err.Error()})
+			return nil
+		}
+		if p.AbortOnError {
+			return err
+		}
+		if p.Report != nil {
+			p.Report(ctx, p.Flow, err, account, postKey)
+		}
+		return nil
+	}
+	failAll := func(err error) error {
+		for _, account := range job.Accounts {
+			if err := fail(account, "", err); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	/*
+		途中で止まった投稿を片付ける
+	*/
+	if job.Reconcile != nil && !dryRun {
+		failures, err := job.Reconcile(ctx)
+		if err != nil {
+			return previews, failAll(err)
+		}
+		for _, f := range failures {
+			if err := fail(f.Account, f.PostKey, f.Err); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	/*
+		連携元と連携先を用意して、投稿を取得する
+	*/
+	source, destinations, err := job.Open(ctx)
+	if err != nil {
+		return previews, failAll(err)
+	}
+	defer source.Close()
+
+	items, err := source.Fetch(ctx)
+	if err != nil {
+		return previews, failAll(err)
+	}
+
+	for _, item := range items {
+		key := source.Key(item)
+		// この投稿で失敗した連携。同じ連携の残りの連携先には投稿しない
+		failed := make(map[domain.Account]bool)
+		failItem := func(account domain.Account, err error) error {
+			failed[account] = true
+			stopped[account] = true
+			return fail(account, key, err)
+		}
+
+		/*
+			この投稿を連携する連携先を決める
+		*/
+		var targets []Destination[T]
+		for _, d := range destinations {
+			account := d.Account()
+			if failed[account] || (p.StopFailedDestination && stopped[account]) {
+				continue
+			}
+			need, err := d.Needs(ctx, item)
+			if err != nil {
+				if err := failItem(account, err); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			if need {
+				targets = append(targets, d)
+			}
+		}
+		if len(targets) == 0 {
+			continue
+		}
+
+		if dryRun {
+			/*
+				メディアのダウンロードなどはせずに、投稿する内容だけを集める
+			*/
+			for _, d := range targets {
+				if failed[d.Account()] {
+					continue
+				}
+				preview, err := d.Preview(ctx, item)
+				if err != nil {
+					_ = failItem(d.Account(), err)
+					continue
+				}
+				previews = append(previews, preview...)
+			}
+			continue
+		}
+
+		/*
+			連携先に共通の準備（連携先の数によらず1回）
+		*/
+		cleanup, err := source.Prepare(ctx, item)
+		if err != nil {
+			for _, d := range targets {
+				if failed[d.Account()] {
+					continue
+				}
+				if err := failItem(d.Account(), err); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		/*
+			連携先に投稿する
+		*/
+		for _, d := range targets {
+			if failed[d.Account()] {
+				continue
+			}
+			if err := d.Publish(ctx, item); err != nil {
+				if err := failItem(d.Account(), err); err != nil {
+					cleanup()
+					return nil, err
+				}
+			}
+		}
+		cleanup()
+	}
+	return previews, nil
+}
@@ -0,0 +1,402 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zuxt268/homing/internal/domain"
+)
+
+type fakeSource struct {
+	items      []string
+	fetchErr   error
+	prepareErr map[string]error
+	prepared   []string
+	cleaned    int
+	closed     bool
+}
+
+func (s *fakeSource) Fetch(context.Context) ([]string, error) {
+	return s.items, s.fetchErr
+}
+
+func (s *fakeSource) Key(item string) string {
+	return item
+}
+
+func (s *fakeSource) Prepare(_ context.Context, item string) (func(), error) {
+	if err := s.prepareErr[item]; err != nil {
+		return nil, err
+	}
+	s.prepared = append(s.prepared, item)
+	return func() { s.cleaned++ }, nil
+}
+
+func (s *fakeSource) Close() {
+	s.closed = true
+}
+
+type fakeDestination struct {
+	account    domain.Account
+	skip       map[string]bool
+	needsErr   map[string]error
+	publishErr map[string]error
+	published  []string
+}
+
+func (d *fakeDestination) Account() domain.Account {
+	return d.account
+}
+
+func (d *fakeDestination) Needs(_ context.Context, item string) (bool, error) {
+	if err := d.needsErr[item]; err != nil {
+		return false, err
+	}
+	return !d.skip[item], nil
+}
+
+func (d *fakeDestination) Publish(_ context.Context, item string) error {
+	if err := d.publishErr[item]; err != nil {
+		return err
+	}
+	d.published = append(d.published, item)
+	return nil
+}
+
+func (d *fakeDestination) Preview(_ context.Context, item string) ([]domain.SyncPreview, error) {
+	if err := d.publishErr[item]; err != nil {
+		return nil, err
+	}
+	return []domain.SyncPreview{{Account: d.account, PostKey: item}}, nil
+}
+
+type report struct {
+	flow    string
+	account domain.Account
+	postKey string
+	err     error
+}
+
+type reporter struct {
+	mu      sync.Mutex
+	reports []report
+}
+
+func (r *reporter) report(_ context.Context, flow string, err error, account domain.Account, postKey string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, report{flow: flow, account: account, postKey: postKey, err: err})
+}
+
+func newJob(source *fakeSource, accounts []domain.Account, destinations ...*fakeDestination) Job[string] {
+	return Job[string]{
+		Accounts: accounts,
+		Open: func(context.Context) (Source[string], []Destination[string], error) {
+			ds := make([]Destination[string], 0, len(destinations))
+			for _, d := range destinations {
+				ds = append(ds, d)
+			}
+			return source, ds, nil
+		},
+	}
+}
+
+var (
+	accountA = domain.Account{Type: domain.AccountTypeWordpressInstagram, ID: 1}
+	accountB = domain.Account{Type: domain.AccountTypeWordpressInstagram, ID: 2}
+)
+
+func TestPipeline_StopFailedDestination(t *testing.T) {
+	errPublish := errors.New("publish failed")
+	source := &fakeSource{items: []string{"m1", "m2", "m3"}}
+	a := &fakeDestination{account: accountA, publishErr: map[string]error{"m2": errPublish}}
+	b := &fakeDestination{account: accountB, skip: map[string]bool{"m1": true}}
+	r := &reporter{}
+
+	var done map[domain.Account]error
+	job := newJob(source, []domain.Account{accountA, accountB}, a, b)
+	job.Done = func(_ context.Context, errs map[domain.Account]error) { done = errs }
+
+	p := &Pipeline[string]{Flow: "instagram => wordpress", StopFailedDestination: true, Report: r.report}
+	assert.NoError(t, p.Run(context.Background(), job))
+
+	// 失敗した連携先には以降の投稿を連携しない。他の連携先は続ける
+	assert.Equal(t, []string{"m1"}, a.published)
+	assert.Equal(t, []string{"m2", "m3"}, b.published)
+	assert.Equal(t, []report{{flow: "instagram => wordpress", account: accountA, postKey: "m2", err: errPublish}}, r.reports)
+	assert.Equal(t, map[domain.Account]error{accountA: errPublish}, done)
+
+	// 準備は連携先の数によらず1投稿につき1回
+	assert.Equal(t, []string{"m1", "m2", "m3"}, source.prepared)
+	assert.Equal(t, 3, source.cleaned)
+	assert.True(t, source.closed)
+}
+
+func TestPipeline_ContinueAfterFailure(t *testing.T) {
+	errPhoto := errors.New("photo failed")
+	source := &fakeSource{items: []string{"m1", "m2"}}
+	photo := &fakeDestination{account: accountA, publishErr: map[string]error{"m1": errPhoto}}
+	localPost := &fakeDestination{account: accountA}
+	r := &reporter{}
+
+	p := &Pipeline[string]{Flow: "instagram => google business profile", Report: r.report}
+	assert.NoError(t, p.Run(context.Background(), newJob(source, []domain.Account{accountA}, photo, localPost)))
+
+	// 同じ連携の連携先は、失敗した投稿だけ残りに投稿しない
+	assert.Equal(t, []string{"m2"}, photo.published)
+	assert.Equal(t, []string{"m2"}, localPost.published)
+	assert.Len(t, r.reports, 1)
+	assert.Equal(t, "m1", r.reports[0].postKey)
+}
+
+func TestPipeline_NeedsError(t *testing.T) {
+	errNeeds := errors.New("db error")
+	source := &fakeSource{items: []string{"m1", "m2"}}
+	photo := &fakeDestination{account: accountA, needsErr: map[string]error{"m1": errNeeds}}
+	localPost := &fakeDestination{account: accountA}
+	r := &reporter{}
+
+	p := &Pipeline[string]{Report: r.report}
+	assert.NoError(t, p.Run(context.Background(), newJob(source, nil, photo, localPost)))
+
+	assert.Equal(t, []string{"m2"}, photo.published)
+	assert.Equal(t, []string{"m2"}, localPost.published)
+	assert.Equal(t, []report{{account: accountA, postKey: "m1", err: errNeeds}}, r.reports)
+}
+
+func TestPipeline_SkipPrepare(t *testing.T) {
+	source := &fakeSource{items: []string{"m1", "m2"}}
+	a := &fakeDestination{account: accountA, skip: map[string]bool{"m1": true}}
+
+	p := &Pipeline[string]{}
+	assert.NoError(t, p.Run(context.Background(), newJob(source, nil, a)))
+
+	// 連携する連携先が無い投稿は準備しない
+	assert.Equal(t, []string{"m2"}, source.prepared)
+	assert.Equal(t, []string{"m2"}, a.published)
+}
+
+func TestPipeline_PrepareError(t *testing.T) {
+	errDownload := errors.New("download failed")
+	source := &fakeSource{items: []string{"m1", "m2"}, prepareErr: map[string]error{"m1": errDownload}}
+	a := &fakeDestination{account: accountA}
+	b := &fakeDestination{account: accountB}
+	r := &reporter{}
+
+	p := &Pipeline[string]{StopFailedDestination: true, Report: r.report}
+	assert.NoError(t, p.Run(context.Background(), newJob(source, nil, a, b)))
+
+	assert.Empty(t, a.published)
+	assert.Empty(t, b.published)
+	assert.Equal(t, []report{
+		{account: accountA, postKey: "m1", err: errDownload},
+		{account: accountB, postKey: "m1", err: errDownload},
+	}, r.reports)
+}
+
+func TestPipeline_FetchError(t *testing.T) {
+	errFetch := errors.New("instagram error")
+	source := &fakeSource{fetchErr: errFetch}
+	r := &reporter{}
+	var done map[domain.Account]error
+
+	job := newJob(source, []domain.Account{accountA, accountB})
+	job.Done = func(_ context.Context, errs map[domain.Account]error) { done = errs }
+
+	p := &Pipeline[string]{Report: r.report}
+	assert.NoError(t, p.Run(context.Background(), job))
+
+	assert.Equal(t, []report{
+		{account: accountA, err: errFetch},
+		{account: accountB, err: errFetch},
+	}, r.reports)
+	assert.Equal(t, map[domain.Account]error{accountA: errFetch, accountB: errFetch}, done)
+	assert.True(t, source.closed)
+}
+
+func TestPipeline_OpenError(t *testing.T) {
+	errToken := errors.New("token not found")
+	r := &reporter{}
+	opened := 0
+	jobs := []Job[string]{
+		{
+			Accounts: []domain.Account{accountA},
+			Open: func(context.Context) (Source[string], []Destination[string], error) {
+				return nil, nil, errToken
+			},
+		},
+		{
+			Accounts: []domain.Account{accountB},
+			Open: func(context.Context) (Source[string], []Destination[string], error) {
+				opened++
+				return &fakeSource{}, nil, nil
+			},
+		},
+	}
+
+	p := &Pipeline[string]{Report: r.report}
+	assert.NoError(t, p.Run(context.Background(), jobs...))
+	assert.Equal(t, []report{{account: accountA, err: errToken}}, r.reports)
+	assert.Equal(t, 1, opened)
+
+	// AbortOnError の場合は通知せずにエラーを返し、残りの Job も実行しない
+	r = &reporter{}
+	opened = 0
+	p = &Pipeline[string]{AbortOnError: true, Report: r.report}
+	assert.ErrorIs(t, p.Run(context.Background(), jobs...), errToken)
+	assert.Empty(t, r.reports)
+	assert.Equal(t, 0, opened)
+}
+
+func TestPipeline_Reconcile(t *testing.T) {
+	errUnconfirmed := errors.New("unconfirmed")
+	source := &fakeSource{items: []string{"m1", "m2"}}
+	a := &fakeDestination{account: accountA}
+	r := &reporter{}
+
+	var order []string
+	var done map[domain.Account]error
+	job := newJob(source, []domain.Account{accountA}, a)
+	open := job.Open
+	job.Open = func(ctx context.Context) (Source[string], []Destination[string], error) {
+		order = append(order, "open")
+		return open(ctx)
+	}
+	job.Reconcile = func(context.Context) ([]Failure, error) {
+		order = append(order, "reconcile")
+		return []Failure{{Account: accountA, PostKey: "m0", Err: errUnconfirmed}}, nil
+	}
+	job.Done = func(_ context.Context, errs map[domain.Account]error) { done = errs }
+
+	p := &Pipeline[string]{StopFailedDestination: true, Report: r.report}
+	assert.NoError(t, p.Run(context.Background(), job))
+
+	// 片付けてから連携元を用意する。確認できない投稿は通知するが、連携は止めない
+	assert.Equal(t, []string{"reconcile", "open"}, order)
+	assert.Equal(t, []report{{account: accountA, postKey: "m0", err: errUnconfirmed}}, r.reports)
+	assert.Equal(t, []string{"m1", "m2"}, a.published)
+	assert.Equal(t, map[domain.Account]error{accountA: errUnconfirmed}, done)
+
+	// ドライランでは片付けない
+	order = nil
+	_, err := p.Preview(context.Background(), job)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"open"}, order)
+
+	// 片付けに失敗した場合は連携元を用意しない
+	errDB := errors.New("db error")
+	order = nil
+	r = &reporter{}
+	job.Reconcile = func(context.Context) ([]Failure, error) { return nil, errDB }
+	p.Report = r.report
+	assert.NoError(t, p.Run(context.Background(), job))
+	assert.Empty(t, order)
+	assert.Equal(t, []report{{account: accountA, err: errDB}}, r.reports)
+}
+
+func TestPipeline_AbortOnError(t *testing.T) {
+	errPublish := errors.New("publish failed")
+	source := &fakeSource{items: []string{"m1", "m2", "m3"}}
+	a := &fakeDestination{account: accountA, publishErr: map[string]error{"m2": errPublish}}
+	r := &reporter{}
+
+	p := &Pipeline[string]{AbortOnError: true, Report: r.report}
+	assert.ErrorIs(t, p.Run(context.Background(), newJob(source, nil, a)), errPublish)
+
+	assert.Equal(t, []string{"m1"}, a.published)
+	assert.Empty(t, r.reports)
+	// 失敗した投稿の準備も片付ける
+	assert.Equal(t, 2, source.cleaned)
+	assert.True(t, source.closed)
+}
+
+func TestPipeline_ConcurrencyAndLock(t *testing.T) {
+	var running, maxRunning, lockedRunning atomic.Int32
+	job := func(lockKey string) Job[string] {
+		return Job[string]{
+			LockKey: lockKey,
+			Open: func(context.Context) (Source[string], []Destination[string], error) {
+				n := running.Add(1)
+				for {
+					m := maxRunning.Load()
+					if n <= m || maxRunning.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				if lockKey != "" {
+					assert.Equal(t, int32(1), lockedRunning.Add(1), "同じキーの同期が同時に実行された")
+				}
+				time.Sleep(10 * time.Millisecond)
+				if lockKey != "" {
+					lockedRunning.Add(-1)
+				}
+				running.Add(-1)
+				return &fakeSource{}, nil, nil
+			},
+		}
+	}
+
+	var jobs []Job[string]
+	for range 4 {
+		jobs = append(jobs, job("wordpress_instagram:1"))
+	}
+	for range 6 {
+		jobs = append(jobs, job(""))
+	}
+
+	p := &Pipeline[string]{Concurrency: 3, Locks: &sync.Map{}}
+	assert.NoError(t, p.Run(context.Background(), jobs...))
+	assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+	assert.Greater(t, maxRunning.Load(), int32(1))
+}
+
+func TestPipeline_Preview(t *testing.T) {
+	errPublish := errors.New("publish failed")
+	errToken := errors.New("token not found")
+	source := &fakeSource{items: []string{"m1", "m2", "m3"}}
+	a := &fakeDestination{account: accountA, publishErr: map[string]error{"m2": errPublish}}
+	b := &fakeDestination{account: accountB, skip: map[string]bool{"m1": true}}
+	r := &reporter{}
+	doneCalled := false
+
+	job := newJob(source, []domain.Account{accountA, accountB}, a, b)
+	job.LockKey = "wordpress_instagram:1"
+	job.Done = func(context.Context, map[domain.Account]error) { doneCalled = true }
+	failedJob := Job[string]{
+		Accounts: []domain.Account{{Type: domain.AccountTypeWordpressInstagram, ID: 3}},
+		Open: func(context.Context) (Source[string], []Destination[string], error) {
+			return nil, nil, errToken
+		},
+	}
+
+	locks := &sync.Map{}
+	mu, _ := locks.LoadOrStore("wordpress_instagram:1", &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	p := &Pipeline[string]{StopFailedDestination: true, AbortOnError: true, Report: r.report, Locks: locks, Concurrency: 2}
+	previews, err := p.Preview(context.Background(), job, failedJob)
+	assert.NoError(t, err)
+
+	// 同期と同じ投稿を Job の順に返し、失敗は通知せずに結果に含める
+	assert.Equal(t, []domain.SyncPreview{
+		{Account: accountA, PostKey: "m1"},
+		{Account: accountA, PostKey: "m2", Error: "publish failed"},
+		{Account: accountB, PostKey: "m2"},
+		{Account: accountB, PostKey: "m3"},
+		{Account: domain.Account{Type: domain.AccountTypeWordpressInstagram, ID: 3}, Error: "token not found"},
+	}, previews)
+	assert.Empty(t, r.reports)
+	assert.False(t, doneCalled)
+
+	// 何も投稿せず、メディアのダウンロードなどの準備もしない
+	assert.Empty(t, a.published)
+	assert.Empty(t, b.published)
+	assert.Empty(t, source.prepared)
+	assert.True(t, source.closed)
+}
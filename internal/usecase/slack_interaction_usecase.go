@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/external"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+type SlackInteractionUsecase interface {
+	// HandleSlackInteraction はSlackの通知のボタンが押された時の操作を実行する。
+	// Slackは3秒以内の応答を求めるため、操作は非同期で実行し、結果でメッセージを置き換える。
+	HandleSlackInteraction(ctx context.Context, payload external.SlackInteractionPayload) error
+}
+
+type slackInteractionUsecase struct {
+	customerUsecase        CustomerUsecase
+	wordpressInstagramRepo repository.WordpressInstagramRepository
+	businessInstagramRepo  repository.BusinessInstagramRepository
+	wordpressGbpRepo       repository.WordpressGbpRepository
+	facebookInstagramRepo  repository.FacebookInstagramRepository
+	feedRepo               repository.FeedRepository
+	slackAdapter           adapter.SlackAdapter
+	webhookUsecase         WebhookUsecase
+}
+
+func NewSlackInteractionUsecase(
+	customerUsecase CustomerUsecase,
+	wordpressInstagramRepo repository.WordpressInstagramRepository,
+	businessInstagramRepo repository.BusinessInstagramRepository,
+	wordpressGbpRepo repository.WordpressGbpRepository,
+	facebookInstagramRepo repository.FacebookInstagramRepository,
+	feedRepo repository.FeedRepository,
+	slackAdapter adapter.SlackAdapter,
+	webhookUsecase WebhookUsecase,
+) SlackInteractionUsecase {
+	return &slackInteractionUsecase{
+		customerUsecase:        customerUsecase,
+		wordpressInstagramRepo: wordpressInstagramRepo,
+		businessInstagramRepo:  businessInstagramRepo,
+		wordpressGbpRepo:       wordpressGbpRepo,
+		facebookInstagramRepo:  facebookInstagramRepo,
+		feedRepo:               feedRepo,
+		slackAdapter:           slackAdapter,
+		webhookUsecase:         webhookUsecase,
+	}
+}
+
+func (u *slackInteractionUsecase) HandleSlackInteraction(ctx context.Context, payload external.SlackInteractionPayload) error {
+	if payload.Type != "block_actions" {
+		return nil
+	}
+
+	var actions []domain.NotificationAction
+	for _, a := range payload.Actions {
+		// 管理画面へのリンクはSlackが開くので何もしない
+		if domain.NotificationActionType(a.ActionID) == domain.NotificationActionOpenAdmin {
+			continue
+		}
+		action, err := domain.ParseNotificationAction(a.ActionID, a.Value)
+		if err != nil {
+			return err
+		}
+		actions = append(actions, action)
+	}
+
+	for _, action := range actions {
+		/*
+			ボタンを外して実行中であることを表示し、二重に押されないようにする
+		*/
+		if err := u.slackAdapter.ReplaceMessage(ctx, payload.ResponseURL, payload.Message.Text, action.InProgress(payload.User.ID)); err != nil {
+			slog.Warn("Slackのメッセージの更新に失敗", "error", err.Error())
+		}
+
+		go func(action domain.NotificationAction) {
+			backGroundCtx := context.Background()
+			err := u.execute(backGroundCtx, action)
+			if err != nil {
+				slog.Warn("Slackからの操作に失敗", "action", action.Type, "value", action.Value(), "error", err.Error())
+			}
+			if err := u.slackAdapter.ReplaceMessage(backGroundCtx, payload.ResponseURL, payload.Message.Text, action.Outcome(payload.User.ID, err)); err != nil {
+				slog.Warn("Slackのメッセージの更新に失敗", "error", err.Error())
+			}
+		}(action)
+	}
+	return nil
+}
+
+func (u *slackInteractionUsecase) execute(ctx context.Context, action domain.NotificationAction) error {
+	id := action.Account.ID
+	switch action.Type {
+	case domain.NotificationActionRetryPost:
+		switch action.Account.Type {
+		case domain.AccountTypeWordpressInstagram:
+			return u.customerUsecase.RetryWordpressInstagramPost(ctx, id, action.PostKey)
+		case domain.AccountTypeBusinessInstagram:
+			return u.customerUsecase.RetryBusinessInstagramPost(ctx, id, action.PostKey)
+		}
+	case domain.NotificationActionResyncAccount:
+		/*
+			同期中のエラーはこれまで通り別の通知で送られる
+		*/
+		switch action.Account.Type {
+		case domain.AccountTypeWordpressInstagram:
+			return u.customerUsecase.SyncOneWordpressInstagram(ctx, id)
+		case domain.AccountTypeBusinessInstagram:
+			return u.customerUsecase.SyncOneGoogleBusinessInstagram(ctx, id)
+		case domain.AccountTypeWordpressGbp:
+			return u.customerUsecase.SyncOneWordpressGbp(ctx, id)
+		case domain.AccountTypeFacebookInstagram:
+			return u.customerUsecase.SyncOneFacebookInstagram(ctx, id)
+		case domain.AccountTypeFeed:
+			return u.customerUsecase.SyncOneFeed(ctx, id)
+		}
+	case domain.NotificationActionPauseAccount:
+		return u.pauseAccount(ctx, action.Account)
+	}
+	return fmt.Errorf("%w: %s は %s に対応していません", domain.ErrBadRequest, action.Account.Type, action.Type)
+}
+
+// pauseAccount は連携を停止する。停止したことはwebhookでも通知する。
+func (u *slackInteractionUsecase) pauseAccount(ctx context.Context, account domain.Account) error {
+	id := account.ID
+	switch account.Type {
+	case domain.AccountTypeWordpressInstagram:
+		wi, err := u.wordpressInstagramRepo.Get(ctx, repository.WordpressInstagramFilter{ID: &id})
+		if err != nil {
+			return err
+		}
+		if wi.ID == 0 {
+			return domain.ErrNotFound
+		}
+		beforeStatus := wi.Status
+		wi.Status = 0
+		if err := u.wordpressInstagramRepo.Update(ctx, wi, repository.WordpressInstagramFilter{ID: &id}); err != nil {
+			return err
+		}
+		publishAccountDisabled(ctx, u.webhookUsecase, beforeStatus, wi.Status, wi.Account())
+		return nil
+	case domain.AccountTypeBusinessInstagram:
+		bi, err := u.businessInstagramRepo.Get(ctx, repository.BusinessInstagramFilter{ID: &id})
+		if err != nil {
+			return err
+		}
+		if bi.ID == 0 {
+			return domain.ErrNotFound
+		}
+		beforeStatus := bi.Status
+		bi.Status = 0
+		if err := u.businessInstagramRepo.Update(ctx, bi, repository.BusinessInstagramFilter{ID: &id}); err != nil {
+			return err
+		}
+		publishAccountDisabled(ctx, u.webhookUsecase, beforeStatus, bi.Status, bi.Account())
+		return nil
+	case domain.AccountTypeWordpressGbp:
+		wg, err := u.wordpressGbpRepo.Get(ctx, repository.WordpressGbpFilter{ID: &id})
+		if err != nil {
+			return err
+		}
+		if wg.ID == 0 {
+			return domain.ErrNotFound
+		}
+		beforeStatus := wg.Status
+		wg.Status = 0
+		if err := u.wordpressGbpRepo.Update(ctx, wg, repository.WordpressGbpFilter{ID: &id}); err != nil {
+			return err
+		}
+		publishAccountDisabled(ctx, u.webhookUsecase, beforeStatus, wg.Status, wg.Account())
+		return nil
+	case domain.AccountTypeFacebookInstagram:
+		fi, err := u.facebookInstagramRepo.Get(ctx, repository.FacebookInstagramFilter{ID: &id})
+		if err != nil {
+			return err
+		}
+		if fi.ID == 0 {
+			return domain.ErrNotFound
+		}
+		beforeStatus := fi.Status
+		fi.Status = 0
+		if err := u.facebookInstagramRepo.Update(ctx, fi, repository.FacebookInstagramFilter{ID: &id}); err != nil {
+			return err
+		}
+		publishAccountDisabled(ctx, u.webhookUsecase, beforeStatus, fi.Status, fi.Account())
+		return nil
+	case domain.AccountTypeFeed:
+		feed, err := u.feedRepo.Get(ctx, repository.FeedFilter{ID: util.Pointer(id)})
+		if err != nil {
+			return err
+		}
+		if feed.ID == 0 {
+			return domain.ErrNotFound
+		}
+		beforeStatus := feed.Status
+		feed.Status = 0
+		if err := u.feedRepo.Update(ctx, feed, repository.FeedFilter{ID: util.Pointer(id)}); err != nil {
+			return err
+		}
+		publishAccountDisabled(ctx, u.webhookUsecase, beforeStatus, feed.Status, feed.Account())
+		return nil
+	}
+	return fmt.Errorf("%w: %s は停止できません", domain.ErrBadRequest, account.Type)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/zuxt268/homing/internal/domain"
 	"github.com/zuxt268/homing/internal/interface/adapter"
 	"github.com/zuxt268/homing/internal/interface/dto/req"
 	"github.com/zuxt268/homing/internal/interface/dto/res"
@@ -17,20 +18,23 @@ type TokenUsecase interface {
 }
 
 type tokenUsecase struct {
-	instagramAdapter adapter.InstagramAdapter
-	slack            adapter.Slack
-	tokenRepo        repository.TokenRepository
+	instagramAdapter    adapter.InstagramAdapter
+	notificationUsecase NotificationUsecase
+	tokenRepo           repository.TokenRepository
+	webhookUsecase      WebhookUsecase
 }
 
 func NewTokenUsecase(
 	instagramAdapter adapter.InstagramAdapter,
-	slack adapter.Slack,
+	notificationUsecase NotificationUsecase,
 	tokenRepo repository.TokenRepository,
+	webhookUsecase WebhookUsecase,
 ) TokenUsecase {
 	return &tokenUsecase{
-		instagramAdapter: instagramAdapter,
-		slack:            slack,
-		tokenRepo:        tokenRepo,
+		instagramAdapter:    instagramAdapter,
+		notificationUsecase: notificationUsecase,
+		tokenRepo:           tokenRepo,
+		webhookUsecase:      webhookUsecase,
 	}
 }
 
@@ -65,12 +69,13 @@ func (u *tokenUsecase) CheckToken(ctx context.Context) error {
 		return err
 	}
 	expiredAt := time.Unix(debug.Data.ExpiresAt, 0)
-	tenDaysLater := time.Now().AddDate(0, 0, 10)
-
-	if tenDaysLater.After(expiredAt) {
-		_ = u.slack.SendTokenExpired(ctx)
+	if time.Now().Add(domain.TokenExpiringWithin).After(expiredAt) {
+		u.notificationUsecase.Notify(ctx, domain.NewTokenExpiringNotification())
+		u.webhookUsecase.Publish(ctx, domain.WebhookEventTokenExpiring, domain.Account{}, map[string]time.Time{
+			"expires_at": expiredAt,
+		})
 	} else {
-		_ = u.slack.SendHealthy(ctx)
+		u.notificationUsecase.Notify(ctx, domain.NewTokenHealthyNotification())
 	}
 	return nil
 }
@@ -0,0 +1,524 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/zuxt268/homing/internal/domain"
+	"github.com/zuxt268/homing/internal/interface/adapter"
+	"github.com/zuxt268/homing/internal/interface/dto/req"
+	"github.com/zuxt268/homing/internal/interface/dto/res"
+	"github.com/zuxt268/homing/internal/interface/repository"
+	"github.com/zuxt268/homing/internal/interface/util"
+)
+
+type WebhookUsecase interface {
+	// Publish はイベントを保存し、購読している送信先に送る。失敗しても呼び出し元の処理は止めない。
+	Publish(ctx context.Context, eventType domain.WebhookEventType, account domain.Account, data any)
+	RetryWebhookDeliveries(ctx context.Context) error
+	ReplayWebhookEvent(ctx context.Context, id int, body req.ReplayWebhookEvent) (*res.WebhookDeliveryList, error)
+
+	GetWebhookSubscriptionList(ctx context.Context, params req.GetWebhookSubscription) (*res.WebhookSubscriptionList, error)
+	GetWebhookSubscription(ctx context.Context, id int) (*res.WebhookSubscription, error)
+	CreateWebhookSubscription(ctx context.Context, body req.CreateWebhookSubscription) (*res.WebhookSubscription, error)
+	UpdateWebhookSubscription(ctx context.Context, id int, body req.UpdateWebhookSubscription) (*res.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id int) error
+
+	GetWebhookEventList(ctx context.Context, params req.GetWebhookEvent) (*res.WebhookEventList, error)
+	GetWebhookEvent(ctx context.Context, id int, params req.GetWebhookEventDetail) (*res.WebhookEventDetail, error)
+	GetWebhookDeliveryList(ctx context.Context, params req.GetWebhookDelivery) (*res.WebhookDeliveryList, error)
+}
+
+type webhookUsecase struct {
+	webhookSubscriptionRepo repository.WebhookSubscriptionRepository
+	webhookEventRepo        repository.WebhookEventRepository
+	webhookDeliveryRepo     repository.WebhookDeliveryRepository
+	webhookAdapter          adapter.WebhookAdapter
+}
+
+func NewWebhookUsecase(
+	webhookSubscriptionRepo repository.WebhookSubscriptionRepository,
+	webhookEventRepo repository.WebhookEventRepository,
+	webhookDeliveryRepo repository.WebhookDeliveryRepository,
+	webhookAdapter adapter.WebhookAdapter,
+) WebhookUsecase {
+	return &webhookUsecase{
+		webhookSubscriptionRepo: webhookSubscriptionRepo,
+		webhookEventRepo:        webhookEventRepo,
+		webhookDeliveryRepo:     webhookDeliveryRepo,
+		webhookAdapter:          webhookAdapter,
+	}
+}
+
+// webhookRetryLimit は1回の再送処理で送る件数
+const webhookRetryLimit = 100
+
+func (u *webhookUsecase) Publish(ctx context.Context, eventType domain.WebhookEventType, account domain.Account, data any) {
+	event, err := domain.NewWebhookEvent(eventType, account, data, time.Now())
+	if err != nil {
+		slog.Warn("webhookのイベント作成に失敗", "event_type", eventType, "error", err.Error())
+		return
+	}
+
+	/*
+		購読者がいなくても後から再送できるように、イベントはすべて保存する
+	*/
+	if err := u.webhookEventRepo.Create(ctx, event); err != nil {
+		slog.Warn("webhookのイベント保存に失敗", "event_type", eventType, "error", err.Error())
+		return
+	}
+
+	subs, err := u.webhookSubscriptionRepo.FindAll(ctx, repository.WebhookSubscriptionFilter{
+		Status: util.Pointer(1),
+	})
+	if err != nil {
+		slog.Warn("webhookの送信先の取得に失敗", "event_id", event.EventID, "error", err.Error())
+		return
+	}
+	for _, sub := range subs {
+		if !sub.Subscribes(event) {
+			continue
+		}
+		if _, err := u.deliver(ctx, sub, event, nil); err != nil {
+			slog.Warn("webhookの送信ログの保存に失敗", "event_id", event.EventID, "subscription_id", sub.ID, "error", err.Error())
+		}
+	}
+}
+
+// RetryWebhookDeliveries は再送時刻を過ぎた送信をまとめて再送する（定期実行）。
+func (u *webhookUsecase) RetryWebhookDeliveries(ctx context.Context) error {
+	deliveries, err := u.webhookDeliveryRepo.FindAll(ctx, repository.WebhookDeliveryFilter{
+		Status:            util.Pointer(domain.WebhookDeliveryRetrying),
+		NextRetryAtBefore: util.Pointer(time.Now()),
+		Limit:             util.Pointer(webhookRetryLimit),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		event, err := u.webhookEventRepo.Get(ctx, repository.WebhookEventFilter{
+			ID: &delivery.EventID,
+		})
+		if err != nil {
+			return err
+		}
+		sub, err := u.webhookSubscriptionRepo.Get(ctx, repository.WebhookSubscriptionFilter{
+			ID: &delivery.SubscriptionID,
+		})
+		if err != nil {
+			return err
+		}
+
+		/*
+			送信先が削除・停止された場合は再送しない
+		*/
+		if event.ID == 0 || sub.ID == 0 || sub.Status != 1 {
+			delivery.Status = domain.WebhookDeliveryFailed
+			delivery.NextRetryAt = nil
+			delivery.Error = "送信先またはイベントが無効になったため再送を中止しました"
+			if err := u.webhookDeliveryRepo.Update(ctx, delivery); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := u.deliver(ctx, sub, event, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReplayWebhookEvent はイベントを再送する。送信ログは再送ごとに新しく作る。
+func (u *webhookUsecase) ReplayWebhookEvent(ctx context.Context, id int, body req.ReplayWebhookEvent) (*res.WebhookDeliveryList, error) {
+	event, err := u.getEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var subs []*domain.WebhookSubscription
+	if body.SubscriptionID != nil {
+		// 送信先を指定した場合は購読の条件によらず送る
+		sub, err := u.getSubscription(ctx, *body.SubscriptionID)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	} else {
+		all, err := u.webhookSubscriptionRepo.FindAll(ctx, repository.WebhookSubscriptionFilter{
+			Status: util.Pointer(1),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, sub := range all {
+			if sub.Subscribes(event) {
+				subs = append(subs, sub)
+			}
+		}
+	}
+
+	result := make([]res.WebhookDelivery, 0, len(subs))
+	for _, sub := range subs {
+		delivery, err := u.deliver(ctx, sub, event, nil)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, toWebhookDeliveryResponse(delivery))
+	}
+	return &res.WebhookDeliveryList{
+		WebhookDeliveryList: result,
+		Paginate: res.Paginate{
+			Total: int64(len(result)),
+			Count: len(result),
+		},
+	}, nil
+}
+
+// deliver はイベントを送信して結果を送信ログに保存する。delivery が nil の場合は新しく送信ログを作る。
+// 送信の失敗は送信ログに記録して再送するため、エラーは送信ログの保存に失敗した場合だけ返す。
+func (u *webhookUsecase) deliver(ctx context.Context, sub *domain.WebhookSubscription, event *domain.WebhookEvent, delivery *domain.WebhookDelivery) (*domain.WebhookDelivery, error) {
+	if delivery == nil {
+		delivery = &domain.WebhookDelivery{
+			EventID:        event.ID,
+			SubscriptionID: sub.ID,
+			Status:         domain.WebhookDeliveryPending,
+		}
+		// 送信ログのIDをヘッダーに含めるため、送信前に保存する
+		if err := u.webhookDeliveryRepo.Create(ctx, delivery); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	header := domain.WebhookHeaders(sub, event, delivery.ID, now)
+	resp, err := u.webhookAdapter.Send(ctx, sub.URL, header, []byte(event.Payload))
+	if err != nil {
+		delivery.RecordAttempt(0, "", err, time.Now())
+	} else {
+		delivery.RecordAttempt(resp.StatusCode, resp.Body, nil, time.Now())
+	}
+	if delivery.Status != domain.WebhookDeliverySucceeded {
+		slog.Warn("webhookの送信に失敗", "event_id", event.EventID, "subscription_id", sub.ID, "attempts", delivery.Attempts, "error", delivery.Error)
+	}
+
+	if err := u.webhookDeliveryRepo.Update(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (u *webhookUsecase) GetWebhookSubscriptionList(ctx context.Context, params req.GetWebhookSubscription) (*res.WebhookSubscriptionList, error) {
+	filter := repository.WebhookSubscriptionFilter{
+		PartialName:   params.Name,
+		Status:        params.Status,
+		Limit:         params.Limit,
+		Offset:        params.Offset,
+		OrderByIDDesc: util.Pointer(true),
+	}
+
+	subs, err := u.webhookSubscriptionRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.webhookSubscriptionRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]res.WebhookSubscription, 0, len(subs))
+	for _, sub := range subs {
+		result = append(result, toWebhookSubscriptionResponse(sub, false))
+	}
+	return &res.WebhookSubscriptionList{
+		WebhookSubscriptionList: result,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(subs),
+		},
+	}, nil
+}
+
+func (u *webhookUsecase) GetWebhookSubscription(ctx context.Context, id int) (*res.WebhookSubscription, error) {
+	sub, err := u.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	resp := toWebhookSubscriptionResponse(sub, false)
+	return &resp, nil
+}
+
+func (u *webhookUsecase) CreateWebhookSubscription(ctx context.Context, body req.CreateWebhookSubscription) (*res.WebhookSubscription, error) {
+	sub := &domain.WebhookSubscription{
+		Name:        body.Name,
+		URL:         body.URL,
+		Secret:      body.Secret,
+		EventTypes:  toWebhookEventTypes(body.EventTypes),
+		AccountType: domain.AccountType(body.AccountType),
+		AccountID:   body.AccountID,
+		Status:      domain.Status(body.Status),
+	}
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+	if sub.Secret == "" {
+		secret, err := domain.GenerateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		sub.Secret = secret
+	}
+
+	if err := u.webhookSubscriptionRepo.Create(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	resp := toWebhookSubscriptionResponse(sub, true)
+	return &resp, nil
+}
+
+func (u *webhookUsecase) UpdateWebhookSubscription(ctx context.Context, id int, body req.UpdateWebhookSubscription) (*res.WebhookSubscription, error) {
+	sub, err := u.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if body.Name != nil {
+		sub.Name = *body.Name
+	}
+	if body.URL != nil {
+		sub.URL = *body.URL
+	}
+	if body.EventTypes != nil {
+		sub.EventTypes = toWebhookEventTypes(body.EventTypes)
+	}
+	if body.AccountType != nil {
+		sub.AccountType = domain.AccountType(*body.AccountType)
+	}
+	if body.AccountID != nil {
+		sub.AccountID = *body.AccountID
+	}
+	if body.Status != nil {
+		sub.Status = domain.Status(*body.Status)
+	}
+	if err := sub.Validate(); err != nil {
+		return nil, err
+	}
+
+	secretChanged := false
+	if body.Secret != nil && *body.Secret != "" {
+		sub.Secret = *body.Secret
+		secretChanged = true
+	}
+	if body.RotateSecret {
+		secret, err := domain.GenerateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		sub.Secret = secret
+		secretChanged = true
+	}
+
+	err = u.webhookSubscriptionRepo.Update(ctx, sub, repository.WebhookSubscriptionFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toWebhookSubscriptionResponse(sub, secretChanged)
+	return &resp, nil
+}
+
+func (u *webhookUsecase) DeleteWebhookSubscription(ctx context.Context, id int) error {
+	return u.webhookSubscriptionRepo.Delete(ctx, repository.WebhookSubscriptionFilter{
+		ID: &id,
+	})
+}
+
+func (u *webhookUsecase) GetWebhookEventList(ctx context.Context, params req.GetWebhookEvent) (*res.WebhookEventList, error) {
+	filter := repository.WebhookEventFilter{
+		EventType:   params.EventType,
+		AccountType: params.AccountType,
+		AccountID:   params.AccountID,
+		Limit:       params.Limit,
+		Offset:      params.Offset,
+	}
+
+	events, err := u.webhookEventRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.webhookEventRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]res.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, res.WebhookEvent{
+			ID:          event.ID,
+			EventID:     event.EventID,
+			EventType:   string(event.EventType),
+			AccountType: string(event.Account.Type),
+			AccountID:   event.Account.ID,
+			AccountName: event.Account.Name,
+			Payload:     json.RawMessage(event.Payload),
+			CreatedAt:   event.CreatedAt,
+		})
+	}
+	return &res.WebhookEventList{
+		WebhookEventList: result,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(events),
+		},
+	}, nil
+}
+
+func (u *webhookUsecase) GetWebhookEvent(ctx context.Context, id int, params req.GetWebhookEventDetail) (*res.WebhookEventDetail, error) {
+	event, err := u.getEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := u.GetWebhookDeliveryList(ctx, req.GetWebhookDelivery{
+		EventID: &event.ID,
+		Limit:   params.Limit,
+		Offset:  params.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &res.WebhookEventDetail{
+		ID:          event.ID,
+		EventID:     event.EventID,
+		EventType:   string(event.EventType),
+		AccountType: string(event.Account.Type),
+		AccountID:   event.Account.ID,
+		AccountName: event.Account.Name,
+		Payload:     json.RawMessage(event.Payload),
+		CreatedAt:   event.CreatedAt,
+		Deliveries:  *deliveries,
+	}, nil
+}
+
+func (u *webhookUsecase) GetWebhookDeliveryList(ctx context.Context, params req.GetWebhookDelivery) (*res.WebhookDeliveryList, error) {
+	filter := repository.WebhookDeliveryFilter{
+		EventID:        params.EventID,
+		SubscriptionID: params.SubscriptionID,
+		Limit:          params.Limit,
+		Offset:         params.Offset,
+	}
+	if params.Status != nil {
+		filter.Status = util.Pointer(domain.WebhookDeliveryStatus(*params.Status))
+	}
+
+	deliveries, err := u.webhookDeliveryRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := u.webhookDeliveryRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]res.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, toWebhookDeliveryResponse(delivery))
+	}
+	return &res.WebhookDeliveryList{
+		WebhookDeliveryList: result,
+		Paginate: res.Paginate{
+			Total: total,
+			Count: len(deliveries),
+		},
+	}, nil
+}
+
+func (u *webhookUsecase) getSubscription(ctx context.Context, id int) (*domain.WebhookSubscription, error) {
+	sub, err := u.webhookSubscriptionRepo.Get(ctx, repository.WebhookSubscriptionFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if sub.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return sub, nil
+}
+
+func (u *webhookUsecase) getEvent(ctx context.Context, id int) (*domain.WebhookEvent, error) {
+	event, err := u.webhookEventRepo.Get(ctx, repository.WebhookEventFilter{
+		ID: &id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if event.ID == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return event, nil
+}
+
+func toWebhookEventTypes(eventTypes []string) []domain.WebhookEventType {
+	result := make([]domain.WebhookEventType, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		result = append(result, domain.WebhookEventType(t))
+	}
+	return result
+}
+
+func toWebhookSubscriptionResponse(sub *domain.WebhookSubscription, withSecret bool) res.WebhookSubscription {
+	eventTypes := make([]string, 0, len(sub.EventTypes))
+	for _, t := range sub.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+	resp := res.WebhookSubscription{
+		ID:          sub.ID,
+		Name:        sub.Name,
+		URL:         sub.URL,
+		EventTypes:  eventTypes,
+		AccountType: string(sub.AccountType),
+		AccountID:   sub.AccountID,
+		Status:      int(sub.Status),
+		UpdatedAt:   sub.UpdatedAt,
+		CreatedAt:   sub.CreatedAt,
+	}
+	if withSecret {
+		resp.Secret = sub.Secret
+	}
+	return resp
+}
+
+func toWebhookDeliveryResponse(delivery *domain.WebhookDelivery) res.WebhookDelivery {
+	return res.WebhookDelivery{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		SubscriptionID: delivery.SubscriptionID,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		NextRetryAt:    delivery.NextRetryAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		UpdatedAt:      delivery.UpdatedAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
+
+// publishAccountDisabled は連携の設定が有効から停止に変わった場合にwebhookで通知する。
+func publishAccountDisabled(ctx context.Context, webhookUsecase WebhookUsecase, before, after domain.Status, account domain.Account) {
+	if before != 1 || after == 1 {
+		return
+	}
+	webhookUsecase.Publish(ctx, domain.WebhookEventAccountDisabled, account, map[string]int{
+		"status": int(after),
+	})
+}
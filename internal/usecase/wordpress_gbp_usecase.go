@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/zuxt268/homing/internal/domain"
@@ -16,15 +18,22 @@ type WordpressGbpUsecase interface {
 	GetWordpressGbpList(ctx context.Context, params req.GetWordpressGbp) (*res.WordpressGbpList, error)
 	GetWordpressGbp(ctx context.Context, id int, params req.GetWordpressGbpDetail) (*res.WordpressGbpDetail, error)
 	CreateWordpressGbp(ctx context.Context, body req.WordpressGbp) (*res.WordpressGbp, error)
+	ValidateWordpressGbp(ctx context.Context, body req.WordpressGbp) (*res.OnboardingCheckList, error)
+	ImportWordpressGbp(ctx context.Context, format domain.AccountConfigFormat, data []byte, dryRun bool) (*res.AccountConfigImport, error)
+	ExportWordpressGbp(ctx context.Context, format domain.AccountConfigFormat) ([]byte, error)
 	UpdateWordpressGbp(ctx context.Context, id int, body req.WordpressGbp) (*res.WordpressGbp, error)
 	DeleteWordpressGbp(ctx context.Context, id int) error
 }
 
 type wordpressGbpUsecase struct {
-	wordpressGbpRepo repository.WordpressGbpRepository
-	googlePostRepo   repository.GooglePostRepository
-	wordpressAdapter adapter.WordpressAdapter
-	gbpAdapter       adapter.GbpAdapter
+	wordpressGbpRepo   repository.WordpressGbpRepository
+	googlePostRepo     repository.GooglePostRepository
+	wordpressAdapter   adapter.WordpressAdapter
+	gbpAdapter         adapter.GbpAdapter
+	googleBusinessRepo repository.GoogleBusinessRepository
+	googleAccountRepo  repository.GoogleAccountRepository
+	webhookUsecase     WebhookUsecase
+	baseRepo           repository.BaseRepository
 }
 
 func NewWordpressGbpUsecase(
@@ -32,12 +41,20 @@ func NewWordpressGbpUsecase(
 	googlePostRepo repository.GooglePostRepository,
 	wordpressAdapter adapter.WordpressAdapter,
 	gbpAdapter adapter.GbpAdapter,
+	googleBusinessRepo repository.GoogleBusinessRepository,
+	googleAccountRepo repository.GoogleAccountRepository,
+	webhookUsecase WebhookUsecase,
+	baseRepo repository.BaseRepository,
 ) WordpressGbpUsecase {
 	return &wordpressGbpUsecase{
-		wordpressGbpRepo: wordpressGbpRepo,
-		googlePostRepo:   googlePostRepo,
-		wordpressAdapter: wordpressAdapter,
-		gbpAdapter:       gbpAdapter,
+		wordpressGbpRepo:   wordpressGbpRepo,
+		googlePostRepo:     googlePostRepo,
+		wordpressAdapter:   wordpressAdapter,
+		gbpAdapter:         gbpAdapter,
+		googleBusinessRepo: googleBusinessRepo,
+		googleAccountRepo:  googleAccountRepo,
+		webhookUsecase:     webhookUsecase,
+		baseRepo:           baseRepo,
 	}
 }
 
@@ -61,17 +78,22 @@ func (u *wordpressGbpUsecase) GetWordpressGbpList(ctx context.Context, params re
 	resWordpressGbp := make([]res.WordpressGbp, len(wgList))
 	for i, wg := range wgList {
 		resWordpressGbp[i] = res.WordpressGbp{
-			ID:              wg.ID,
-			Name:            wg.Name,
-			WordpressDomain: wg.WordpressDomain,
-			BusinessName:    wg.BusinessName,
-			BusinessTitle:   wg.BusinessTitle,
-			Memo:            wg.Memo,
-			MapsURL:         wg.MapsURL,
-			StartDate:       wg.StartDate,
-			Status:          int(wg.Status),
-			CreatedAt:       wg.CreatedAt,
-			UpdatedAt:       wg.UpdatedAt,
+			ID:                 wg.ID,
+			Name:               wg.Name,
+			WordpressDomain:    wg.WordpressDomain,
+			BusinessName:       wg.BusinessName,
+			BusinessTitle:      wg.BusinessTitle,
+			Memo:               wg.Memo,
+			MapsURL:            wg.MapsURL,
+			CallToActionType:   string(wg.CallToActionType),
+			CallToActionURL:    wg.CallToActionURL,
+			MediaCategory:      string(wg.MediaCategory),
+			MediaCategoryRules: toMediaCategoryRulesResponse(wg.MediaCategoryRules),
+			SanitizeConfig:     toSanitizeConfigResponse(wg.SanitizeConfig),
+			StartDate:          wg.StartDate,
+			Status:             int(wg.Status),
+			CreatedAt:          wg.CreatedAt,
+			UpdatedAt:          wg.UpdatedAt,
 		}
 	}
 	return &res.WordpressGbpList{
@@ -112,23 +134,89 @@ func (u *wordpressGbpUsecase) GetWordpressGbp(ctx context.Context, id int, param
 	}
 
 	return &res.WordpressGbpDetail{
-		ID:                wg.ID,
-		Name:              wg.Name,
-		WordpressDomain:   wg.WordpressDomain,
-		BusinessName:      wg.BusinessName,
-		BusinessTitle:     wg.BusinessTitle,
-		Memo:              wg.Memo,
-		MapsURL:           wg.MapsURL,
-		StartDate:         wg.StartDate,
-		Status:            int(wg.Status),
-		GooglePhotosCount: googlePhotosCount,
-		GooglePostsCount:  googlePostsCount,
-		CreatedAt:         wg.CreatedAt,
-		UpdatedAt:         wg.UpdatedAt,
+		ID:                 wg.ID,
+		Name:               wg.Name,
+		WordpressDomain:    wg.WordpressDomain,
+		BusinessName:       wg.BusinessName,
+		BusinessTitle:      wg.BusinessTitle,
+		Memo:               wg.Memo,
+		MapsURL:            wg.MapsURL,
+		CallToActionType:   string(wg.CallToActionType),
+		CallToActionURL:    wg.CallToActionURL,
+		MediaCategory:      string(wg.MediaCategory),
+		MediaCategoryRules: toMediaCategoryRulesResponse(wg.MediaCategoryRules),
+		SanitizeConfig:     toSanitizeConfigResponse(wg.SanitizeConfig),
+		StartDate:          wg.StartDate,
+		Status:             int(wg.Status),
+		GooglePhotosCount:  googlePhotosCount,
+		GooglePostsCount:   googlePostsCount,
+		CreatedAt:          wg.CreatedAt,
+		UpdatedAt:          wg.UpdatedAt,
 	}, nil
 }
 
+// ValidateWordpressGbp は作成前に、設定値・WordPressのプラグイン・GBPのビジネスを確認する。何も作成・投稿しない。
+func (u *wordpressGbpUsecase) ValidateWordpressGbp(ctx context.Context, body req.WordpressGbp) (*res.OnboardingCheckList, error) {
+	// URL未設定の場合はWordPressの投稿URLを使う
+	settingsErr := errors.Join(
+		domain.ValidateCallToAction(domain.CallToActionType(body.CallToActionType), body.CallToActionURL, true),
+		domain.ValidateGbpMediaCategory(domain.GbpMediaCategory(body.MediaCategory), toGbpMediaCategoryRules(body.MediaCategoryRules)),
+	)
+	if config := toGbpSanitizeConfig(body.SanitizeConfig); config != nil {
+		settingsErr = errors.Join(settingsErr, config.Validate())
+	}
+
+	return toOnboardingCheckList([]domain.OnboardingCheck{
+		checkSettings(settingsErr),
+		checkWordpressSite(ctx, u.wordpressAdapter, domain.WordpressInstagram{WordpressDomain: body.WordpressDomain}),
+		checkWordpressGbpPosts(ctx, u.wordpressAdapter, body.WordpressDomain),
+		checkGoogleBusiness(ctx, u.googleBusinessRepo, u.googleAccountRepo, u.gbpAdapter, body.BusinessName),
+	}), nil
+}
+
 func (u *wordpressGbpUsecase) CreateWordpressGbp(ctx context.Context, body req.WordpressGbp) (*res.WordpressGbp, error) {
+	wg, err := u.newWordpressGbp(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.wordpressGbpRepo.Create(ctx, wg); err != nil {
+		return nil, err
+	}
+
+	return &res.WordpressGbp{
+		ID:                 wg.ID,
+		Name:               wg.Name,
+		WordpressDomain:    wg.WordpressDomain,
+		BusinessName:       wg.BusinessName,
+		BusinessTitle:      wg.BusinessTitle,
+		Memo:               wg.Memo,
+		MapsURL:            wg.MapsURL,
+		CallToActionType:   string(wg.CallToActionType),
+		CallToActionURL:    wg.CallToActionURL,
+		MediaCategory:      string(wg.MediaCategory),
+		MediaCategoryRules: toMediaCategoryRulesResponse(wg.MediaCategoryRules),
+		SanitizeConfig:     toSanitizeConfigResponse(wg.SanitizeConfig),
+		Status:             int(wg.Status),
+		CreatedAt:          wg.CreatedAt,
+		UpdatedAt:          wg.UpdatedAt,
+	}, nil
+}
+
+// newWordpressGbp は作成の入力を検証し、WordPressとGBPのビジネスに接続できるかを確認する。DBには保存しない。
+func (u *wordpressGbpUsecase) newWordpressGbp(ctx context.Context, body req.WordpressGbp) (*domain.WordpressGbp, error) {
+	// URL未設定の場合はWordPressの投稿URLを使う
+	if err := domain.ValidateCallToAction(domain.CallToActionType(body.CallToActionType), body.CallToActionURL, true); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateGbpMediaCategory(domain.GbpMediaCategory(body.MediaCategory), toGbpMediaCategoryRules(body.MediaCategoryRules)); err != nil {
+		return nil, err
+	}
+	if config := toGbpSanitizeConfig(body.SanitizeConfig); config != nil {
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	// WordPress接続確認
 	_, err := u.wordpressAdapter.GetTitle(ctx, body.WordpressDomain)
 	if err != nil {
@@ -136,7 +224,11 @@ func (u *wordpressGbpUsecase) CreateWordpressGbp(ctx context.Context, body req.W
 	}
 
 	// GBPビジネス存在確認
-	business, err := u.gbpAdapter.GetBusiness(ctx, body.BusinessName)
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, body.BusinessName)
+	if err != nil {
+		return nil, err
+	}
+	business, err := u.gbpAdapter.GetBusiness(ctx, account, body.BusinessName)
 	if err != nil {
 		return nil, domain.ErrBusinessConnection
 	}
@@ -145,35 +237,110 @@ func (u *wordpressGbpUsecase) CreateWordpressGbp(ctx context.Context, body req.W
 	}
 
 	wg := &domain.WordpressGbp{
-		Name:            body.Name,
-		Memo:            body.Memo,
-		WordpressDomain: body.WordpressDomain,
-		BusinessName:    business.Name,
-		BusinessTitle:   business.Title,
-		MapsURL:         business.MapsURL,
-		StartDate:       body.StartDate,
-		Status:          domain.Status(body.Status),
+		Name:               body.Name,
+		Memo:               body.Memo,
+		WordpressDomain:    body.WordpressDomain,
+		BusinessName:       business.Name,
+		BusinessTitle:      business.Title,
+		MapsURL:            business.MapsURL,
+		CallToActionType:   domain.CallToActionType(body.CallToActionType),
+		CallToActionURL:    body.CallToActionURL,
+		MediaCategory:      domain.GbpMediaCategory(body.MediaCategory),
+		MediaCategoryRules: toGbpMediaCategoryRules(body.MediaCategoryRules),
+		SanitizeConfig:     toGbpSanitizeConfig(body.SanitizeConfig),
+		StartDate:          body.StartDate,
+		Status:             domain.Status(body.Status),
 	}
 
-	if err := u.wordpressGbpRepo.Create(ctx, wg); err != nil {
+	return wg, nil
+}
+
+// wordpressGbpColumns はインポート・エクスポートするCSVの列
+var wordpressGbpColumns = []accountConfigColumn[req.WordpressGbp]{
+	stringColumn("name", func(b *req.WordpressGbp) *string { return &b.Name }),
+	stringColumn("wordpress_domain", func(b *req.WordpressGbp) *string { return &b.WordpressDomain }),
+	stringColumn("business_name", func(b *req.WordpressGbp) *string { return &b.BusinessName }),
+	stringColumn("memo", func(b *req.WordpressGbp) *string { return &b.Memo }),
+	stringColumn("call_to_action_type", func(b *req.WordpressGbp) *string { return &b.CallToActionType }),
+	stringColumn("call_to_action_url", func(b *req.WordpressGbp) *string { return &b.CallToActionURL }),
+	stringColumn("media_category", func(b *req.WordpressGbp) *string { return &b.MediaCategory }),
+	jsonColumn("media_category_rules", func(b *req.WordpressGbp) *[]req.GbpMediaCategoryRule { return &b.MediaCategoryRules }),
+	jsonColumn("sanitize_config", func(b *req.WordpressGbp) **req.GbpSanitizeConfig { return &b.SanitizeConfig }),
+	timeColumn("start_date", func(b *req.WordpressGbp) *time.Time { return &b.StartDate }),
+	intColumn("status", func(b *req.WordpressGbp) *int { return &b.Status }),
+}
+
+// ImportWordpressGbp はCSV・JSONの全ての行を作成APIと同じように検証し、全て成功した場合だけ1つのトランザクションで作成する。
+// 同じWordPressとビジネスの連携が既にある行は失敗にする。
+func (u *wordpressGbpUsecase) ImportWordpressGbp(ctx context.Context, format domain.AccountConfigFormat, data []byte, dryRun bool) (*res.AccountConfigImport, error) {
+	rows, err := decodeAccountConfigs(format, data, wordpressGbpColumns)
+	if err != nil {
 		return nil, err
 	}
+	return importAccountConfigs(ctx, u.baseRepo, rows, dryRun,
+		func(body req.WordpressGbp) string {
+			return body.WordpressDomain + " " + body.BusinessName
+		},
+		func(ctx context.Context, body req.WordpressGbp) (*domain.WordpressGbp, error) {
+			exists, err := u.wordpressGbpRepo.Exists(ctx, repository.WordpressGbpFilter{
+				WordpressDomain: &body.WordpressDomain,
+				BusinessName:    &body.BusinessName,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, fmt.Errorf("%w: 同じWordPressとビジネスの連携が既にあります", domain.ErrBadRequest)
+			}
+			return u.newWordpressGbp(ctx, body)
+		},
+		func(ctx context.Context, wg *domain.WordpressGbp) (int, error) {
+			if err := u.wordpressGbpRepo.Create(ctx, wg); err != nil {
+				return 0, err
+			}
+			return wg.ID, nil
+		},
+	)
+}
 
-	return &res.WordpressGbp{
-		ID:              wg.ID,
-		Name:            wg.Name,
-		WordpressDomain: wg.WordpressDomain,
-		BusinessName:    wg.BusinessName,
-		BusinessTitle:   wg.BusinessTitle,
-		Memo:            wg.Memo,
-		MapsURL:         wg.MapsURL,
-		Status:          int(wg.Status),
-		CreatedAt:       wg.CreatedAt,
-		UpdatedAt:       wg.UpdatedAt,
-	}, nil
+// ExportWordpressGbp は全ての連携を、インポートできる形式で出力する。
+func (u *wordpressGbpUsecase) ExportWordpressGbp(ctx context.Context, format domain.AccountConfigFormat) ([]byte, error) {
+	wgList, err := u.wordpressGbpRepo.FindAll(ctx, repository.WordpressGbpFilter{})
+	if err != nil {
+		return nil, err
+	}
+	bodies := make([]req.WordpressGbp, 0, len(wgList))
+	for _, wg := range wgList {
+		bodies = append(bodies, req.WordpressGbp{
+			Name:               wg.Name,
+			WordpressDomain:    wg.WordpressDomain,
+			BusinessName:       wg.BusinessName,
+			Memo:               wg.Memo,
+			CallToActionType:   string(wg.CallToActionType),
+			CallToActionURL:    wg.CallToActionURL,
+			MediaCategory:      string(wg.MediaCategory),
+			MediaCategoryRules: toGbpMediaCategoryRulesRequest(wg.MediaCategoryRules),
+			SanitizeConfig:     toGbpSanitizeConfigRequest(wg.SanitizeConfig),
+			StartDate:          wg.StartDate,
+			Status:             int(wg.Status),
+		})
+	}
+	return encodeAccountConfigs(format, bodies, wordpressGbpColumns)
 }
 
 func (u *wordpressGbpUsecase) UpdateWordpressGbp(ctx context.Context, id int, body req.WordpressGbp) (*res.WordpressGbp, error) {
+	// URL未設定の場合はWordPressの投稿URLを使う
+	if err := domain.ValidateCallToAction(domain.CallToActionType(body.CallToActionType), body.CallToActionURL, true); err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateGbpMediaCategory(domain.GbpMediaCategory(body.MediaCategory), toGbpMediaCategoryRules(body.MediaCategoryRules)); err != nil {
+		return nil, err
+	}
+	if config := toGbpSanitizeConfig(body.SanitizeConfig); config != nil {
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	// WordPress接続確認
 	_, err := u.wordpressAdapter.GetTitle(ctx, body.WordpressDomain)
 	if err != nil {
@@ -181,7 +348,11 @@ func (u *wordpressGbpUsecase) UpdateWordpressGbp(ctx context.Context, id int, bo
 	}
 
 	// GBPビジネス存在確認
-	business, err := u.gbpAdapter.GetBusiness(ctx, body.BusinessName)
+	account, err := findGoogleAccount(ctx, u.googleBusinessRepo, u.googleAccountRepo, body.BusinessName)
+	if err != nil {
+		return nil, err
+	}
+	business, err := u.gbpAdapter.GetBusiness(ctx, account, body.BusinessName)
 	if err != nil {
 		return nil, domain.ErrBusinessConnection
 	}
@@ -192,12 +363,18 @@ func (u *wordpressGbpUsecase) UpdateWordpressGbp(ctx context.Context, id int, bo
 	if err != nil {
 		return nil, err
 	}
+	beforeStatus := wg.Status
 	wg.Name = body.Name
 	wg.Memo = body.Memo
 	wg.WordpressDomain = body.WordpressDomain
 	wg.BusinessName = business.Name
 	wg.BusinessTitle = business.Title
 	wg.MapsURL = business.MapsURL
+	wg.CallToActionType = domain.CallToActionType(body.CallToActionType)
+	wg.CallToActionURL = body.CallToActionURL
+	wg.MediaCategory = domain.GbpMediaCategory(body.MediaCategory)
+	wg.MediaCategoryRules = toGbpMediaCategoryRules(body.MediaCategoryRules)
+	wg.SanitizeConfig = toGbpSanitizeConfig(body.SanitizeConfig)
 	wg.StartDate = body.StartDate
 	wg.Status = domain.Status(body.Status)
 	wg.UpdatedAt = time.Now()
@@ -208,17 +385,25 @@ func (u *wordpressGbpUsecase) UpdateWordpressGbp(ctx context.Context, id int, bo
 		return nil, err
 	}
 
+	// 停止した場合はwebhookで通知
+	publishAccountDisabled(ctx, u.webhookUsecase, beforeStatus, wg.Status, wg.Account())
+
 	return &res.WordpressGbp{
-		ID:              wg.ID,
-		Name:            wg.Name,
-		WordpressDomain: wg.WordpressDomain,
-		BusinessName:    wg.BusinessName,
-		BusinessTitle:   wg.BusinessTitle,
-		Memo:            wg.Memo,
-		MapsURL:         wg.MapsURL,
-		Status:          int(wg.Status),
-		CreatedAt:       wg.CreatedAt,
-		UpdatedAt:       wg.UpdatedAt,
+		ID:                 wg.ID,
+		Name:               wg.Name,
+		WordpressDomain:    wg.WordpressDomain,
+		BusinessName:       wg.BusinessName,
+		BusinessTitle:      wg.BusinessTitle,
+		Memo:               wg.Memo,
+		MapsURL:            wg.MapsURL,
+		CallToActionType:   string(wg.CallToActionType),
+		CallToActionURL:    wg.CallToActionURL,
+		MediaCategory:      string(wg.MediaCategory),
+		MediaCategoryRules: toMediaCategoryRulesResponse(wg.MediaCategoryRules),
+		SanitizeConfig:     toSanitizeConfigResponse(wg.SanitizeConfig),
+		Status:             int(wg.Status),
+		CreatedAt:          wg.CreatedAt,
+		UpdatedAt:          wg.UpdatedAt,
 	}, nil
 }
 
@@ -2,6 +2,9 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/zuxt268/homing/internal/domain"
 	"github.com/zuxt268/homing/internal/interface/adapter"
@@ -16,6 +19,9 @@ type WordpressInstagramUsecase interface {
 	GetWordpressInstagramList(ctx context.Context, params req.GetWordpressInstagram) (*res.WordpressInstagramList, error)
 	GetWordpressInstagram(ctx context.Context, id int, params req.GetWordpressInstagramDetail) (*res.WordpressInstagramDetail, error)
 	CreateWordpressInstagram(ctx context.Context, body req.CreateWordpressInstagram) (*res.WordpressInstagram, error)
+	ValidateWordpressInstagram(ctx context.Context, body req.CreateWordpressInstagram) (*res.OnboardingCheckList, error)
+	ImportWordpressInstagram(ctx context.Context, format domain.AccountConfigFormat, data []byte, dryRun bool) (*res.AccountConfigImport, error)
+	ExportWordpressInstagram(ctx context.Context, format domain.AccountConfigFormat) ([]byte, error)
 	UpdateWordpressInstagram(ctx context.Context, id int, body req.UpdateWordpressInstagram) (*res.WordpressInstagram, error)
 	DeleteWordpressInstagram(ctx context.Context, id int) error
 }
@@ -26,6 +32,8 @@ type wordpressInstagramUsecase struct {
 	postRepo               repository.PostRepository
 	instagramAdapter       adapter.InstagramAdapter
 	wordpressAdapter       adapter.WordpressAdapter
+	webhookUsecase         WebhookUsecase
+	baseRepo               repository.BaseRepository
 }
 
 func NewWordpressInstagramUsecase(
@@ -34,6 +42,8 @@ func NewWordpressInstagramUsecase(
 	postRepo repository.PostRepository,
 	instagramAdapter adapter.InstagramAdapter,
 	wordpressAdapter adapter.WordpressAdapter,
+	webhookUsecase WebhookUsecase,
+	baseRepo repository.BaseRepository,
 ) WordpressInstagramUsecase {
 	return &wordpressInstagramUsecase{
 		wordpressInstagramRepo: wordpressInstagramRepo,
@@ -41,6 +51,8 @@ func NewWordpressInstagramUsecase(
 		postRepo:               postRepo,
 		instagramAdapter:       instagramAdapter,
 		wordpressAdapter:       wordpressAdapter,
+		webhookUsecase:         webhookUsecase,
+		baseRepo:               baseRepo,
 	}
 }
 
@@ -100,6 +112,8 @@ func (u *wordpressInstagramUsecase) GetWordpressInstagramList(ctx context.Contex
 			Status:             int(wi.Status),
 			DeleteHash:         wi.DeleteHash,
 			Categories:         categories,
+			APIMode:            string(wi.APIMode),
+			WordpressUsername:  wi.WordpressUsername,
 		})
 	}
 
@@ -121,7 +135,7 @@ func (u *wordpressInstagramUsecase) GetWordpressInstagram(ctx context.Context, i
 	}
 
 	filter := repository.PostFilter{
-		CustomerID:           util.Pointer(100000 + wi.ID),
+		WordpressInstagramID: util.Pointer(wi.ID),
 		OrderByCreatedAtDesc: util.Pointer(true),
 		Limit:                params.Limit,
 		Offset:               params.Offset,
@@ -140,8 +154,11 @@ func (u *wordpressInstagramUsecase) GetWordpressInstagram(ctx context.Context, i
 	respPosts := make([]res.Post, len(posts))
 	for i, post := range posts {
 		respPosts[i] = res.Post{
+			MediaID:      post.MediaID,
+			Status:       string(post.Status),
 			WordpressUrl: post.WordpressURL,
 			InstagramUrl: post.InstagramURL,
+			PostedAt:     post.PostedAt,
 			CreatedAt:    post.CreatedAt,
 		}
 	}
@@ -165,6 +182,11 @@ func (u *wordpressInstagramUsecase) GetWordpressInstagram(ctx context.Context, i
 		Status:             int(wi.Status),
 		DeleteHash:         wi.DeleteHash,
 		Categories:         categories,
+		APIMode:            string(wi.APIMode),
+		WordpressUsername:  wi.WordpressUsername,
+		Template:           wi.Template,
+		LastSyncedAt:       wi.LastSyncedAt,
+		LastSyncError:      wi.LastSyncError,
 		Posts: res.Posts{
 			Posts: respPosts,
 			Paginate: res.Paginate{
@@ -177,6 +199,37 @@ func (u *wordpressInstagramUsecase) GetWordpressInstagram(ctx context.Context, i
 
 func (u *wordpressInstagramUsecase) CreateWordpressInstagram(ctx context.Context, req req.CreateWordpressInstagram) (*res.WordpressInstagram, error) {
 
+	wi, err := u.newWordpressInstagram(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.wordpressInstagramRepo.Create(ctx, wi); err != nil {
+		return nil, err
+	}
+
+	return &res.WordpressInstagram{
+		ID:                 wi.ID,
+		Name:               wi.Name,
+		WordpressDomain:    wi.WordpressDomain,
+		WordpressSiteTitle: wi.WordpressSiteTitle,
+		InstagramID:        wi.InstagramID,
+		InstagramName:      wi.InstagramName,
+		Memo:               wi.Memo,
+		StartDate:          wi.StartDate,
+		Status:             int(wi.Status),
+		DeleteHash:         wi.DeleteHash,
+		Categories:         req.Categories,
+		APIMode:            string(wi.APIMode),
+		WordpressUsername:  wi.WordpressUsername,
+		Template:           wi.Template,
+		LastSyncedAt:       wi.LastSyncedAt,
+		LastSyncError:      wi.LastSyncError,
+	}, nil
+}
+
+// newWordpressInstagram は作成の入力を検証し、InstagramとWordPressに接続できるかを確認する。DBには保存しない。
+func (u *wordpressInstagramUsecase) newWordpressInstagram(ctx context.Context, req req.CreateWordpressInstagram) (*domain.WordpressInstagram, error) {
 	token, err := u.tokenRepo.First(ctx)
 	if err != nil {
 		return nil, domain.ErrTokenNotFound
@@ -191,42 +244,134 @@ func (u *wordpressInstagramUsecase) CreateWordpressInstagram(ctx context.Context
 		return nil, domain.ErrInstagramConnection
 	}
 
+	wi := &domain.WordpressInstagram{
+		Name:              req.Name,
+		WordpressDomain:   req.WordpressDomain,
+		InstagramID:       req.InstagramID,
+		InstagramName:     account.InstagramAccountUserName,
+		Memo:              req.Memo,
+		StartDate:         req.StartDate,
+		Status:            domain.Status(req.Status),
+		DeleteHash:        req.DeleteHash,
+		Categories:        req.Categories,
+		APIMode:           domain.WordpressAPIMode(req.APIMode),
+		WordpressUsername: req.WordpressUsername,
+		AppPassword:       req.AppPassword,
+		Template:          req.Template,
+	}
+	if wi.APIMode == "" {
+		wi.APIMode = domain.WordpressAPIModeRodut
+	}
+	if err := wi.ValidateAPIMode(); err != nil {
+		return nil, err
+	}
+	if err := wi.ValidateTemplate(); err != nil {
+		return nil, err
+	}
+
 	// ワードプレスと疎通できるか
-	title, err := u.wordpressAdapter.GetTitle(ctx, req.WordpressDomain)
+	title, err := u.wordpressAdapter.GetSiteTitle(ctx, *wi)
 	if err != nil {
 		return nil, domain.ErrWordpressConnection
 	}
+	wi.WordpressSiteTitle = title
 
-	wi := &domain.WordpressInstagram{
-		Name:               req.Name,
-		WordpressDomain:    req.WordpressDomain,
-		WordpressSiteTitle: title,
-		InstagramID:        req.InstagramID,
-		InstagramName:      account.InstagramAccountUserName,
-		Memo:               req.Memo,
-		StartDate:          req.StartDate,
-		Status:             domain.Status(req.Status),
-		DeleteHash:         req.DeleteHash,
-		Categories:         req.Categories,
+	return wi, nil
+}
+
+// wordpressInstagramColumns はインポート・エクスポートするCSVの列。app_password はエクスポートしない
+var wordpressInstagramColumns = []accountConfigColumn[req.CreateWordpressInstagram]{
+	stringColumn("name", func(b *req.CreateWordpressInstagram) *string { return &b.Name }),
+	stringColumn("wordpress_domain", func(b *req.CreateWordpressInstagram) *string { return &b.WordpressDomain }),
+	stringColumn("instagram_id", func(b *req.CreateWordpressInstagram) *string { return &b.InstagramID }),
+	stringColumn("memo", func(b *req.CreateWordpressInstagram) *string { return &b.Memo }),
+	timeColumn("start_date", func(b *req.CreateWordpressInstagram) *time.Time { return &b.StartDate }),
+	intColumn("status", func(b *req.CreateWordpressInstagram) *int { return &b.Status }),
+	boolColumn("delete_hash", func(b *req.CreateWordpressInstagram) *bool { return &b.DeleteHash }),
+	jsonColumn("categories", func(b *req.CreateWordpressInstagram) *[]string { return &b.Categories }),
+	stringColumn("api_mode", func(b *req.CreateWordpressInstagram) *string { return &b.APIMode }),
+	stringColumn("wordpress_username", func(b *req.CreateWordpressInstagram) *string { return &b.WordpressUsername }),
+	stringColumn("app_password", func(b *req.CreateWordpressInstagram) *string { return &b.AppPassword }),
+	stringColumn("template", func(b *req.CreateWordpressInstagram) *string { return &b.Template }),
+}
+
+// ImportWordpressInstagram はCSV・JSONの全ての行を作成APIと同じように検証し、全て成功した場合だけ1つのトランザクションで作成する。
+// 同じWordPressとInstagramの連携が既にある行は失敗にする。
+func (u *wordpressInstagramUsecase) ImportWordpressInstagram(ctx context.Context, format domain.AccountConfigFormat, data []byte, dryRun bool) (*res.AccountConfigImport, error) {
+	rows, err := decodeAccountConfigs(format, data, wordpressInstagramColumns)
+	if err != nil {
+		return nil, err
 	}
+	return importAccountConfigs(ctx, u.baseRepo, rows, dryRun,
+		func(body req.CreateWordpressInstagram) string {
+			return body.WordpressDomain + " " + body.InstagramID
+		},
+		func(ctx context.Context, body req.CreateWordpressInstagram) (*domain.WordpressInstagram, error) {
+			exists, err := u.wordpressInstagramRepo.Exists(ctx, repository.WordpressInstagramFilter{
+				WordpressDomain: &body.WordpressDomain,
+				InstagramID:     &body.InstagramID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				return nil, fmt.Errorf("%w: 同じWordPressとInstagramの連携が既にあります", domain.ErrBadRequest)
+			}
+			return u.newWordpressInstagram(ctx, body)
+		},
+		func(ctx context.Context, wi *domain.WordpressInstagram) (int, error) {
+			if err := u.wordpressInstagramRepo.Create(ctx, wi); err != nil {
+				return 0, err
+			}
+			return wi.ID, nil
+		},
+	)
+}
 
-	if err := u.wordpressInstagramRepo.Create(ctx, wi); err != nil {
+// ExportWordpressInstagram は全ての連携を、インポートできる形式で出力する。
+func (u *wordpressInstagramUsecase) ExportWordpressInstagram(ctx context.Context, format domain.AccountConfigFormat) ([]byte, error) {
+	wiList, err := u.wordpressInstagramRepo.FindAll(ctx, repository.WordpressInstagramFilter{})
+	if err != nil {
 		return nil, err
 	}
+	bodies := make([]req.CreateWordpressInstagram, 0, len(wiList))
+	for _, wi := range wiList {
+		bodies = append(bodies, req.CreateWordpressInstagram{
+			Name:              wi.Name,
+			WordpressDomain:   wi.WordpressDomain,
+			InstagramID:       wi.InstagramID,
+			Memo:              wi.Memo,
+			StartDate:         wi.StartDate,
+			Status:            int(wi.Status),
+			DeleteHash:        wi.DeleteHash,
+			Categories:        wi.Categories,
+			APIMode:           string(wi.APIMode),
+			WordpressUsername: wi.WordpressUsername,
+			Template:          wi.Template,
+		})
+	}
+	return encodeAccountConfigs(format, bodies, wordpressInstagramColumns)
+}
 
-	return &res.WordpressInstagram{
-		ID:                 wi.ID,
-		Name:               wi.Name,
-		WordpressDomain:    wi.WordpressDomain,
-		WordpressSiteTitle: wi.WordpressSiteTitle,
-		InstagramID:        wi.InstagramID,
-		InstagramName:      wi.InstagramName,
-		Memo:               wi.Memo,
-		StartDate:          wi.StartDate,
-		Status:             int(wi.Status),
-		DeleteHash:         wi.DeleteHash,
-		Categories:         req.Categories,
-	}, nil
+// ValidateWordpressInstagram は作成前に、WordPressのプラグイン・署名の鍵・Instagramアカウントを確認する。何も作成・投稿しない。
+func (u *wordpressInstagramUsecase) ValidateWordpressInstagram(ctx context.Context, req req.CreateWordpressInstagram) (*res.OnboardingCheckList, error) {
+	wi := domain.WordpressInstagram{
+		WordpressDomain:   req.WordpressDomain,
+		APIMode:           domain.WordpressAPIMode(req.APIMode),
+		WordpressUsername: req.WordpressUsername,
+		AppPassword:       req.AppPassword,
+		Template:          req.Template,
+	}
+	if wi.APIMode == "" {
+		wi.APIMode = domain.WordpressAPIModeRodut
+	}
+
+	return toOnboardingCheckList([]domain.OnboardingCheck{
+		checkSettings(errors.Join(wi.ValidateAPIMode(), wi.ValidateTemplate())),
+		checkWordpressSite(ctx, u.wordpressAdapter, wi),
+		checkWordpressSignature(ctx, u.wordpressAdapter, wi),
+		checkInstagramAccount(ctx, u.tokenRepo, u.instagramAdapter, req.InstagramID),
+	}), nil
 }
 
 func (u *wordpressInstagramUsecase) UpdateWordpressInstagram(ctx context.Context, id int, req req.UpdateWordpressInstagram) (*res.WordpressInstagram, error) {
@@ -236,13 +381,28 @@ func (u *wordpressInstagramUsecase) UpdateWordpressInstagram(ctx context.Context
 	if err != nil {
 		return nil, err
 	}
+	beforeStatus := wi.Status
 
 	if req.Name != nil {
 		wi.Name = *req.Name
 	}
-	if req.Wordpress != nil {
-		wi.WordpressDomain = *req.Wordpress
-		title, err := u.wordpressAdapter.GetTitle(ctx, wi.WordpressDomain)
+	if req.Wordpress != nil || req.APIMode != nil || req.WordpressUsername != nil || req.AppPassword != nil {
+		if req.Wordpress != nil {
+			wi.WordpressDomain = *req.Wordpress
+		}
+		if req.APIMode != nil {
+			wi.APIMode = domain.WordpressAPIMode(*req.APIMode)
+		}
+		if req.WordpressUsername != nil {
+			wi.WordpressUsername = *req.WordpressUsername
+		}
+		if req.AppPassword != nil {
+			wi.AppPassword = *req.AppPassword
+		}
+		if err := wi.ValidateAPIMode(); err != nil {
+			return nil, err
+		}
+		title, err := u.wordpressAdapter.GetSiteTitle(ctx, *wi)
 		if err != nil {
 			return nil, domain.ErrWordpressConnection
 		}
@@ -278,6 +438,12 @@ func (u *wordpressInstagramUsecase) UpdateWordpressInstagram(ctx context.Context
 	if req.Categories != nil {
 		wi.Categories = req.Categories
 	}
+	if req.Template != nil {
+		wi.Template = *req.Template
+		if err := wi.ValidateTemplate(); err != nil {
+			return nil, err
+		}
+	}
 
 	err = u.wordpressInstagramRepo.Update(ctx, wi, repository.WordpressInstagramFilter{
 		ID: &id,
@@ -286,6 +452,9 @@ func (u *wordpressInstagramUsecase) UpdateWordpressInstagram(ctx context.Context
 		return nil, err
 	}
 
+	// 停止した場合はwebhookで通知
+	publishAccountDisabled(ctx, u.webhookUsecase, beforeStatus, wi.Status, wi.Account())
+
 	return &res.WordpressInstagram{
 		ID:                 wi.ID,
 		Name:               wi.Name,
@@ -298,6 +467,11 @@ func (u *wordpressInstagramUsecase) UpdateWordpressInstagram(ctx context.Context
 		Status:             int(wi.Status),
 		DeleteHash:         wi.DeleteHash,
 		Categories:         wi.Categories,
+		APIMode:            string(wi.APIMode),
+		WordpressUsername:  wi.WordpressUsername,
+		Template:           wi.Template,
+		LastSyncedAt:       wi.LastSyncedAt,
+		LastSyncError:      wi.LastSyncError,
 	}, nil
 }
 
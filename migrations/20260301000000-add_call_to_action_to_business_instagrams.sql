@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE `business_instagrams`
+    ADD COLUMN `call_to_action_type` varchar(32) NOT NULL DEFAULT '' AFTER `maps_url`,
+    ADD COLUMN `call_to_action_url` varchar(512) NOT NULL DEFAULT '' AFTER `call_to_action_type`;
+
+-- +migrate Down
+ALTER TABLE `business_instagrams`
+    DROP COLUMN `call_to_action_type`,
+    DROP COLUMN `call_to_action_url`;
@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `google_reviews` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `business_name` varchar(255) NOT NULL,
+    `review_name` varchar(500) NOT NULL,
+    `reviewer_name` varchar(255) NOT NULL DEFAULT '',
+    `star_rating` tinyint NOT NULL DEFAULT 0,
+    `comment` text NOT NULL,
+    `reply_comment` text NOT NULL,
+    `reply_updated_at` datetime DEFAULT NULL,
+    `reviewed_at` datetime NOT NULL,
+    `update_time` varchar(255) NOT NULL DEFAULT '',
+    `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_google_reviews_review_name` (`review_name`),
+    KEY `idx_google_reviews_business_name` (`business_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `google_reviews`;
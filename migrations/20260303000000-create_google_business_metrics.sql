@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `google_business_metrics` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `business_name` varchar(255) NOT NULL,
+    `metric` varchar(64) NOT NULL,
+    `date` date NOT NULL,
+    `value` bigint NOT NULL DEFAULT 0,
+    `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_google_business_metrics` (`business_name`, `metric`, `date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `google_business_metrics`;
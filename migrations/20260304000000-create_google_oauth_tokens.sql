@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `google_oauth_tokens` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `access_token` text NOT NULL,
+    `refresh_token` varchar(512) NOT NULL,
+    `token_type` varchar(50) NOT NULL DEFAULT '',
+    `expiry` datetime NOT NULL,
+    `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `google_oauth_tokens`;
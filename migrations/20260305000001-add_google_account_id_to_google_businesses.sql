@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE `google_businesses` ADD COLUMN `google_account_id` int NOT NULL DEFAULT 0 AFTER `id`;
+
+-- +migrate Down
+ALTER TABLE `google_businesses` DROP COLUMN `google_account_id`;
@@ -0,0 +1,14 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `google_business_location_changes` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `business_name` varchar(255) NOT NULL,
+    `field` varchar(255) NOT NULL,
+    `before_value` text NOT NULL,
+    `after_value` text NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_google_business_location_changes_business_name` (`business_name`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `google_business_location_changes`;
@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE `google_posts` ADD COLUMN `deleted_at` datetime NULL DEFAULT NULL AFTER `post_type`;
+
+-- +migrate Down
+ALTER TABLE `google_posts` DROP COLUMN `deleted_at`;
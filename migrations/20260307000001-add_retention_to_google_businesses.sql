@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE `google_businesses`
+    ADD COLUMN `photo_retention_count` int NOT NULL DEFAULT 0 AFTER `title`,
+    ADD COLUMN `local_post_retention_days` int NOT NULL DEFAULT 0 AFTER `photo_retention_count`;
+
+-- +migrate Down
+ALTER TABLE `google_businesses`
+    DROP COLUMN `photo_retention_count`,
+    DROP COLUMN `local_post_retention_days`;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE `business_instagrams`
+    ADD COLUMN `media_category` varchar(32) NOT NULL DEFAULT '' AFTER `call_to_action_url`,
+    ADD COLUMN `media_category_rules` text NULL AFTER `media_category`;
+
+-- +migrate Down
+ALTER TABLE `business_instagrams`
+    DROP COLUMN `media_category`,
+    DROP COLUMN `media_category_rules`;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE `wordpress_gbps`
+    ADD COLUMN `media_category` varchar(32) NOT NULL DEFAULT '' AFTER `call_to_action_url`,
+    ADD COLUMN `media_category_rules` text NULL AFTER `media_category`;
+
+-- +migrate Down
+ALTER TABLE `wordpress_gbps`
+    DROP COLUMN `media_category`,
+    DROP COLUMN `media_category_rules`;
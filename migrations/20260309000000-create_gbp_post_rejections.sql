@@ -0,0 +1,20 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `gbp_post_rejections` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `customer_id` int NOT NULL,
+    `media_id` varchar(255) NOT NULL,
+    `business_name` varchar(255) NOT NULL,
+    `reason` varchar(32) NOT NULL,
+    `field` varchar(255) NOT NULL DEFAULT '',
+    `message` text NOT NULL,
+    `summary` text NOT NULL,
+    `count` int NOT NULL DEFAULT 1,
+    `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uk_customer_media` (`customer_id`, `media_id`),
+    KEY `idx_reason` (`reason`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `gbp_post_rejections`;
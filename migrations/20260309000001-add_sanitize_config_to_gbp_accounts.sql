@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE `business_instagrams` ADD COLUMN `sanitize_config` text NULL AFTER `media_category_rules`;
+ALTER TABLE `wordpress_gbps` ADD COLUMN `sanitize_config` text NULL AFTER `media_category_rules`;
+
+-- +migrate Down
+ALTER TABLE `business_instagrams` DROP COLUMN `sanitize_config`;
+ALTER TABLE `wordpress_gbps` DROP COLUMN `sanitize_config`;
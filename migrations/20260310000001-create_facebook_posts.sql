@@ -0,0 +1,15 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `facebook_posts` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `facebook_instagram_id` int NOT NULL,
+    `media_id` varchar(255) NOT NULL,
+    `permalink` varchar(255) NOT NULL DEFAULT '',
+    `facebook_post_id` varchar(255) NOT NULL,
+    `facebook_url` varchar(255) NOT NULL DEFAULT '',
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_facebook_posts_media` (`facebook_instagram_id`, `media_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `facebook_posts`;
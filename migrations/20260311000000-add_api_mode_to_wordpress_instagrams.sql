@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE `wordpress_instagrams` ADD COLUMN `api_mode` varchar(20) NOT NULL DEFAULT 'rodut' AFTER `categories`;
+ALTER TABLE `wordpress_instagrams` ADD COLUMN `wordpress_username` varchar(255) NOT NULL DEFAULT '' AFTER `api_mode`;
+ALTER TABLE `wordpress_instagrams` ADD COLUMN `app_password` varchar(255) NOT NULL DEFAULT '' AFTER `wordpress_username`;
+
+-- +migrate Down
+ALTER TABLE `wordpress_instagrams` DROP COLUMN `app_password`;
+ALTER TABLE `wordpress_instagrams` DROP COLUMN `wordpress_username`;
+ALTER TABLE `wordpress_instagrams` DROP COLUMN `api_mode`;
@@ -0,0 +1,26 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `feeds` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL,
+    `memo` text,
+    `feed_url` varchar(1024) NOT NULL,
+    `feed_title` varchar(255) NOT NULL DEFAULT '',
+    `start_date` datetime NOT NULL,
+    `status` int NOT NULL DEFAULT '0',
+    `wordpress_domain` varchar(255) NOT NULL DEFAULT '',
+    `wordpress_site_title` varchar(255) NOT NULL DEFAULT '',
+    `api_mode` varchar(20) NOT NULL DEFAULT 'rodut',
+    `wordpress_username` varchar(255) NOT NULL DEFAULT '',
+    `app_password` varchar(255) NOT NULL DEFAULT '',
+    `categories` varchar(255) NOT NULL DEFAULT '',
+    `business_name` varchar(255) NOT NULL DEFAULT '',
+    `business_title` varchar(255) NOT NULL DEFAULT '',
+    `call_to_action_type` varchar(32) NOT NULL DEFAULT '',
+    `call_to_action_url` varchar(512) NOT NULL DEFAULT '',
+    `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `feeds`;
@@ -0,0 +1,16 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `feed_posts` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `feed_id` int NOT NULL,
+    `destination` varchar(20) NOT NULL,
+    `guid_hash` char(64) NOT NULL,
+    `guid` text NOT NULL,
+    `link` varchar(1024) NOT NULL DEFAULT '',
+    `url` varchar(1024) NOT NULL DEFAULT '',
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_feed_posts_guid` (`feed_id`, `destination`, `guid_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `feed_posts`;
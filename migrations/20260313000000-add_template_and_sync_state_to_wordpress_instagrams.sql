@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE `wordpress_instagrams` ADD COLUMN `template` text NOT NULL AFTER `categories`;
+ALTER TABLE `wordpress_instagrams` ADD COLUMN `last_synced_at` datetime NULL DEFAULT NULL AFTER `app_password`;
+ALTER TABLE `wordpress_instagrams` ADD COLUMN `last_sync_error` varchar(1000) NOT NULL DEFAULT '' AFTER `last_synced_at`;
+
+-- +migrate Down
+ALTER TABLE `wordpress_instagrams` DROP COLUMN `last_sync_error`;
+ALTER TABLE `wordpress_instagrams` DROP COLUMN `last_synced_at`;
+ALTER TABLE `wordpress_instagrams` DROP COLUMN `template`;
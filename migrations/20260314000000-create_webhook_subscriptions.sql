@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `webhook_subscriptions` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL DEFAULT '',
+    `url` varchar(1024) NOT NULL,
+    `secret` varchar(255) NOT NULL,
+    `event_types` varchar(1024) NOT NULL DEFAULT '',
+    `account_type` varchar(50) NOT NULL DEFAULT '',
+    `account_id` int NOT NULL DEFAULT 0,
+    `status` int NOT NULL DEFAULT 1,
+    `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `webhook_subscriptions`;
@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `webhook_events` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `event_id` varchar(64) NOT NULL,
+    `event_type` varchar(50) NOT NULL,
+    `account_type` varchar(50) NOT NULL DEFAULT '',
+    `account_id` int NOT NULL DEFAULT 0,
+    `account_name` varchar(255) NOT NULL DEFAULT '',
+    `payload` mediumtext NOT NULL,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_webhook_events_event_id` (`event_id`),
+    KEY `idx_webhook_events_account` (`account_type`, `account_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `webhook_events`;
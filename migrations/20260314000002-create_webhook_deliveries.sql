@@ -0,0 +1,21 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `event_id` int NOT NULL,
+    `subscription_id` int NOT NULL,
+    `status` varchar(20) NOT NULL,
+    `attempts` int NOT NULL DEFAULT 0,
+    `response_status` int NOT NULL DEFAULT 0,
+    `response_body` text NOT NULL,
+    `error` varchar(1000) NOT NULL DEFAULT '',
+    `next_retry_at` datetime NULL DEFAULT NULL,
+    `delivered_at` datetime NULL DEFAULT NULL,
+    `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_webhook_deliveries_event_id` (`event_id`),
+    KEY `idx_webhook_deliveries_retry` (`status`, `next_retry_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `webhook_deliveries`;
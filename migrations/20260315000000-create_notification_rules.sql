@@ -0,0 +1,18 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `notification_rules` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL DEFAULT '',
+    `event_types` varchar(1024) NOT NULL DEFAULT '',
+    `account_type` varchar(50) NOT NULL DEFAULT '',
+    `account_id` int NOT NULL DEFAULT 0,
+    `channel` varchar(50) NOT NULL,
+    `destination` varchar(1024) NOT NULL,
+    `mention` varchar(255) NOT NULL DEFAULT '',
+    `status` int NOT NULL DEFAULT 1,
+    `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- +migrate Down
+DROP TABLE `notification_rules`;
@@ -0,0 +1,51 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS `sync_activities` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `account_type` varchar(50) NOT NULL,
+    `account_id` int NOT NULL,
+    `account_name` varchar(255) NOT NULL DEFAULT '',
+    `result` varchar(20) NOT NULL,
+    `post_key` varchar(255) NOT NULL DEFAULT '',
+    `detail` varchar(1000) NOT NULL DEFAULT '',
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_sync_activities_created_at` (`created_at`),
+    KEY `idx_sync_activities_account` (`account_type`, `account_id`, `result`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci;
+
+-- 投稿が無い連携の判定に使うため、既存の連携は最後の投稿を1件ずつ記録しておく
+INSERT INTO `sync_activities` (`account_type`, `account_id`, `account_name`, `result`, `detail`, `created_at`)
+SELECT 'wordpress_instagram', wi.`id`, wi.`name`, 'published', 'backfill', MAX(p.`created_at`)
+FROM `posts` p
+INNER JOIN `wordpress_instagrams` wi ON wi.`id` = p.`customer_id` - 100000
+WHERE p.`customer_id` BETWEEN 100000 AND 199999
+GROUP BY wi.`id`, wi.`name`;
+
+INSERT INTO `sync_activities` (`account_type`, `account_id`, `account_name`, `result`, `detail`, `created_at`)
+SELECT 'business_instagram', bi.`id`, bi.`name`, 'published', 'backfill', MAX(gp.`created_at`)
+FROM `google_posts` gp
+INNER JOIN `business_instagrams` bi ON bi.`id` = gp.`customer_id`
+WHERE gp.`customer_id` < 300000
+GROUP BY bi.`id`, bi.`name`;
+
+INSERT INTO `sync_activities` (`account_type`, `account_id`, `account_name`, `result`, `detail`, `created_at`)
+SELECT 'wordpress_gbp', wg.`id`, wg.`name`, 'published', 'backfill', MAX(gp.`created_at`)
+FROM `google_posts` gp
+INNER JOIN `wordpress_gbps` wg ON wg.`id` = gp.`customer_id` - 300000
+WHERE gp.`customer_id` BETWEEN 300000 AND 399999
+GROUP BY wg.`id`, wg.`name`;
+
+INSERT INTO `sync_activities` (`account_type`, `account_id`, `account_name`, `result`, `detail`, `created_at`)
+SELECT 'facebook_instagram', fi.`id`, fi.`name`, 'published', 'backfill', MAX(fp.`created_at`)
+FROM `facebook_posts` fp
+INNER JOIN `facebook_instagrams` fi ON fi.`id` = fp.`facebook_instagram_id`
+GROUP BY fi.`id`, fi.`name`;
+
+INSERT INTO `sync_activities` (`account_type`, `account_id`, `account_name`, `result`, `detail`, `created_at`)
+SELECT 'feed', f.`id`, f.`name`, 'published', 'backfill', MAX(fp.`created_at`)
+FROM `feed_posts` fp
+INNER JOIN `feeds` f ON f.`id` = fp.`feed_id`
+GROUP BY f.`id`, f.`name`;
+
+-- +migrate Down
+DROP TABLE `sync_activities`;
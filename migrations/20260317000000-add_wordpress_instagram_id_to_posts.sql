@@ -0,0 +1,16 @@
+-- +migrate Up
+ALTER TABLE `posts`
+    ADD COLUMN `wordpress_instagram_id` int DEFAULT NULL AFTER `customer_id`,
+    ADD KEY `idx_posts_wordpress_instagram_id` (`wordpress_instagram_id`),
+    ADD CONSTRAINT `fk_posts_wordpress_instagram_id` FOREIGN KEY (`wordpress_instagram_id`) REFERENCES `wordpress_instagrams` (`id`) ON DELETE SET NULL;
+
+-- 100000 + wordpress_instagrams.id の投稿を紐付ける。旧 customers の投稿は cmd/migrate-customers で移行する
+UPDATE `posts` p
+    JOIN `wordpress_instagrams` wi ON p.`customer_id` = 100000 + wi.`id`
+SET p.`wordpress_instagram_id` = wi.`id`
+WHERE p.`customer_id` >= 100000;
+
+-- +migrate Down
+ALTER TABLE `posts` DROP FOREIGN KEY `fk_posts_wordpress_instagram_id`;
+ALTER TABLE `posts` DROP KEY `idx_posts_wordpress_instagram_id`;
+ALTER TABLE `posts` DROP COLUMN `wordpress_instagram_id`;
@@ -0,0 +1,30 @@
+-- +migrate Up
+ALTER TABLE `posts`
+    ADD COLUMN `source` varchar(20) NOT NULL DEFAULT 'instagram' AFTER `wordpress_instagram_id`,
+    ADD COLUMN `destination` varchar(20) NOT NULL DEFAULT 'wordpress' AFTER `source`,
+    ADD COLUMN `posted_at` datetime DEFAULT NULL AFTER `timestamp`;
+
+-- timestamp はInstagramの投稿日時（2006-01-02T15:04:05+0000、UTC）。形式が異なる値は NULL のままにする
+UPDATE `posts`
+SET `posted_at` = CONVERT_TZ(STR_TO_DATE(LEFT(`timestamp`, 19), '%Y-%m-%dT%H:%i:%s'), '+00:00', @@session.time_zone)
+WHERE `timestamp` REGEXP '^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}';
+
+-- 同じ投稿が重複して記録されている場合は最初の記録だけを残し、残りは posts_duplicates に移して確認できるようにする
+CREATE TABLE `posts_duplicates` LIKE `posts`;
+
+INSERT INTO `posts_duplicates`
+SELECT p.* FROM `posts` p
+WHERE EXISTS (
+    SELECT 1 FROM `posts` o
+    WHERE o.`destination` = p.`destination` AND o.`customer_id` = p.`customer_id` AND o.`media_id` = p.`media_id` AND o.`id` < p.`id`
+);
+
+DELETE FROM `posts` WHERE `id` IN (SELECT `id` FROM `posts_duplicates`);
+
+ALTER TABLE `posts` ADD UNIQUE KEY `uq_posts_destination_media` (`destination`, `customer_id`, `media_id`);
+
+-- +migrate Down
+ALTER TABLE `posts` DROP KEY `uq_posts_destination_media`;
+INSERT INTO `posts` SELECT * FROM `posts_duplicates`;
+DROP TABLE `posts_duplicates`;
+ALTER TABLE `posts` DROP COLUMN `posted_at`, DROP COLUMN `destination`, DROP COLUMN `source`;
@@ -0,0 +1,65 @@
+-- +migrate Up
+ALTER TABLE `google_posts`
+    ADD COLUMN `source` varchar(20) NOT NULL DEFAULT 'instagram' AFTER `customer_id`,
+    ADD COLUMN `destination` varchar(20) NOT NULL DEFAULT 'gbp_photo' AFTER `source`,
+    ADD COLUMN `business_instagram_id` int DEFAULT NULL AFTER `destination`,
+    ADD COLUMN `wordpress_gbp_id` int DEFAULT NULL AFTER `business_instagram_id`,
+    ADD COLUMN `published_at` datetime DEFAULT NULL AFTER `create_time`,
+    ADD KEY `idx_google_posts_business_instagram_id` (`business_instagram_id`),
+    ADD KEY `idx_google_posts_wordpress_gbp_id` (`wordpress_gbp_id`),
+    ADD CONSTRAINT `fk_google_posts_business_instagram_id` FOREIGN KEY (`business_instagram_id`) REFERENCES `business_instagrams` (`id`) ON DELETE SET NULL,
+    ADD CONSTRAINT `fk_google_posts_wordpress_gbp_id` FOREIGN KEY (`wordpress_gbp_id`) REFERENCES `wordpress_gbps` (`id`) ON DELETE SET NULL;
+
+UPDATE `google_posts` SET `destination` = 'gbp_post' WHERE `post_type` = 'post';
+
+-- customer_id はビジネスInstagram連携が id、WordPress-GBP連携が 300000 + id
+UPDATE `google_posts` SET `source` = 'wordpress' WHERE `customer_id` BETWEEN 300000 AND 399999;
+
+UPDATE `google_posts` gp
+    JOIN `business_instagrams` bi ON bi.`id` = gp.`customer_id`
+SET gp.`business_instagram_id` = bi.`id`
+WHERE gp.`customer_id` < 300000;
+
+UPDATE `google_posts` gp
+    JOIN `wordpress_gbps` wg ON wg.`id` = gp.`customer_id` - 300000
+SET gp.`wordpress_gbp_id` = wg.`id`
+WHERE gp.`customer_id` BETWEEN 300000 AND 399999;
+
+-- create_time はGBPの投稿日時（RFC3339、UTC）。形式が異なる値は NULL のままにする
+UPDATE `google_posts`
+SET `published_at` = CONVERT_TZ(STR_TO_DATE(LEFT(`create_time`, 19), '%Y-%m-%dT%H:%i:%s'), '+00:00', @@session.time_zone)
+WHERE `create_time` REGEXP '^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}';
+
+-- 以前の同期はカルーセルの子要素のIDを取得しておらず、子要素の画像を media_id = '' で記録していた。
+-- 元のIDは分からないため、別々の記録として残せるよう記録ごとに一意な値にする
+UPDATE `google_posts` SET `media_id` = CONCAT('legacy_child_', `id`) WHERE `media_id` = '';
+
+-- 同じ投稿が重複して記録されている場合は最初の記録だけを残し、残りは google_posts_duplicates に移して確認できるようにする
+CREATE TABLE `google_posts_duplicates` LIKE `google_posts`;
+
+INSERT INTO `google_posts_duplicates`
+SELECT gp.* FROM `google_posts` gp
+WHERE EXISTS (
+    SELECT 1 FROM `google_posts` o
+    WHERE o.`destination` = gp.`destination` AND o.`customer_id` = gp.`customer_id` AND o.`media_id` = gp.`media_id` AND o.`id` < gp.`id`
+);
+
+DELETE FROM `google_posts` WHERE `id` IN (SELECT `id` FROM `google_posts_duplicates`);
+
+ALTER TABLE `google_posts` ADD UNIQUE KEY `uq_google_posts_destination_media` (`destination`, `customer_id`, `media_id`);
+
+-- +migrate Down
+ALTER TABLE `google_posts` DROP KEY `uq_google_posts_destination_media`;
+INSERT INTO `google_posts` SELECT * FROM `google_posts_duplicates`;
+DROP TABLE `google_posts_duplicates`;
+UPDATE `google_posts` SET `media_id` = '' WHERE `media_id` LIKE 'legacy\_child\_%';
+ALTER TABLE `google_posts` DROP FOREIGN KEY `fk_google_posts_business_instagram_id`;
+ALTER TABLE `google_posts` DROP FOREIGN KEY `fk_google_posts_wordpress_gbp_id`;
+ALTER TABLE `google_posts`
+    DROP KEY `idx_google_posts_business_instagram_id`,
+    DROP KEY `idx_google_posts_wordpress_gbp_id`,
+    DROP COLUMN `published_at`,
+    DROP COLUMN `wordpress_gbp_id`,
+    DROP COLUMN `business_instagram_id`,
+    DROP COLUMN `destination`,
+    DROP COLUMN `source`;
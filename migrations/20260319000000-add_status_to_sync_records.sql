@@ -0,0 +1,14 @@
+-- +migrate Up
+-- 投稿先に送る前に reserved で記録し、投稿できたら published にする。既存の記録は投稿済み
+ALTER TABLE `posts`
+    ADD COLUMN `status` varchar(20) NOT NULL DEFAULT 'published' AFTER `destination`,
+    ADD COLUMN `idempotency_key` char(64) DEFAULT NULL AFTER `status`,
+    ADD KEY `idx_posts_status` (`status`, `created_at`);
+
+ALTER TABLE `google_posts`
+    ADD COLUMN `status` varchar(20) NOT NULL DEFAULT 'published' AFTER `destination`,
+    ADD KEY `idx_google_posts_status` (`status`, `created_at`);
+
+-- +migrate Down
+ALTER TABLE `google_posts` DROP KEY `idx_google_posts_status`, DROP COLUMN `status`;
+ALTER TABLE `posts` DROP KEY `idx_posts_status`, DROP COLUMN `idempotency_key`, DROP COLUMN `status`;
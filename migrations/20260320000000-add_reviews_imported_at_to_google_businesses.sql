@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE `google_businesses` ADD COLUMN `reviews_imported_at` datetime DEFAULT NULL AFTER `local_post_retention_days`;
+
+-- 口コミを取り込み済みのビジネスは、最初に取り込んだ日時を初回取り込みの日時にする
+UPDATE `google_businesses` gb
+    JOIN (SELECT `business_name`, MIN(`created_at`) AS `imported_at` FROM `google_reviews` GROUP BY `business_name`) gr
+        ON gr.`business_name` = gb.`name`
+SET gb.`reviews_imported_at` = gr.`imported_at`;
+
+-- +migrate Down
+ALTER TABLE `google_businesses` DROP COLUMN `reviews_imported_at`;
@@ -0,0 +1,3 @@
+#/bin/bash
+
+curl -X POST "http://localhost:8090/api/sync/digest?period=weekly"
\ No newline at end of file
@@ -6,3 +6,11 @@ curl -X POST http://localhost:8090/api/sync/wordpress-instagram
 curl -X POST http://localhost:8090/api/sync/business-instagram
 
 curl -X POST http://localhost:8090/api/sync/wordpress-gbp
+
+curl -X POST http://localhost:8090/api/sync/facebook-instagram
+
+curl -X POST http://localhost:8090/api/sync/feed
+
+
+curl -X POST http://localhost:8090/api/sync/google-review
+curl -X POST http://localhost:8090/api/sync/webhook-retry